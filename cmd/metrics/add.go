@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/sources"
+)
+
+// lookupMetadataFunc resolves the title of a new article; overridden in tests
+var lookupMetadataFunc = func(ctx context.Context, id identity.Identifier) (identity.Metadata, error) {
+	return identity.NewResolver().Lookup(ctx, id)
+}
+
+// runAdd appends a single article to the Articles sheet, fetching its title from the page
+// (or from Crossref/Open Library for DOIs and ISBNs)
+func runAdd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	source := fs.String("source", "", "Source name (default: the link domain, or Papers/Books for DOIs/ISBNs)")
+	title := fs.String("title", "", "Title to use instead of fetching it")
+	date := fs.String("date", "", "Date added as YYYY-MM-DD (default: today)")
+	read := fs.Bool("read", false, "Mark the article as read")
+	dryRun := fs.Bool("dry-run", false, "Print the row without writing to the sheet")
+
+	// Accept flags on either side of the URL: add URL --read, or add --read URL
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: add [--source NAME] [--title TITLE] [--date YYYY-MM-DD] [--read] [--dry-run] <url|doi|isbn>")
+	}
+	raw := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("add takes a single url, got extra arguments %v", fs.Args())
+	}
+
+	if *date == "" {
+		*date = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", *date); err != nil {
+		return fmt.Errorf("invalid --date %q: expected YYYY-MM-DD", *date)
+	}
+
+	id := identity.Parse(raw)
+	if id.Value == "" {
+		return fmt.Errorf("empty url")
+	}
+	article := schema.ArticleMeta{Date: *date, Title: strings.TrimSpace(*title), Link: id.URL(), Read: *read}
+
+	// YouTube links are always looked up so the channel and duration fill the remaining columns
+	var video *identity.Metadata
+	isVideo := identity.YouTubeVideoID(article.Link) != ""
+	if article.Title == "" || isVideo {
+		meta, err := lookupMetadataFunc(ctx, id)
+		switch {
+		case err != nil && article.Title == "":
+			slog.Warn("Unable to fetch title, using the link instead", "link", raw, "err", err)
+			article.Title = article.Link
+		case err != nil:
+			slog.Warn("Unable to fetch video details", "link", raw, "err", err)
+		default:
+			if article.Title == "" {
+				article.Title = meta.Title
+			}
+			if isVideo {
+				video = &meta
+			}
+		}
+	}
+
+	sheetID, credentialsPath, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	fetcher, writer, err := openSheetsFunc(ctx, credentialsPath)
+	if err != nil {
+		return err
+	}
+
+	spreadsheet, err := fetcher.GetSpreadsheet(sheetID)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve spreadsheet: %w", err)
+	}
+	articlesSheet, providersSheet := metrics.FindSheetNames(spreadsheet)
+
+	// Match the capitalization of known providers (e.g. --source github -> GitHub)
+	providerRows, err := fetcher.GetProvidersSheet(sheetID, providersSheet)
+	if err != nil {
+		slog.Warn("Unable to read providers sheet", "err", err)
+	}
+	sourceMap := metrics.BuildSourceMap(providerRows)
+	article.Category = metrics.NormalizeSourceName(defaultSource(*source, id), sourceMap)
+	if video != nil {
+		applyVideoMetadata(&article, *video, sourceMap)
+	}
+
+	rows, err := fetcher.GetArticleRows(sheetID, articlesSheet)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve data from sheet: %w", err)
+	}
+	if metrics.ExistingLinks(rows)[article.Link] {
+		return fmt.Errorf("%s is already tracked", article.Link)
+	}
+
+	slog.Info("Article", "date", article.Date, "title", article.Title, "category", article.Category, "read", article.Read)
+	if article.DurationMinutes > 0 {
+		slog.Info("Media", "type", article.MediaType, "minutes", article.DurationMinutes)
+	}
+	if *dryRun {
+		return nil
+	}
+
+	if err := writer.AppendRows(sheetID, metrics.ArticlesRange(articlesSheet), [][]interface{}{metrics.ArticleRow(article)}); err != nil {
+		return fmt.Errorf("failed to append row: %w", err)
+	}
+
+	slog.Info("✅ Added article", "link", article.Link, "sheet", articlesSheet)
+	return nil
+}
+
+// defaultSource picks the explicit source, else Papers/Books for identifiers, else the link domain
+func defaultSource(source string, id identity.Identifier) string {
+	if source = strings.TrimSpace(source); source != "" {
+		return source
+	}
+	switch id.Kind {
+	case identity.KindDOI:
+		return sources.PapersSource
+	case identity.KindISBN:
+		return sources.BooksSource
+	}
+	return metrics.LinkDomain(id.URL())
+}
+
+// applyVideoMetadata marks an article as a video, filing it under its channel unless a source was
+// given, and records the video's duration when the lookup found one
+func applyVideoMetadata(article *schema.ArticleMeta, meta identity.Metadata, sourceMap map[string]string) {
+	article.MediaType = metrics.MediaVideo
+	if meta.Duration > 0 {
+		article.DurationMinutes = int((meta.Duration + time.Minute - 1) / time.Minute)
+	}
+	if meta.Publisher != "" && article.Category == metrics.LinkDomain(article.Link) {
+		article.Category = metrics.NormalizeSourceName(meta.Publisher, sourceMap)
+	}
+}
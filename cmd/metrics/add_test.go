@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+func TestRunAdd(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-01-01", "Tracked", "https://github.blog/tracked", "GitHub", "FALSE"},
+	}
+	providers := [][]interface{}{
+		{"name", "url"},
+		{"GitHub", "https://github.blog"},
+	}
+	t.Setenv("SHEET_ID", "test-sheet")
+
+	originalOpen, originalLookup := openSheetsFunc, lookupMetadataFunc
+	defer func() { openSheetsFunc, lookupMetadataFunc = originalOpen, originalLookup }()
+	writer := &mockSheetsWriter{}
+	openSheetsFunc = func(ctx context.Context, credentialsPath string) (metrics.SheetsFetcher, metrics.SheetsWriter, error) {
+		return &mockSheetsFetcher{rows: rows, providers: providers}, writer, nil
+	}
+	lookupMetadataFunc = func(ctx context.Context, id identity.Identifier) (identity.Metadata, error) {
+		switch id.Kind {
+		case identity.KindDOI:
+			return identity.Metadata{Title: "A Paper"}, nil
+		case identity.KindURL:
+			if id.Value == "https://github.blog/new" {
+				return identity.Metadata{Title: "Fetched Title"}, nil
+			}
+			if identity.YouTubeVideoID(id.Value) != "" {
+				return identity.Metadata{Title: "A Great Talk", Publisher: "GopherCon", Duration: 42*time.Minute + 10*time.Second}, nil
+			}
+		}
+		return identity.Metadata{}, fmt.Errorf("not found")
+	}
+	today := time.Now().Format("2006-01-02")
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectError bool
+		expectedRow []interface{}
+	}{
+		{name: "missing url", args: nil, expectError: true},
+		{name: "extra arguments", args: []string{"https://a.com", "https://b.com"}, expectError: true},
+		{name: "invalid date", args: []string{"--date", "yesterday", "https://a.com"}, expectError: true},
+		{name: "already tracked", args: []string{"https://github.blog/tracked"}, expectError: true},
+		{name: "dry run writes nothing", args: []string{"--dry-run", "https://github.blog/new"}},
+		{
+			name:        "flags after url with normalized source",
+			args:        []string{"https://github.blog/new", "--source", "github", "--read"},
+			expectedRow: []interface{}{today, "Fetched Title", "https://github.blog/new", "GitHub", "TRUE"},
+		},
+		{
+			name:        "title lookup failure falls back to the link and domain",
+			args:        []string{"--date", "2024-05-01", "https://www.example.com/post"},
+			expectedRow: []interface{}{"2024-05-01", "https://www.example.com/post", "https://www.example.com/post", "example.com", "FALSE"},
+		},
+		{
+			name: "youtube fills channel, media type and duration",
+			args: []string{"--date", "2024-05-01", "https://youtu.be/dQw4w9WgXcQ"},
+			expectedRow: []interface{}{
+				"2024-05-01", "A Great Talk", "https://youtu.be/dQw4w9WgXcQ", "GopherCon", "FALSE", "", "", "", "", "video", "43",
+			},
+		},
+		{
+			name: "youtube keeps the given title and source",
+			args: []string{"--title", "Mine", "--source", "Talks", "--date", "2024-05-01", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+			expectedRow: []interface{}{
+				"2024-05-01", "Mine", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", "Talks", "FALSE", "", "", "", "", "video", "43",
+			},
+		},
+		{
+			name:        "doi goes to Papers",
+			args:        []string{"--title", "Given", "doi:10.1000/XYZ"},
+			expectedRow: []interface{}{today, "Given", "https://doi.org/10.1000/xyz", "Papers", "FALSE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer.appended = nil
+			err := runAdd(context.Background(), tt.args)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("runAdd() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectedRow == nil {
+				if len(writer.appended) != 0 {
+					t.Errorf("expected no rows appended, got %v", writer.appended)
+				}
+				return
+			}
+			if len(writer.appended) != 1 || fmt.Sprint(writer.appended[0]) != fmt.Sprint(tt.expectedRow) {
+				t.Errorf("appended %v, want %v", writer.appended, tt.expectedRow)
+			}
+		})
+	}
+}
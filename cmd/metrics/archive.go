@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/wayback"
+)
+
+// archiveLinkFunc is a package-level variable that can be mocked in tests
+var archiveLinkFunc = wayback.NewClient(2 * time.Minute).Save
+
+// runArchive submits the newest articles without an archive URL to the Wayback Machine and
+// stores each capture in the archive column
+func runArchive(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("archive", flag.ContinueOnError)
+	limit := fs.Int("limit", 25, "Links submitted per run")
+	delay := fs.Duration("delay", 5*time.Second, "Pause between submissions, to stay under Save Page Now rate limits")
+	dryRun := fs.Bool("dry-run", false, "List the links that would be archived without submitting them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *limit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+
+	sheetID, credentialsPath, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	fetcher, writer, err := openSheetsFunc(ctx, credentialsPath)
+	if err != nil {
+		return err
+	}
+
+	spreadsheet, err := fetcher.GetSpreadsheet(sheetID)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve spreadsheet: %w", err)
+	}
+	articlesSheet, _ := metrics.FindSheetNames(spreadsheet)
+
+	rows, err := fetcher.GetArticleRows(sheetID, articlesSheet)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve data from sheet: %w", err)
+	}
+
+	pending := unarchivedRows(rows)
+	if len(pending) > *limit {
+		pending = pending[:*limit]
+	}
+	if len(pending) == 0 {
+		slog.Info("✅ Every article link is already archived")
+		return nil
+	}
+
+	// Captures made before a cancellation are still stored, so they are not submitted again
+	updates := make(map[string]interface{})
+	var cancelled error
+	for i, idx := range pending {
+		link := cellString(rows[idx], metrics.ColLink)
+		if *dryRun {
+			slog.Info("Would archive", "date", cellString(rows[idx], metrics.ColDate), "link", link)
+			continue
+		}
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(*delay):
+			}
+		}
+		if cancelled = ctx.Err(); cancelled != nil {
+			break
+		}
+
+		archived, err := archiveLinkFunc(ctx, link)
+		if err != nil && ctx.Err() != nil {
+			cancelled = ctx.Err()
+			break
+		}
+		if errors.Is(err, wayback.ErrRateLimited) {
+			slog.Warn("Rate limited, stopping early", "archived", len(updates), "err", err)
+			break
+		}
+		if err != nil {
+			slog.Warn("Unable to archive link", "link", link, "err", err)
+			continue
+		}
+		slog.Info("🗄️ Archived", "link", link, "archive_url", archived)
+		updates[metrics.ArchiveCell(articlesSheet, idx)] = archived
+	}
+	if *dryRun {
+		return nil
+	}
+
+	if err := writer.UpdateCells(sheetID, updates); err != nil {
+		return fmt.Errorf("failed to store archive URLs: %w", err)
+	}
+	if cancelled != nil {
+		slog.Warn("Archiving cancelled, stored earlier captures", "archived", len(updates), "pending", len(pending))
+		return cancelled
+	}
+	slog.Info("✅ Archived links", "archived", len(updates), "pending", len(pending))
+	return nil
+}
+
+// unarchivedRows returns the indexes of rows (header excluded) with a web link but no archive URL,
+// newest first so freshly saved articles are archived before older ones
+func unarchivedRows(rows [][]interface{}) []int {
+	var pending []int
+	for i := 1; i < len(rows); i++ {
+		link := cellString(rows[i], metrics.ColLink)
+		if !strings.HasPrefix(link, "http://") && !strings.HasPrefix(link, "https://") {
+			continue
+		}
+		if cellString(rows[i], metrics.ColArchive) == "" {
+			pending = append(pending, i)
+		}
+	}
+	sort.SliceStable(pending, func(a, b int) bool {
+		return cellString(rows[pending[a]], metrics.ColDate) > cellString(rows[pending[b]], metrics.ColDate)
+	})
+	return pending
+}
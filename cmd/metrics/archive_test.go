@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/wayback"
+)
+
+func TestUnarchivedRows(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read", "Authors", "Notes", "Highlights", "Archive"},
+		{"2024-01-01", "Old", "https://example.com/old", "GitHub", "FALSE"},
+		{"2024-03-01", "Archived", "https://example.com/archived", "GitHub", "TRUE", "", "", "", "https://web.archive.org/web/1/x"},
+		{"2024-02-01", "New", "https://example.com/new", "GitHub", "TRUE"},
+		{"2024-04-01", "Identifier", "doi:10.1000/xyz", "Papers", "FALSE"},
+		{"2024-05-01", "Short"},
+	}
+
+	pending := unarchivedRows(rows)
+	if fmt.Sprint(pending) != "[3 1]" {
+		t.Errorf("expected unarchived web links newest first, got %v", pending)
+	}
+}
+
+func TestRunArchive(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-01-01", "One", "https://example.com/1", "GitHub", "FALSE"},
+		{"2024-01-02", "Two", "https://example.com/2", "GitHub", "FALSE"},
+		{"2024-01-03", "Three", "https://example.com/3", "GitHub", "FALSE"},
+	}
+	t.Setenv("SHEET_ID", "test-sheet")
+
+	originalOpen, originalArchive := openSheetsFunc, archiveLinkFunc
+	defer func() { openSheetsFunc, archiveLinkFunc = originalOpen, originalArchive }()
+	writer := &mockSheetsWriter{}
+	openSheetsFunc = func(ctx context.Context, credentialsPath string) (metrics.SheetsFetcher, metrics.SheetsWriter, error) {
+		return &mockSheetsFetcher{rows: rows}, writer, nil
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		failures map[string]error
+		expected map[string]interface{}
+	}{
+		{
+			name: "stores every capture",
+			args: []string{"--delay", "0"},
+			expected: map[string]interface{}{
+				"articles!I2": "https://web.archive.org/web/1/https://example.com/1",
+				"articles!I3": "https://web.archive.org/web/1/https://example.com/2",
+				"articles!I4": "https://web.archive.org/web/1/https://example.com/3",
+			},
+		},
+		{
+			name:     "limit takes the newest and failures are skipped",
+			args:     []string{"--delay", "0", "--limit", "2"},
+			failures: map[string]error{"https://example.com/3": fmt.Errorf("archive returned 520")},
+			expected: map[string]interface{}{"articles!I3": "https://web.archive.org/web/1/https://example.com/2"},
+		},
+		{
+			name:     "rate limit stops the run but keeps earlier captures",
+			args:     []string{"--delay", "0"},
+			failures: map[string]error{"https://example.com/2": wayback.ErrRateLimited},
+			expected: map[string]interface{}{"articles!I4": "https://web.archive.org/web/1/https://example.com/3"},
+		},
+		{name: "dry run", args: []string{"--dry-run"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer.updates = nil
+			archiveLinkFunc = func(ctx context.Context, link string) (string, error) {
+				if err := tt.failures[link]; err != nil {
+					return "", err
+				}
+				return "https://web.archive.org/web/1/" + link, nil
+			}
+
+			if err := runArchive(context.Background(), tt.args); err != nil {
+				t.Fatalf("runArchive() error = %v", err)
+			}
+			if fmt.Sprint(writer.updates) != fmt.Sprint(tt.expected) && len(writer.updates)+len(tt.expected) > 0 {
+				t.Errorf("updates = %v, want %v", writer.updates, tt.expected)
+			}
+		})
+	}
+
+	t.Run("cancel stores earlier captures", func(t *testing.T) {
+		writer.updates = nil
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		archiveLinkFunc = func(ctx context.Context, link string) (string, error) {
+			cancel()
+			return "https://web.archive.org/web/1/" + link, nil
+		}
+
+		if err := runArchive(ctx, []string{"--delay", "0"}); !errors.Is(err, context.Canceled) {
+			t.Fatalf("runArchive() error = %v, want context.Canceled", err)
+		}
+		expected := map[string]interface{}{"articles!I4": "https://web.archive.org/web/1/https://example.com/3"}
+		if fmt.Sprint(writer.updates) != fmt.Sprint(expected) {
+			t.Errorf("updates = %v, want %v", writer.updates, expected)
+		}
+	})
+
+	if err := runArchive(context.Background(), []string{"--limit", "0"}); err == nil {
+		t.Error("expected an error for a zero limit")
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// fetchSheetRowsFunc is a package-level variable that can be mocked in tests
+var fetchSheetRowsFunc = metrics.FetchSheetRows
+
+// runBackfill synthesizes metrics snapshots for past dates from the full article list
+func runBackfill(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	since := fs.String("since", "", "First date to backfill (YYYY-MM-DD, default: earliest article)")
+	until := fs.String("until", "", "Last date to backfill (YYYY-MM-DD, default: one interval before the earliest snapshot)")
+	interval := fs.String("interval", metrics.IntervalWeekly, "Snapshot spacing: weekly or monthly")
+	overwrite := fs.Bool("overwrite", false, "Replace snapshots that already exist")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	sheetID, credentialsPath, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	articleRows, providerRows, err := fetchSheetRowsFunc(ctx, sheetID, credentialsPath)
+	if err != nil {
+		return fmt.Errorf("failed to fetch sheet rows: %w", err)
+	}
+
+	sinceDate, untilDate, err := resolveBackfillRange(*since, *until, *interval, articleRows)
+	if err != nil {
+		return err
+	}
+
+	dates, err := metrics.BackfillDates(sinceDate, untilDate, *interval)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		slog.Warn("Using default configuration", "err", err)
+	}
+	rules, err := metrics.CompileRules(cfg.CategoryRules)
+	if err != nil {
+		return fmt.Errorf("invalid category rules: %w", err)
+	}
+
+	written := 0
+	var prev *schema.Metrics
+	for _, date := range dates {
+		if err := ctx.Err(); err != nil {
+			slog.Warn("Backfill cancelled", "written", written, "dates", len(dates))
+			return err
+		}
+		filename := date.Format("2006-01-02") + ".json"
+		if _, err := os.Stat(filepath.Join(paths.MetricsDir(), filename)); err == nil && !*overwrite {
+			slog.Info("Skipping date, snapshot already exists", "file", filename)
+			prev, _ = metrics.LoadSnapshot(paths.MetricsDir(), filename)
+			continue
+		}
+
+		snapshot, err := metrics.BackfillSnapshot(articleRows, providerRows, date, metrics.ComputeOptions{Rules: rules, YearStartMonth: cfg.YearStartMonth})
+		if err != nil {
+			slog.Warn("Skipping date", "file", filename, "err", err)
+			continue
+		}
+		metrics.ApplySourceAliases(&snapshot, cfg.SourceAliases)
+		metrics.ReportConsistency(filename, snapshot)
+
+		// Chain energy scores so streaks carry across backfilled weeks
+		if prev == nil {
+			prev, _ = metrics.LoadSnapshotBefore(paths.MetricsDir(), filename)
+		}
+		score := metrics.CalculateEnergyScore(snapshot, prev, cfg.Energy)
+		snapshot.EnergyScore = &score
+		metrics.ApplyAlerts(&snapshot, prev)
+		if err := metrics.ApplyRollingBaselines(&snapshot, paths.MetricsDir()); err != nil {
+			slog.Warn("Unable to load snapshots for rolling statistics", "file", filename, "err", err)
+		}
+
+		if _, err := saveMetrics(paths.MetricsDir(), snapshot); err != nil {
+			return err
+		}
+		prev = &snapshot
+		written++
+	}
+
+	slog.Info("✅ Backfilled snapshots", "written", written, "dates", len(dates))
+	return nil
+}
+
+// resolveBackfillRange parses the date flags, defaulting to the full span of article data before existing history
+func resolveBackfillRange(since, until, interval string, articleRows [][]interface{}) (time.Time, time.Time, error) {
+	var sinceDate, untilDate time.Time
+	var err error
+
+	if since != "" {
+		if sinceDate, err = time.Parse("2006-01-02", since); err != nil {
+			return sinceDate, untilDate, fmt.Errorf("invalid --since date %q: %w", since, err)
+		}
+	} else {
+		sinceDate = metrics.EarliestArticleDate(articleRows)
+		if sinceDate.IsZero() {
+			return sinceDate, untilDate, fmt.Errorf("no dated articles found to backfill from")
+		}
+	}
+
+	if until != "" {
+		if untilDate, err = time.Parse("2006-01-02", until); err != nil {
+			return sinceDate, untilDate, fmt.Errorf("invalid --until date %q: %w", until, err)
+		}
+		return sinceDate, untilDate, nil
+	}
+
+	// Stop one interval before the first real snapshot so backfilled history never overlaps it
+	untilDate = time.Now().UTC().Truncate(24 * time.Hour)
+	if files, err := metrics.ListSnapshotFiles(paths.MetricsDir()); err == nil && len(files) > 0 {
+		first, _ := time.Parse("2006-01-02", files[0][:len("2006-01-02")])
+		if interval == metrics.IntervalMonthly {
+			untilDate = metrics.AddMonths(first, -1)
+		} else {
+			untilDate = first.AddDate(0, 0, -7)
+		}
+	}
+
+	return sinceDate, untilDate, nil
+}
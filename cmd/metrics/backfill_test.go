@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+func TestRunBackfill(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2025-01-02", "First", "https://example.com/1", "GitHub", "TRUE"},
+		{"2025-01-09", "Second", "https://example.com/2", "Substack", "FALSE"},
+		{"2025-01-16", "Third", "https://example.com/3", "Substack", "TRUE"},
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		existing      []string
+		fetchErr      error
+		expectError   bool
+		expectedFiles []string
+	}{
+		{
+			name:          "weekly backfill writes one file per week",
+			args:          []string{"--since", "2025-01-02", "--until", "2025-01-16"},
+			expectedFiles: []string{"2025-01-02.json", "2025-01-09.json", "2025-01-16.json"},
+		},
+		{
+			name:          "defaults stop one week before existing history",
+			args:          []string{},
+			existing:      []string{"2025-01-23.json"},
+			expectedFiles: []string{"2025-01-02.json", "2025-01-09.json", "2025-01-16.json", "2025-01-23.json"},
+		},
+		{
+			name:        "invalid since date",
+			args:        []string{"--since", "01/02/2025"},
+			expectError: true,
+		},
+		{
+			name:        "fetch error",
+			args:        []string{},
+			fetchErr:    fmt.Errorf("API error"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			originalDir, _ := os.Getwd()
+			if err := os.Chdir(tmpDir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(originalDir)
+
+			t.Setenv("SHEET_ID", "test-sheet")
+			t.Setenv("CONFIG_PATH", filepath.Join(tmpDir, "missing.yml"))
+
+			originalFetch := fetchSheetRowsFunc
+			defer func() { fetchSheetRowsFunc = originalFetch }()
+			fetchSheetRowsFunc = func(ctx context.Context, sheetID, credentialsPath string) ([][]interface{}, [][]interface{}, error) {
+				return rows, nil, tt.fetchErr
+			}
+
+			if err := os.MkdirAll("metrics", 0755); err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range tt.existing {
+				if err := os.WriteFile(filepath.Join("metrics", name), []byte(`{"total_articles": 99}`), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := runBackfill(context.Background(), tt.args)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("runBackfill() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+
+			files, _ := metrics.ListSnapshotFiles("metrics")
+			if len(files) != len(tt.expectedFiles) {
+				t.Fatalf("expected files %v, got %v", tt.expectedFiles, files)
+			}
+			for i, name := range tt.expectedFiles {
+				if files[i] != name {
+					t.Errorf("file[%d] = %s, want %s", i, files[i], name)
+				}
+			}
+
+			// The latest backfilled week should have every article up to that date
+			last, err := metrics.LoadSnapshot("metrics", "2025-01-16.json")
+			if err != nil {
+				t.Fatalf("failed to load backfilled snapshot: %v", err)
+			}
+			if last.TotalArticles != 3 {
+				t.Errorf("expected 3 articles in 2025-01-16 snapshot, got %d", last.TotalArticles)
+			}
+			if last.EnergyScore == nil {
+				t.Error("expected backfilled snapshot to carry an energy score")
+			}
+			if !last.LastUpdated.Equal(time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("unexpected LastUpdated %v", last.LastUpdated)
+			}
+		})
+	}
+}
+
+func TestRunBackfillCancelled(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("SHEET_ID", "test-sheet")
+	t.Setenv("CONFIG_PATH", "missing.yml")
+
+	originalFetch := fetchSheetRowsFunc
+	defer func() { fetchSheetRowsFunc = originalFetch }()
+	fetchSheetRowsFunc = func(ctx context.Context, sheetID, credentialsPath string) ([][]interface{}, [][]interface{}, error) {
+		return [][]interface{}{{"Date", "Title", "Link", "Category", "Read"}, {"2025-01-02", "First", "https://example.com/1", "GitHub", "TRUE"}}, nil, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := runBackfill(ctx, []string{"--since", "2025-01-02", "--until", "2025-01-16"}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("runBackfill() error = %v, want context.Canceled", err)
+	}
+	if files, _ := metrics.ListSnapshotFiles("metrics"); len(files) != 0 {
+		t.Errorf("expected no snapshots after cancelling, got %v", files)
+	}
+}
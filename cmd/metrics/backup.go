@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/backup"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/googleauth"
+)
+
+// openBackupStoreFunc is a package-level variable that can be mocked in tests
+var openBackupStoreFunc = func(ctx context.Context) (backup.Store, error) {
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		return nil, err
+	}
+	return backup.FromConfig(ctx, cfg.Backup, googleauth.CredentialsPath())
+}
+
+// runBackup uploads the snapshots that changed since the last backup to the configured bucket
+func runBackup(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	dir := fs.String("dir", paths.MetricsDir(), "Directory of metrics snapshots")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	store, err := openBackupStoreFunc(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := backup.Sync(ctx, store, *dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range result.Copied {
+		slog.Info("⬆️ Uploaded", "file", name)
+	}
+	slog.Info("✅ Backed up files", "copied", len(result.Copied), "unchanged", result.Unchanged)
+	return nil
+}
+
+// runRestore downloads the backed-up snapshots missing from the metrics directory
+func runRestore(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	dir := fs.String("dir", paths.MetricsDir(), "Directory of metrics snapshots")
+	overwrite := fs.Bool("overwrite", false, "Replace local files that differ from their backup")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	store, err := openBackupStoreFunc(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := backup.Restore(ctx, store, *dir, *overwrite)
+	if err != nil {
+		return err
+	}
+	for _, name := range result.Copied {
+		slog.Info("⬇️ Downloaded", "file", name)
+	}
+	for _, name := range result.Skipped {
+		slog.Warn("Kept local file, which differs from its backup (use --overwrite to replace it)", "file", name)
+	}
+	if len(result.Copied) == 0 && len(result.Skipped) == 0 && result.Unchanged == 0 {
+		return fmt.Errorf("the backup bucket holds no files")
+	}
+	slog.Info("✅ Restored files", "copied", len(result.Copied), "unchanged", result.Unchanged)
+	return nil
+}
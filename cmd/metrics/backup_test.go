@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/backup"
+)
+
+type mockBackupStore struct {
+	objects map[string][]byte
+}
+
+func (m *mockBackupStore) List(ctx context.Context) (map[string]string, error) {
+	sums := make(map[string]string)
+	for name := range m.objects {
+		sums[name] = "unknown"
+	}
+	return sums, nil
+}
+
+func (m *mockBackupStore) Put(ctx context.Context, name string, data []byte) error {
+	m.objects[name] = data
+	return nil
+}
+
+func (m *mockBackupStore) Get(ctx context.Context, name string) ([]byte, error) {
+	return m.objects[name], nil
+}
+
+func TestRunBackupAndRestore(t *testing.T) {
+	original := openBackupStoreFunc
+	defer func() { openBackupStoreFunc = original }()
+	store := &mockBackupStore{objects: map[string][]byte{}}
+	openBackupStoreFunc = func(ctx context.Context) (backup.Store, error) { return store, nil }
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "2025-01-05.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runBackup(context.Background(), []string{"--dir", dir}); err != nil {
+		t.Fatalf("runBackup() error = %v", err)
+	}
+	if string(store.objects["2025-01-05.json"]) != "{}" {
+		t.Fatalf("expected the snapshot uploaded, got %v", store.objects)
+	}
+
+	restored := filepath.Join(t.TempDir(), "metrics")
+	if err := runRestore(context.Background(), []string{"--dir", restored}); err != nil {
+		t.Fatalf("runRestore() error = %v", err)
+	}
+	if content, err := os.ReadFile(filepath.Join(restored, "2025-01-05.json")); err != nil || string(content) != "{}" {
+		t.Errorf("expected the snapshot restored, got %q, %v", content, err)
+	}
+
+	store.objects = map[string][]byte{}
+	if err := runRestore(context.Background(), []string{"--dir", restored}); err == nil {
+		t.Error("expected an error restoring from an empty bucket")
+	}
+}
+
+func TestRunBackupStoreError(t *testing.T) {
+	original := openBackupStoreFunc
+	defer func() { openBackupStoreFunc = original }()
+	openBackupStoreFunc = func(ctx context.Context) (backup.Store, error) { return nil, errors.New("no backup bucket is set") }
+
+	if err := runBackup(context.Background(), []string{"--dir", t.TempDir()}); err == nil {
+		t.Error("expected the store error")
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/linkcheck"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// runCheckLinks checks every unread article link and writes dead and redirected links to a report
+func runCheckLinks(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("checklinks", flag.ContinueOnError)
+	concurrency := fs.Int("concurrency", 8, "Links checked at once")
+	timeout := fs.Duration("timeout", 15*time.Second, "Timeout per link")
+	out := fs.String("out", linkcheck.DefaultReportFile, "Path of the report to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	sheetID, credentialsPath, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	articleRows, providerRows, err := fetchSheetRowsFunc(ctx, sheetID, credentialsPath)
+	if err != nil {
+		return fmt.Errorf("failed to fetch sheet rows: %w", err)
+	}
+
+	var unread []schema.ArticleMeta
+	for _, article := range metrics.ParseArticles(articleRows, metrics.BuildSourceMap(providerRows)) {
+		if !article.Read && article.Link != "" {
+			unread = append(unread, article)
+		}
+	}
+
+	slog.Info("Checking unread links", "count", len(unread))
+	report := linkcheck.NewChecker(*timeout, *concurrency).CheckAll(ctx, unread, time.Now())
+	for _, result := range report.Results {
+		switch result.Status {
+		case linkcheck.StatusRedirected:
+			slog.Info("↪️ Redirected", "link", result.Link, "final_url", result.FinalURL)
+		case linkcheck.StatusDead:
+			slog.Warn("💀 Dead link", "link", result.Link, "reason", describeFailure(result))
+		default:
+			slog.Warn("Link check failed", "link", result.Link, "reason", describeFailure(result))
+		}
+	}
+
+	if err := linkcheck.Write(*out, report); err != nil {
+		return err
+	}
+
+	slog.Info("✅ Checked links", "checked", report.Checked, "ok", report.Counts[linkcheck.StatusOK],
+		"redirected", report.Counts[linkcheck.StatusRedirected], "dead", report.Counts[linkcheck.StatusDead],
+		"errors", report.Counts[linkcheck.StatusError], "report", *out)
+	return nil
+}
+
+// describeFailure summarizes why a link failed, preferring the HTTP status
+func describeFailure(result linkcheck.Result) string {
+	if result.StatusCode != 0 {
+		return fmt.Sprintf("HTTP %d", result.StatusCode)
+	}
+	return result.Error
+}
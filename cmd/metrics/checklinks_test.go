@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/linkcheck"
+)
+
+func TestRunCheckLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-01-01", "Alive", server.URL + "/alive", "GitHub", "FALSE"},
+		{"2024-01-02", "Gone", server.URL + "/gone", "GitHub", "FALSE"},
+		{"2024-01-03", "Read and gone", server.URL + "/gone?read", "GitHub", "TRUE"},
+	}
+	t.Setenv("SHEET_ID", "test-sheet")
+
+	originalFetch := fetchSheetRowsFunc
+	defer func() { fetchSheetRowsFunc = originalFetch }()
+	fetchSheetRowsFunc = func(ctx context.Context, sheetID, credentialsPath string) ([][]interface{}, [][]interface{}, error) {
+		return rows, nil, nil
+	}
+
+	out := filepath.Join(t.TempDir(), "report.json")
+	if err := runCheckLinks(context.Background(), []string{"--concurrency", "0"}); err == nil {
+		t.Error("expected error for zero concurrency")
+	}
+	if err := runCheckLinks(context.Background(), []string{"--out", out, "--concurrency", "2"}); err != nil {
+		t.Fatalf("runCheckLinks() error = %v", err)
+	}
+
+	report, err := linkcheck.Read(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Checked != 2 {
+		t.Errorf("expected only the 2 unread links to be checked, got %d", report.Checked)
+	}
+	if len(report.Results) != 1 || report.Results[0].Title != "Gone" || report.Results[0].Status != linkcheck.StatusDead {
+		t.Errorf("expected the dead unread link in the report, got %+v", report.Results)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/gitcommit"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// runCommit commits the run's outputs with a message describing the newest snapshot
+func runCommit(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("commit", flag.ContinueOnError)
+	dir := fs.String("dir", paths.MetricsDir(), "Directory of metrics snapshots the message describes")
+	paths := fs.String("paths", "", "Comma-separated paths to commit (default git.paths in config.yml, or the snapshot directory, plan and feed)")
+	message := fs.String("message", "", "Commit message template (default git.message in config.yml)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		slog.Warn("Using default configuration", "err", err)
+	}
+	gitCfg := cfg.Git
+	if *paths != "" {
+		gitCfg.Paths = strings.Split(*paths, ",")
+	}
+	if *message != "" {
+		gitCfg.Message = *message
+	}
+	return commitSnapshots(ctx, gitCfg, *dir)
+}
+
+// commitSnapshots commits the configured paths with the message template rendered for the newest
+// snapshot in dir
+func commitSnapshots(ctx context.Context, cfg config.GitConfig, dir string) error {
+	files, err := metrics.ListSnapshotFiles(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no snapshots found in %s", dir)
+	}
+	latest := files[len(files)-1]
+	curr, err := metrics.LoadSnapshot(dir, latest)
+	if err != nil {
+		return err
+	}
+	prev, err := metrics.LoadSnapshotBefore(dir, latest)
+	if err != nil {
+		return err
+	}
+
+	message, err := gitcommit.RenderMessage(cfg.Message, gitcommit.NewMessageData(*curr, prev))
+	if err != nil {
+		return err
+	}
+	paths := cfg.Paths
+	if len(paths) == 0 {
+		paths = gitcommit.DefaultPaths(dir)
+	}
+	committed, err := gitcommit.Commit(ctx, ".", paths, message)
+	if err != nil {
+		return err
+	}
+	if !committed {
+		slog.Info("✅ No changes to commit")
+		return nil
+	}
+	slog.Info("✅ Committed", "message", message)
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func TestCommitSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := repo.Config()
+	cfg.User.Name, cfg.User.Email = "Test", "test@example.com"
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	// A custom snapshot directory is committed by default, not only metrics/
+	snapshots := filepath.Join("data", "snapshots")
+	os.MkdirAll(snapshots, 0755)
+	os.WriteFile(filepath.Join(snapshots, "2025-12-14.json"), []byte(`{"last_updated":"2025-12-14T08:00:00Z","total_articles":200}`), 0644)
+	os.WriteFile(filepath.Join(snapshots, "2025-12-21.json"), []byte(`{"last_updated":"2025-12-21T08:00:00Z","total_articles":214}`), 0644)
+
+	if err := commitSnapshots(context.Background(), config.GitConfig{}, snapshots); err != nil {
+		t.Fatalf("commitSnapshots() error = %v", err)
+	}
+	ref, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit.Message != "metrics: 2025-12-21, +14 articles" {
+		t.Errorf("commit message = %q, expected %q", commit.Message, "metrics: 2025-12-21, +14 articles")
+	}
+	if _, err := commit.File("data/snapshots/2025-12-21.json"); err != nil {
+		t.Errorf("expected the snapshots committed: %v", err)
+	}
+	if err := commitSnapshots(context.Background(), config.GitConfig{Message: "{{.Date"}, snapshots); err == nil {
+		t.Error("expected an invalid template error")
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/digest"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// sendDigestFunc is a package-level variable that can be mocked in tests
+var sendDigestFunc = digest.Send
+
+// runDigest emails a summary of the latest snapshot against the previous one
+func runDigest(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	dir := fs.String("dir", paths.MetricsDir(), "Directory of metrics snapshots")
+	out := fs.String("out", "", "Write the HTML email to this file instead of sending it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	files, err := metrics.ListSnapshotFiles(*dir)
+	if err != nil {
+		return err
+	}
+	if len(files) < 2 {
+		return fmt.Errorf("need two snapshots in %s to build a digest, found %d", *dir, len(files))
+	}
+	prev, err := metrics.LoadSnapshot(*dir, files[len(files)-2])
+	if err != nil {
+		return err
+	}
+	latest, err := metrics.LoadSnapshot(*dir, files[len(files)-1])
+	if err != nil {
+		return err
+	}
+
+	d := digest.Build(*prev, *latest)
+	body, err := digest.HTML(d)
+	if err != nil {
+		return err
+	}
+
+	if *out != "" {
+		if err := os.WriteFile(*out, body, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", *out, err)
+		}
+		slog.Info("✅ Digest written", "snapshot", files[len(files)-1], "file", *out)
+		return nil
+	}
+
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		slog.Warn("Using default configuration", "err", err)
+	}
+	smtpCfg, err := smtpConfig(cfg.Digest)
+	if err != nil {
+		return err
+	}
+	if err := sendDigestFunc(smtpCfg, digest.Subject(d), body, time.Now()); err != nil {
+		return err
+	}
+	slog.Info("✅ Digest sent", "snapshot", files[len(files)-1], "to", strings.Join(smtpCfg.To, ", "))
+	return nil
+}
+
+// smtpConfig applies the SMTP_* and DIGEST_* environment variables on top of the digest config
+func smtpConfig(cfg config.DigestConfig) (digest.SMTPConfig, error) {
+	smtpCfg := digest.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     cfg.From,
+		To:       cfg.To,
+	}
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		smtpCfg.Host = host
+	}
+	if port := os.Getenv("SMTP_PORT"); port != "" {
+		parsed, err := strconv.Atoi(port)
+		if err != nil {
+			return smtpCfg, fmt.Errorf("invalid SMTP_PORT %q: %w", port, err)
+		}
+		smtpCfg.Port = parsed
+	}
+	if from := os.Getenv("DIGEST_FROM"); from != "" {
+		smtpCfg.From = from
+	}
+	if to := os.Getenv("DIGEST_TO"); to != "" {
+		smtpCfg.To = nil
+		for _, address := range strings.Split(to, ",") {
+			if address = strings.TrimSpace(address); address != "" {
+				smtpCfg.To = append(smtpCfg.To, address)
+			}
+		}
+	}
+	return smtpCfg, smtpCfg.Validate()
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/digest"
+)
+
+func TestRunDigest(t *testing.T) {
+	tmpDir := t.TempDir()
+	metricsDir := filepath.Join(tmpDir, "metrics")
+	if err := os.MkdirAll(metricsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeSnapshot := func(date string, m schema.Metrics) {
+		content, _ := json.Marshal(m)
+		if err := os.WriteFile(filepath.Join(metricsDir, date+".json"), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeSnapshot("2026-03-06", schema.Metrics{TotalArticles: 10, ReadCount: 4, UnreadCount: 6})
+
+	t.Setenv("CONFIG_PATH", filepath.Join(tmpDir, "missing.yml"))
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	t.Setenv("DIGEST_FROM", "digest@example.com")
+	t.Setenv("DIGEST_TO", "me@example.com, friend@example.com")
+
+	originalSend := sendDigestFunc
+	defer func() { sendDigestFunc = originalSend }()
+	var sent digest.SMTPConfig
+	var subject string
+	sendDigestFunc = func(cfg digest.SMTPConfig, s string, html []byte, now time.Time) error {
+		sent, subject = cfg, s
+		return nil
+	}
+
+	if err := runDigest(context.Background(), []string{"--dir", metricsDir}); err == nil {
+		t.Fatal("expected an error with a single snapshot")
+	}
+
+	writeSnapshot("2026-03-13", schema.Metrics{TotalArticles: 12, ReadCount: 7, UnreadCount: 5, LastUpdated: time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC)})
+	if err := runDigest(context.Background(), []string{"--dir", metricsDir}); err != nil {
+		t.Fatalf("runDigest() error = %v", err)
+	}
+	if sent.Host != "smtp.example.com" || len(sent.To) != 2 || sent.To[1] != "friend@example.com" {
+		t.Errorf("unexpected SMTP config: %+v", sent)
+	}
+	if !strings.Contains(subject, "3 read, backlog -1") {
+		t.Errorf("unexpected subject %q", subject)
+	}
+
+	out := filepath.Join(tmpDir, "digest.html")
+	sent = digest.SMTPConfig{}
+	if err := runDigest(context.Background(), []string{"--dir", metricsDir, "--out", out}); err != nil {
+		t.Fatalf("runDigest() error = %v", err)
+	}
+	if _, err := os.Stat(out); err != nil || sent.Host != "" {
+		t.Errorf("expected --out to write the email without sending it")
+	}
+}
+
+func TestSMTPConfig(t *testing.T) {
+	fromConfig := config.DigestConfig{SMTPHost: "smtp.config.com", SMTPPort: 2525, From: "a@b.c", To: []string{"d@e.f"}}
+	t.Setenv("SMTP_PORT", "not-a-port")
+	if _, err := smtpConfig(fromConfig); err == nil {
+		t.Error("expected an invalid SMTP_PORT to fail")
+	}
+
+	t.Setenv("SMTP_PORT", "")
+	t.Setenv("SMTP_USERNAME", "user")
+	cfg, err := smtpConfig(fromConfig)
+	if err != nil {
+		t.Fatalf("smtpConfig() error = %v", err)
+	}
+	if cfg.Host != "smtp.config.com" || cfg.Port != 2525 || cfg.Username != "user" {
+		t.Errorf("unexpected SMTP config: %+v", cfg)
+	}
+}
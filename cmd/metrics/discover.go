@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/sources"
+)
+
+// runDiscover polls the rss providers (or an OPML feed list) and appends articles not yet in the Articles sheet
+func runDiscover(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("discover", flag.ContinueOnError)
+	opmlPath := fs.String("opml", "", "Poll the feeds listed in this OPML file instead of the providers sheet")
+	dryRun := fs.Bool("dry-run", false, "Print what would be added without writing to the sheet")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("usage: discover [--opml FILE] [--dry-run]")
+	}
+
+	sheetID, credentialsPath, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	fetcher, writer, err := openSheetsFunc(ctx, credentialsPath)
+	if err != nil {
+		return err
+	}
+
+	spreadsheet, err := fetcher.GetSpreadsheet(sheetID)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve spreadsheet: %w", err)
+	}
+	articlesSheet, providersSheet := metrics.FindSheetNames(spreadsheet)
+
+	var feeds []sources.Feed
+	if *opmlPath != "" {
+		feeds, err = sources.ReadOPML(*opmlPath)
+		if err != nil {
+			return err
+		}
+	} else {
+		providerRows, err := fetcher.GetProvidersSheet(sheetID, providersSheet)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve providers: %w", err)
+		}
+		feeds = sources.FeedsFromProviders(providerRows)
+	}
+	if len(feeds) == 0 {
+		slog.Info("No feeds to poll")
+		return nil
+	}
+
+	slog.Info("Polling feeds", "count", len(feeds))
+	articles, err := sources.NewFeedSourceFromFeeds(feeds).Fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	return appendNewArticles(fetcher, writer, sheetID, articlesSheet, articles, *dryRun)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+func TestRunDiscover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<rss><channel><title>Example</title>
+<item><title>Tracked</title><link>https://a.com/1</link><pubDate>Mon, 01 Jan 2024 00:00:00 +0000</pubDate></item>
+<item><title>New</title><link>https://a.com/2</link><pubDate>Tue, 02 Jan 2024 00:00:00 +0000</pubDate></item>
+</channel></rss>`)
+	}))
+	defer server.Close()
+
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-01-01", "Tracked", "https://a.com/1", "Example", "FALSE"},
+	}
+	providers := [][]interface{}{
+		{"name", "url", "element", "strategy"},
+		{"Example", server.URL + "/feed", "", "rss"},
+		{"Scraped", "https://b.com", "article", "html"},
+	}
+
+	opmlPath := filepath.Join(t.TempDir(), "feeds.opml")
+	opml := fmt.Sprintf(`<opml version="2.0"><body><outline text="From OPML" xmlUrl="%s/opml"/></body></opml>`, server.URL)
+	if err := os.WriteFile(opmlPath, []byte(opml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SHEET_ID", "test-sheet")
+
+	originalOpen := openSheetsFunc
+	defer func() { openSheetsFunc = originalOpen }()
+	writer := &mockSheetsWriter{}
+	openSheetsFunc = func(ctx context.Context, credentialsPath string) (metrics.SheetsFetcher, metrics.SheetsWriter, error) {
+		return &mockSheetsFetcher{rows: rows, providers: providers}, writer, nil
+	}
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectError    bool
+		expectedAdded  int
+		expectedSource string
+	}{
+		{name: "rejects positional args", args: []string{"extra"}, expectError: true},
+		{name: "dry run writes nothing", args: []string{"--dry-run"}},
+		{name: "polls rss providers", args: nil, expectedAdded: 1, expectedSource: "Example"},
+		{name: "polls opml feeds", args: []string{"--opml", opmlPath}, expectedAdded: 1, expectedSource: "From OPML"},
+		{name: "missing opml file", args: []string{"--opml", filepath.Join(t.TempDir(), "missing.opml")}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer.appended = nil
+			err := runDiscover(context.Background(), tt.args)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("runDiscover() error = %v, expectError %v", err, tt.expectError)
+			}
+			if len(writer.appended) != tt.expectedAdded {
+				t.Fatalf("expected %d appended rows, got %v", tt.expectedAdded, writer.appended)
+			}
+			if tt.expectedAdded > 0 && (writer.appended[0][1] != "New" || writer.appended[0][3] != tt.expectedSource) {
+				t.Errorf("unexpected appended row %v", writer.appended[0])
+			}
+		})
+	}
+}
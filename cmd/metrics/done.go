@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// runDone marks the article matching a link or title substring as read in the Articles sheet
+func runDone(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("done", flag.ContinueOnError)
+	refresh := fs.Bool("metrics", false, "Regenerate today's metrics snapshot after marking the article")
+	dryRun := fs.Bool("dry-run", false, "Print the matching article without writing to the sheet")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	query := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if query == "" {
+		return fmt.Errorf("usage: done [--metrics] [--dry-run] <link-or-title-substring>")
+	}
+
+	sheetID, credentialsPath, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	fetcher, writer, err := openSheetsFunc(ctx, credentialsPath)
+	if err != nil {
+		return err
+	}
+
+	spreadsheet, err := fetcher.GetSpreadsheet(sheetID)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve spreadsheet: %w", err)
+	}
+	articlesSheet, _ := metrics.FindSheetNames(spreadsheet)
+
+	rows, err := fetcher.GetArticleRows(sheetID, articlesSheet)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve data from sheet: %w", err)
+	}
+
+	idx, err := findArticleRow(rows, query)
+	if err != nil {
+		return err
+	}
+	title := cellString(rows[idx], metrics.ColTitle)
+	if strings.EqualFold(cellString(rows[idx], metrics.ColRead), "TRUE") {
+		slog.Info("Already marked as read", "title", title)
+		return nil
+	}
+
+	slog.Info("Marking as read", "date", cellString(rows[idx], metrics.ColDate), "title", title, "link", cellString(rows[idx], metrics.ColLink))
+	if *dryRun {
+		return nil
+	}
+
+	if err := writer.UpdateCells(sheetID, map[string]interface{}{metrics.ReadCell(articlesSheet, idx): "TRUE"}); err != nil {
+		return fmt.Errorf("failed to mark article as read: %w", err)
+	}
+	slog.Info("✅ Marked as read", "title", title)
+
+	if *refresh {
+		if _, _, err := runFetch(ctx, &DefaultMetricsFetcher{}, false); err != nil {
+			return fmt.Errorf("failed to regenerate metrics: %w", err)
+		}
+	}
+	return nil
+}
+
+// findArticleRow returns the index of the single row (header excluded) whose link equals the query
+// or whose title or link contains it, case-insensitively. When several rows match, unread rows win;
+// a query that still matches more than one row is rejected with the candidates listed.
+func findArticleRow(rows [][]interface{}, query string) (int, error) {
+	link := identity.Parse(query).URL()
+	needle := strings.ToLower(query)
+
+	var exact, partial []int
+	for i := 1; i < len(rows); i++ {
+		rowLink := cellString(rows[i], metrics.ColLink)
+		if rowLink == "" && cellString(rows[i], metrics.ColTitle) == "" {
+			continue
+		}
+		switch {
+		case link != "" && identity.Parse(rowLink).URL() == link:
+			exact = append(exact, i)
+		case strings.Contains(strings.ToLower(cellString(rows[i], metrics.ColTitle)), needle),
+			strings.Contains(strings.ToLower(rowLink), needle):
+			partial = append(partial, i)
+		}
+	}
+
+	matches := exact
+	if len(matches) == 0 {
+		matches = partial
+	}
+	if len(matches) > 1 {
+		var unread []int
+		for _, i := range matches {
+			if !strings.EqualFold(cellString(rows[i], metrics.ColRead), "TRUE") {
+				unread = append(unread, i)
+			}
+		}
+		if len(unread) > 0 {
+			matches = unread
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("no article matches %q", query)
+	case 1:
+		return matches[0], nil
+	}
+
+	var candidates []string
+	for _, i := range matches {
+		candidates = append(candidates, fmt.Sprintf("  %s (%s)", cellString(rows[i], metrics.ColTitle), cellString(rows[i], metrics.ColLink)))
+	}
+	return 0, fmt.Errorf("%q matches %d articles, be more specific:\n%s", query, len(matches), strings.Join(candidates, "\n"))
+}
+
+// cellString returns the trimmed text of a row column, or "" when the row is short
+func cellString(row []interface{}, col int) string {
+	if len(row) <= col {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprintf("%v", row[col]))
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+func TestFindArticleRow(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-01-01", "Scaling Postgres", "https://example.com/postgres", "GitHub", "FALSE"},
+		{"2024-01-02", "Scaling Postgres, again", "https://example.com/postgres-2", "GitHub", "TRUE"},
+		{"2024-01-03", "Go Generics", "https://example.com/generics", "Stripe", "FALSE"},
+		{"2024-01-04", "Go Fuzzing", "https://example.com/fuzz", "Stripe", "FALSE"},
+		{"2024-01-05", "A Paper", "https://doi.org/10.1000/xyz", "Papers", "FALSE"},
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		expected    int
+		expectError string
+	}{
+		{name: "exact link", query: "https://example.com/postgres", expected: 1},
+		{name: "title substring ignores case", query: "generics", expected: 3},
+		{name: "link substring", query: "fuzz", expected: 4},
+		{name: "doi spelling matches stored link", query: "doi:10.1000/XYZ", expected: 5},
+		{name: "unread row wins over read row", query: "scaling postgres", expected: 1},
+		{name: "ambiguous", query: "Go ", expectError: "matches 2 articles"},
+		{name: "no match", query: "rust", expectError: "no article matches"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx, err := findArticleRow(rows, tt.query)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if idx != tt.expected {
+				t.Errorf("findArticleRow(%q) = %d, want %d", tt.query, idx, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunDone(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-01-01", "Unread Post", "https://example.com/1", "GitHub", "FALSE"},
+		{"2024-01-02", "Read Post", "https://example.com/2", "GitHub", "TRUE"},
+	}
+	t.Setenv("SHEET_ID", "test-sheet")
+
+	originalOpen := openSheetsFunc
+	defer func() { openSheetsFunc = originalOpen }()
+	writer := &mockSheetsWriter{}
+	openSheetsFunc = func(ctx context.Context, credentialsPath string) (metrics.SheetsFetcher, metrics.SheetsWriter, error) {
+		return &mockSheetsFetcher{rows: rows}, writer, nil
+	}
+
+	tests := []struct {
+		name            string
+		args            []string
+		expectError     bool
+		expectedUpdates map[string]interface{}
+	}{
+		{name: "missing query", args: nil, expectError: true},
+		{name: "no match", args: []string{"missing"}, expectError: true},
+		{name: "dry run writes nothing", args: []string{"--dry-run", "unread"}},
+		{name: "already read is a no-op", args: []string{"https://example.com/2"}},
+		{
+			name:            "multi-word title",
+			args:            []string{"unread", "post"},
+			expectedUpdates: map[string]interface{}{"articles!E2": "TRUE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer.updates = nil
+			err := runDone(context.Background(), tt.args)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("runDone() error = %v, expectError %v", err, tt.expectError)
+			}
+			if fmt.Sprint(writer.updates) != fmt.Sprint(tt.expectedUpdates) {
+				t.Errorf("updates = %v, want %v", writer.updates, tt.expectedUpdates)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/export"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/sources"
+)
+
+// runExport writes read articles as one bibliography file per year, or the latest snapshot's aggregates as spreadsheets
+func runExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", export.FormatBibTeX, "Output format: bibtex, csl, articles (JSON with notes and highlights), or csv or xlsx (aggregates of the latest snapshot)")
+	year := fs.String("year", "", "Only export this year, such as 2025 or 2025-26 with --year-start-month (default: every year with read articles)")
+	yearStartMonth := fs.Int("year-start-month", -1, "First month (1-12) of each exported year (default: year_start_month from config.yml, else January)")
+	out := fs.String("out", "exports", "Directory to write export files to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if export.IsAggregateFormat(*format) {
+		return exportAggregates(*format, paths.MetricsDir(), *out)
+	}
+
+	ext, err := export.BibliographyExtension(*format)
+	if err != nil {
+		return err
+	}
+
+	startMonth := *yearStartMonth
+	if startMonth < 0 {
+		cfg, _ := config.Load(config.Path())
+		startMonth = cfg.YearStartMonth
+	}
+	if startMonth > 12 {
+		return fmt.Errorf("invalid --year-start-month %d (expected 1-12)", startMonth)
+	}
+
+	articles, err := fetchArticles(ctx)
+	if err != nil {
+		return err
+	}
+
+	byYear := export.ReadByYear(articles, startMonth)
+	var years []string
+	for y := range byYear {
+		if *year == "" || y == *year {
+			years = append(years, y)
+		}
+	}
+	sort.Strings(years)
+	if len(years) == 0 {
+		return fmt.Errorf("no read articles to export")
+	}
+
+	if err := os.MkdirAll(*out, 0755); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	for _, y := range years {
+		content, err := export.Bibliography(byYear[y], *format)
+		if err != nil {
+			return fmt.Errorf("failed to render %s bibliography: %w", y, err)
+		}
+		path := filepath.Join(*out, "reading-"+y+ext)
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		slog.Info("✅ Exported read articles", "count", len(byYear[y]), "file", path)
+	}
+	return nil
+}
+
+// exportAggregates writes the per-source, per-month and per-year tables of the latest snapshot in metricsDir
+// as one CSV file each, or as the worksheets of one xlsx workbook
+func exportAggregates(format, metricsDir, out string) error {
+	files, err := metrics.ListSnapshotFiles(metricsDir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no snapshots found in %s", metricsDir)
+	}
+	latest, err := metrics.LoadSnapshot(metricsDir, files[len(files)-1])
+	if err != nil {
+		return err
+	}
+	tables := export.AggregateTables(*latest)
+
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	if format == export.FormatXLSX {
+		content, err := export.XLSX(tables)
+		if err != nil {
+			return fmt.Errorf("failed to render workbook: %w", err)
+		}
+		path := filepath.Join(out, export.XLSXFile)
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		slog.Info("✅ Exported worksheets", "count", len(tables), "snapshot", files[len(files)-1], "file", path)
+		return nil
+	}
+
+	for _, table := range tables {
+		content, err := export.CSV(table)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(out, table.Name+".csv")
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		slog.Info("✅ Exported rows", "table", table.Name, "count", len(table.Rows), "snapshot", files[len(files)-1], "file", path)
+	}
+	return nil
+}
+
+// fetchArticles lists every tracked article from the configured sources, or the Google Sheet alone
+func fetchArticles(ctx context.Context) ([]schema.ArticleMeta, error) {
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		slog.Warn("Using default configuration", "err", err)
+	}
+
+	if len(cfg.Sources) > 0 {
+		all, err := sources.NewAll(cfg.Sources)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure sources: %w", err)
+		}
+		return sources.FetchArticles(ctx, all)
+	}
+
+	sheetID, credentialsPath, err := loadConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	articleRows, providerRows, err := fetchSheetRowsFunc(ctx, sheetID, credentialsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sheet rows: %w", err)
+	}
+	return metrics.ParseArticles(articleRows, metrics.BuildSourceMap(providerRows)), nil
+}
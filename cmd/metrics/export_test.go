@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunExport(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-05-01", "Read in 2024", "https://a.com/1", "github", "TRUE"},
+		{"2025-02-01", "Read in 2025", "doi:10.1000/xyz", "Papers", "TRUE"},
+		{"2025-03-01", "Still unread", "https://a.com/3", "github", "FALSE"},
+	}
+
+	tmpDir := t.TempDir()
+	t.Setenv("SHEET_ID", "test-sheet")
+	t.Setenv("CONFIG_PATH", filepath.Join(tmpDir, "missing.yml"))
+
+	originalFetch := fetchSheetRowsFunc
+	defer func() { fetchSheetRowsFunc = originalFetch }()
+	fetchSheetRowsFunc = func(ctx context.Context, sheetID, credentialsPath string) ([][]interface{}, [][]interface{}, error) {
+		return rows, nil, nil
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectError   bool
+		expectedFiles []string
+	}{
+		{name: "unknown format", args: []string{"--format", "ris"}, expectError: true},
+		{name: "bibtex per year", args: []string{"--format", "bibtex"}, expectedFiles: []string{"reading-2024.bib", "reading-2025.bib"}},
+		{name: "csl single year", args: []string{"--format", "csl", "--year", "2025"}, expectedFiles: []string{"reading-2025.json"}},
+		{name: "articles json", args: []string{"--format", "articles", "--year", "2024"}, expectedFiles: []string{"reading-2024.articles.json"}},
+		{name: "year without reads", args: []string{"--year", "2019"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "exports")
+			err := runExport(context.Background(), append(tt.args, "--out", out))
+			if (err != nil) != tt.expectError {
+				t.Fatalf("runExport() error = %v, expectError %v", err, tt.expectError)
+			}
+
+			entries, _ := os.ReadDir(out)
+			if len(entries) != len(tt.expectedFiles) {
+				t.Fatalf("expected files %v, got %d entries", tt.expectedFiles, len(entries))
+			}
+			for _, name := range tt.expectedFiles {
+				content, err := os.ReadFile(filepath.Join(out, name))
+				if err != nil {
+					t.Fatalf("expected %s: %v", name, err)
+				}
+				if strings.Contains(string(content), "Still unread") {
+					t.Errorf("%s should only contain read articles", name)
+				}
+			}
+		})
+	}
+}
+
+func TestExportAggregates(t *testing.T) {
+	metricsDir := t.TempDir()
+	if err := exportAggregates("csv", metricsDir, t.TempDir()); err == nil {
+		t.Error("expected an error without snapshots")
+	}
+
+	snapshots := map[string]string{
+		"2025-01-03.json": `{"by_source": {"GitHub": 1}}`,
+		"2025-01-10.json": `{"by_source": {"GitHub": 2}, "by_source_read_status": {"GitHub": [1, 1]}, "by_year": {"2025": 2}}`,
+	}
+	for name, content := range snapshots {
+		if err := os.WriteFile(filepath.Join(metricsDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		format        string
+		expectedFiles []string
+	}{
+		{format: "csv", expectedFiles: []string{"months.csv", "sources.csv", "years.csv"}},
+		{format: "xlsx", expectedFiles: []string{"reading-aggregates.xlsx"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "exports")
+			if err := exportAggregates(tt.format, metricsDir, out); err != nil {
+				t.Fatalf("exportAggregates() error = %v", err)
+			}
+
+			entries, _ := os.ReadDir(out)
+			if len(entries) != len(tt.expectedFiles) {
+				t.Fatalf("expected files %v, got %d entries", tt.expectedFiles, len(entries))
+			}
+			for i, name := range tt.expectedFiles {
+				if entries[i].Name() != name {
+					t.Errorf("expected %s, got %s", name, entries[i].Name())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/promexport"
+)
+
+// runExporter serves the latest snapshot as Prometheus gauges on /metrics until interrupted
+func runExporter(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("exporter", flag.ContinueOnError)
+	addr := fs.String("addr", ":9108", "Address to listen on")
+	dir := fs.String("dir", paths.MetricsDir(), "Directory of metrics snapshots")
+	refresh := fs.Duration("refresh", 5*time.Minute, "How often to pick up a newer snapshot (0 loads it once)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	exporter, err := promexport.NewExporter(*dir)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *refresh > 0 {
+		go func() {
+			ticker := time.NewTicker(*refresh)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := exporter.Reload(); err != nil {
+						slog.Warn("Reload failed, still exporting the previous snapshot", "err", err)
+					}
+				}
+			}
+		}()
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter)
+	server := &http.Server{Addr: *addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	slog.Info("✅ Serving Prometheus metrics", "url", *addr+"/metrics")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve metrics: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"time"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/feed"
+)
+
+// runFeed logs newly added and newly read articles to the activity log the site's RSS feed is built from
+func runFeed(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("feed", flag.ContinueOnError)
+	eventsPath := fs.String("events", feed.DefaultEventsFile, "Path of the activity log to update")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	events, err := feed.LoadEvents(*eventsPath)
+	if err != nil {
+		return err
+	}
+	articles, err := fetchArticles(ctx)
+	if err != nil {
+		return err
+	}
+
+	events, added := feed.Update(events, articles, time.Now().UTC())
+	if err := feed.SaveEvents(*eventsPath, events); err != nil {
+		return err
+	}
+	slog.Info("✅ Logged new reading events", "count", added, "file", *eventsPath)
+	return nil
+}
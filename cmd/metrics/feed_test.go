@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/feed"
+)
+
+func TestRunFeed(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2025-02-01", "Read post", "https://a.com/1", "github", "TRUE"},
+		{"2025-03-01", "Unread post", "https://a.com/2", "github", "FALSE"},
+	}
+
+	tmpDir := t.TempDir()
+	t.Setenv("SHEET_ID", "test-sheet")
+	t.Setenv("CONFIG_PATH", filepath.Join(tmpDir, "missing.yml"))
+
+	originalFetch := fetchSheetRowsFunc
+	defer func() { fetchSheetRowsFunc = originalFetch }()
+	fetchSheetRowsFunc = func(ctx context.Context, sheetID, credentialsPath string) ([][]interface{}, [][]interface{}, error) {
+		return rows, nil, nil
+	}
+
+	eventsPath := filepath.Join(tmpDir, "feed", "events.json")
+	if err := runFeed(context.Background(), []string{"--events", eventsPath}); err != nil {
+		t.Fatalf("runFeed() error = %v", err)
+	}
+	events, err := feed.LoadEvents(eventsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 2 added and 1 read events, got %v", events)
+	}
+
+	// Reading the second article logs one more event
+	rows[2][4] = "TRUE"
+	if err := runFeed(context.Background(), []string{"--events", eventsPath}); err != nil {
+		t.Fatalf("runFeed() error = %v", err)
+	}
+	events, _ = feed.LoadEvents(eventsPath)
+	if len(events) != 4 || events[3].GUID != "read:https://a.com/2" {
+		t.Errorf("expected the new read event last, got %v", events)
+	}
+}
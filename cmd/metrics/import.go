@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/sources"
+)
+
+// runImport appends articles from a read-later export or a list of links to the Articles sheet,
+// skipping links already tracked
+func runImport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := fs.String("format", "", "Export format: pocket, instapaper, csv or urls (one link per line)")
+	concurrency := fs.Int("concurrency", 8, "Pages fetched at once for the urls format")
+	dryRun := fs.Bool("dry-run", false, "Print what would be imported without writing to the sheet")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || *format == "" {
+		return fmt.Errorf("usage: import --format <pocket|instapaper|csv|urls> [--concurrency N] [--dry-run] <file>")
+	}
+
+	source, err := sources.New(config.SourceConfig{Type: *format, Options: map[string]string{
+		"path":        fs.Arg(0),
+		"concurrency": strconv.Itoa(*concurrency),
+	}})
+	if err != nil {
+		return err
+	}
+	articles, err := source.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	sheetID, credentialsPath, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	fetcher, writer, err := openSheetsFunc(ctx, credentialsPath)
+	if err != nil {
+		return err
+	}
+
+	spreadsheet, err := fetcher.GetSpreadsheet(sheetID)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve spreadsheet: %w", err)
+	}
+	articlesSheet, providersSheet := metrics.FindSheetNames(spreadsheet)
+
+	// File articles from a known provider's domain under that provider
+	providerRows, err := fetcher.GetProvidersSheet(sheetID, providersSheet)
+	if err != nil {
+		slog.Warn("Unable to read providers sheet", "err", err)
+	}
+	enrichVideos(ctx, articles, metrics.BuildSourceMap(providerRows))
+	assignDomainSources(articles, providerRows)
+
+	return appendNewArticles(fetcher, writer, sheetID, articlesSheet, articles, *dryRun)
+}
+
+// enrichVideos fills the title, channel and duration of imported YouTube links, keeping any title
+// the export already had
+func enrichVideos(ctx context.Context, articles []schema.ArticleMeta, sourceMap map[string]string) {
+	for i := range articles {
+		if identity.YouTubeVideoID(articles[i].Link) == "" {
+			continue
+		}
+		meta, err := lookupMetadataFunc(ctx, identity.Parse(articles[i].Link))
+		if err != nil {
+			slog.Warn("Unable to fetch video details", "link", articles[i].Link, "err", err)
+			continue
+		}
+		if articles[i].Title == "" || articles[i].Title == articles[i].Link {
+			articles[i].Title = meta.Title
+		}
+		applyVideoMetadata(&articles[i], meta, sourceMap)
+	}
+}
+
+// assignDomainSources replaces a source that is only the link domain with the provider owning that domain
+func assignDomainSources(articles []schema.ArticleMeta, providerRows [][]interface{}) {
+	domainMap := metrics.BuildDomainMap(providerRows)
+	sourceMap := metrics.BuildSourceMap(providerRows)
+	for i := range articles {
+		domain := metrics.LinkDomain(articles[i].Link)
+		if domain != "" && articles[i].Category == domain {
+			articles[i].Category = metrics.NormalizeSourceName(metrics.SourceForDomain(domain, domainMap), sourceMap)
+		}
+	}
+}
+
+// appendNewArticles appends the articles not yet tracked in the Articles sheet, or only lists them on a dry run
+func appendNewArticles(fetcher metrics.SheetsFetcher, writer metrics.SheetsWriter, sheetID, articlesSheet string, articles []schema.ArticleMeta, dryRun bool) error {
+	rows, err := fetcher.GetArticleRows(sheetID, articlesSheet)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve data from sheet: %w", err)
+	}
+
+	newArticles := filterNewArticles(articles, metrics.ExistingLinks(rows))
+	slog.Info("Import plan", "new", len(newArticles), "tracked", len(articles)-len(newArticles))
+
+	if dryRun {
+		for _, article := range newArticles {
+			slog.Info("Would import", "date", article.Date, "title", article.Title, "category", article.Category, "read", article.Read)
+		}
+		return nil
+	}
+
+	var newRows [][]interface{}
+	for _, article := range newArticles {
+		newRows = append(newRows, metrics.ArticleRow(article))
+	}
+	if err := writer.AppendRows(sheetID, metrics.ArticlesRange(articlesSheet), newRows); err != nil {
+		return fmt.Errorf("failed to append rows: %w", err)
+	}
+
+	slog.Info("✅ Imported articles", "count", len(newRows), "sheet", articlesSheet)
+	return nil
+}
+
+// filterNewArticles drops articles whose link is already tracked or repeated, returning the rest oldest first
+func filterNewArticles(articles []schema.ArticleMeta, existing map[string]bool) []schema.ArticleMeta {
+	seen := make(map[string]bool, len(existing))
+	for link := range existing {
+		seen[link] = true
+	}
+
+	var fresh []schema.ArticleMeta
+	for _, article := range articles {
+		link := identity.Parse(article.Link).URL()
+		if link == "" || seen[link] {
+			continue
+		}
+		seen[link] = true
+		fresh = append(fresh, article)
+	}
+
+	sort.SliceStable(fresh, func(i, j int) bool { return fresh[i].Date < fresh[j].Date })
+	return fresh
+}
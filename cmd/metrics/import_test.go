@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+func TestRunImport(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-01-01", "Tracked", "https://a.com/1", "a.com", "FALSE"},
+	}
+
+	tmpDir := t.TempDir()
+	exportPath := filepath.Join(tmpDir, "instapaper.csv")
+	export := "URL,Title,Selection,Folder,Timestamp\n" +
+		"https://a.com/1,Tracked,,Unread,1704067200\n" +
+		"https://b.com/2,Second,,Archive,1704240000\n" +
+		"https://b.com/1,First,,Unread,1704153600\n" +
+		"https://b.com/1,Duplicate,,Unread,1704153600\n"
+	if err := os.WriteFile(exportPath, []byte(export), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SHEET_ID", "test-sheet")
+
+	originalOpen := openSheetsFunc
+	defer func() { openSheetsFunc = originalOpen }()
+	writer := &mockSheetsWriter{}
+	openSheetsFunc = func(ctx context.Context, credentialsPath string) (metrics.SheetsFetcher, metrics.SheetsWriter, error) {
+		return &mockSheetsFetcher{rows: rows}, writer, nil
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectError   bool
+		expectedAdded int
+	}{
+		{name: "missing format", args: []string{exportPath}, expectError: true},
+		{name: "unknown format", args: []string{"--format", "delicious", exportPath}, expectError: true},
+		{name: "dry run writes nothing", args: []string{"--format", "instapaper", "--dry-run", exportPath}},
+		{name: "appends new articles", args: []string{"--format", "instapaper", exportPath}, expectedAdded: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer.appended = nil
+			err := runImport(context.Background(), tt.args)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("runImport() error = %v, expectError %v", err, tt.expectError)
+			}
+			if len(writer.appended) != tt.expectedAdded {
+				t.Fatalf("expected %d appended rows, got %d", tt.expectedAdded, len(writer.appended))
+			}
+		})
+	}
+
+	// New rows are appended oldest first with read status mapped from the Archive folder
+	if writer.appended[0][1] != "First" || writer.appended[1][4] != "TRUE" {
+		t.Errorf("unexpected appended rows %v", writer.appended)
+	}
+}
+
+func TestRunImportURLs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/post":
+			fmt.Fprint(w, `<html><head><title>A Post</title><link rel="canonical" href="/post-canonical"></head></html>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-01-01", "Tracked", server.URL + "/tracked", "Local", "FALSE"},
+	}
+	providers := [][]interface{}{
+		{"name", "url"},
+		{"Local Blog", server.URL},
+	}
+	listPath := filepath.Join(t.TempDir(), "tabs.txt")
+	list := "# browser tabs\n" + server.URL + "/post\n\n" + server.URL + "/missing\n" + server.URL + "/tracked\n" + server.URL + "/post\n"
+	if err := os.WriteFile(listPath, []byte(list), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SHEET_ID", "test-sheet")
+
+	originalOpen := openSheetsFunc
+	defer func() { openSheetsFunc = originalOpen }()
+	writer := &mockSheetsWriter{}
+	openSheetsFunc = func(ctx context.Context, credentialsPath string) (metrics.SheetsFetcher, metrics.SheetsWriter, error) {
+		return &mockSheetsFetcher{rows: rows, providers: providers}, writer, nil
+	}
+
+	if err := runImport(context.Background(), []string{"--format", "urls", "--concurrency", "2", listPath}); err != nil {
+		t.Fatalf("runImport() error = %v", err)
+	}
+
+	today := time.Now().Format("2006-01-02")
+	expected := [][]interface{}{
+		{today, "A Post", server.URL + "/post-canonical", "Local Blog", "FALSE"},
+		{today, server.URL + "/missing", server.URL + "/missing", "Local Blog", "FALSE"},
+	}
+	if fmt.Sprint(writer.appended) != fmt.Sprint(expected) {
+		t.Errorf("appended %v, want %v", writer.appended, expected)
+	}
+
+	if err := runImport(context.Background(), []string{"--format", "urls", "--concurrency", "0", listPath}); err == nil {
+		t.Error("expected error for zero concurrency")
+	}
+}
+
+func TestEnrichVideos(t *testing.T) {
+	originalLookup := lookupMetadataFunc
+	defer func() { lookupMetadataFunc = originalLookup }()
+	lookupMetadataFunc = func(ctx context.Context, id identity.Identifier) (identity.Metadata, error) {
+		if id.Value == "https://youtu.be/badbadbad00" {
+			return identity.Metadata{}, fmt.Errorf("not found")
+		}
+		return identity.Metadata{Title: "A Great Talk", Publisher: "gophercon", Duration: 30 * time.Minute}, nil
+	}
+
+	articles := []schema.ArticleMeta{
+		{Title: "https://youtu.be/dQw4w9WgXcQ", Link: "https://youtu.be/dQw4w9WgXcQ", Category: "youtu.be"},
+		{Title: "Saved Title", Link: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", Category: "Talks"},
+		{Title: "Missing", Link: "https://youtu.be/badbadbad00", Category: "youtu.be"},
+		{Title: "A Post", Link: "https://github.blog/post", Category: "github.blog"},
+	}
+	enrichVideos(context.Background(), articles, map[string]string{"gophercon": "GopherCon"})
+
+	expected := []schema.ArticleMeta{
+		{Title: "A Great Talk", Link: "https://youtu.be/dQw4w9WgXcQ", Category: "GopherCon", MediaType: "video", DurationMinutes: 30},
+		{Title: "Saved Title", Link: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", Category: "Talks", MediaType: "video", DurationMinutes: 30},
+		{Title: "Missing", Link: "https://youtu.be/badbadbad00", Category: "youtu.be"},
+		{Title: "A Post", Link: "https://github.blog/post", Category: "github.blog"},
+	}
+	if fmt.Sprint(articles) != fmt.Sprint(expected) {
+		t.Errorf("enrichVideos() = %+v, want %+v", articles, expected)
+	}
+}
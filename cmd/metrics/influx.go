@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/influx"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// runInflux writes snapshot aggregates as line protocol to InfluxDB, stdout or a file
+func runInflux(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("influx", flag.ContinueOnError)
+	dir := fs.String("dir", paths.MetricsDir(), "Directory of metrics snapshots")
+	all := fs.Bool("all", false, "Write every snapshot instead of only the latest, to backfill history")
+	out := fs.String("out", "", "Write line protocol to this file, or - for stdout, instead of pushing to InfluxDB")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	files, err := metrics.ListSnapshotFiles(*dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no snapshots found in %s", *dir)
+	}
+	if !*all {
+		files = files[len(files)-1:]
+	}
+
+	var lines []string
+	for _, file := range files {
+		snapshot, err := metrics.LoadSnapshot(*dir, file)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, influx.Lines(*snapshot)...)
+	}
+
+	switch *out {
+	case "":
+		cfg, err := config.Load(config.Path())
+		if err != nil {
+			return err
+		}
+		client := influx.FromConfig(cfg.InfluxDB)
+		if client == nil {
+			return fmt.Errorf("no InfluxDB server is set (influxdb.url or INFLUX_URL); use --out to write line protocol instead")
+		}
+		if err := client.Push(ctx, lines); err != nil {
+			return err
+		}
+		slog.Info("✅ Wrote points to InfluxDB", "points", len(lines), "snapshots", len(files))
+		return nil
+	case "-":
+		return influx.Write(os.Stdout, lines)
+	default:
+		return writeLineProtocolFile(*out, lines)
+	}
+}
+
+// writeLineProtocolFile writes lines to path, replacing any previous file
+func writeLineProtocolFile(path string, lines []string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := influx.Write(f, lines); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+	slog.Info("✅ Wrote points", "points", len(lines), "file", path)
+	return nil
+}
+
+// applyInflux writes the snapshot's aggregates to InfluxDB when a server is configured.
+// Failures are logged only; InfluxDB never fails a metrics run.
+func applyInflux(ctx context.Context, metricsData schema.Metrics, cfg config.InfluxConfig) {
+	client := influx.FromConfig(cfg)
+	if client == nil {
+		return
+	}
+	if err := client.Push(ctx, influx.Lines(metricsData)); err != nil {
+		slog.Warn("Unable to write to InfluxDB", "err", err)
+		return
+	}
+	slog.Info("📈 Wrote snapshot to InfluxDB")
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestRunInflux(t *testing.T) {
+	tmpDir := t.TempDir()
+	metricsDir := filepath.Join(tmpDir, "metrics")
+	if err := os.MkdirAll(metricsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for i, date := range []string{"2026-03-06", "2026-03-13"} {
+		day, _ := time.Parse("2006-01-02", date)
+		content, _ := json.Marshal(schema.Metrics{TotalArticles: 10 + i, UnreadCount: 10 + i, LastUpdated: day})
+		if err := os.WriteFile(filepath.Join(metricsDir, date+".json"), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("CONFIG_PATH", filepath.Join(tmpDir, "missing.yml"))
+	t.Setenv("INFLUX_URL", "")
+	if err := runInflux(context.Background(), []string{"--dir", metricsDir}); err == nil {
+		t.Error("expected an error without an InfluxDB server or --out")
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{"latest only", nil, []string{"total_articles=11i"}},
+		{"all snapshots", []string{"--all"}, []string{"total_articles=10i", "total_articles=11i"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(tmpDir, "points.txt")
+			args := append([]string{"--dir", metricsDir, "--out", out}, tt.args...)
+			if err := runInflux(context.Background(), args); err != nil {
+				t.Fatalf("runInflux() error = %v", err)
+			}
+			content, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			if len(lines) != len(tt.expected) {
+				t.Fatalf("expected %d points, got:\n%s", len(tt.expected), content)
+			}
+			for i, want := range tt.expected {
+				if !strings.Contains(lines[i], want) {
+					t.Errorf("line %d = %q, expected it to contain %q", i, lines[i], want)
+				}
+			}
+		})
+	}
+}
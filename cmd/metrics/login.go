@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/googleauth"
+)
+
+// runLogin asks the user to consent to reading and editing their sheet ahead of the first run, so
+// scheduled runs with an OAuth client ID never stop to wait for a browser
+func runLogin(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	defaultPath := os.Getenv(googleauth.CredentialsPathEnv)
+	if defaultPath == "" {
+		defaultPath = googleauth.DefaultCredentialsPath
+	}
+	credentialsPath := fs.String("credentials", defaultPath, "OAuth client ID of a desktop app, downloaded from the Cloud console (default $CREDENTIALS_PATH, or ./credentials.json)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(*credentialsPath)
+	if err != nil {
+		return fmt.Errorf("unable to read credentials %s: %w", *credentialsPath, err)
+	}
+	if !googleauth.IsOAuthClient(data) {
+		return fmt.Errorf("%s is not an OAuth client ID; service account keys need no login", *credentialsPath)
+	}
+	if _, err := googleauth.ClientOption(ctx, *credentialsPath, sheets.SpreadsheetsReadonlyScope, sheets.SpreadsheetsScope); err != nil {
+		return err
+	}
+	slog.Info("✅ Signed in", "token", googleauth.TokenPath())
+	return nil
+}
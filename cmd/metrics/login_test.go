@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunLogin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(path, []byte(`{"type": "service_account"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	err := runLogin(context.Background(), []string{"--credentials", path})
+	if err == nil || !strings.Contains(err.Error(), "service account") {
+		t.Errorf("expected a service account key to be refused, got %v", err)
+	}
+	if err := runLogin(context.Background(), []string{"--credentials", filepath.Join(t.TempDir(), "missing.json")}); err == nil {
+		t.Error("expected an error for missing credentials")
+	}
+}
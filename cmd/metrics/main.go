@@ -5,13 +5,30 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 
 	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/community"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/googleauth"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/locale"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/logging"
 	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/notify"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/runmanifest"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/sources"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/stepsummary"
 )
 
 // MetricsFetcher defines the interface for fetching metrics
@@ -25,24 +42,162 @@ type DefaultMetricsFetcher struct{}
 // fetchMetricsFunc is a package-level variable that can be mocked in tests
 var fetchMetricsFunc = metrics.FetchMetricsFromSheets
 
-// logFatalf is a package-level variable that can be mocked in tests
-var logFatalf = log.Fatalf
+// fetchSourcesFunc is a package-level variable that can be mocked in tests
+var fetchSourcesFunc = sources.FetchMetrics
+
+// publicMode strips article titles and links from the snapshot before it is written, set by --public
+var publicMode bool
+
+// logFatal is a package-level variable that can be mocked in tests
+var logFatal = logging.Fatal
+
+// run records the default run's stage timings and counts for its run manifest. It stays nil in
+// tests and subcommands, which record nothing.
+var run *runmanifest.Recorder
+
+// paths holds the snapshot and site directories from config.yml, which subcommands default their
+// --dir and --site flags to; --metrics-dir overrides the snapshot directory of the default run
+var paths config.PathsConfig
+
+// lockedSubcommands write metrics snapshots, so they hold the run lock like the default run
+var lockedSubcommands = map[string]bool{"backfill": true, "commit": true, "done": true, "prune": true, "restore": true, "source": true}
+
+// subcommands maps the first CLI argument to a dedicated command with its own flags
+var subcommands = map[string]func(ctx context.Context, args []string) error{
+	"add":        runAdd,
+	"archive":    runArchive,
+	"backfill":   runBackfill,
+	"backup":     runBackup,
+	"checklinks": runCheckLinks,
+	"commit":     runCommit,
+	"discover":   runDiscover,
+	"digest":     runDigest,
+	"done":       runDone,
+	"export":     runExport,
+	"exporter":   runExporter,
+	"feed":       runFeed,
+	"import":     runImport,
+	"influx":     runInflux,
+	"login":      runLogin,
+	"plan":       runPlan,
+	"prune":      runPrune,
+	"restore":    runRestore,
+	"serve":      runServe,
+	"source":     runSource,
+	"triage":     runTriage,
+}
 
 func main() {
-	if err := godotenv.Load(); err != nil {
-		log.Println("Warning: .env file not found, will use environment variables")
+	// Load .env first, so LOG_LEVEL and LOG_FORMAT can come from it
+	envErr := godotenv.Load()
+	args, err := logging.Init(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if envErr != nil {
+		slog.Warn(".env file not found, will use environment variables")
+	}
+	if args, err = config.InitProfile(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if _, err := config.Load(config.Path()); err != nil && config.Profile() != "" {
+		logFatal("Invalid --profile", "err", err)
+	}
+	applyTimezone()
+	paths = loadPaths()
+
+	// Ctrl-C, or the SIGTERM of an Actions timeout, cancels the run so the run lock is released
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if len(args) > 0 {
+		if command, exists := subcommands[args[0]]; exists {
+			var err error
+			if lockedSubcommands[args[0]] {
+				err = withRunLock(func() error { return command(ctx, args[1:]) })
+			} else {
+				err = command(ctx, args[1:])
+			}
+			if err != nil {
+				logFatal("❌ Command failed", "command", args[0], "err", err)
+			}
+			return
+		}
 	}
 
 	fetchFlag := flag.Bool("fetch", false, "Only fetch metrics from Google Sheets")
 	summarizeFlag := flag.Bool("summarize", false, "Only generate AI delta analysis for the latest metrics")
-	flag.Parse()
+	metricsDirFlag := flag.String("metrics-dir", paths.MetricsDir(), "Directory snapshots are read from and written to (default paths.metrics in config.yml, or metrics)")
+	publicFlag := flag.Bool("public", false, "Strip article titles, links and annotations from the snapshot, keeping only aggregates (default public in config.yml)")
+	dryRunFlag := flag.Bool("dry-run", false, "Fetch and compute metrics and print how they compare to the latest snapshot, without writing, publishing or committing anything")
+	// Listed for -h only; logging.Init and config.InitProfile have already consumed them
+	flag.String("log-level", "info", "Minimum log level: debug, info, warn or error (default $LOG_LEVEL)")
+	flag.String("log-format", logging.FormatText, "Log output format: text or json (default $LOG_FORMAT)")
+	flag.String("profile", "", "Run the named profile from config.yml, with its own sources and snapshot subdirectory (default $PROFILE)")
+	flag.CommandLine.Parse(args)
+	paths.Metrics = *metricsDirFlag
+	publicMode = *publicFlag
 
-	ctx := context.Background()
 	fetcher := &DefaultMetricsFetcher{}
 
-	if err := execute(ctx, fetcher, *fetchFlag, *summarizeFlag); err != nil {
-		logFatalf("%v", err)
+	run = runmanifest.New("metrics")
+	restoreLogger := run.CaptureWarnings()
+	err = withRunLock(func() error { return execute(ctx, fetcher, *fetchFlag, *summarizeFlag, *dryRunFlag) })
+	restoreLogger()
+	if (!*summarizeFlag || *fetchFlag) && !*dryRunFlag {
+		writeRunManifest(paths.MetricsDir(), run.Finish(err))
 	}
+	if err != nil {
+		logFatal("❌ Metrics run failed", "err", err)
+	}
+}
+
+// writeRunManifest saves the run's manifest beside the snapshot it produced, named after the run's
+// start date, as the snapshot is. A failed write is logged only.
+func writeRunManifest(dir string, m runmanifest.Manifest) {
+	path := filepath.Join(dir, runmanifest.Filename(m.StartedAt.Format("2006-01-02")))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		slog.Warn("Unable to write the run manifest", "err", err)
+		return
+	}
+	if err := runmanifest.Write(path, m); err != nil {
+		slog.Warn("Unable to write the run manifest", "err", err)
+		return
+	}
+	slog.Info("✅ Run manifest saved", "file", path, "duration_ms", m.DurationMS)
+}
+
+// withRunLock runs fn holding the lock shared with cmd/web, so two runs never interleave their writes
+func withRunLock(fn func() error) error {
+	lock, err := safefile.Acquire(safefile.LockFile, safefile.StaleLockAge)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+	return fn()
+}
+
+// applyTimezone switches the process to the timezone in config.yml, so snapshot filenames and
+// month boundaries do not depend on the runner's zone
+func applyTimezone() {
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		return // reported by the command that loads the config
+	}
+	if err := config.ApplyTimezone(cfg.Timezone); err != nil {
+		slog.Warn("Invalid timezone", "err", err, "using", time.Local.String())
+	}
+}
+
+// loadPaths reads the snapshot and site directories from config.yml
+func loadPaths() config.PathsConfig {
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		return config.PathsConfig{} // reported by the command that loads the config
+	}
+	return cfg.Paths
 }
 
 // FetchMetrics fetches metrics from Google Sheets
@@ -50,25 +205,35 @@ func (d *DefaultMetricsFetcher) FetchMetrics(ctx context.Context, sheetID, crede
 	return fetchMetricsFunc(ctx, sheetID, credentialsPath)
 }
 
-// loadConfiguration loads environment variables and returns sheetID and credentialsPath
+// loadConfiguration loads environment variables and returns sheetID and credentialsPath, which is
+// empty when Application Default Credentials should be used. When SHEET_ID lists several sheets,
+// the first one is returned, as the sheet that commands editing rows work on.
 func loadConfiguration() (string, string, error) {
-	sheetID := os.Getenv("SHEET_ID")
-	credentialsPath := os.Getenv("CREDENTIALS_PATH")
+	sheetIDs := loadSheetIDs()
+	credentialsPath := googleauth.CredentialsPath()
 
-	if sheetID == "" {
+	if len(sheetIDs) == 0 {
 		return "", "", fmt.Errorf("SHEET_ID environment variable is required")
 	}
-	if credentialsPath == "" {
-		credentialsPath = "./credentials.json"
-	}
 
-	return sheetID, credentialsPath, nil
+	return sheetIDs[0], credentialsPath, nil
+}
+
+// loadSheetIDs splits SHEET_ID on commas, so older data can live in separate spreadsheets
+func loadSheetIDs() []string {
+	var sheetIDs []string
+	for _, id := range strings.Split(os.Getenv("SHEET_ID"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			sheetIDs = append(sheetIDs, id)
+		}
+	}
+	return sheetIDs
 }
 
-// saveMetrics saves metrics to a JSON file
-func saveMetrics(metricsData schema.Metrics) (string, error) {
+// saveMetrics saves metrics to a JSON file in dir
+func saveMetrics(dir string, metricsData schema.Metrics) (string, error) {
 	// Create metrics directory
-	if err := os.MkdirAll("metrics", 0755); err != nil {
+	if err := os.MkdirAll(dir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create metrics directory: %w", err)
 	}
 
@@ -81,38 +246,269 @@ func saveMetrics(metricsData schema.Metrics) (string, error) {
 	// Generate filename with date
 
 	dateFilename := metricsData.LastUpdated.Format("2006-01-02") + ".json"
-	metricsFilePath := fmt.Sprintf("metrics/%s", dateFilename)
+	metricsFilePath := filepath.Join(dir, dateFilename)
 
 	// Write to file
-	if err := os.WriteFile(metricsFilePath, metricsJSON, 0644); err != nil {
+	if err := safefile.WriteFile(metricsFilePath, metricsJSON, 0644); err != nil {
 		return "", fmt.Errorf("failed to write metrics file: %w", err)
 	}
 
-	log.Printf("✅ Metrics saved to metrics/%s\n", dateFilename)
+	slog.Info("✅ Metrics saved", "file", metricsFilePath)
 	return dateFilename, nil
 }
 
-// runFetch executes the fetch logic
-func runFetch(ctx context.Context, fetcher MetricsFetcher) (string, *schema.Metrics, error) {
-	// Load configuration
-	sheetID, credentialsPath, err := loadConfiguration()
+// runFetch executes the fetch logic. A dry run stops before anything is published or written and
+// prints a preview of the snapshot instead.
+func runFetch(ctx context.Context, fetcher MetricsFetcher, dryRun bool) (string, *schema.Metrics, error) {
+	cfg, err := config.Load(config.Path())
 	if err != nil {
-		return "", nil, err
+		slog.Warn("Using default configuration", "err", err)
 	}
 
-	// Fetch metrics from Google Sheets
-	metricsData, err := fetcher.FetchMetrics(ctx, sheetID, credentialsPath)
+	// Fetch metrics from the configured sources, or the Google Sheet alone
+	endStage := run.Start("fetch")
+	metricsData, err := fetchConfiguredMetrics(ctx, fetcher, cfg)
+	endStage(err)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to fetch metrics: %w", err)
+		return "", nil, err
+	}
+	run.Count(runmanifest.RowsProcessed, metricsData.TotalArticles+metricsData.SkippedRows)
+	run.Count(runmanifest.RowsSkipped, metricsData.SkippedRows)
+
+	// Fold renamed source labels into their canonical names
+	endStage = run.Start("enrich")
+	metrics.ApplySourceAliases(&metricsData, cfg.SourceAliases)
+
+	// Flag aggregation bugs before the snapshot is written
+	metrics.ReportConsistency("fetched metrics", metricsData)
+
+	// Score this snapshot against the previous one
+	applyEnergyScore(&metricsData, cfg.Energy)
+
+	// Flag a read rate drop and the milestones crossed since the previous snapshot
+	applyAlerts(&metricsData)
+
+	// Compare against the snapshots taken 30 and 90 days ago
+	if err := metrics.ApplyRollingBaselines(&metricsData, paths.MetricsDir()); err != nil {
+		slog.Warn("Unable to load snapshots for rolling statistics", "err", err)
+	}
+
+	// Share anonymized counts and compare against the community, when opted in
+	if !dryRun {
+		applyCommunity(ctx, &metricsData, cfg.Community)
+	}
+
+	// Keep only aggregates in a snapshot that is published
+	if publicMode || cfg.Public {
+		metrics.Redact(&metricsData)
+	}
+	endStage()
+
+	if dryRun {
+		return previewMetrics(metricsData)
 	}
 
 	// Save metrics
-	filename, err := saveMetrics(metricsData)
+	endStage = run.Start("save")
+	filename, err := saveMetrics(paths.MetricsDir(), metricsData)
+	endStage(err)
 	if err != nil {
 		return "", nil, err
 	}
 
-	log.Println("✅ Successfully generated metrics from Google Sheets")
+	// Post the run's summary to the configured chat webhooks and push topics
+	endStage = run.Start("publish")
+	applyNotifications(ctx, metricsData, cfg.Notifications, cfg.Branding)
+
+	// Write the snapshot's aggregates to InfluxDB, when configured
+	applyInflux(ctx, metricsData, cfg.InfluxDB)
+
+	// Report the snapshot on the Actions run page
+	appendStepSummary(metricsData)
+	endStage()
+
+	slog.Info("✅ Successfully generated metrics")
+	return filename, &metricsData, nil
+}
+
+// fetchConfiguredMetrics merges every source listed in config.yml, falling back to the sheets in SHEET_ID when none are listed.
+// Category rules, identifier lookups, fiscal years and several sheets need article-level data, so they always go through
+// the source registry.
+func fetchConfiguredMetrics(ctx context.Context, fetcher MetricsFetcher, cfg config.Config) (schema.Metrics, error) {
+	rules, err := metrics.CompileRules(cfg.CategoryRules)
+	if err != nil {
+		return schema.Metrics{}, fmt.Errorf("invalid category rules: %w", err)
+	}
+
+	sheetIDs := loadSheetIDs()
+	if len(cfg.Sources) > 0 || rules != nil || cfg.LookupIdentifiers || cfg.YearStartMonth > 1 || len(sheetIDs) > 1 {
+		sourceConfigs := cfg.Sources
+		if len(sourceConfigs) == 0 {
+			sourceConfigs = []config.SourceConfig{{Type: "sheets"}}
+			if len(sheetIDs) > 1 {
+				sourceConfigs = nil
+				for _, id := range sheetIDs {
+					sourceConfigs = append(sourceConfigs, config.SourceConfig{Type: "sheets", Options: map[string]string{"sheet_id": id}})
+				}
+			}
+		}
+		all, err := sources.NewAll(sourceConfigs)
+		if err != nil {
+			return schema.Metrics{}, fmt.Errorf("failed to configure sources: %w", err)
+		}
+		opts := sources.Options{Compute: metrics.ComputeOptions{Rules: rules, YearStartMonth: cfg.YearStartMonth}}
+		if cfg.LookupIdentifiers {
+			opts.Resolver = identity.NewResolver()
+		}
+		metricsData, err := fetchSourcesFunc(ctx, all, time.Now(), opts)
+		if err != nil {
+			return schema.Metrics{}, fmt.Errorf("failed to fetch metrics: %w", err)
+		}
+		logRuleMatches(metricsData.CategoryRuleMatches)
+		return metricsData, nil
+	}
+
+	sheetID, credentialsPath, err := loadConfiguration()
+	if err != nil {
+		return schema.Metrics{}, err
+	}
+
+	metricsData, err := fetcher.FetchMetrics(ctx, sheetID, credentialsPath)
+	if err != nil {
+		return schema.Metrics{}, fmt.Errorf("failed to fetch metrics: %w", err)
+	}
+	return metricsData, nil
+}
+
+// logRuleMatches prints how many articles each category rule matched, flagging rules that matched nothing
+func logRuleMatches(matches map[string]int) {
+	if len(matches) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(matches))
+	for name := range matches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if matches[name] == 0 {
+			slog.Warn("📐 Category rule never matched, check the pattern", "rule", name)
+			continue
+		}
+		slog.Info("📐 Category rule matches", "rule", name, "matches", matches[name])
+	}
+}
+
+// applyEnergyScore calculates the composite energy score against the latest earlier snapshot
+func applyEnergyScore(metricsData *schema.Metrics, cfg config.EnergyConfig) {
+	filename := metricsData.LastUpdated.Format("2006-01-02") + ".json"
+	prev, err := metrics.LoadSnapshotBefore(paths.MetricsDir(), filename)
+	if err != nil {
+		slog.Warn("Unable to load previous snapshot for energy score", "err", err)
+	}
+
+	score := metrics.CalculateEnergyScore(*metricsData, prev, cfg)
+	metricsData.EnergyScore = &score
+	slog.Info("⚡ Energy score", "score", score.Score)
+}
+
+// applyAlerts adds the alerts that compare the snapshot with the previous one and logs every alert
+func applyAlerts(metricsData *schema.Metrics) {
+	filename := metricsData.LastUpdated.Format("2006-01-02") + ".json"
+	prev, err := metrics.LoadSnapshotBefore(paths.MetricsDir(), filename)
+	if err != nil {
+		slog.Warn("Unable to load previous snapshot for alerts", "err", err)
+	}
+
+	metrics.ApplyAlerts(metricsData, prev)
+	for _, alert := range metricsData.Alerts {
+		slog.Info("🔔 Alert", "kind", alert.Kind, "message", alert.Message)
+	}
+}
+
+// applyCommunity publishes the snapshot's anonymized counts and stores the community medians on it.
+// Failures are logged only; the community endpoint never blocks a metrics run.
+func applyCommunity(ctx context.Context, metricsData *schema.Metrics, cfg config.CommunityConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	if cfg.Endpoint == "" {
+		slog.Warn("community.enabled is set without community.endpoint, skipping community stats")
+		return
+	}
+
+	client := community.NewClient(cfg.Endpoint)
+	if err := client.Publish(ctx, community.FromMetrics(*metricsData)); err != nil {
+		slog.Warn("Unable to publish community stats", "err", err)
+	}
+	comparison, err := client.Compare(ctx)
+	if err != nil {
+		slog.Warn("Unable to compare against the community", "err", err)
+		return
+	}
+	metricsData.Community = &comparison
+	slog.Info("👥 Community comparison", "read_rate", metricsData.ReadRate,
+		"median_read_rate", comparison.MedianReadRate, "participants", comparison.Participants)
+}
+
+// applyNotifications posts the snapshot's summary to every configured notifier, titled and
+// localized by the branding settings. Failures are logged only; a webhook never fails a metrics run.
+func applyNotifications(ctx context.Context, metricsData schema.Metrics, cfgs []config.NotifierConfig, branding config.BrandingConfig) {
+	cfgs = notify.WithEnvNotifiers(cfgs)
+	if len(cfgs) == 0 {
+		return
+	}
+
+	filename := metricsData.LastUpdated.Format("2006-01-02") + ".json"
+	prev, err := metrics.LoadSnapshotBefore(paths.MetricsDir(), filename)
+	if err != nil {
+		slog.Warn("Unable to load previous snapshot for notifications", "err", err)
+	}
+	summary := notify.BuildSummary(prev, metricsData)
+	summary.Title = branding.Title
+	summary.Locale, err = locale.Parse(branding.Locale)
+	if err != nil {
+		slog.Warn("Invalid locale", "err", err, "using", locale.Default)
+	}
+	if err := notify.NotifyAll(ctx, cfgs, summary); err != nil {
+		slog.Warn("Notifications failed", "err", err)
+		return
+	}
+	slog.Info("🔔 Notified channels", "count", len(cfgs))
+}
+
+// appendStepSummary adds the snapshot's totals, deltas and warnings to the GitHub Actions step summary
+func appendStepSummary(metricsData schema.Metrics) {
+	if os.Getenv(stepsummary.EnvVar) == "" {
+		return
+	}
+	filename := metricsData.LastUpdated.Format("2006-01-02") + ".json"
+	prev, err := metrics.LoadSnapshotBefore(paths.MetricsDir(), filename)
+	if err != nil {
+		slog.Warn("Unable to load previous snapshot for the step summary", "err", err)
+	}
+	summary := stepsummary.Metrics(prev, metricsData, metrics.CheckConsistency(metricsData))
+	if err := stepsummary.Append(summary); err != nil {
+		slog.Warn("Unable to write the step summary", "err", err)
+	}
+}
+
+// previewMetrics prints the computed snapshot against the one it would replace, or else the latest
+// earlier one, in the step summary's Markdown format, without writing it
+func previewMetrics(metricsData schema.Metrics) (string, *schema.Metrics, error) {
+	filename := metricsData.LastUpdated.Format("2006-01-02") + ".json"
+	prev, err := metrics.LoadSnapshot(paths.MetricsDir(), filename)
+	if err != nil {
+		prev, err = metrics.LoadSnapshotBefore(paths.MetricsDir(), filename)
+		if err != nil {
+			slog.Warn("Unable to load previous snapshot for the preview", "err", err)
+		}
+	}
+
+	fmt.Print(stepsummary.Metrics(prev, metricsData, metrics.CheckConsistency(metricsData)))
+	slog.Info("Dry run, nothing written", "would_write", filepath.Join(paths.MetricsDir(), filename))
 	return filename, &metricsData, nil
 }
 
@@ -123,15 +519,16 @@ func runDeltaAnalysis(ctx context.Context, filename string, metricsData *schema.
 	}
 
 	// Generate AI Delta Analysis
-	if err := metrics.GenerateAndSaveDeltaAnalysis(ctx, "metrics", filename, metricsData); err != nil {
-		fmt.Fprintf(os.Stderr, "Error generating AI delta analysis: %v\n", err)
+	if err := metrics.GenerateAndSaveDeltaAnalysis(ctx, paths.MetricsDir(), filename, metricsData); err != nil {
+		slog.Error("Error generating AI delta analysis", "err", err)
 	}
-	log.Println("✅ AI Delta Analysis generated and saved.")
+	slog.Info("✅ AI Delta Analysis generated and saved")
 	return nil
 }
 
-// execute runs the application logic based on flags
-func execute(ctx context.Context, fetcher MetricsFetcher, fetchFlag, summarizeFlag bool) error {
+// execute runs the application logic based on flags. A dry run only fetches and previews; the AI
+// analysis and auto-commit both write, so they are skipped.
+func execute(ctx context.Context, fetcher MetricsFetcher, fetchFlag, summarizeFlag, dryRun bool) error {
 	// Default behavior: Run both
 	runBoth := !fetchFlag && !summarizeFlag
 
@@ -140,28 +537,32 @@ func execute(ctx context.Context, fetcher MetricsFetcher, fetchFlag, summarizeFl
 	var err error
 
 	if runBoth || fetchFlag {
-		filename, metricsData, err = runFetch(ctx, fetcher)
+		filename, metricsData, err = runFetch(ctx, fetcher, dryRun)
 		if err != nil {
 			return fmt.Errorf("Error fetching metrics: %w", err)
 		}
 	}
+	if dryRun {
+		if runBoth || summarizeFlag {
+			slog.Info("Dry run, skipping AI delta analysis")
+		}
+		return nil
+	}
 
 	if runBoth || summarizeFlag {
 		if summarizeFlag && filename == "" {
 			// Standalone mode: Find latest file in metrics/ dir
-			entries, err := os.ReadDir("metrics")
+			files, err := metrics.ListSnapshotFiles(paths.MetricsDir())
 			if err == nil {
-				// Find last one
+				// Find last one, skipping run manifests and other side files
 				var lastFile string
-				for _, e := range entries {
-					if !e.IsDir() {
-						lastFile = e.Name()
-					}
+				if len(files) > 0 {
+					lastFile = files[len(files)-1]
 				}
 				if lastFile != "" {
 					filename = lastFile
 					// Load it
-					bytes, _ := os.ReadFile("metrics/" + lastFile)
+					bytes, _ := os.ReadFile(filepath.Join(paths.MetricsDir(), lastFile))
 					var m schema.Metrics
 					if json.Unmarshal(bytes, &m) == nil {
 						metricsData = &m
@@ -171,12 +572,27 @@ func execute(ctx context.Context, fetcher MetricsFetcher, fetchFlag, summarizeFl
 		}
 
 		if metricsData != nil {
-			if err := runDeltaAnalysis(ctx, filename, metricsData); err != nil {
-				log.Printf("Warning: AI delta analysis failed: %v", err)
+			endStage := run.Start("summarize")
+			err := runDeltaAnalysis(ctx, filename, metricsData)
+			endStage(err)
+			if err != nil {
+				slog.Warn("AI delta analysis failed", "err", err)
 				// Don't error here, as the primary metrics are safe
 			}
 		} else {
-			log.Println("No metrics data available to perform delta analysis.")
+			slog.Info("No metrics data available to perform delta analysis")
+		}
+	}
+
+	// Commit the new snapshot, with its delta analysis, when git.auto_commit is set
+	if runBoth || fetchFlag {
+		if cfg, err := config.Load(config.Path()); err == nil && cfg.Git.AutoCommit {
+			endStage := run.Start("commit")
+			err := commitSnapshots(ctx, cfg.Git, paths.MetricsDir())
+			endStage(err)
+			if err != nil {
+				slog.Warn("Auto-commit failed", "err", err)
+			}
 		}
 	}
 	return nil
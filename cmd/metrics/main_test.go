@@ -3,12 +3,19 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/sources"
 )
 
 // MockMetricsFetcher implements MetricsFetcher for testing
@@ -59,11 +66,11 @@ func TestLoadConfiguration(t *testing.T) {
 			expectError:   false,
 		},
 		{
-			name:          "Success with default credentials path",
+			name:          "Success with Application Default Credentials",
 			envSheetID:    "test-sheet-123",
 			envCredPath:   "",
 			expectedSheet: "test-sheet-123",
-			expectedCred:  "./credentials.json",
+			expectedCred:  "",
 			expectError:   false,
 		},
 		{
@@ -180,7 +187,7 @@ func TestSaveMetrics(t *testing.T) {
 				t.Fatalf("Setup failed: %v", err)
 			}
 
-			filename, err := saveMetrics(tt.metrics)
+			filename, err := saveMetrics("metrics", tt.metrics)
 
 			if tt.expectError {
 				if err == nil {
@@ -270,7 +277,7 @@ func TestRunFetch(t *testing.T) {
 			}
 			os.Setenv("CREDENTIALS_PATH", "dummy.json")
 
-			filename, metrics, err := runFetch(context.Background(), tt.fetcher)
+			filename, metrics, err := runFetch(context.Background(), tt.fetcher, false)
 
 			if tt.expectError {
 				if err == nil {
@@ -365,7 +372,7 @@ func TestMainBehavior(t *testing.T) {
 			// Call execute() directly instead of main() to avoid flag redefinition
 			fetcher := &DefaultMetricsFetcher{}
 			// Default flags: fetch=false, summarize=false -> runs both
-			err = execute(context.Background(), fetcher, false, false)
+			err = execute(context.Background(), fetcher, false, false, false)
 
 			if tt.expectError {
 				if err == nil {
@@ -402,3 +409,206 @@ func searchString(s, substr string) bool {
 	}
 	return false
 }
+
+// TestExecuteDryRun tests that a dry run computes the snapshot without writing anything
+func TestExecuteDryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change to temp directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+	t.Setenv("CONFIG_PATH", "config.yml")
+	t.Setenv("SHEET_ID", "test-sheet-123")
+
+	fetcher := &MockMetricsFetcher{mockMetrics: createMockMetrics(time.Date(2025, 12, 21, 10, 30, 0, 0, time.UTC))}
+	if err := execute(context.Background(), fetcher, false, false, true); err != nil {
+		t.Fatalf("execute() error = %v", err)
+	}
+	if _, err := os.Stat("metrics"); !os.IsNotExist(err) {
+		t.Errorf("expected a dry run to write nothing, got %v", err)
+	}
+}
+
+// TestRunFetchWithSources tests that configured sources replace the single sheet fetch
+func TestRunFetchWithSources(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change to temp directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	configYAML := "sources:\n  - type: csv\n    path: papers.csv\n"
+	if err := os.WriteFile("config.yml", []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_PATH", "config.yml")
+	t.Setenv("SHEET_ID", "")
+
+	originalFetch := fetchSourcesFunc
+	defer func() { fetchSourcesFunc = originalFetch }()
+
+	var received int
+	fetchSourcesFunc = func(ctx context.Context, all []sources.Source, referenceDate time.Time, opts sources.Options) (schema.Metrics, error) {
+		received = len(all)
+		return createMockMetrics(time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC)), nil
+	}
+
+	fetcher := &MockMetricsFetcher{mockError: fmt.Errorf("sheet fetcher should not be used")}
+	filename, _, err := runFetch(context.Background(), fetcher, false)
+	if err != nil {
+		t.Fatalf("runFetch() error = %v", err)
+	}
+	if received != 1 {
+		t.Errorf("expected 1 configured source, got %d", received)
+	}
+	if filename != "2025-12-21.json" {
+		t.Errorf("expected 2025-12-21.json, got %s", filename)
+	}
+}
+
+// TestRunFetchWithCategoryRules tests that category rules route the sheet through the source registry
+func TestRunFetchWithCategoryRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current directory: %v", err)
+	}
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change to temp directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	configYAML := "category_rules:\n  - name: ai\n    title: LLM\n    category: AI\n"
+	if err := os.WriteFile("config.yml", []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_PATH", "config.yml")
+	t.Setenv("SHEET_ID", "sheet-from-env")
+
+	originalFetch := fetchSourcesFunc
+	defer func() { fetchSourcesFunc = originalFetch }()
+
+	var received int
+	var rules *metrics.RuleSet
+	fetchSourcesFunc = func(ctx context.Context, all []sources.Source, referenceDate time.Time, opts sources.Options) (schema.Metrics, error) {
+		received, rules = len(all), opts.Compute.Rules
+		return createMockMetrics(time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC)), nil
+	}
+
+	if _, _, err := runFetch(context.Background(), &MockMetricsFetcher{}, false); err != nil {
+		t.Fatalf("runFetch() error = %v", err)
+	}
+	if received != 1 {
+		t.Errorf("expected the env sheet as the only source, got %d", received)
+	}
+	if rules == nil || len(rules.Rules) != 1 {
+		t.Errorf("expected 1 compiled rule, got %+v", rules)
+	}
+}
+
+// TestRunFetchWithSeveralSheets tests that a comma-separated SHEET_ID reads each sheet as its own source
+func TestRunFetchWithSeveralSheets(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("CONFIG_PATH", "config.yml")
+	t.Setenv("SHEET_ID", "sheet-2026, sheet-2024 ,")
+
+	originalFetch := fetchSourcesFunc
+	defer func() { fetchSourcesFunc = originalFetch }()
+
+	var sheetIDs []string
+	fetchSourcesFunc = func(ctx context.Context, all []sources.Source, referenceDate time.Time, opts sources.Options) (schema.Metrics, error) {
+		for _, source := range all {
+			sheetIDs = append(sheetIDs, source.(*sources.SheetsSource).SheetID)
+		}
+		return createMockMetrics(time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC)), nil
+	}
+
+	fetcher := &MockMetricsFetcher{mockError: fmt.Errorf("sheet fetcher should not be used")}
+	if _, _, err := runFetch(context.Background(), fetcher, false); err != nil {
+		t.Fatalf("runFetch() error = %v", err)
+	}
+	if !reflect.DeepEqual(sheetIDs, []string{"sheet-2026", "sheet-2024"}) {
+		t.Errorf("expected one source per sheet, got %v", sheetIDs)
+	}
+
+	sheetID, _, err := loadConfiguration()
+	if err != nil || sheetID != "sheet-2026" {
+		t.Errorf("expected commands to edit the first sheet, got %q (%v)", sheetID, err)
+	}
+}
+
+// TestRunFetchPublic tests that --public writes a snapshot without article titles or links
+func TestRunFetchPublic(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("CONFIG_PATH", "config.yml")
+	t.Setenv("SHEET_ID", "sheet")
+	publicMode = true
+	defer func() { publicMode = false }()
+
+	m := createMockMetrics(time.Date(2025, 12, 21, 0, 0, 0, 0, time.UTC))
+	m.TopOldestUnreadArticles = []schema.ArticleMeta{{Title: "Private title", Date: "2020-01-01", Link: "https://example.com/private", Category: "GitHub"}}
+	filename, _, err := runFetch(context.Background(), &MockMetricsFetcher{mockMetrics: m}, false)
+	if err != nil {
+		t.Fatalf("runFetch() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join("metrics", filename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "Private title") || strings.Contains(string(data), "example.com") {
+		t.Errorf("expected a redacted snapshot, got %s", data)
+	}
+	if !strings.Contains(string(data), "2020-01-01") {
+		t.Error("expected the unread article's date to be kept")
+	}
+}
+
+func TestApplyCommunity(t *testing.T) {
+	var posted int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posted++
+			return
+		}
+		fmt.Fprint(w, `{"participants": 5, "median_read_rate": 30, "median_total_articles": 80}`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		cfg            config.CommunityConfig
+		expectedPosts  int
+		expectCompared bool
+	}{
+		{name: "off by default", cfg: config.CommunityConfig{Endpoint: server.URL}},
+		{name: "enabled without endpoint", cfg: config.CommunityConfig{Enabled: true}},
+		{name: "enabled", cfg: config.CommunityConfig{Enabled: true, Endpoint: server.URL}, expectedPosts: 1, expectCompared: true},
+		{name: "unreachable endpoint", cfg: config.CommunityConfig{Enabled: true, Endpoint: "http://127.0.0.1:0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posted = 0
+			m := schema.Metrics{TotalArticles: 10, ReadCount: 4, ReadRate: 40}
+			applyCommunity(context.Background(), &m, tt.cfg)
+			if posted != tt.expectedPosts {
+				t.Errorf("expected %d posts, got %d", tt.expectedPosts, posted)
+			}
+			if (m.Community != nil) != tt.expectCompared {
+				t.Fatalf("unexpected community comparison %+v", m.Community)
+			}
+			if tt.expectCompared && m.Community.MedianReadRate != 30 {
+				t.Errorf("unexpected comparison %+v", m.Community)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/forecast"
+)
+
+// runPlan writes next week's reading plan, prioritized by the goals in config.yml, as Markdown
+func runPlan(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("plan", flag.ContinueOnError)
+	out := fs.String("out", forecast.WeeklyPlanFile, "Path of the Markdown plan to write")
+	start := fs.String("start", "", "First day of the planned week, YYYY-MM-DD (default: next Monday)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	weekStart := forecast.NextWeekStart(time.Now())
+	if *start != "" {
+		parsed, err := time.Parse("2006-01-02", *start)
+		if err != nil {
+			return fmt.Errorf("invalid --start date %q: expected YYYY-MM-DD", *start)
+		}
+		weekStart = parsed
+	}
+
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		slog.Warn("Using default configuration", "err", err)
+	}
+	opts := forecast.Options{
+		DailyMinutes:      cfg.Planning.DailyMinutes,
+		MinutesPerArticle: cfg.Planning.MinutesPerArticle,
+		BlockStart:        cfg.Planning.StartTime,
+	}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	articles, err := fetchArticles(ctx)
+	if err != nil {
+		return err
+	}
+
+	goals := forecast.Goals{Focus: cfg.Goals.Focus, WeeklyItems: cfg.Goals.WeeklyItems}
+	days := forecast.PlanWeek(articles, opts, goals, weekStart)
+
+	if err := os.MkdirAll(filepath.Dir(*out), 0755); err != nil {
+		return fmt.Errorf("failed to create plan directory: %w", err)
+	}
+	if err := os.WriteFile(*out, forecast.WeeklyMarkdown(days, goals), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *out, err)
+	}
+
+	planned := 0
+	for _, day := range days {
+		planned += len(day.Items)
+	}
+	slog.Info("✅ Planned items", "count", planned, "week", weekStart.Format("2006-01-02"), "file", *out)
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunPlan(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-05-01", "Old GitHub post", "https://a.com/1", "github", "FALSE"},
+		{"2025-02-01", "Newer Stripe post", "https://b.com/2", "stripe", "FALSE"},
+		{"2025-03-01", "Already read", "https://a.com/3", "github", "TRUE"},
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+	if err := os.WriteFile(configPath, []byte("goals:\n  focus: [Stripe]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SHEET_ID", "test-sheet")
+	t.Setenv("CONFIG_PATH", configPath)
+
+	originalFetch := fetchSheetRowsFunc
+	defer func() { fetchSheetRowsFunc = originalFetch }()
+	fetchSheetRowsFunc = func(ctx context.Context, sheetID, credentialsPath string) ([][]interface{}, [][]interface{}, error) {
+		return rows, nil, nil
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectError bool
+		expected    []string
+	}{
+		{name: "invalid start", args: []string{"--start", "next monday"}, expectError: true},
+		{
+			name: "focus source first",
+			args: []string{"--start", "2026-10-19"},
+			expected: []string{
+				"# Reading Plan: Week of Oct 19, 2026",
+				"## Monday, Oct 19 (20 min)\n\n- [ ] [Newer Stripe post](https://b.com/2) · Stripe · 10 min · focus\n- [ ] [Old GitHub post](https://a.com/1) · GitHub · 10 min\n",
+				"## Tuesday, Oct 20 (0 min)\n\nNothing planned.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "plan", "next-week.md")
+			err := runPlan(context.Background(), append(tt.args, "--out", out))
+			if (err != nil) != tt.expectError {
+				t.Fatalf("runPlan() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+
+			content, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("expected plan to contain %q, got:\n%s", want, content)
+				}
+			}
+			if strings.Contains(string(content), "Already read") {
+				t.Error("read articles should not be planned")
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/runmanifest"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/web"
+)
+
+// runPrune deletes the snapshots a retention policy no longer keeps, along with their history
+// pages in the site directory
+func runPrune(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("prune", flag.ContinueOnError)
+	dir := fs.String("dir", paths.MetricsDir(), "Directory of metrics snapshots")
+	site := fs.String("site", paths.OutputDir(), "Generated site whose history pages are removed with their snapshots")
+	keepDaily := fs.String("keep-daily", "30", "Days to keep the newest snapshot of, or all")
+	keepWeekly := fs.String("keep-weekly", "52", "ISO weeks to keep the newest snapshot of, or all")
+	keepMonthly := fs.String("keep-monthly", "all", "Months to keep the newest snapshot of, or all")
+	dryRun := fs.Bool("dry-run", false, "List the snapshots that would be pruned without deleting them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var policy metrics.RetentionPolicy
+	for _, keep := range []struct {
+		flag  string
+		value string
+		dst   *int
+	}{
+		{"--keep-daily", *keepDaily, &policy.Daily},
+		{"--keep-weekly", *keepWeekly, &policy.Weekly},
+		{"--keep-monthly", *keepMonthly, &policy.Monthly},
+	} {
+		n, err := metrics.ParseKeep(keep.value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", keep.flag, err)
+		}
+		*keep.dst = n
+	}
+
+	files, err := metrics.ListSnapshotFiles(*dir)
+	if err != nil {
+		return err
+	}
+	keep, prune := metrics.SelectRetained(files, policy)
+	if len(prune) == 0 {
+		slog.Info("✅ All snapshots are within the retention policy", "snapshots", len(keep))
+		return nil
+	}
+
+	for _, file := range prune {
+		date := strings.TrimSuffix(file, ".json")
+		if *dryRun {
+			slog.Info("Would prune", "date", date)
+			continue
+		}
+		if err := os.Remove(filepath.Join(*dir, file)); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", file, err)
+		}
+		if err := os.Remove(filepath.Join(*dir, runmanifest.Filename(date))); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove run manifest for %s: %w", date, err)
+		}
+		if err := os.RemoveAll(filepath.Join(*site, "history", date)); err != nil {
+			return fmt.Errorf("failed to remove history page for %s: %w", date, err)
+		}
+	}
+	if *dryRun {
+		slog.Info("Dry run, nothing pruned", "prune", len(prune), "snapshots", len(files), "keep", len(keep))
+		return nil
+	}
+
+	if err := dropPrunedFromManifest(*site, prune); err != nil {
+		return err
+	}
+	slog.Info("✅ Pruned snapshots", "pruned", len(prune), "kept", len(keep))
+	return nil
+}
+
+// dropPrunedFromManifest removes the history pages of pruned snapshots from the site manifest, so the
+// next build does not report them as missing. A site without a manifest is left alone.
+func dropPrunedFromManifest(site string, pruned []string) error {
+	manifest, err := web.ReadSiteManifest(site)
+	if err != nil {
+		return err
+	}
+	if len(manifest.Files) == 0 {
+		return nil
+	}
+
+	dates := make(map[string]bool, len(pruned))
+	for _, file := range pruned {
+		dates[strings.TrimSuffix(file, ".json")] = true
+	}
+	files := manifest.Files[:0]
+	for _, file := range manifest.Files {
+		if rest, ok := strings.CutPrefix(file, "history/"); ok {
+			if date, _, _ := strings.Cut(rest, "/"); dates[date] {
+				continue
+			}
+		}
+		files = append(files, file)
+	}
+	manifest.Files = files
+	return web.WriteSiteManifest(site, manifest)
+}
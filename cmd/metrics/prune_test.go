@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/web"
+)
+
+func TestRunPrune(t *testing.T) {
+	dates := []string{"2025-01-10", "2025-01-31", "2025-02-14", "2025-02-28"}
+	setup := func(t *testing.T) (string, string) {
+		dir, site := t.TempDir(), t.TempDir()
+		var files []string
+		for _, date := range dates {
+			if err := os.WriteFile(filepath.Join(dir, date+".json"), []byte("{}"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.MkdirAll(filepath.Join(site, "history", date), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(site, "history", date, "analytics.html"), []byte("<html>"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			files = append(files, "history/"+date+"/analytics.html")
+		}
+		files = append(files, "history/index.html", "index.html")
+		if err := web.WriteSiteManifest(site, web.SiteManifest{Files: files}); err != nil {
+			t.Fatal(err)
+		}
+		return dir, site
+	}
+	remaining := func(t *testing.T, dir string) []string {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		return names
+	}
+
+	t.Run("removes snapshots, run manifests, pages and manifest entries", func(t *testing.T) {
+		dir, site := setup(t)
+		if err := os.WriteFile(filepath.Join(dir, "2025-01-10.run.json"), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := runPrune(context.Background(), []string{"--dir", dir, "--site", site, "--keep-daily", "1", "--keep-weekly", "0", "--keep-monthly", "all"}); err != nil {
+			t.Fatalf("runPrune() error = %v", err)
+		}
+
+		if got, expected := remaining(t, dir), []string{"2025-01-31.json", "2025-02-28.json"}; !reflect.DeepEqual(got, expected) {
+			t.Errorf("snapshots = %v, expected %v", got, expected)
+		}
+		if got, expected := remaining(t, filepath.Join(site, "history")), []string{"2025-01-31", "2025-02-28"}; !reflect.DeepEqual(got, expected) {
+			t.Errorf("history pages = %v, expected %v", got, expected)
+		}
+		manifest, err := web.ReadSiteManifest(site)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []string{"history/2025-01-31/analytics.html", "history/2025-02-28/analytics.html", "history/index.html", "index.html"}
+		if !reflect.DeepEqual(manifest.Files, expected) {
+			t.Errorf("manifest = %v, expected %v", manifest.Files, expected)
+		}
+	})
+
+	t.Run("dry run deletes nothing", func(t *testing.T) {
+		dir, site := setup(t)
+		if err := runPrune(context.Background(), []string{"--dir", dir, "--site", site, "--keep-daily", "1", "--keep-weekly", "0", "--keep-monthly", "0", "--dry-run"}); err != nil {
+			t.Fatalf("runPrune() error = %v", err)
+		}
+		if got := remaining(t, dir); len(got) != len(dates) {
+			t.Errorf("expected every snapshot kept, got %v", got)
+		}
+	})
+
+	t.Run("invalid count", func(t *testing.T) {
+		dir, site := setup(t)
+		if err := runPrune(context.Background(), []string{"--dir", dir, "--site", site, "--keep-weekly", "some"}); err == nil {
+			t.Error("expected an error for an invalid --keep-weekly")
+		}
+	})
+}
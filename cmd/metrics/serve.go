@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/apiserver"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/runmanifest"
+)
+
+// runServe serves the snapshots as a JSON API beside the built site until interrupted. With
+// API_TOKEN set, POST /api/refresh fetches a new snapshot as `metrics --fetch` does.
+func runServe(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "Address to listen on")
+	dir := fs.String("dir", paths.MetricsDir(), "Directory of metrics snapshots")
+	site := fs.String("site", paths.OutputDir(), "Built site served at /")
+	debounce := fs.Duration("debounce", apiserver.DefaultDebounce, "Coalesce refresh requests arriving within this window of the first into one fetch")
+	minInterval := fs.Duration("min-interval", apiserver.DefaultMinInterval, "Least time between the starts of two fetches")
+	public := fs.Bool("public", false, "Strip article titles, links and annotations from served and refreshed snapshots (default public in config.yml)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	paths.Metrics = *dir
+	cfg, _ := config.Load(config.Path())
+	publicMode = *public || cfg.Public
+
+	token := os.Getenv(apiserver.TokenEnvVar)
+	if token == "" {
+		slog.Warn("Refresh is disabled", "reason", apiserver.TokenEnvVar+" is not set")
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{
+		Addr: *addr,
+		Handler: apiserver.New(ctx, apiserver.Config{
+			MetricsDir:  *dir,
+			SiteDir:     *site,
+			Token:       token,
+			Public:      publicMode,
+			Refresh:     refreshMetrics,
+			Debounce:    *debounce,
+			MinInterval: *minInterval,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	slog.Info("✅ Serving site and API", "url", *addr, "site", *site, "metrics", *dir)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
+
+// refreshMetrics fetches and saves a new snapshot holding the run lock, recording its run manifest
+// like the default run. The AI delta analysis is skipped.
+func refreshMetrics(ctx context.Context) error {
+	run = runmanifest.New("metrics")
+	restoreLogger := run.CaptureWarnings()
+	err := withRunLock(func() error {
+		_, _, err := runFetch(ctx, &DefaultMetricsFetcher{}, false)
+		return err
+	})
+	restoreLogger()
+	writeRunManifest(paths.MetricsDir(), run.Finish(err))
+	run = nil
+	return err
+}
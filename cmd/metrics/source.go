@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// runSource dispatches source maintenance commands
+func runSource(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: source rename [flags] <old> <new>")
+	}
+
+	switch args[0] {
+	case "rename":
+		return runSourceRename(ctx, args[1:])
+	default:
+		return fmt.Errorf("unknown source action %q (expected rename)", args[0])
+	}
+}
+
+// runSourceRename records a source alias in config and optionally rewrites past snapshots to use it
+func runSourceRename(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("source rename", flag.ContinueOnError)
+	rewriteHistory := fs.Bool("rewrite-history", false, "Also rename the source in existing metrics snapshots")
+	dir := fs.String("dir", paths.MetricsDir(), "Directory containing metrics snapshots")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("usage: source rename [flags] <old> <new>")
+	}
+	from, to := fs.Arg(0), fs.Arg(1)
+
+	path := config.Path()
+	if err := config.SetSourceAlias(path, from, to); err != nil {
+		return err
+	}
+	slog.Info("✅ Recorded alias", "from", from, "to", to, "config", path)
+
+	if !*rewriteHistory {
+		return nil
+	}
+
+	rewritten, err := metrics.RenameSourceInSnapshots(*dir, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to rewrite snapshots: %w", err)
+	}
+	slog.Info("✅ Rewrote snapshots", "count", rewritten, "dir", *dir)
+	return nil
+}
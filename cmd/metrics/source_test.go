@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func TestRunSource(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectError   bool
+		expectAlias   bool
+		expectRewrite bool
+	}{
+		{name: "missing action", args: []string{}, expectError: true},
+		{name: "unknown action", args: []string{"merge"}, expectError: true},
+		{name: "missing names", args: []string{"rename", "fcc"}, expectError: true},
+		{name: "rename records alias only", args: []string{"rename", "fcc", "freeCodeCamp"}, expectAlias: true},
+		{name: "rename rewrites history", args: []string{"rename", "--rewrite-history", "fcc", "freeCodeCamp"}, expectAlias: true, expectRewrite: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, "config.yml")
+			t.Setenv("CONFIG_PATH", configPath)
+
+			metricsDir := filepath.Join(dir, "metrics")
+			if err := os.MkdirAll(metricsDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			data, _ := json.Marshal(schema.Metrics{BySource: map[string]int{"fcc": 4}})
+			if err := os.WriteFile(filepath.Join(metricsDir, "2025-01-01.json"), data, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			args := tt.args
+			if len(args) > 0 && args[0] == "rename" {
+				args = append([]string{"rename", "--dir", metricsDir}, args[1:]...)
+			}
+
+			err := runSource(context.Background(), args)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("runSource() error = %v, expectError %v", err, tt.expectError)
+			}
+
+			cfg, _ := config.Load(configPath)
+			if got := cfg.SourceAliases["fcc"] == "freeCodeCamp"; got != tt.expectAlias {
+				t.Errorf("alias recorded = %v, want %v", got, tt.expectAlias)
+			}
+
+			content, _ := os.ReadFile(filepath.Join(metricsDir, "2025-01-01.json"))
+			var snapshot schema.Metrics
+			json.Unmarshal(content, &snapshot)
+			if got := snapshot.BySource["freeCodeCamp"] == 4; got != tt.expectRewrite {
+				t.Errorf("snapshot rewritten = %v, want %v", got, tt.expectRewrite)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/triage"
+)
+
+// openSheetsFunc is a package-level variable that can be mocked in tests
+var openSheetsFunc = func(ctx context.Context, credentialsPath string) (metrics.SheetsFetcher, metrics.SheetsWriter, error) {
+	return metrics.NewSheetsReadWriter(ctx, credentialsPath)
+}
+
+// runTriage dispatches the triage generate/apply workflow for backlog cleanup
+func runTriage(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: triage <generate|apply> [flags]")
+	}
+
+	switch args[0] {
+	case "generate":
+		return runTriageGenerate(ctx, args[1:])
+	case "apply":
+		return runTriageApply(ctx, args[1:])
+	default:
+		return fmt.Errorf("unknown triage action %q (expected generate or apply)", args[0])
+	}
+}
+
+// runTriageGenerate writes the oldest unread articles to an editable triage file
+func runTriageGenerate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("triage generate", flag.ContinueOnError)
+	limit := fs.Int("limit", 20, "Number of oldest unread articles to include")
+	out := fs.String("out", triage.DefaultFile, "Path of the triage file to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	sheetID, credentialsPath, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	articleRows, providerRows, err := fetchSheetRowsFunc(ctx, sheetID, credentialsPath)
+	if err != nil {
+		return fmt.Errorf("failed to fetch sheet rows: %w", err)
+	}
+
+	var unread []schema.ArticleMeta
+	for _, article := range metrics.ParseArticles(articleRows, metrics.BuildSourceMap(providerRows)) {
+		if !article.Read {
+			unread = append(unread, article)
+		}
+	}
+
+	file := triage.Generate(unread, *limit, time.Now())
+	if err := triage.Write(*out, file); err != nil {
+		return err
+	}
+
+	slog.Info("✅ Wrote unread articles", "count", len(file.Articles), "file", *out)
+	return nil
+}
+
+// runTriageApply reads an edited triage file and applies read/archive decisions to the sheet in bulk
+func runTriageApply(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("triage apply", flag.ContinueOnError)
+	archiveSheet := fs.String("archive-sheet", "archive", "Sheet that archived rows are moved to")
+	dryRun := fs.Bool("dry-run", false, "Print the planned changes without writing to the sheet")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path := triage.DefaultFile
+	if fs.NArg() > 0 {
+		path = fs.Arg(0)
+	}
+
+	file, err := triage.Read(path)
+	if err != nil {
+		return err
+	}
+
+	sheetID, credentialsPath, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	fetcher, writer, err := openSheetsFunc(ctx, credentialsPath)
+	if err != nil {
+		return err
+	}
+
+	spreadsheet, err := fetcher.GetSpreadsheet(sheetID)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve spreadsheet: %w", err)
+	}
+	articlesSheet, _ := metrics.FindSheetNames(spreadsheet)
+
+	rows, err := fetcher.GetArticleRows(sheetID, articlesSheet)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve data from sheet: %w", err)
+	}
+
+	plan := triage.BuildPlan(rows, file.Articles, metrics.ColLink, metrics.ColTitle, metrics.ColDate)
+	for _, item := range plan.Unmatched {
+		slog.Warn("No sheet row matches, skipping", "title", item.Title, "link", item.Link)
+	}
+	slog.Info("Triage plan", "read", len(plan.ReadRows), "archive", len(plan.ArchiveRows), "keep", plan.Kept, "unmatched", len(plan.Unmatched))
+
+	if *dryRun {
+		return nil
+	}
+
+	updates := make(map[string]interface{}, len(plan.ReadRows))
+	for _, idx := range plan.ReadRows {
+		updates[metrics.ReadCell(articlesSheet, idx)] = "TRUE"
+	}
+	if err := writer.UpdateCells(sheetID, updates); err != nil {
+		return fmt.Errorf("failed to mark articles as read: %w", err)
+	}
+
+	if len(plan.ArchiveRows) > 0 {
+		// Copy rows to the archive sheet before deleting so nothing is lost
+		var archived [][]interface{}
+		for _, idx := range plan.ArchiveRows {
+			archived = append(archived, rows[idx])
+		}
+		if err := writer.AppendRows(sheetID, metrics.ArticlesRange(*archiveSheet), archived); err != nil {
+			return fmt.Errorf("failed to copy rows to %s sheet: %w", *archiveSheet, err)
+		}
+
+		articlesSheetID, err := metrics.FindSheetID(spreadsheet, articlesSheet)
+		if err != nil {
+			return err
+		}
+		if err := writer.DeleteRows(sheetID, articlesSheetID, plan.ArchiveRows); err != nil {
+			return fmt.Errorf("failed to remove archived rows: %w", err)
+		}
+	}
+
+	slog.Info("✅ Triage applied")
+	return nil
+}
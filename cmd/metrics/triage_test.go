@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/triage"
+)
+
+// mockSheetsFetcher implements metrics.SheetsFetcher for CLI tests
+type mockSheetsFetcher struct {
+	rows      [][]interface{}
+	providers [][]interface{}
+}
+
+func (m *mockSheetsFetcher) GetSpreadsheet(spreadsheetID string) (*sheets.Spreadsheet, error) {
+	return &sheets.Spreadsheet{Sheets: []*sheets.Sheet{
+		{Properties: &sheets.SheetProperties{Title: "articles", SheetId: 7}},
+	}}, nil
+}
+
+func (m *mockSheetsFetcher) GetArticleRows(spreadsheetID, articlesSheet string) ([][]interface{}, error) {
+	return m.rows, nil
+}
+
+func (m *mockSheetsFetcher) GetProvidersSheet(spreadsheetID, providersSheet string) ([][]interface{}, error) {
+	return m.providers, nil
+}
+
+// mockSheetsWriter records mutations for CLI tests
+type mockSheetsWriter struct {
+	updates  map[string]interface{}
+	appended [][]interface{}
+	deleted  []int
+	sheetID  int64
+}
+
+func (m *mockSheetsWriter) UpdateCells(spreadsheetID string, updates map[string]interface{}) error {
+	m.updates = updates
+	return nil
+}
+
+func (m *mockSheetsWriter) AppendRows(spreadsheetID, appendRange string, rows [][]interface{}) error {
+	m.appended = append(m.appended, rows...)
+	return nil
+}
+
+func (m *mockSheetsWriter) DeleteRows(spreadsheetID string, sheetID int64, rowIndexes []int) error {
+	m.sheetID = sheetID
+	m.deleted = rowIndexes
+	return nil
+}
+
+func TestRunTriage(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-01-01", "Oldest", "https://example.com/1", "GitHub", "FALSE"},
+		{"2024-02-01", "Middle", "https://example.com/2", "Substack", "FALSE"},
+		{"2024-03-01", "Already Read", "https://example.com/3", "Substack", "TRUE"},
+	}
+
+	tmpDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(originalDir)
+	t.Setenv("SHEET_ID", "test-sheet")
+
+	originalFetch, originalOpen := fetchSheetRowsFunc, openSheetsFunc
+	defer func() { fetchSheetRowsFunc, openSheetsFunc = originalFetch, originalOpen }()
+
+	fetchSheetRowsFunc = func(ctx context.Context, sheetID, credentialsPath string) ([][]interface{}, [][]interface{}, error) {
+		return rows, nil, nil
+	}
+	writer := &mockSheetsWriter{}
+	openSheetsFunc = func(ctx context.Context, credentialsPath string) (metrics.SheetsFetcher, metrics.SheetsWriter, error) {
+		return &mockSheetsFetcher{rows: rows}, writer, nil
+	}
+
+	path := filepath.Join(tmpDir, "triage.yml")
+	if err := runTriage(context.Background(), []string{"generate", "--limit", "5", "--out", path}); err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	file, err := triage.Read(path)
+	if err != nil {
+		t.Fatalf("failed to read generated file: %v", err)
+	}
+	if len(file.Articles) != 2 {
+		t.Fatalf("expected 2 unread articles, got %d", len(file.Articles))
+	}
+
+	// Simulate the user's edits
+	file.Articles[0].Action = triage.ActionRead
+	file.Articles[1].Action = triage.ActionArchive
+	if err := triage.Write(path, file); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runTriage(context.Background(), []string{"apply", "--dry-run", path}); err != nil {
+		t.Fatalf("dry-run apply failed: %v", err)
+	}
+	if writer.updates != nil || writer.deleted != nil {
+		t.Fatal("dry run should not write to the sheet")
+	}
+
+	if err := runTriage(context.Background(), []string{"apply", path}); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if writer.updates["articles!E2"] != "TRUE" {
+		t.Errorf("expected articles!E2 to be marked read, got %v", writer.updates)
+	}
+	if len(writer.appended) != 1 || writer.appended[0][1] != "Middle" {
+		t.Errorf("expected Middle to be copied to the archive sheet, got %v", writer.appended)
+	}
+	if writer.sheetID != 7 || len(writer.deleted) != 1 || writer.deleted[0] != 2 {
+		t.Errorf("expected row 2 deleted from sheet 7, got %v on %d", writer.deleted, writer.sheetID)
+	}
+
+	if err := runTriage(context.Background(), []string{"unknown"}); err == nil {
+		t.Error("expected error for unknown triage action")
+	}
+	if err := runTriage(context.Background(), nil); err == nil {
+		t.Error("expected usage error without an action")
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/deploy"
+)
+
+// runDeploy publishes a built site to a GitHub Pages branch or a Netlify site
+func runDeploy(ctx context.Context, args []string, paths config.PathsConfig) error {
+	fs := flag.NewFlagSet("deploy", flag.ContinueOnError)
+	target := fs.String("target", deploy.TargetGitHubPages, "Where to publish: github-pages or netlify")
+	dir := fs.String("dir", paths.OutputDir(), "Built site directory to publish")
+	remote := fs.String("remote", "origin", "github-pages: remote name or URL to push to")
+	branch := fs.String("branch", "gh-pages", "github-pages: branch GitHub Pages serves")
+	cname := fs.String("cname", "", "github-pages: custom domain to write to CNAME")
+	siteID := fs.String("netlify-site", os.Getenv("NETLIFY_SITE_ID"), "netlify: site ID or name (default $NETLIFY_SITE_ID)")
+	message := fs.String("message", "", "Commit message or deploy title (default: Deploy YYYY-MM-DD)")
+	dryRun := fs.Bool("dry-run", false, "List the files and destination without publishing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *message == "" {
+		*message = "Deploy " + time.Now().Format("2006-01-02")
+	}
+
+	var t deploy.Target
+	switch *target {
+	case deploy.TargetGitHubPages:
+		t = deploy.GitHubPages{RepoDir: ".", Remote: *remote, Branch: *branch, Message: *message, CNAME: *cname, Token: os.Getenv("GITHUB_TOKEN")}
+	case deploy.TargetNetlify:
+		if *siteID == "" {
+			return fmt.Errorf("netlify deploys need --netlify-site or NETLIFY_SITE_ID")
+		}
+		token := os.Getenv("NETLIFY_AUTH_TOKEN")
+		if token == "" && !*dryRun {
+			return fmt.Errorf("netlify deploys need NETLIFY_AUTH_TOKEN")
+		}
+		t = deploy.NewNetlify(*siteID, token, *message)
+	default:
+		return fmt.Errorf("unknown --target %q: expected %s or %s", *target, deploy.TargetGitHubPages, deploy.TargetNetlify)
+	}
+
+	files, err := deploy.ListFiles(*dir)
+	if err != nil {
+		return err
+	}
+	var size int64
+	for _, file := range files {
+		size += file.Size
+	}
+	if *dryRun {
+		for _, file := range files {
+			slog.Info("Would publish", "file", file.Path)
+		}
+		slog.Info("Dry run, nothing published", "files", len(files), "kb", size>>10, "dir", *dir, "target", t.Describe())
+		return nil
+	}
+
+	slog.Info("🚀 Publishing", "files", len(files), "kb", size>>10, "dir", *dir, "target", t.Describe())
+	if err := t.Deploy(ctx, *dir); err != nil {
+		return err
+	}
+	slog.Info("✅ Deployed", "target", t.Describe())
+	return nil
+}
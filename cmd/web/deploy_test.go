@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func TestRunDeploy(t *testing.T) {
+	site := t.TempDir()
+	if err := os.WriteFile(filepath.Join(site, "index.html"), []byte("<html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("NETLIFY_AUTH_TOKEN", "")
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{"github pages dry run", []string{"--dir", site, "--dry-run"}, ""},
+		{"netlify dry run needs no token", []string{"--dir", site, "--target", "netlify", "--netlify-site", "reading", "--dry-run"}, ""},
+		{"netlify needs a token", []string{"--dir", site, "--target", "netlify", "--netlify-site", "reading"}, "NETLIFY_AUTH_TOKEN"},
+		{"netlify needs a site", []string{"--dir", site, "--target", "netlify", "--netlify-site", ""}, "--netlify-site"},
+		{"unknown target", []string{"--dir", site, "--target", "ftp"}, "unknown --target"},
+		{"empty site", []string{"--dir", t.TempDir(), "--dry-run"}, "no files to deploy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runDeploy(context.Background(), tt.args, config.PathsConfig{})
+			if tt.expectedError == "" {
+				if err != nil {
+					t.Errorf("runDeploy() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("runDeploy() error = %v, expected it to mention %q", err, tt.expectedError)
+			}
+		})
+	}
+}
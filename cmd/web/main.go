@@ -1,78 +1,552 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"io/fs"
+	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"syscall"
+	"time"
 
 	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/export"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/feed"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/forecast"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/linkcheck"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/locale"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/logging"
+	metricspkg "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/runmanifest"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/stepsummary"
 	web "github.com/victoriacheng15/personal-reading-analytics/internal/web"
 )
 
+// pagesSiteLimitBytes is the published site size limit of GitHub Pages
+const pagesSiteLimitBytes = 1 << 30
+
 func main() {
+	args, err := logging.Init(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if args, err = config.InitProfile(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if _, err := config.Load(config.Path()); err != nil && config.Profile() != "" {
+		logging.Fatal("Invalid --profile", "err", err)
+	}
+	applyTimezone()
+	paths := loadPaths()
+
+	// Ctrl-C, or the SIGTERM of an Actions timeout, stops a build between pages
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if len(args) > 0 && args[0] == "wrapped" {
+		lock, err := safefile.Acquire(safefile.LockFile, safefile.StaleLockAge)
+		if err != nil {
+			logging.Fatal("❌ Command failed", "command", "wrapped", "err", err)
+		}
+		err = runWrapped(ctx, args[1:], paths)
+		lock.Release()
+		if err != nil {
+			logging.Fatal("❌ Command failed", "command", "wrapped", "err", err)
+		}
+		return
+	}
+	if len(args) > 0 && args[0] == "deploy" {
+		lock, err := safefile.Acquire(safefile.LockFile, safefile.StaleLockAge)
+		if err != nil {
+			logging.Fatal("❌ Command failed", "command", "deploy", "err", err)
+		}
+		err = runDeploy(ctx, args[1:], paths)
+		lock.Release()
+		if err != nil {
+			logging.Fatal("❌ Command failed", "command", "deploy", "err", err)
+		}
+		return
+	}
+
+	if len(args) > 0 && args[0] == "profiles" {
+		if err := runProfiles(args[1:]); err != nil {
+			logging.Fatal("❌ Command failed", "command", "profiles", "err", err)
+		}
+		return
+	}
+
+	metricsDir := flag.String("metrics-dir", paths.MetricsDir(), "Directory of metrics snapshots (default paths.metrics in config.yml, or metrics)")
+	outputDir := flag.String("output-dir", paths.OutputDir(), "Directory the site is generated into (default paths.output in config.yml, or dist)")
+	historySince := flag.String("history-since", "", "Only generate history pages for snapshots on or after this date (YYYY-MM-DD)")
+	historyUntil := flag.String("history-until", "", "Only generate history pages for snapshots on or before this date (YYYY-MM-DD)")
+	historyOnly := flag.String("history-dates", "", "Only generate history pages for these comma-separated snapshot dates, such as 2025-11-01,2025-11-08")
+	historyLimit := flag.Int("history-limit", 0, "Only generate history pages for the N most recent snapshots (0 = all)")
+	feedPath := flag.String("feed", feed.DefaultEventsFile, "`metrics feed` activity log published as the site's RSS and JSON feeds, if present")
+	linkReportPath := flag.String("link-report", linkcheck.DefaultReportFile, "checklinks report shown on the latest analytics page, if present")
+	charts := flag.String("charts", web.ChartsChartJS, "Chart renderer: chartjs (interactive) or svg (drawn at build time, no JavaScript needed)")
+	markdownPath := flag.String("markdown", "", "Also write a Markdown summary of the latest snapshot to this file, such as WEEKLY.md")
+	jsonPath := flag.String("json", "", "Also write the latest snapshot's figures and chart series as JSON to this file, such as dist/report.json")
+	permalinksDir := flag.String("permalinks", "", "Generate per-article permalink pages from the `metrics export --format articles` files in this directory")
+	assetsDir := flag.String("assets-dir", paths.Assets, "Read templates/ and content/ from this directory instead of the copies built into the binary, such as internal/web (default paths.assets in config.yml)")
+	templatesDir := flag.String("templates-dir", paths.TemplatesDir(), "Theme directory whose templates, static files and css/theme.css replace the built-in ones file by file (default $THEME_DIR, or paths.templates in config.yml)")
+	public := flag.Bool("public", loadPublic(), "Strip article titles, links and annotations from every page and published snapshot, and skip the feeds and permalink pages (default public in config.yml)")
+	dryRun := flag.Bool("dry-run", false, "Render into a temporary directory and report which files in the output directory would be added or changed, leaving it untouched")
+	checkContent := flag.Bool("check-content", false, "Validate content/evolution.yml and content/landing.yml, reporting every problem with its line, and exit without building")
+	keepGoing := flag.Bool("keep-going", false, "Keep generating after a history page fails, then exit non-zero listing every failed date (default stops at the first failure)")
+	watch := flag.Bool("watch", false, "Keep running, rebuilding the site whenever a snapshot in the metrics directory is added, rewritten or removed")
+	watchInterval := flag.Duration("watch-interval", 30*time.Second, "How often --watch checks the metrics directory")
+	compress := flag.String("compress", "", "Also write precompressed siblings of every HTML, CSS and JSON file in the output directory: gzip, br or gzip,br (br needs the brotli command)")
+	// Listed for -h only; logging.Init and config.InitProfile have already consumed them
+	flag.String("log-level", "info", "Minimum log level: debug, info, warn or error (default $LOG_LEVEL)")
+	flag.String("log-format", logging.FormatText, "Log output format: text or json (default $LOG_FORMAT)")
+	flag.String("profile", "", "Build the named profile from config.yml into its own subdirectory of the output directory (default $PROFILE)")
+	flag.CommandLine.Parse(args)
+	if err := web.SetAssetsDir(*assetsDir); err != nil {
+		logging.Fatal("Invalid --assets-dir", "err", err)
+	}
+	if err := web.SetThemeDir(*templatesDir); err != nil {
+		logging.Fatal("Invalid --templates-dir", "err", err)
+	}
+	// Broken content fails the build rather than rendering an empty timeline
+	if err := web.CheckContent(); err != nil {
+		logging.Fatal("❌ Invalid content", "err", err)
+	}
+	if *checkContent {
+		slog.Info("✅ Content is valid")
+		return
+	}
+	if *charts != web.ChartsChartJS && *charts != web.ChartsSVG {
+		logging.Fatal("Invalid --charts: expected "+web.ChartsChartJS+" or "+web.ChartsSVG, "charts", *charts)
+	}
+	encodings, err := web.ParseEncodings(*compress)
+	if err != nil {
+		logging.Fatal("Invalid --compress", "err", err)
+	}
+	bounds, err := parseHistoryBounds(*historySince, *historyUntil, *historyOnly, *historyLimit)
+	if err != nil {
+		logging.Fatal("Invalid history range", "err", err)
+	}
+
+	// Watch mode reruns this command as a regular build for every change, until interrupted
+	if *watch {
+		if *watchInterval <= 0 {
+			logging.Fatal("Invalid --watch-interval: expected a positive duration", "interval", *watchInterval)
+		}
+		build, err := buildCommand(os.Args[1:])
+		if err != nil {
+			logging.Fatal("❌ Command failed", "command", "watch", "err", err)
+		}
+		watchMetrics(ctx, *metricsDir, *watchInterval, build)
+		slog.Info("🛑 Stopped watching", "dir", *metricsDir)
+		return
+	}
+
+	// A dry run renders into a temporary directory, so the output directory is only read
+	outDir := *outputDir
+	if *dryRun {
+		outDir, err = os.MkdirTemp("", "dist-preview-")
+		if err != nil {
+			logging.Fatal("Failed to create preview directory", "err", err)
+		}
+	}
+
+	// Keep a concurrent metrics run or build from interleaving with this one
+	lock, err := safefile.Acquire(safefile.LockFile, safefile.StaleLockAge)
+	if err != nil {
+		logging.Fatal("❌ Build failed", "err", err)
+	}
+	defer lock.Release()
+
+	// Time each stage for build.json, counting every warning logged along the way
+	build := runmanifest.New("web")
+	restoreLogger := build.CaptureWarnings()
+
 	// 1. Get all available metrics dates
-	dates, err := getMetricsDates()
+	endStage := build.Start("load")
+	dates, err := getMetricsDates(*metricsDir)
 	if err != nil {
-		log.Fatalf("Failed to discover metrics: %v", err)
+		lock.Release()
+		logging.Fatal("Failed to discover metrics", "err", err)
 	}
 
-	// 2. Initialize Analytics Service
-	service := web.NewAnalyticsService("dist")
+	// 2. Load every snapshot up front so cross-snapshot series can be built
+	snapshots := loadSnapshots(*metricsDir, dates)
+	// Source "added" dates come from the first snapshot listing each provider
+	metricspkg.ApplyProviderAddedDates(snapshots)
+	energyHistory := metricspkg.BuildEnergyHistory(snapshots)
+	providerTimeline := metricspkg.BuildProviderTimeline(snapshots)
+	backlogFlow := metricspkg.BuildBacklogFlow(snapshots)
+	sourceReadRates := metricspkg.BuildSourceReadRates(snapshots)
 
-	log.Printf("Generating reports for %d dates...\n", len(dates))
+	// Files owned by earlier builds are merged into this run's manifest rather than dropped
+	previousManifest, err := web.ReadSiteManifest(*outputDir)
+	if err != nil {
+		warnf("%v", err)
+	}
+
+	// Bound the history pages regenerated this run; pages from earlier builds stay linked
+	window := selectHistoryWindow(dates, bounds)
+	for _, date := range bounds.dates {
+		if !slices.Contains(dates, date) {
+			warnf("No snapshot for --history-dates %s", date)
+		}
+	}
+	historyDates := linkedHistoryDates(dates, window, filepath.Join(*outputDir, "history"))
 
-	// 3. Multi-pass generation
+	linkReport := loadLinkReport(*linkReportPath)
+	feedEvents, err := feed.LoadEvents(*feedPath)
+	if err != nil {
+		warnf("Skipping feeds: %v", err)
+	}
+	// The feeds and permalink pages are made of article titles, so a public site has neither
+	if *public {
+		feedEvents = nil
+		if *permalinksDir != "" {
+			warnf("Skipping permalink pages: they list article titles, which --public hides")
+			*permalinksDir = ""
+		}
+	}
+	readingPlan := buildReadingPlan(snapshots[dates[0]], dates[0])
+	endStage()
+	build.Count(runmanifest.Snapshots, len(snapshots))
+
+	// 3. Initialize Analytics Service
+	service := web.NewAnalyticsService(outDir)
+	service.SetBranding(loadBranding())
+	service.SetTheme(loadTheme())
+	service.SetHighlights(loadHighlights())
+	service.SetPublic(*public)
+	// Reports outside the site land in the preview directory on a dry run
+	reportPath := func(path string) string {
+		if *dryRun {
+			return filepath.Join(outDir, filepath.Base(path))
+		}
+		return path
+	}
+	renderers := []web.Renderer{web.HTMLRenderer{}}
+	if *markdownPath != "" {
+		renderers = append(renderers, web.MarkdownRenderer{Path: reportPath(*markdownPath)})
+	}
+	if *jsonPath != "" {
+		renderers = append(renderers, web.JSONRenderer{Path: reportPath(*jsonPath)})
+	}
+	service.SetRenderers(renderers...)
+
+	slog.Info("Generating reports", "dates", len(window), "snapshots", len(dates))
+
+	// 4. Multi-pass generation
+	endStage = build.Start("pages")
+	inWindow := make(map[string]bool, len(window))
+	for _, date := range window {
+		inWindow[date] = true
+	}
+	// An interrupted or failed build records its outcome and exits non-zero, naming the history
+	// pages written and those that failed
+	var completed, failed []string
+	latestDone := false
+	abort := func(message string, err error) {
+		endStage(err)
+		restoreLogger()
+		writeBuildManifest(outDir, build.Finish(err), service.WrittenFiles())
+		lock.Release()
+		logging.Fatal(message, "completed", strings.Join(completed, ", "), "failed", strings.Join(failed, ", "), "remaining", len(window)-len(completed)-len(failed), "latest_site", latestDone)
+	}
+	var failures []string
 	for i, date := range dates {
-		metrics, err := loadMetricsByDate(date)
-		if err != nil {
-			log.Printf("⚠️ Warning: Skipping %s: %v\n", date, err)
+		if ctx.Err() != nil || (len(failed) > 0 && !*keepGoing) {
+			break
+		}
+		metrics, exists := snapshots[date]
+		if !exists || (i != 0 && !inWindow[date]) {
 			continue
 		}
+		// The snapshot before this one, for the key metric deltas
+		var previous *schema.Metrics
+		if i+1 < len(dates) {
+			if prev, ok := snapshots[dates[i+1]]; ok {
+				previous = &prev
+			}
+		}
 
-		// Historical: ONLY analytics.html in dist/history/YYYY-MM-DD
-		err = service.GenerateAnalyticsOnly(metrics, web.GenConfig{
-			OutputDir:    filepath.Join("dist", "history", date),
-			BaseURL:      "../../",
-			IsHistorical: true,
-			HistoryDates: dates,
-			ReportDate:   date,
-		})
-		if err != nil {
-			log.Printf("⚠️ Warning: Failed historical generation for %s: %v\n", date, err)
+		// Historical: ONLY analytics.html in history/YYYY-MM-DD, with chart data in a side file.
+		// The latest snapshot's copy defers its canonical URL to the root page it duplicates.
+		if inWindow[date] {
+			canonicalDir := "history/" + date
+			if i == 0 {
+				canonicalDir = ""
+			}
+			err = service.GenerateAnalyticsOnly(ctx, metrics, web.GenConfig{
+				OutputDir:       filepath.Join(outDir, "history", date),
+				BaseURL:         "../../",
+				IsHistorical:    true,
+				HistoryDates:    historyDates,
+				ReportDate:      date,
+				EnergyHistory:   energyHistory,
+				BacklogFlow:     backlogFlow,
+				SourceReadRates: sourceReadRates,
+				Previous:        previous,
+				LazyChartData:   true,
+				Charts:          *charts,
+				Feed:            len(feedEvents) > 0,
+				CanonicalDir:    canonicalDir,
+			})
+			switch {
+			case err == nil:
+				completed = append(completed, date)
+			case ctx.Err() == nil:
+				warnf("Failed historical generation for %s: %v", date, err)
+				build.Fail(date, err)
+				failed = append(failed, date)
+				failures = append(failures, fmt.Sprintf("%s: %v", date, err))
+				if !*keepGoing {
+					continue
+				}
+			}
 		}
 
-		// Latest (root): ALL pages in dist/
+		// Latest (root): ALL pages in the output directory
 		if i == 0 {
-			err = service.GenerateFullSite(metrics, web.GenConfig{
-				OutputDir:    "dist",
-				BaseURL:      "./",
-				IsHistorical: false,
-				HistoryDates: dates,
-				ReportDate:   date,
+			err = service.GenerateFullSite(ctx, metrics, web.GenConfig{
+				OutputDir:        outDir,
+				BaseURL:          "./",
+				IsHistorical:     false,
+				HistoryDates:     historyDates,
+				ReportDate:       date,
+				EnergyHistory:    energyHistory,
+				ProviderTimeline: providerTimeline,
+				BacklogFlow:      backlogFlow,
+				SourceReadRates:  sourceReadRates,
+				Previous:         previous,
+				LinkReport:       linkReport,
+				ReadingPlan:      readingPlan,
+				LazyChartData:    true,
+				Charts:           *charts,
+				Feed:             len(feedEvents) > 0,
 			})
-			if err != nil {
-				log.Fatalf("Failed to generate latest site: %v", err)
+			if err != nil && ctx.Err() == nil {
+				endStage(err)
+				restoreLogger()
+				writeBuildManifest(outDir, build.Finish(err), service.WrittenFiles())
+				lock.Release()
+				logging.Fatal("Failed to generate latest site", "err", err)
 			}
+			latestDone = err == nil
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		abort("🛑 Build cancelled", err)
+	}
+	// Without --keep-going the first failed history page stops the build
+	var historyErr error
+	if len(failed) > 0 {
+		historyErr = fmt.Errorf("%d history pages failed: %s", len(failed), strings.Join(failed, ", "))
+		if !*keepGoing {
+			abort("❌ History generation failed", historyErr)
+		}
+	}
+	endStage()
+
+	// The history index, permalinks, calendar, badges, social card, feeds and snapshot API
+	endStage = build.Start("extras")
+
+	// Browsable index of every snapshot page, linked from each archived analytics page
+	if len(historyDates) > 0 {
+		if err := service.GenerateHistoryIndex(ctx, snapshots[dates[0]], snapshots, web.GenConfig{
+			OutputDir:    filepath.Join(outDir, "history"),
+			BaseURL:      "../",
+			HistoryDates: historyDates,
+			ReportDate:   dates[0],
+		}); err != nil {
+			warnf("Failed to generate history index: %v", err)
+		}
+	}
+
+	// Optional permalink pages for every read article, built against the latest snapshot
+	if *permalinksDir != "" {
+		articles, err := loadExportedArticles(*permalinksDir)
+		if err != nil {
+			warnf("Skipping permalink pages: %v", err)
+		} else if count, err := service.GeneratePermalinks(ctx, snapshots[dates[0]], articles, web.GenConfig{
+			OutputDir:    filepath.Join(outDir, web.PermalinkDir),
+			BaseURL:      "../",
+			HistoryDates: historyDates,
+			ReportDate:   dates[0],
+			CanonicalDir: web.PermalinkDir,
+		}); err != nil {
+			warnf("Failed to generate permalink pages: %v", err)
+		} else {
+			slog.Info("✅ Generated permalink pages", "count", count)
+		}
+	}
+
+	// Calendar of the reading blocks forecast to clear the latest backlog
+	if readingPlan != nil {
+		if err := service.WriteReadingPlan(outDir, *readingPlan, snapshots[dates[0]].LastUpdated); err != nil {
+			warnf("Failed to publish reading plan: %v", err)
+		}
+	}
+
+	// Shields.io endpoint badges for the latest snapshot
+	if err := service.WriteBadges(outDir, snapshots[dates[0]]); err != nil {
+		warnf("Failed to publish badges: %v", err)
+	}
+
+	// Social preview card referenced by every page's og:image
+	if err := service.WriteOGImage(outDir, snapshots[dates[0]]); err != nil {
+		warnf("Failed to publish social preview image: %v", err)
+	}
+
+	// RSS and JSON feeds of recently added and read articles
+	if len(feedEvents) > 0 {
+		if err := service.WriteFeed(outDir, feedEvents); err != nil {
+			warnf("Failed to publish feeds: %v", err)
+		}
+	}
+
+	// 5. Publish raw snapshots for the explorer page
+	if err := service.WriteSnapshotAPI(outDir, snapshots); err != nil {
+		warnf("Failed to publish snapshot API: %v", err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		abort("🛑 Build cancelled", err)
+	}
+	endStage()
+
+	// Report the preview instead of publishing it; the manifest, compression and summaries describe the published site
+	if *dryRun {
+		restoreLogger()
+		reportPreview(outDir, *outputDir, service.WrittenFiles())
+		if historyErr != nil {
+			lock.Release()
+			logging.Fatal("❌ History generation failed", "failed", strings.Join(failed, ", "))
+		}
+		return
+	}
+
+	// 6. Record every owned file, warning about previously published files that disappeared
+	manifest, missing := web.MergeSiteManifest(outDir, previousManifest, service.WrittenFiles(), time.Now())
+	for _, file := range missing {
+		warnf("%s was published by an earlier build but is missing from %s", file, outDir)
+	}
+	if err := web.WriteSiteManifest(outDir, manifest); err != nil {
+		warnf("%v", err)
+	}
+
+	// 7. Precompressed siblings for hosts that serve them, such as nginx's gzip_static. Runs without
+	// --compress too, to drop siblings that would go stale.
+	endStage = build.Start("compress")
+	if count, err := web.CompressDir(outDir, encodings); err != nil {
+		warnf("Failed to precompress %s: %v", outDir, err)
+	} else if len(encodings) > 0 {
+		slog.Info("✅ Wrote precompressed files", "count", count, "encodings", strings.Join(encodings, ", "))
+	}
+
+	endStage()
+
+	// 8. Warn before the site outgrows GitHub Pages
+	siteSize, err := web.DirSize(outDir)
+	if err != nil {
+		warnf("%v", err)
+	} else if siteSize > pagesSiteLimitBytes {
+		warnf("%s is %d MB, over the %d MB GitHub Pages limit", outDir, siteSize>>20, pagesSiteLimitBytes>>20)
+	}
+
+	// 9. Report the build on the Actions run page
+	if err := stepsummary.Append(stepsummary.SiteMarkdown(stepsummary.Site{
+		ReportDate:   dates[0],
+		Snapshots:    len(dates),
+		HistoryPages: len(window),
+		Files:        len(service.WrittenFiles()),
+		SizeBytes:    siteSize,
+		Warnings:     buildWarnings,
+		Failures:     failures,
+	})); err != nil {
+		slog.Warn("Unable to write the step summary", "err", err)
+	}
+
+	// 10. Record the build's timings and outcome
+	restoreLogger()
+	writeBuildManifest(outDir, build.Finish(historyErr), service.WrittenFiles())
+	if historyErr != nil {
+		lock.Release()
+		logging.Fatal("❌ History generation failed", "failed", strings.Join(failed, ", "))
+	}
+
+	slog.Info("✅ Successfully generated all historical and latest analytics")
+}
+
+// reportPreview logs each file a dry run would add to or change in siteDir, keeping the rendered
+// preview for inspection
+func reportPreview(previewDir, siteDir string, written []string) {
+	preview, err := web.ComparePreview(previewDir, siteDir, written)
+	if err != nil {
+		slog.Warn("Unable to compare the preview with the site", "err", err)
+		return
+	}
+	for _, file := range preview.Added {
+		slog.Info("Would add", "file", file)
+	}
+	for _, file := range preview.Changed {
+		slog.Info("Would change", "file", file)
+	}
+	slog.Info("Dry run, nothing published", "site", siteDir, "added", len(preview.Added),
+		"changed", len(preview.Changed), "unchanged", len(preview.Unchanged), "preview", previewDir)
+}
+
+// writeBuildManifest counts the pages and files a build wrote and saves its manifest to
+// dir/build.json. A failed write is logged only.
+func writeBuildManifest(dir string, m runmanifest.Manifest, written []string) {
+	pages := 0
+	for _, file := range written {
+		if strings.HasSuffix(file, ".html") {
+			pages++
 		}
 	}
+	if m.Counts == nil {
+		m.Counts = map[string]int{}
+	}
+	m.Counts[runmanifest.PagesGenerated] = pages
+	m.Counts[runmanifest.FilesWritten] = len(written)
 
-	log.Println("✅ Successfully generated all historical and latest analytics")
+	if err := runmanifest.Write(filepath.Join(dir, runmanifest.SiteFile), m); err != nil {
+		slog.Warn("Unable to write the build manifest", "err", err)
+	}
 }
 
-// getMetricsDates returns all YYYY-MM-DD dates from JSON files in metrics/ folder, sorted descending
-func getMetricsDates() ([]string, error) {
-	entries, err := os.ReadDir("metrics")
+// buildWarnings collects every warnf message for the step summary
+var buildWarnings []string
+
+// warnf logs a build warning and records it for the step summary
+func warnf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	buildWarnings = append(buildWarnings, message)
+	slog.Warn(message)
+}
+
+// getMetricsDates returns all YYYY-MM-DD dates from JSON files in dir, sorted descending
+func getMetricsDates(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read metrics directory: %w", err)
 	}
 
 	var dates []string
 	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+		if !entry.IsDir() && metricspkg.IsSnapshotFilename(entry.Name()) {
 			date := strings.TrimSuffix(entry.Name(), ".json")
 			dates = append(dates, date)
 		}
@@ -86,9 +560,9 @@ func getMetricsDates() ([]string, error) {
 	return dates, nil
 }
 
-// loadMetricsByDate reads a specific metrics JSON file from metrics/ folder
-func loadMetricsByDate(date string) (schema.Metrics, error) {
-	filename := fmt.Sprintf("metrics/%s.json", date)
+// loadMetricsByDate reads a specific metrics JSON file from dir
+func loadMetricsByDate(dir, date string) (schema.Metrics, error) {
+	filename := filepath.Join(dir, date+".json")
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return schema.Metrics{}, fmt.Errorf("unable to read metrics file %s: %w", filename, err)
@@ -102,3 +576,227 @@ func loadMetricsByDate(date string) (schema.Metrics, error) {
 
 	return metrics, nil
 }
+
+// loadSnapshots loads the snapshot of every date, skipping unreadable files and reporting aggregation bugs
+func loadSnapshots(dir string, dates []string) map[string]schema.Metrics {
+	snapshots := make(map[string]schema.Metrics, len(dates))
+	for _, date := range dates {
+		metrics, err := loadMetricsByDate(dir, date)
+		if err != nil {
+			warnf("Skipping %s: %v", date, err)
+			continue
+		}
+		metricspkg.ReportConsistency(date, metrics)
+		snapshots[date] = metrics
+	}
+	return snapshots
+}
+
+// applyTimezone switches the process to the timezone in config.yml, so "this month" and the
+// displayed update times match the metrics generator
+func applyTimezone() {
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		return // reported when the branding and planning settings are loaded
+	}
+	if err := config.ApplyTimezone(cfg.Timezone); err != nil {
+		warnf("%v, using %s", err, time.Local)
+	}
+}
+
+// loadPaths reads the snapshot, site and template directories from config.yml
+func loadPaths() config.PathsConfig {
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		return config.PathsConfig{} // reported when the branding is loaded
+	}
+	return cfg.Paths
+}
+
+// loadPublic reads whether config.yml publishes aggregates only
+func loadPublic() bool {
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		return false // reported when the branding is loaded
+	}
+	return cfg.Public
+}
+
+// loadBranding reads the dashboard title, page titles, footer and locale from config.yml,
+// keeping the defaults for anything missing or invalid
+func loadBranding() web.Branding {
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		warnf("%v, using default branding", err)
+	}
+	branding, err := web.BrandingFromConfig(cfg.Branding)
+	if err != nil {
+		warnf("%v, using %s", err, locale.Default)
+	}
+	return branding
+}
+
+// loadTheme reads the light and dark color tokens from config.yml, keeping the built-in colors for
+// anything missing or invalid
+func loadTheme() web.Theme {
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		return web.DefaultTheme() // reported when the branding is loaded
+	}
+	theme, err := web.ThemeFromConfig(cfg.Theme)
+	if err != nil {
+		warnf("%v, keeping the default colors for those values", err)
+	}
+	return theme
+}
+
+// loadHighlights reads the highlight badges from config.yml, leaving out invalid ones and keeping the
+// built-in badges when none are set
+func loadHighlights() []web.Highlight {
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		return web.DefaultHighlights() // reported when the branding is loaded
+	}
+	highlights, err := web.HighlightsFromConfig(cfg.Highlights)
+	if err != nil {
+		warnf("%v, leaving those highlights out", err)
+	}
+	return highlights
+}
+
+// buildReadingPlan forecasts daily reading blocks from the day after the latest snapshot, sized by
+// the planning settings in config.yml. It returns nil when the settings are invalid.
+func buildReadingPlan(latest schema.Metrics, date string) *forecast.Plan {
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		warnf("%v, using default configuration", err)
+	}
+	opts := forecast.Options{
+		DailyMinutes:      cfg.Planning.DailyMinutes,
+		MinutesPerArticle: cfg.Planning.MinutesPerArticle,
+		BlockStart:        cfg.Planning.StartTime,
+	}
+	if err := opts.Validate(); err != nil {
+		warnf("Skipping reading plan: %v", err)
+		return nil
+	}
+
+	start, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		start = latest.LastUpdated
+	}
+	plan := forecast.NewPlan(latest, opts, start.AddDate(0, 0, 1))
+	return &plan
+}
+
+// loadLinkReport reads the checklinks report, returning nil when none has been written
+func loadLinkReport(path string) *linkcheck.Report {
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	report, err := linkcheck.Read(path)
+	if err != nil {
+		warnf("Skipping link report: %v", err)
+		return nil
+	}
+	return &report
+}
+
+// loadExportedArticles reads every articles export (reading-YYYY.articles.json) in dir
+func loadExportedArticles(dir string) ([]schema.ArticleMeta, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+export.ArticlesExtension))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list article exports: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no %s files found in %s", export.ArticlesExtension, dir)
+	}
+
+	var articles []schema.ArticleMeta
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w", path, err)
+		}
+		var exported []schema.ArticleMeta
+		if err := json.Unmarshal(data, &exported); err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+		articles = append(articles, exported...)
+	}
+	return articles, nil
+}
+
+// historyBounds selects the history pages regenerated this run. Zero values keep every date.
+type historyBounds struct {
+	since, until string   // inclusive YYYY-MM-DD range
+	dates        []string // explicit dates
+	limit        int      // most recent N
+}
+
+// parseHistoryBounds validates the --history-* flags; only is a comma-separated list of dates
+func parseHistoryBounds(since, until, only string, limit int) (historyBounds, error) {
+	bounds := historyBounds{since: since, until: until, limit: limit}
+	for _, bound := range [][2]string{{"--history-since", since}, {"--history-until", until}} {
+		if bound[1] == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", bound[1]); err != nil {
+			return historyBounds{}, fmt.Errorf("%s %q: expected YYYY-MM-DD", bound[0], bound[1])
+		}
+	}
+	if since != "" && until != "" && until < since {
+		return historyBounds{}, fmt.Errorf("--history-until %s is before --history-since %s", until, since)
+	}
+	for _, date := range strings.Split(only, ",") {
+		date = strings.TrimSpace(date)
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return historyBounds{}, fmt.Errorf("--history-dates %q: expected YYYY-MM-DD", date)
+		}
+		bounds.dates = append(bounds.dates, date)
+	}
+	return bounds, nil
+}
+
+// selectHistoryWindow bounds the (descending) dates to those between since and until, then to
+// the listed dates, and then to the limit most recent
+func selectHistoryWindow(dates []string, bounds historyBounds) []string {
+	var window []string
+	for _, date := range dates {
+		if bounds.since != "" && date < bounds.since || bounds.until != "" && date > bounds.until {
+			continue
+		}
+		if len(bounds.dates) > 0 && !slices.Contains(bounds.dates, date) {
+			continue
+		}
+		if bounds.limit > 0 && len(window) >= bounds.limit {
+			break
+		}
+		window = append(window, date)
+	}
+	return window
+}
+
+// linkedHistoryDates returns the dates the history selector should list: those generated this run
+// plus those whose page survives from an earlier build in historyDir
+func linkedHistoryDates(dates, window []string, historyDir string) []string {
+	inWindow := make(map[string]bool, len(window))
+	for _, date := range window {
+		inWindow[date] = true
+	}
+
+	var linked []string
+	for _, date := range dates {
+		if inWindow[date] {
+			linked = append(linked, date)
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(historyDir, date, "analytics.html")); err == nil {
+			linked = append(linked, date)
+		}
+	}
+	return linked
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -24,8 +26,8 @@ func isValidURL(link string) bool {
 }
 
 // ============================================================================
-// getMetricsDates: Returns all YYYY-MM-DD dates from JSON files in metrics/ folder
-// loadMetricsByDate: Reads a specific metrics JSON file from metrics/ folder
+// getMetricsDates: Returns all YYYY-MM-DD dates from JSON files in a metrics folder
+// loadMetricsByDate: Reads a specific metrics JSON file from a metrics folder
 // ============================================================================
 
 func TestGetMetricsDates(t *testing.T) {
@@ -37,7 +39,7 @@ func TestGetMetricsDates(t *testing.T) {
 	}{
 		{
 			name:          "returns sorted dates",
-			fileNames:     []string{"2025-01-01.json", "2024-01-01.json", "invalid.txt"},
+			fileNames:     []string{"2025-01-01.json", "2025-01-01.run.json", "2024-01-01.json", "invalid.txt"},
 			expectedDates: []string{"2025-01-01", "2024-01-01"},
 			expectError:   false,
 		},
@@ -74,7 +76,7 @@ func TestGetMetricsDates(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			dates, err := getMetricsDates()
+			dates, err := getMetricsDates("metrics")
 			if (err != nil) != tt.expectError {
 				t.Errorf("unexpected error: %v", err)
 			}
@@ -141,7 +143,7 @@ func TestLoadMetricsByDate(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			metrics, err := loadMetricsByDate(tt.date)
+			metrics, err := loadMetricsByDate("metrics", tt.date)
 			if (err != nil) != tt.expectError {
 				t.Errorf("unexpected error: %v", err)
 			}
@@ -151,3 +153,136 @@ func TestLoadMetricsByDate(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectHistoryWindow(t *testing.T) {
+	dates := []string{"2025-03-01", "2025-02-01", "2025-01-01", "2024-12-01"}
+
+	tests := []struct {
+		name     string
+		bounds   historyBounds
+		expected []string
+	}{
+		{name: "no bounds keeps every date", expected: dates},
+		{name: "since drops older dates", bounds: historyBounds{since: "2025-01-01"}, expected: []string{"2025-03-01", "2025-02-01", "2025-01-01"}},
+		{name: "until drops newer dates", bounds: historyBounds{until: "2025-01-15"}, expected: []string{"2025-01-01", "2024-12-01"}},
+		{name: "since and until bound a range", bounds: historyBounds{since: "2025-01-01", until: "2025-02-01"}, expected: []string{"2025-02-01", "2025-01-01"}},
+		{name: "dates keep only those listed", bounds: historyBounds{dates: []string{"2024-12-01", "2025-02-01", "2025-02-08"}}, expected: []string{"2025-02-01", "2024-12-01"}},
+		{name: "limit keeps the most recent", bounds: historyBounds{limit: 2}, expected: []string{"2025-03-01", "2025-02-01"}},
+		{name: "since and limit combine", bounds: historyBounds{since: "2025-02-01", limit: 5}, expected: []string{"2025-03-01", "2025-02-01"}},
+		{name: "since after every date", bounds: historyBounds{since: "2026-01-01"}, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := selectHistoryWindow(dates, tt.bounds)
+			if strings.Join(window, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("selectHistoryWindow() = %v, want %v", window, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseHistoryBounds(t *testing.T) {
+	tests := []struct {
+		name        string
+		since       string
+		until       string
+		only        string
+		expected    historyBounds
+		expectError bool
+	}{
+		{name: "no flags", expected: historyBounds{}},
+		{name: "range", since: "2025-11-01", until: "2025-11-30", expected: historyBounds{since: "2025-11-01", until: "2025-11-30"}},
+		{name: "dates list", only: "2025-11-01, 2025-11-08,", expected: historyBounds{dates: []string{"2025-11-01", "2025-11-08"}}},
+		{name: "invalid since", since: "2025/11/01", expectError: true},
+		{name: "invalid until", until: "yesterday", expectError: true},
+		{name: "until before since", since: "2025-11-08", until: "2025-11-01", expectError: true},
+		{name: "invalid listed date", only: "2025-11-01,2025-13-01", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bounds, err := parseHistoryBounds(tt.since, tt.until, tt.only, 0)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("parseHistoryBounds() error = %v, expectError %v", err, tt.expectError)
+			}
+			if !reflect.DeepEqual(bounds, tt.expected) {
+				t.Errorf("parseHistoryBounds() = %+v, want %+v", bounds, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLinkedHistoryDates(t *testing.T) {
+	historyDir := t.TempDir()
+	// A page kept from an earlier build
+	if err := os.MkdirAll(filepath.Join(historyDir, "2024-12-01"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(historyDir, "2024-12-01", "analytics.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dates := []string{"2025-02-01", "2025-01-01", "2024-12-01"}
+	linked := linkedHistoryDates(dates, []string{"2025-02-01"}, historyDir)
+
+	expected := []string{"2025-02-01", "2024-12-01"}
+	if strings.Join(linked, ",") != strings.Join(expected, ",") {
+		t.Errorf("linkedHistoryDates() = %v, want %v", linked, expected)
+	}
+}
+
+func TestLoadLinkReport(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(valid, []byte(`{"checked":4,"counts":{"dead":1},"results":[{"link":"https://a.com","status":"dead"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(invalid, []byte(`{not json`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if report := loadLinkReport(filepath.Join(dir, "missing.json")); report != nil {
+		t.Errorf("expected no report for a missing file, got %+v", report)
+	}
+	if report := loadLinkReport(invalid); report != nil {
+		t.Errorf("expected no report for invalid JSON, got %+v", report)
+	}
+	if report := loadLinkReport(valid); report == nil || report.Checked != 4 || len(report.Results) != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestLoadExportedArticles(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := loadExportedArticles(dir); err == nil {
+		t.Error("expected an error without article exports")
+	}
+
+	files := map[string]string{
+		"reading-2024.articles.json": `[{"title":"A","link":"https://a.com","read":true,"notes":"Good"}]`,
+		"reading-2025.articles.json": `[{"title":"B","link":"https://b.com","read":true}]`,
+		"reading-2025.json":          `not an articles export`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	articles, err := loadExportedArticles(dir)
+	if err != nil {
+		t.Fatalf("loadExportedArticles() error = %v", err)
+	}
+	if len(articles) != 2 || articles[0].Notes != "Good" || articles[1].Title != "B" {
+		t.Errorf("unexpected articles %+v", articles)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "reading-2023.articles.json"), []byte(`{`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadExportedArticles(dir); err == nil {
+		t.Error("expected an error for an invalid export")
+	}
+}
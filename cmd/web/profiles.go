@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	web "github.com/victoriacheng15/personal-reading-analytics/internal/web"
+)
+
+// runProfiles writes the site root's index.html linking the dashboard of every profile in
+// config.yml, each built beforehand with --profile
+func runProfiles(args []string) error {
+	if config.Profile() != "" {
+		return fmt.Errorf("profiles links every profile, run it without --profile")
+	}
+	cfg, err := config.Load(config.Path())
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("profiles", flag.ContinueOnError)
+	outputDir := fs.String("output-dir", cfg.Paths.OutputDir(), "Site root the profiles were built into, one subdirectory each")
+	title := fs.String("title", loadBranding().Title, "Heading of the profile index (default branding.title in config.yml)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(cfg.Profiles) == 0 {
+		return fmt.Errorf("no profiles in %s", config.Path())
+	}
+
+	links := make([]web.ProfileLink, 0, len(cfg.Profiles))
+	for _, profile := range cfg.Profiles {
+		link := web.ProfileLink{Name: profile.Name, Title: profile.Title}
+		if link.Title == "" {
+			link.Title = profile.Name
+		}
+		// A profile without snapshots yet is still linked, just without a date
+		if dates, err := getMetricsDates(filepath.Join(cfg.Paths.MetricsDir(), profile.Name)); err == nil {
+			link.Updated = dates[0]
+		}
+		links = append(links, link)
+	}
+
+	if err := web.WriteProfileIndex(*outputDir, *title, links); err != nil {
+		return err
+	}
+	slog.Info("✅ Wrote profile index", "dir", *outputDir, "profiles", len(links))
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func TestRunProfiles(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yml")
+	content := "paths:\n  metrics: " + filepath.Join(dir, "metrics") + "\n  output: " + filepath.Join(dir, "dist") + "\n" +
+		"profiles:\n  - name: victoria\n    title: Victoria\n  - name: partner\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_PATH", configPath)
+	snapshots := filepath.Join(dir, "metrics", "victoria")
+	if err := os.MkdirAll(snapshots, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(snapshots, "2026-03-13.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runProfiles(nil); err != nil {
+		t.Fatalf("runProfiles() error = %v", err)
+	}
+	index, err := os.ReadFile(filepath.Join(dir, "dist", "index.html"))
+	if err != nil {
+		t.Fatalf("expected the profile index: %v", err)
+	}
+	for _, expected := range []string{`href="victoria/index.html"`, "Updated 2026-03-13", `href="partner/index.html"`} {
+		if !strings.Contains(string(index), expected) {
+			t.Errorf("expected %q in the profile index", expected)
+		}
+	}
+
+	config.SetProfile("victoria")
+	defer config.SetProfile("")
+	if err := runProfiles(nil); err == nil {
+		t.Error("expected an error when a profile is selected")
+	}
+}
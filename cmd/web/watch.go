@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	metricspkg "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// watchMetrics builds once, then polls dir every interval and builds again whenever a snapshot
+// is added, rewritten or removed, until ctx is cancelled. A failed build is retried on the next
+// poll, such as when a metrics run still holds the lock.
+func watchMetrics(ctx context.Context, dir string, interval time.Duration, build func(context.Context) error) {
+	var built map[string]time.Time
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		state, err := snapshotState(dir)
+		if err != nil {
+			slog.Warn("Unable to scan metrics directory", "dir", dir, "err", err)
+		} else if built == nil || !maps.Equal(state, built) {
+			if built != nil {
+				slog.Info("🔄 Snapshots changed, rebuilding", "dir", dir)
+			}
+			if err := build(ctx); err != nil {
+				if ctx.Err() == nil {
+					slog.Warn("Build failed, retrying on the next poll", "err", err)
+				}
+			} else {
+				built = state
+				slog.Info("👀 Watching for new snapshots", "dir", dir, "interval", interval)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// snapshotState maps each snapshot filename in dir to its modification time
+func snapshotState(dir string) (map[string]time.Time, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read metrics directory: %w", err)
+	}
+	state := make(map[string]time.Time)
+	for _, entry := range entries {
+		if entry.IsDir() || !metricspkg.IsSnapshotFilename(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		state[entry.Name()] = info.ModTime()
+	}
+	return state, nil
+}
+
+// buildCommand runs this binary again with args minus the watch flags, so each build is a regular
+// one-off build whose failures leave the watcher running. Cancelling ctx sends the build SIGTERM,
+// which stops it between pages.
+func buildCommand(args []string) (func(context.Context) error, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate the web binary: %w", err)
+	}
+	buildArgs := withoutWatchFlags(args)
+	return func(ctx context.Context) error {
+		cmd := exec.CommandContext(ctx, exe, buildArgs...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		cmd.Cancel = func() error { return cmd.Process.Signal(syscall.SIGTERM) }
+		return cmd.Run()
+	}, nil
+}
+
+// withoutWatchFlags drops --watch and --watch-interval, in any of the forms the flag package
+// accepts, from args. Appending --watch=false instead would be read as a positional argument
+// after one, leaving the build watching and respawning itself.
+func withoutWatchFlags(args []string) []string {
+	var kept []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--" {
+			return append(kept, args[i:]...)
+		}
+		name, _, hasValue := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(args[i], "-"), "-"), "=")
+		switch {
+		case !strings.HasPrefix(args[i], "-"):
+			kept = append(kept, args[i])
+		case name == "watch":
+		case name == "watch-interval":
+			if !hasValue {
+				i++ // skip the interval that follows
+			}
+		default:
+			kept = append(kept, args[i])
+		}
+	}
+	return kept
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshotState(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"2026-03-06.json", "2026-03-06.run.json", "notes.json"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	state, err := snapshotState(dir)
+	if err != nil {
+		t.Fatalf("snapshotState() error = %v", err)
+	}
+	if len(state) != 1 || state["2026-03-06.json"].IsZero() {
+		t.Errorf("expected only the snapshot, got %v", state)
+	}
+
+	if _, err := snapshotState(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+
+func TestWatchMetrics(t *testing.T) {
+	dir := t.TempDir()
+	writeSnapshot := func(date string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, date+".json"), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeSnapshot("2026-03-06")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	builds := make(chan int, 10)
+	calls := 0
+	done := make(chan struct{})
+	go func() {
+		watchMetrics(ctx, dir, 5*time.Millisecond, func(context.Context) error {
+			calls++
+			builds <- calls
+			// The second build fails, so the watcher retries without a new snapshot
+			if calls == 2 {
+				return errors.New("locked")
+			}
+			return nil
+		})
+		close(done)
+	}()
+
+	waitBuild := func(expected int) {
+		t.Helper()
+		select {
+		case n := <-builds:
+			if n != expected {
+				t.Fatalf("expected build %d, got %d", expected, n)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for build %d", expected)
+		}
+	}
+	waitBuild(1)
+	select {
+	case n := <-builds:
+		t.Fatalf("expected no rebuild without a change, got build %d", n)
+	case <-time.After(30 * time.Millisecond):
+	}
+
+	writeSnapshot("2026-03-13")
+	waitBuild(2)
+	waitBuild(3)
+	select {
+	case n := <-builds:
+		t.Fatalf("expected no rebuild after the retry succeeded, got build %d", n)
+	case <-time.After(30 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the watcher to stop when cancelled")
+	}
+}
+
+func TestWithoutWatchFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{name: "bool flag", args: []string{"--watch", "--charts", "svg"}, expected: []string{"--charts", "svg"}},
+		{name: "single dash and value", args: []string{"-watch=true", "-public"}, expected: []string{"-public"}},
+		{name: "interval as next argument", args: []string{"--watch", "--watch-interval", "1m", "--dry-run"}, expected: []string{"--dry-run"}},
+		{name: "interval with equals", args: []string{"-watch-interval=10s", "--watch", "--output", "dist"}, expected: []string{"--output", "dist"}},
+		{name: "after terminator", args: []string{"--watch", "--", "--watch"}, expected: []string{"--", "--watch"}},
+		{name: "no watch flags", args: []string{"--keep-going"}, expected: []string{"--keep-going"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withoutWatchFlags(tt.args); strings.Join(got, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("withoutWatchFlags(%v) = %v, want %v", tt.args, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	metricspkg "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	web "github.com/victoriacheng15/personal-reading-analytics/internal/web"
+)
+
+// runWrapped renders the standalone year-in-review page for one year of snapshots
+func runWrapped(ctx context.Context, args []string, paths config.PathsConfig) error {
+	fs := flag.NewFlagSet("wrapped", flag.ContinueOnError)
+	metricsDir := fs.String("metrics-dir", paths.MetricsDir(), "Directory of metrics snapshots")
+	year := fs.String("year", "", "Year to review, such as 2025 (default: the year of the latest snapshot)")
+	out := fs.String("out", filepath.Join(paths.OutputDir(), web.WrappedDir), "Directory to write YEAR/wrapped.html to, two levels below the site root")
+	compress := fs.String("compress", "", "Also write precompressed siblings of the page: gzip, br or gzip,br")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	encodings, err := web.ParseEncodings(*compress)
+	if err != nil {
+		return fmt.Errorf("invalid --compress: %w", err)
+	}
+
+	dates, err := getMetricsDates(*metricsDir)
+	if err != nil {
+		return fmt.Errorf("failed to discover metrics: %w", err)
+	}
+	if *year == "" {
+		*year = dates[0][:len("2006")]
+	}
+	if _, err := time.Parse("2006", *year); err != nil {
+		return fmt.Errorf("invalid --year %q: expected YYYY", *year)
+	}
+
+	snapshots := loadSnapshots(*metricsDir, dates)
+	review, err := metricspkg.BuildYearInReview(snapshots, *year)
+	if err != nil {
+		return err
+	}
+
+	// The year's last snapshot supplies the page header and footer
+	var last string
+	for _, date := range dates {
+		if _, exists := snapshots[date]; exists && strings.HasPrefix(date, *year+"-") {
+			last = date
+			break
+		}
+	}
+
+	dir := filepath.Join(*out, *year)
+	service := web.NewAnalyticsService(*out)
+	service.SetBranding(loadBranding())
+	service.SetTheme(loadTheme())
+	if err := service.GenerateWrapped(ctx, snapshots[last], review, web.GenConfig{
+		OutputDir:    dir,
+		BaseURL:      "../../",
+		ReportDate:   last,
+		CanonicalDir: filepath.Base(*out) + "/" + *year,
+	}); err != nil {
+		return fmt.Errorf("failed to generate %s year in review: %w", *year, err)
+	}
+	if _, err := web.CompressDir(dir, encodings); err != nil {
+		return fmt.Errorf("failed to precompress %s: %w", dir, err)
+	}
+
+	slog.Info("✅ Generated the year in review", "year", *year, "file", filepath.Join(dir, "wrapped.html"))
+	return nil
+}
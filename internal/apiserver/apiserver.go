@@ -0,0 +1,163 @@
+package apiserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// TokenEnvVar holds the bearer token that authorizes POST /api/refresh
+const TokenEnvVar = "API_TOKEN"
+
+// Refresh states reported by GET /api/refresh
+const (
+	StateIdle      = "idle"
+	StateRunning   = "running"
+	StateSucceeded = "succeeded"
+	StateFailed    = "failed"
+)
+
+// Config configures a Server
+type Config struct {
+	MetricsDir string // snapshots served under /api/metrics/
+	SiteDir    string // static site served under /
+	Token      string // bearer token for POST /api/refresh; empty disables refreshes
+	Public     bool   // redact article titles and links from served snapshots
+	// Refresh fetches a new snapshot, such as a metrics run writing into MetricsDir
+	Refresh func(context.Context) error
+	// Debounce coalesces the refresh requests of a burst into one fetch (default DefaultDebounce)
+	Debounce time.Duration
+	// MinInterval is the least time between the starts of two fetches (default DefaultMinInterval)
+	MinInterval time.Duration
+}
+
+// RefreshStatus describes the latest refresh and whether another is queued
+type RefreshStatus struct {
+	State      string     `json:"state"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	Pending    bool       `json:"pending"`
+	NextRun    *time.Time `json:"next_run,omitempty"` // when the queued refresh is expected to start
+}
+
+// Server serves the snapshots as JSON, the static site, and refreshes on demand
+type Server struct {
+	cfg   Config
+	mux   *http.ServeMux
+	queue *refreshQueue
+}
+
+// New returns a Server whose refreshes run until ctx is cancelled
+func New(ctx context.Context, cfg Config) *Server {
+	if cfg.Debounce <= 0 {
+		cfg.Debounce = DefaultDebounce
+	}
+	if cfg.MinInterval <= 0 {
+		cfg.MinInterval = DefaultMinInterval
+	}
+	s := &Server{cfg: cfg, mux: http.NewServeMux(), queue: newRefreshQueue(cfg.Refresh, cfg.Debounce, cfg.MinInterval)}
+	if cfg.Token != "" && cfg.Refresh != nil {
+		go s.queue.Run(ctx)
+	}
+	s.mux.HandleFunc("GET /api/metrics/latest", s.handleLatest)
+	s.mux.HandleFunc("GET /api/metrics/{date}", s.handleSnapshot)
+	s.mux.HandleFunc("GET /api/refresh", s.handleRefreshStatus)
+	s.mux.HandleFunc("POST /api/refresh", s.handleRefresh)
+	s.mux.Handle("/", http.FileServer(http.Dir(cfg.SiteDir)))
+	return s
+}
+
+// ServeHTTP routes a request
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleLatest(w http.ResponseWriter, r *http.Request) {
+	files, err := metrics.ListSnapshotFiles(s.cfg.MetricsDir)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if len(files) == 0 {
+		writeError(w, http.StatusNotFound, errors.New("no snapshots yet"))
+		return
+	}
+	s.serveSnapshot(w, files[len(files)-1])
+}
+
+func (s *Server) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	date := r.PathValue("date")
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid date %q: expected YYYY-MM-DD or latest", date))
+		return
+	}
+	s.serveSnapshot(w, date+".json")
+}
+
+// serveSnapshot writes one snapshot, redacted in public mode
+func (s *Server) serveSnapshot(w http.ResponseWriter, filename string) {
+	snapshot, err := metrics.LoadSnapshot(s.cfg.MetricsDir, filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			writeError(w, http.StatusNotFound, fmt.Errorf("no snapshot for %s", strings.TrimSuffix(filename, ".json")))
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if s.cfg.Public {
+		metrics.Redact(snapshot)
+	}
+	writeJSON(w, http.StatusOK, snapshot)
+}
+
+func (s *Server) handleRefreshStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Status())
+}
+
+// handleRefresh queues a refresh, answering 202 with the queue's status
+func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	if s.cfg.Token == "" || s.cfg.Refresh == nil {
+		writeError(w, http.StatusForbidden, fmt.Errorf("refresh is disabled, set %s to enable it", TokenEnvVar))
+		return
+	}
+	if !authorized(r, s.cfg.Token) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, s.queue.Trigger())
+}
+
+// Status returns the latest refresh's state
+func (s *Server) Status() RefreshStatus {
+	return s.queue.Status()
+}
+
+// authorized reports whether r carries "Authorization: Bearer token"
+func authorized(r *http.Request, token string) bool {
+	given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
+
+// writeJSON writes v as a JSON response with the status code
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes {"error": "..."} with the status code
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
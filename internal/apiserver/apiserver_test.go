@@ -0,0 +1,140 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func newTestServer(t *testing.T, cfg Config) *Server {
+	t.Helper()
+	cfg.MetricsDir = t.TempDir()
+	cfg.SiteDir = t.TempDir()
+	for date, total := range map[string]int{"2026-03-06": 10, "2026-03-13": 12} {
+		content, _ := json.Marshal(schema.Metrics{
+			TotalArticles:           total,
+			TopOldestUnreadArticles: []schema.ArticleMeta{{Title: "Secret title", Link: "https://example.com/a"}},
+		})
+		if err := os.WriteFile(filepath.Join(cfg.MetricsDir, date+".json"), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(cfg.SiteDir, "index.html"), []byte("<h1>Dashboard</h1>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return New(t.Context(), cfg)
+}
+
+func request(s *Server, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServeSnapshots(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		public       bool
+		expectedCode int
+		expectedBody []string
+		unexpected   []string
+	}{
+		{name: "latest", path: "/api/metrics/latest", expectedCode: http.StatusOK, expectedBody: []string{`"total_articles":12`, "Secret title"}},
+		{name: "by date", path: "/api/metrics/2026-03-06", expectedCode: http.StatusOK, expectedBody: []string{`"total_articles":10`}},
+		{name: "public redacts titles", path: "/api/metrics/latest", public: true, expectedCode: http.StatusOK, unexpected: []string{"Secret title"}},
+		{name: "missing date", path: "/api/metrics/2026-01-01", expectedCode: http.StatusNotFound, expectedBody: []string{"no snapshot for 2026-01-01"}},
+		{name: "invalid date", path: "/api/metrics/last-week", expectedCode: http.StatusBadRequest},
+		{name: "static site", path: "/", expectedCode: http.StatusOK, expectedBody: []string{"<h1>Dashboard</h1>"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, Config{Public: tt.public})
+			rec := request(s, http.MethodGet, tt.path, "")
+			if rec.Code != tt.expectedCode {
+				t.Fatalf("GET %s = %d, expected %d: %s", tt.path, rec.Code, tt.expectedCode, rec.Body)
+			}
+			body := rec.Body.String()
+			for _, want := range tt.expectedBody {
+				if !strings.Contains(body, want) {
+					t.Errorf("expected body to contain %q, got %s", want, body)
+				}
+			}
+			for _, unwanted := range tt.unexpected {
+				if strings.Contains(body, unwanted) {
+					t.Errorf("expected body not to contain %q, got %s", unwanted, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	done := make(chan struct{}, 10)
+	s := newTestServer(t, Config{Token: "secret", Debounce: 20 * time.Millisecond, MinInterval: time.Minute, Refresh: func(context.Context) error {
+		done <- struct{}{}
+		return errors.New("sheet unavailable")
+	}})
+
+	if rec := request(s, http.MethodPost, "/api/refresh", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a token, got %d", rec.Code)
+	}
+	if rec := request(s, http.MethodPost, "/api/refresh", "wrong"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 with a wrong token, got %d", rec.Code)
+	}
+	// A burst of requests is coalesced into one fetch
+	for range 3 {
+		rec := request(s, http.MethodPost, "/api/refresh", "secret")
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body)
+		}
+		var status RefreshStatus
+		if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+			t.Fatal(err)
+		}
+		if !status.Pending || status.NextRun == nil {
+			t.Errorf("expected a queued refresh, got %+v", status)
+		}
+	}
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the refresh")
+	}
+	select {
+	case <-done:
+		t.Fatal("expected the burst to run one refresh")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	rec := request(s, http.MethodGet, "/api/refresh", "")
+	var status RefreshStatus
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatal(err)
+	}
+	if status.State != StateFailed || status.Error != "sheet unavailable" || status.FinishedAt == nil || status.Pending {
+		t.Errorf("expected the failed refresh recorded, got %+v", status)
+	}
+}
+
+func TestRefreshDisabled(t *testing.T) {
+	s := newTestServer(t, Config{Refresh: func(context.Context) error { return nil }})
+	if rec := request(s, http.MethodPost, "/api/refresh", "anything"); rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403 without a configured token, got %d", rec.Code)
+	}
+}
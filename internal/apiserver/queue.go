@@ -0,0 +1,128 @@
+package apiserver
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Queue defaults for refreshes, which each read the whole sheet through the Sheets API
+const (
+	DefaultDebounce    = 30 * time.Second
+	DefaultMinInterval = 5 * time.Minute
+)
+
+// refreshQueue runs at most one refresh at a time. Triggers within debounce of the first are
+// coalesced into one run, which starts no sooner than minInterval after the previous one. A
+// trigger during a run queues exactly one more.
+type refreshQueue struct {
+	run         func(context.Context) error
+	debounce    time.Duration
+	minInterval time.Duration
+	trigger     chan struct{}
+
+	mu        sync.Mutex
+	status    RefreshStatus
+	lastStart time.Time
+}
+
+func newRefreshQueue(run func(context.Context) error, debounce, minInterval time.Duration) *refreshQueue {
+	return &refreshQueue{
+		run:         run,
+		debounce:    debounce,
+		minInterval: minInterval,
+		trigger:     make(chan struct{}, 1),
+		status:      RefreshStatus{State: StateIdle},
+	}
+}
+
+// Trigger queues a refresh, coalescing it with one already queued, and returns the status
+func (q *refreshQueue) Trigger() RefreshStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	select {
+	case q.trigger <- struct{}{}:
+	default: // already queued
+	}
+	q.status.Pending = true
+	if q.status.NextRun == nil {
+		next := time.Now().Add(q.debounce)
+		if earliest := q.lastStart.Add(q.minInterval); earliest.After(next) {
+			next = earliest
+		}
+		q.status.NextRun = &next
+	}
+	return q.status
+}
+
+// Status returns the latest run's outcome and whether another is queued
+func (q *refreshQueue) Status() RefreshStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.status
+}
+
+// Run processes triggers until ctx is cancelled
+func (q *refreshQueue) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.trigger:
+		}
+
+		// Absorb the burst, then keep to the minimum interval between fetches
+		wait := q.debounce
+		q.mu.Lock()
+		if earliest := time.Until(q.lastStart.Add(q.minInterval)); earliest > wait {
+			wait = earliest
+		}
+		next := time.Now().Add(wait)
+		q.status.NextRun = &next
+		q.mu.Unlock()
+		if !sleep(ctx, wait) {
+			return
+		}
+		started := time.Now()
+		q.mu.Lock()
+		select {
+		case <-q.trigger: // coalesced into this run
+		default:
+		}
+		q.lastStart = started
+		q.status = RefreshStatus{State: StateRunning, StartedAt: &started}
+		q.mu.Unlock()
+
+		slog.Info("🔄 Refreshing metrics")
+		err := q.run(ctx)
+		finished := time.Now()
+
+		q.mu.Lock()
+		q.status.FinishedAt = &finished
+		q.status.State = StateSucceeded
+		if err != nil {
+			q.status.State = StateFailed
+			q.status.Error = err.Error()
+		}
+		q.mu.Unlock()
+
+		if err != nil {
+			slog.Warn("Refresh failed", "err", err)
+		} else {
+			slog.Info("✅ Refreshed metrics")
+		}
+	}
+}
+
+// sleep waits for d, returning false when ctx is cancelled first
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,59 @@
+package apiserver
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRefreshQueue(t *testing.T) {
+	const minInterval = 150 * time.Millisecond
+	starts := make(chan time.Time, 10)
+	release := make(chan struct{})
+	q := newRefreshQueue(func(context.Context) error {
+		starts <- time.Now()
+		<-release
+		return nil
+	}, 10*time.Millisecond, minInterval)
+	go q.Run(t.Context())
+
+	waitStart := func() time.Time {
+		t.Helper()
+		select {
+		case started := <-starts:
+			return started
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for a refresh")
+			return time.Time{}
+		}
+	}
+
+	q.Trigger()
+	first := waitStart()
+	if status := q.Status(); status.State != StateRunning || status.Pending {
+		t.Errorf("expected a running refresh with nothing queued, got %+v", status)
+	}
+
+	// Triggers during a run queue exactly one more, no sooner than minInterval after the first
+	for range 3 {
+		q.Trigger()
+	}
+	if status := q.Status(); !status.Pending || status.NextRun == nil {
+		t.Errorf("expected a queued refresh, got %+v", status)
+	}
+	release <- struct{}{}
+	second := waitStart()
+	if gap := second.Sub(first); gap < minInterval {
+		t.Errorf("expected at least %v between refreshes, got %v", minInterval, gap)
+	}
+	release <- struct{}{}
+
+	select {
+	case <-starts:
+		t.Fatal("expected the queued triggers to run one refresh")
+	case <-time.After(minInterval + 50*time.Millisecond):
+	}
+	if status := q.Status(); status.State != StateSucceeded || status.Pending {
+		t.Errorf("expected an idle queue after a successful refresh, got %+v", status)
+	}
+}
@@ -0,0 +1,182 @@
+package backup
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// Store is a bucket prefix that backups are copied to. Names are relative to the prefix.
+type Store interface {
+	// List returns the hex MD5 of every object under the prefix, keyed by name
+	List(ctx context.Context) (map[string]string, error)
+	Put(ctx context.Context, name string, data []byte) error
+	Get(ctx context.Context, name string) ([]byte, error)
+}
+
+// Location is a parsed backup URL
+type Location struct {
+	Scheme string // s3 or gs
+	Bucket string
+	Prefix string // empty or ending in a slash
+}
+
+// ParseURL reads s3://bucket/prefix or gs://bucket/prefix
+func ParseURL(raw string) (Location, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Location{}, fmt.Errorf("invalid backup URL %q: %w", raw, err)
+	}
+	if u.Scheme != "s3" && u.Scheme != "gs" {
+		return Location{}, fmt.Errorf("invalid backup URL %q: want s3://bucket/prefix or gs://bucket/prefix", raw)
+	}
+	if u.Host == "" {
+		return Location{}, fmt.Errorf("invalid backup URL %q: no bucket", raw)
+	}
+	prefix := strings.Trim(u.Path, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return Location{Scheme: u.Scheme, Bucket: u.Host, Prefix: prefix}, nil
+}
+
+// FromConfig opens the store named by config, with BACKUP_URL, BACKUP_ENDPOINT and BACKUP_REGION
+// taking precedence. GCS authenticates with the credentials at credentialsPath, or
+// Application Default Credentials when it is empty.
+func FromConfig(ctx context.Context, cfg config.BackupConfig, credentialsPath string) (Store, error) {
+	raw := envOr("BACKUP_URL", cfg.URL)
+	if raw == "" {
+		return nil, fmt.Errorf("no backup bucket is set (backup.url or BACKUP_URL)")
+	}
+	loc, err := ParseURL(raw)
+	if err != nil {
+		return nil, err
+	}
+	if loc.Scheme == "gs" {
+		return NewGCSStore(ctx, loc, credentialsPath)
+	}
+
+	accessKey, secretKey := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("S3 backups need AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	return NewS3Store(loc, envOr("BACKUP_ENDPOINT", cfg.Endpoint), envOr("BACKUP_REGION", envOr("AWS_REGION", cfg.Region)), accessKey, secretKey, os.Getenv("AWS_SESSION_TOKEN")), nil
+}
+
+// Result counts the files a Sync or Restore copied and those already matching
+type Result struct {
+	Copied    []string
+	Unchanged int
+	Skipped   []string // local files Restore kept because they differ from the backup
+}
+
+// Sync uploads every file in dir whose content differs from its backup. Objects without a local
+// file are kept, so pruning or losing snapshots locally never removes them from the bucket.
+func Sync(ctx context.Context, store Store, dir string) (Result, error) {
+	remote, err := store.List(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	names, err := localFiles(dir)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var result Result
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return result, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if remote[name] == md5Hex(data) {
+			result.Unchanged++
+			continue
+		}
+		if err := store.Put(ctx, name, data); err != nil {
+			return result, err
+		}
+		result.Copied = append(result.Copied, name)
+	}
+	return result, nil
+}
+
+// Restore downloads every backed-up file missing from dir. Local files that differ from their
+// backup are kept and reported unless overwrite is set.
+func Restore(ctx context.Context, store Store, dir string, overwrite bool) (Result, error) {
+	remote, err := store.List(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return Result{}, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	names := make([]string, 0, len(remote))
+	for name := range remote {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result Result
+	for _, name := range names {
+		if strings.Contains(name, "/") {
+			continue // only the flat metrics directory is backed up
+		}
+		path := filepath.Join(dir, name)
+		if local, err := os.ReadFile(path); err == nil {
+			if md5Hex(local) == remote[name] {
+				result.Unchanged++
+				continue
+			}
+			if !overwrite {
+				result.Skipped = append(result.Skipped, name)
+				continue
+			}
+		}
+		data, err := store.Get(ctx, name)
+		if err != nil {
+			return result, err
+		}
+		if err := safefile.WriteFile(path, data, 0644); err != nil {
+			return result, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		result.Copied = append(result.Copied, name)
+	}
+	return result, nil
+}
+
+// localFiles lists the regular files in dir, skipping hidden and temporary files
+func localFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+func md5Hex(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,128 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// memStore is an in-memory Store
+type memStore struct {
+	objects map[string][]byte
+	puts    []string
+}
+
+func (m *memStore) List(ctx context.Context) (map[string]string, error) {
+	sums := make(map[string]string, len(m.objects))
+	for name, data := range m.objects {
+		sums[name] = md5Hex(data)
+	}
+	return sums, nil
+}
+
+func (m *memStore) Put(ctx context.Context, name string, data []byte) error {
+	m.objects[name] = data
+	m.puts = append(m.puts, name)
+	return nil
+}
+
+func (m *memStore) Get(ctx context.Context, name string) ([]byte, error) {
+	data, exists := m.objects[name]
+	if !exists {
+		return nil, errors.New("not found")
+	}
+	return data, nil
+}
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		raw         string
+		expected    Location
+		expectError bool
+	}{
+		{"s3://reading/metrics", Location{Scheme: "s3", Bucket: "reading", Prefix: "metrics/"}, false},
+		{"gs://reading/backups/metrics/", Location{Scheme: "gs", Bucket: "reading", Prefix: "backups/metrics/"}, false},
+		{"s3://reading", Location{Scheme: "s3", Bucket: "reading"}, false},
+		{"https://reading/metrics", Location{}, true},
+		{"s3:///metrics", Location{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := ParseURL(tt.raw)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("ParseURL(%q) error = %v, expectError %v", tt.raw, err, tt.expectError)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseURL(%q) = %+v, expected %+v", tt.raw, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSync(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{"2025-01-05.json": "{\"a\":1}", "2025-01-12.json": "{\"a\":2}", ".2025-01-19.json.tmp-1": "partial"}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store := &memStore{objects: map[string][]byte{
+		"2025-01-05.json": []byte("{\"a\":1}"),
+		"2024-12-29.json": []byte("{\"a\":0}"),
+	}}
+
+	result, err := Sync(context.Background(), store, dir)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !reflect.DeepEqual(result.Copied, []string{"2025-01-12.json"}) || result.Unchanged != 1 {
+		t.Errorf("Sync() = %+v, expected only 2025-01-12.json uploaded", result)
+	}
+	if _, exists := store.objects["2024-12-29.json"]; !exists {
+		t.Error("expected objects without a local file to be kept")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	store := &memStore{objects: map[string][]byte{
+		"2025-01-05.json": []byte("{\"a\":1}"),
+		"2025-01-12.json": []byte("{\"a\":2}"),
+		"2025-01-19.json": []byte("{\"a\":3}"),
+	}}
+	tests := []struct {
+		name            string
+		overwrite       bool
+		expectedCopied  []string
+		expectedSkipped []string
+		expected12      string
+	}{
+		{"keeps differing local files", false, []string{"2025-01-19.json"}, []string{"2025-01-12.json"}, "local"},
+		{"overwrite replaces them", true, []string{"2025-01-12.json", "2025-01-19.json"}, nil, "{\"a\":2}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			os.WriteFile(filepath.Join(dir, "2025-01-05.json"), []byte("{\"a\":1}"), 0644)
+			os.WriteFile(filepath.Join(dir, "2025-01-12.json"), []byte("local"), 0644)
+
+			result, err := Restore(context.Background(), store, dir, tt.overwrite)
+			if err != nil {
+				t.Fatalf("Restore() error = %v", err)
+			}
+			if !reflect.DeepEqual(result.Copied, tt.expectedCopied) || !reflect.DeepEqual(result.Skipped, tt.expectedSkipped) || result.Unchanged != 1 {
+				t.Errorf("Restore() = %+v, expected copied %v and skipped %v", result, tt.expectedCopied, tt.expectedSkipped)
+			}
+			content, _ := os.ReadFile(filepath.Join(dir, "2025-01-12.json"))
+			if string(content) != tt.expected12 {
+				t.Errorf("2025-01-12.json = %q, expected %q", content, tt.expected12)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "2025-01-19.json")); err != nil {
+				t.Errorf("expected 2025-01-19.json restored: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/googleauth"
+)
+
+// GCSStore keeps backups in a Google Cloud Storage bucket
+type GCSStore struct {
+	service  *storage.Service
+	location Location
+}
+
+// NewGCSStore opens loc with the service account or OAuth client at credentialsPath, or Application
+// Default Credentials when it is empty, whose account needs write access to the bucket
+func NewGCSStore(ctx context.Context, loc Location, credentialsPath string) (*GCSStore, error) {
+	auth, err := googleauth.ClientOption(ctx, credentialsPath, storage.DevstorageReadWriteScope)
+	if err != nil {
+		return nil, err
+	}
+	service, err := storage.NewService(ctx, auth, option.WithScopes(storage.DevstorageReadWriteScope))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Cloud Storage client: %w", err)
+	}
+	return &GCSStore{service: service, location: loc}, nil
+}
+
+// List returns every object under the prefix with its MD5
+func (g *GCSStore) List(ctx context.Context) (map[string]string, error) {
+	objects := make(map[string]string)
+	err := g.service.Objects.List(g.location.Bucket).Prefix(g.location.Prefix).Pages(ctx, func(page *storage.Objects) error {
+		for _, object := range page.Items {
+			sum, _ := base64.StdEncoding.DecodeString(object.Md5Hash)
+			objects[strings.TrimPrefix(object.Name, g.location.Prefix)] = hex.EncodeToString(sum)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gs://%s/%s: %w", g.location.Bucket, g.location.Prefix, err)
+	}
+	return objects, nil
+}
+
+// Put uploads data as name
+func (g *GCSStore) Put(ctx context.Context, name string, data []byte) error {
+	object := &storage.Object{Name: g.location.Prefix + name}
+	if _, err := g.service.Objects.Insert(g.location.Bucket, object).Media(bytes.NewReader(data)).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+	return nil
+}
+
+// Get downloads name
+func (g *GCSStore) Get(ctx context.Context, name string) ([]byte, error) {
+	resp, err := g.service.Objects.Get(g.location.Bucket, g.location.Prefix+name).Context(ctx).Download()
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	return data, nil
+}
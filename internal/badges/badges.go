@@ -0,0 +1,65 @@
+package badges
+
+import (
+	"fmt"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// Dir holds the badge endpoint files, relative to the site root
+const Dir = "badges"
+
+// Badge is a shields.io endpoint badge (https://shields.io/badges/endpoint-badge)
+type Badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// Named pairs a badge with the file stem it is published under
+type Named struct {
+	Name  string
+	Badge Badge
+}
+
+// FromMetrics maps a snapshot to its total articles, read rate and backlog badges
+func FromMetrics(m schema.Metrics) []Named {
+	return []Named{
+		{Name: "total-articles", Badge: newBadge("articles", fmt.Sprintf("%d", m.TotalArticles), "blue")},
+		{Name: "read-rate", Badge: newBadge("read rate", fmt.Sprintf("%.1f%%", m.ReadRate), ReadRateColor(m.ReadRate))},
+		{Name: "backlog", Badge: newBadge("backlog", fmt.Sprintf("%d unread", m.UnreadCount), BacklogColor(m.UnreadCount))},
+	}
+}
+
+// ReadRateColor grades a read rate from red (under 25%) to bright green (75% and over)
+func ReadRateColor(rate float64) string {
+	switch {
+	case rate >= 75:
+		return "brightgreen"
+	case rate >= 50:
+		return "green"
+	case rate >= 25:
+		return "yellow"
+	default:
+		return "red"
+	}
+}
+
+// BacklogColor grades a backlog from bright green (under 100 unread) to red (1000 and over)
+func BacklogColor(unread int) string {
+	switch {
+	case unread < 100:
+		return "brightgreen"
+	case unread < 500:
+		return "yellow"
+	case unread < 1000:
+		return "orange"
+	default:
+		return "red"
+	}
+}
+
+func newBadge(label, message, color string) Badge {
+	return Badge{SchemaVersion: 1, Label: label, Message: message, Color: color}
+}
@@ -0,0 +1,57 @@
+package badges
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestFromMetrics(t *testing.T) {
+	got := FromMetrics(schema.Metrics{TotalArticles: 3339, ReadRate: 52.79, UnreadCount: 1576})
+
+	expected := []Named{
+		{Name: "total-articles", Badge: Badge{SchemaVersion: 1, Label: "articles", Message: "3339", Color: "blue"}},
+		{Name: "read-rate", Badge: Badge{SchemaVersion: 1, Label: "read rate", Message: "52.8%", Color: "green"}},
+		{Name: "backlog", Badge: Badge{SchemaVersion: 1, Label: "backlog", Message: "1576 unread", Color: "red"}},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d badges, got %+v", len(expected), got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("badge %d = %+v, want %+v", i, got[i], expected[i])
+		}
+	}
+
+	data, _ := json.Marshal(got[0].Badge)
+	if string(data) != `{"schemaVersion":1,"label":"articles","message":"3339","color":"blue"}` {
+		t.Errorf("unexpected endpoint JSON %s", data)
+	}
+}
+
+func TestColors(t *testing.T) {
+	rates := []struct {
+		rate     float64
+		expected string
+	}{
+		{0, "red"}, {24.9, "red"}, {25, "yellow"}, {50, "green"}, {75, "brightgreen"}, {100, "brightgreen"},
+	}
+	for _, tt := range rates {
+		if got := ReadRateColor(tt.rate); got != tt.expected {
+			t.Errorf("ReadRateColor(%v) = %s, want %s", tt.rate, got, tt.expected)
+		}
+	}
+
+	backlogs := []struct {
+		unread   int
+		expected string
+	}{
+		{0, "brightgreen"}, {99, "brightgreen"}, {100, "yellow"}, {500, "orange"}, {999, "orange"}, {1000, "red"},
+	}
+	for _, tt := range backlogs {
+		if got := BacklogColor(tt.unread); got != tt.expected {
+			t.Errorf("BacklogColor(%d) = %s, want %s", tt.unread, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package community
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// Stats is the anonymized aggregate shared with the community endpoint: counts only, never
+// titles, links or source names
+type Stats struct {
+	Week          string  `json:"week"` // ISO week of the snapshot, e.g. 2025-W07
+	TotalArticles int     `json:"total_articles"`
+	ReadCount     int     `json:"read_count"`
+	UnreadCount   int     `json:"unread_count"`
+	ReadRate      float64 `json:"read_rate"`
+	Sources       int     `json:"sources"`
+}
+
+// FromMetrics reduces a snapshot to the counts that may be shared
+func FromMetrics(m schema.Metrics) Stats {
+	year, week := m.LastUpdated.ISOWeek()
+	return Stats{
+		Week:          fmt.Sprintf("%04d-W%02d", year, week),
+		TotalArticles: m.TotalArticles,
+		ReadCount:     m.ReadCount,
+		UnreadCount:   m.UnreadCount,
+		ReadRate:      m.ReadRate,
+		Sources:       len(m.BySource),
+	}
+}
+
+// Client publishes stats to, and reads community medians from, one endpoint. Stats are POSTed
+// as JSON; a GET returns the current schema.CommunityComparison.
+type Client struct {
+	HTTP     *http.Client
+	Endpoint string
+}
+
+// NewClient returns a Client for endpoint with a short timeout, so an unreachable endpoint never
+// holds up a metrics run
+func NewClient(endpoint string) *Client {
+	return &Client{HTTP: &http.Client{Timeout: 15 * time.Second}, Endpoint: endpoint}
+}
+
+// Publish sends the anonymized stats to the endpoint
+func (c *Client) Publish(ctx context.Context, stats Stats) error {
+	body, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("failed to marshal community stats: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build community request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish community stats: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to publish community stats: endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Compare fetches the community medians
+func (c *Client) Compare(ctx context.Context) (schema.CommunityComparison, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Endpoint, nil)
+	if err != nil {
+		return schema.CommunityComparison{}, fmt.Errorf("failed to build community request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return schema.CommunityComparison{}, fmt.Errorf("failed to fetch community stats: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return schema.CommunityComparison{}, fmt.Errorf("failed to fetch community stats: endpoint returned %s", resp.Status)
+	}
+
+	var comparison schema.CommunityComparison
+	if err := json.NewDecoder(resp.Body).Decode(&comparison); err != nil {
+		return schema.CommunityComparison{}, fmt.Errorf("failed to decode community stats: %w", err)
+	}
+	if comparison.Participants < 1 {
+		return schema.CommunityComparison{}, fmt.Errorf("community stats have no participants yet")
+	}
+	return comparison, nil
+}
@@ -0,0 +1,79 @@
+package community
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestFromMetrics(t *testing.T) {
+	m := schema.Metrics{
+		TotalArticles: 10, ReadCount: 4, UnreadCount: 6, ReadRate: 40,
+		BySource:            map[string]int{"GitHub": 6, "Stripe": 4},
+		LastUpdated:         time.Date(2025, 2, 14, 0, 0, 0, 0, time.UTC),
+		OldestUnreadArticle: &schema.ArticleMeta{Title: "Private"},
+	}
+
+	stats := FromMetrics(m)
+	expected := Stats{Week: "2025-W07", TotalArticles: 10, ReadCount: 4, UnreadCount: 6, ReadRate: 40, Sources: 2}
+	if stats != expected {
+		t.Errorf("FromMetrics() = %+v, want %+v", stats, expected)
+	}
+
+	data, _ := json.Marshal(stats)
+	for _, private := range []string{"GitHub", "Private"} {
+		if strings.Contains(string(data), private) {
+			t.Errorf("expected %q to stay private, got %s", private, data)
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	var published Stats
+	participants := 12
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&published); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodGet:
+			fmt.Fprintf(w, `{"participants": %d, "median_read_rate": 35.5, "median_total_articles": 120}`, participants)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if err := client.Publish(context.Background(), Stats{Week: "2025-W07", ReadRate: 40}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if published.Week != "2025-W07" || published.ReadRate != 40 {
+		t.Errorf("unexpected published stats %+v", published)
+	}
+
+	comparison, err := client.Compare(context.Background())
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if comparison.Participants != 12 || comparison.MedianReadRate != 35.5 || comparison.MedianTotalArticles != 120 {
+		t.Errorf("unexpected comparison %+v", comparison)
+	}
+
+	participants = 0
+	if _, err := client.Compare(context.Background()); err == nil {
+		t.Error("expected an error without participants")
+	}
+
+	if err := NewClient("http://127.0.0.1:0").Publish(context.Background(), Stats{}); err == nil {
+		t.Error("expected an error for an unreachable endpoint")
+	}
+}
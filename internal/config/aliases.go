@@ -0,0 +1,99 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const sourceAliasesKey = "source_aliases"
+
+// SetSourceAlias records from -> to under source_aliases in the config file at path,
+// creating the file or section when missing. Existing aliases that pointed at from are
+// re-pointed at to so renames chain, and comments elsewhere in the file are preserved.
+func SetSourceAlias(path, from, to string) error {
+	if strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+		return fmt.Errorf("source names must not be empty")
+	}
+	if from == to {
+		return fmt.Errorf("source %q cannot be renamed to itself", from)
+	}
+
+	var doc yaml.Node
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+	if len(bytes.TrimSpace(content)) > 0 {
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("config %s must be a YAML mapping", path)
+	}
+
+	aliases := mappingValue(root, sourceAliasesKey)
+	if aliases == nil || aliases.Kind != yaml.MappingNode {
+		aliases = &yaml.Node{Kind: yaml.MappingNode}
+		setMappingValue(root, sourceAliasesKey, aliases)
+	}
+
+	// Re-point chained aliases and drop any alias for the new canonical name
+	for i := 0; i+1 < len(aliases.Content); {
+		key, value := aliases.Content[i], aliases.Content[i+1]
+		if key.Value == to {
+			aliases.Content = append(aliases.Content[:i], aliases.Content[i+2:]...)
+			continue
+		}
+		if value.Value == from {
+			value.Value = to
+		}
+		i += 2
+	}
+	setMappingValue(aliases, from, &yaml.Node{Kind: yaml.ScalarNode, Value: to})
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write config %s: %w", path, err)
+	}
+	return nil
+}
+
+// mappingValue returns the value node stored under key, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces the value stored under key, appending the pair when absent
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetSourceAlias(t *testing.T) {
+	tests := []struct {
+		name        string
+		initial     string
+		from, to    string
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name:     "creates section in missing file",
+			from:     "fcc",
+			to:       "freeCodeCamp",
+			expected: map[string]string{"fcc": "freeCodeCamp"},
+		},
+		{
+			name:     "chains existing aliases",
+			initial:  "source_aliases:\n  free code camp: fcc\n",
+			from:     "fcc",
+			to:       "freeCodeCamp",
+			expected: map[string]string{"free code camp": "freeCodeCamp", "fcc": "freeCodeCamp"},
+		},
+		{
+			name:     "drops alias for the new canonical name",
+			initial:  "source_aliases:\n  freeCodeCamp: fcc\n",
+			from:     "fcc",
+			to:       "freeCodeCamp",
+			expected: map[string]string{"fcc": "freeCodeCamp"},
+		},
+		{
+			name:        "rejects self rename",
+			from:        "GitHub",
+			to:          "GitHub",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yml")
+			if tt.initial != "" {
+				if err := os.WriteFile(path, []byte(tt.initial), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := SetSourceAlias(path, tt.from, tt.to)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("SetSourceAlias() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if len(cfg.SourceAliases) != len(tt.expected) {
+				t.Fatalf("expected aliases %v, got %v", tt.expected, cfg.SourceAliases)
+			}
+			for from, to := range tt.expected {
+				if cfg.SourceAliases[from] != to {
+					t.Errorf("alias %q = %q, want %q", from, cfg.SourceAliases[from], to)
+				}
+			}
+		})
+	}
+}
+
+func TestSetSourceAliasKeepsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	initial := "# Energy weights\nenergy:\n  weights:\n    reads: 0.5\n"
+	if err := os.WriteFile(path, []byte(initial), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetSourceAlias(path, "fcc", "freeCodeCamp"); err != nil {
+		t.Fatalf("SetSourceAlias() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	if !strings.Contains(string(content), "# Energy weights") {
+		t.Errorf("expected comment to be preserved, got:\n%s", content)
+	}
+	cfg, _ := Load(path)
+	if cfg.Energy.Weights.Reads != 0.5 {
+		t.Errorf("expected existing settings to survive, got %v", cfg.Energy.Weights.Reads)
+	}
+}
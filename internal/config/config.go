@@ -0,0 +1,335 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+	_ "time/tzdata" // zone data for runners and copied binaries without a zoneinfo database
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath is the config file looked up when CONFIG_PATH is not set
+const DefaultPath = "config.yml"
+
+// Directories used when paths in config.yml leaves them unset
+const (
+	DefaultMetricsDir = "metrics"
+	DefaultOutputDir  = "dist"
+)
+
+// Config holds user-tunable settings shared by the metrics and web generators
+type Config struct {
+	Energy        EnergyConfig      `yaml:"energy"`
+	Sources       []SourceConfig    `yaml:"sources"`
+	SourceAliases map[string]string `yaml:"source_aliases"` // old source label -> canonical name
+	CategoryRules []CategoryRule    `yaml:"category_rules"`
+
+	// LookupIdentifiers fetches titles and authors for DOI/ISBN links from Crossref and Open Library
+	LookupIdentifiers bool `yaml:"lookup_identifiers"`
+
+	Community CommunityConfig `yaml:"community"`
+
+	// YearStartMonth starts yearly rollups in another month (9 for September-to-August academic
+	// years) in addition to calendar years. 0 or 1 keeps calendar years only.
+	YearStartMonth int `yaml:"year_start_month"`
+
+	Planning PlanningConfig `yaml:"planning"`
+	Goals    GoalsConfig    `yaml:"goals"`
+	Digest   DigestConfig   `yaml:"digest"`
+
+	// Notifications are the chat webhooks a metrics run posts its summary to
+	Notifications []NotifierConfig `yaml:"notifications"`
+
+	InfluxDB InfluxConfig `yaml:"influxdb"`
+	Backup   BackupConfig `yaml:"backup"`
+	Git      GitConfig    `yaml:"git"`
+
+	Branding BrandingConfig `yaml:"branding"`
+	Theme    ThemeConfig    `yaml:"theme"`
+
+	// Highlights replace the badges of the analytics page's Highlights section
+	Highlights []HighlightConfig `yaml:"highlights"`
+
+	// Public strips article titles and links from snapshots and the site, keeping only aggregates,
+	// as the --public flag of both commands does
+	Public bool `yaml:"public"`
+
+	Paths PathsConfig `yaml:"paths"`
+
+	// Profiles are separate dashboards on one deployment, such as one per household member
+	Profiles []ProfileConfig `yaml:"profiles"`
+
+	// Timezone is the IANA zone, such as America/Vancouver, used for snapshot dates, month
+	// boundaries and displayed times. Empty keeps the TZ environment variable or the system zone.
+	Timezone string `yaml:"timezone"`
+}
+
+// ApplyTimezone makes the named IANA zone the process's local time zone, so every time.Now and
+// Local conversion agrees on where a day or month ends. An empty name changes nothing.
+func ApplyTimezone(name string) error {
+	if name == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	time.Local = loc
+	return nil
+}
+
+// PathsConfig moves the snapshot and site directories and the template lookup paths. Relative paths
+// are resolved against the working directory; the --metrics-dir, --output-dir, --assets-dir and
+// --templates-dir flags override them.
+type PathsConfig struct {
+	Metrics   string `yaml:"metrics"`   // snapshot directory, default metrics
+	Output    string `yaml:"output"`    // generated site directory, default dist
+	Assets    string `yaml:"assets"`    // read templates/ and content/ from here instead of the embedded copies
+	Templates string `yaml:"templates"` // theme directory overlaying the templates file by file; THEME_DIR overrides it
+}
+
+// MetricsDir returns the snapshot directory, falling back to DefaultMetricsDir
+func (p PathsConfig) MetricsDir() string {
+	if p.Metrics == "" {
+		return DefaultMetricsDir
+	}
+	return p.Metrics
+}
+
+// OutputDir returns the generated site directory, falling back to DefaultOutputDir
+func (p PathsConfig) OutputDir() string {
+	if p.Output == "" {
+		return DefaultOutputDir
+	}
+	return p.Output
+}
+
+// TemplatesDir returns the theme directory from THEME_DIR, falling back to the configured one
+func (p PathsConfig) TemplatesDir() string {
+	if dir := os.Getenv("THEME_DIR"); dir != "" {
+		return dir
+	}
+	return p.Templates
+}
+
+// BrandingConfig names the dashboard and picks the locale numbers and dates are formatted in.
+// Empty values keep the built-in title, page titles and footer, and en-US.
+type BrandingConfig struct {
+	Title      string            `yaml:"title"`
+	PageTitles map[string]string `yaml:"page_titles"` // page file, such as analytics.html, to its heading
+	Footer     string            `yaml:"footer"`
+	Locale     string            `yaml:"locale"` // BCP 47 tag, such as de-DE
+}
+
+// ThemeConfig sets the color tokens of the light and dark themes. Empty values keep the built-in
+// colors; default picks the theme shown before a visitor toggles one.
+type ThemeConfig struct {
+	Default string      `yaml:"default"` // light, dark or system (the visitor's OS setting)
+	Light   ThemeColors `yaml:"light"`
+	Dark    ThemeColors `yaml:"dark"`
+
+	// Sources sets a source's color on every chart, over the brand color of the providers sheet
+	Sources map[string]string `yaml:"sources"`
+}
+
+// ThemeColors are one theme's chart colors and extra CSS custom properties
+type ThemeColors struct {
+	Read      string            `yaml:"read"`
+	Unread    string            `yaml:"unread"`
+	Primary   string            `yaml:"primary"`
+	Secondary string            `yaml:"secondary"`
+	Accent    string            `yaml:"accent"`
+	Text      string            `yaml:"text"`
+	Muted     string            `yaml:"muted"`
+	Grid      string            `yaml:"grid"`
+	Palette   []string          `yaml:"palette"` // series and slices without a color of their own
+	CSS       map[string]string `yaml:"css"`     // custom property name, without --, to its value
+}
+
+// HighlightConfig is one highlight badge: a metric of the snapshot, reduced to one value by
+// Aggregate when it has a value per source, year or month, and shown in Format
+type HighlightConfig struct {
+	Title     string `yaml:"title"`
+	Metric    string `yaml:"metric"`    // such as source_read_rate or oldest_unread_days
+	Aggregate string `yaml:"aggregate"` // max, min, sum, avg or count; keyed metrics only
+	Format    string `yaml:"format"`    // name, int, decimal, percent, days or weeks
+}
+
+// InfluxConfig addresses the InfluxDB 2.x bucket each snapshot is written to. INFLUX_URL, INFLUX_ORG
+// and INFLUX_BUCKET override these settings; the token only comes from INFLUX_TOKEN.
+type InfluxConfig struct {
+	URL    string `yaml:"url"`
+	Org    string `yaml:"org"`
+	Bucket string `yaml:"bucket"`
+}
+
+// BackupConfig addresses the bucket `metrics backup` copies metrics/ to. BACKUP_URL, BACKUP_ENDPOINT
+// and BACKUP_REGION override these settings; S3 keys only come from AWS_ACCESS_KEY_ID and
+// AWS_SECRET_ACCESS_KEY, and GCS uses the service account at CREDENTIALS_PATH.
+type BackupConfig struct {
+	URL      string `yaml:"url"`      // s3://bucket/prefix or gs://bucket/prefix
+	Endpoint string `yaml:"endpoint"` // S3-compatible server such as MinIO or R2; empty for AWS
+	Region   string `yaml:"region"`
+}
+
+// GitConfig commits each run's outputs with `metrics commit`, or after every fetch with AutoCommit
+type GitConfig struct {
+	AutoCommit bool     `yaml:"auto_commit"`
+	Message    string   `yaml:"message"` // text/template over gitcommit.MessageData
+	Paths      []string `yaml:"paths"`   // default metrics, plan and feed
+}
+
+// DigestConfig addresses the weekly email digest. SMTP_HOST, SMTP_PORT, DIGEST_FROM and DIGEST_TO
+// override these settings; the SMTP username and password only come from the environment.
+type DigestConfig struct {
+	SMTPHost string   `yaml:"smtp_host"`
+	SMTPPort int      `yaml:"smtp_port"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+}
+
+// GoalsConfig steers the weekly reading plan: focus sources or categories are planned first, and
+// WeeklyItems caps the items planned per week (0 fills every daily block)
+type GoalsConfig struct {
+	Focus       []string `yaml:"focus"`
+	WeeklyItems int      `yaml:"weekly_items"`
+}
+
+// PlanningConfig sizes the daily reading blocks of the reading plan calendar. Zero values use the
+// forecast defaults (30 minutes a day at 07:30, 10 minutes per unread article).
+type PlanningConfig struct {
+	DailyMinutes      int    `yaml:"daily_minutes"`
+	MinutesPerArticle int    `yaml:"minutes_per_article"`
+	StartTime         string `yaml:"start_time"` // HH:MM, local time
+}
+
+// CommunityConfig opts in to sharing anonymized counts with a central endpoint and comparing
+// the read rate against the community median. It is off unless enabled with an endpoint.
+type CommunityConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	Endpoint string `yaml:"endpoint"`
+}
+
+// CategoryRule assigns a category and tags to articles whose link domain or title matches.
+// Domain and Title are regular expressions; when both are set, both must match.
+type CategoryRule struct {
+	Name     string   `yaml:"name"`
+	Domain   string   `yaml:"domain"`
+	Title    string   `yaml:"title"`
+	Category string   `yaml:"category"`
+	Tags     []string `yaml:"tags"`
+}
+
+// SourceConfig selects a registered article source and passes it backend-specific options.
+// Any key other than type and name is collected into Options.
+type SourceConfig struct {
+	Type    string            `yaml:"type"`
+	Name    string            `yaml:"name"`
+	Options map[string]string `yaml:",inline"`
+}
+
+// Option returns a source option, falling back to the given environment variable when unset
+func (s SourceConfig) Option(key, envFallback string) string {
+	if value := s.Options[key]; value != "" {
+		return value
+	}
+	if envFallback != "" {
+		return os.Getenv(envFallback)
+	}
+	return ""
+}
+
+// NotifierConfig selects a registered notification channel and passes it channel-specific options.
+// Any key other than type is collected into Options.
+type NotifierConfig struct {
+	Type    string            `yaml:"type"`
+	Options map[string]string `yaml:",inline"`
+}
+
+// Option returns a notifier option, falling back to the given environment variable when unset
+func (n NotifierConfig) Option(key, envFallback string) string {
+	if value := n.Options[key]; value != "" {
+		return value
+	}
+	if envFallback != "" {
+		return os.Getenv(envFallback)
+	}
+	return ""
+}
+
+// EnergyConfig controls how the composite weekly energy score is calculated
+type EnergyConfig struct {
+	Weights EnergyWeights `yaml:"weights"`
+	Targets EnergyTargets `yaml:"targets"`
+}
+
+// EnergyWeights sets the relative importance of each energy score component
+type EnergyWeights struct {
+	Reads     float64 `yaml:"reads"`
+	Diversity float64 `yaml:"diversity"`
+	Backlog   float64 `yaml:"backlog"`
+	Streak    float64 `yaml:"streak"`
+}
+
+// EnergyTargets sets the value at which each component reaches its full score
+type EnergyTargets struct {
+	Reads     int `yaml:"reads"`     // articles read since the previous snapshot
+	Diversity int `yaml:"diversity"` // distinct sources read since the previous snapshot
+	Backlog   int `yaml:"backlog"`   // unread articles cleared since the previous snapshot
+	Streak    int `yaml:"streak"`    // consecutive snapshots with at least one read
+}
+
+// Default returns the configuration used when no config file is present
+func Default() Config {
+	return Config{
+		Energy: EnergyConfig{
+			Weights: EnergyWeights{Reads: 0.4, Diversity: 0.2, Backlog: 0.2, Streak: 0.2},
+			Targets: EnergyTargets{Reads: 20, Diversity: 3, Backlog: 10, Streak: 4},
+		},
+	}
+}
+
+// Path returns the config file path from CONFIG_PATH, falling back to DefaultPath
+func Path() string {
+	if path := os.Getenv("CONFIG_PATH"); path != "" {
+		return path
+	}
+	return DefaultPath
+}
+
+// Load reads the YAML config at path on top of the defaults, applying the profile set with
+// SetProfile. A missing file is not an error; the defaults are returned instead.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			if activeProfile != "" {
+				return cfg, fmt.Errorf("unknown profile %q: no config %s", activeProfile, path)
+			}
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return Default(), fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if cfg.YearStartMonth < 0 || cfg.YearStartMonth > 12 {
+		return Default(), fmt.Errorf("invalid year_start_month %d in config %s (expected 1-12)", cfg.YearStartMonth, path)
+	}
+	if err := validateProfiles(cfg.Profiles); err != nil {
+		return Default(), fmt.Errorf("invalid profiles in config %s: %w", path, err)
+	}
+	if activeProfile != "" {
+		profiled, err := cfg.WithProfile(activeProfile)
+		if err != nil {
+			return Default(), fmt.Errorf("config %s: %w", path, err)
+		}
+		return profiled, nil
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		writeFile   bool
+		expectError bool
+		validate    func(t *testing.T, cfg Config)
+	}{
+		{
+			name:      "missing file returns defaults",
+			writeFile: false,
+			validate: func(t *testing.T, cfg Config) {
+				if !reflect.DeepEqual(cfg, Default()) {
+					t.Errorf("expected defaults, got %+v", cfg)
+				}
+			},
+		},
+		{
+			name:      "partial file keeps remaining defaults",
+			writeFile: true,
+			content:   "energy:\n  weights:\n    reads: 1\n",
+			validate: func(t *testing.T, cfg Config) {
+				if cfg.Energy.Weights.Reads != 1 {
+					t.Errorf("expected reads weight 1, got %v", cfg.Energy.Weights.Reads)
+				}
+				if cfg.Energy.Weights.Streak != Default().Energy.Weights.Streak {
+					t.Errorf("expected default streak weight, got %v", cfg.Energy.Weights.Streak)
+				}
+				if cfg.Energy.Targets != Default().Energy.Targets {
+					t.Errorf("expected default targets, got %+v", cfg.Energy.Targets)
+				}
+			},
+		},
+		{
+			name:      "year start month",
+			writeFile: true,
+			content:   "year_start_month: 9\n",
+			validate: func(t *testing.T, cfg Config) {
+				if cfg.YearStartMonth != 9 {
+					t.Errorf("expected year start month 9, got %d", cfg.YearStartMonth)
+				}
+			},
+		},
+		{
+			name:        "out of range year start month returns error",
+			writeFile:   true,
+			content:     "year_start_month: 13\n",
+			expectError: true,
+		},
+		{
+			name:        "invalid yaml returns error",
+			writeFile:   true,
+			content:     "energy: [unclosed",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yml")
+			if tt.writeFile {
+				if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg, err := Load(path)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Load() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.validate != nil {
+				tt.validate(t, cfg)
+			}
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	t.Setenv("CONFIG_PATH", "")
+	if got := Path(); got != DefaultPath {
+		t.Errorf("Path() = %q, want %q", got, DefaultPath)
+	}
+
+	t.Setenv("CONFIG_PATH", "/tmp/custom.yml")
+	if got := Path(); got != "/tmp/custom.yml" {
+		t.Errorf("Path() = %q, want %q", got, "/tmp/custom.yml")
+	}
+}
+
+func TestSourceConfigOption(t *testing.T) {
+	t.Setenv("TEST_SOURCE_SHEET", "from-env")
+
+	tests := []struct {
+		name     string
+		source   SourceConfig
+		key      string
+		fallback string
+		expected string
+	}{
+		{"option wins over env", SourceConfig{Options: map[string]string{"sheet_id": "abc"}}, "sheet_id", "TEST_SOURCE_SHEET", "abc"},
+		{"env used when option missing", SourceConfig{}, "sheet_id", "TEST_SOURCE_SHEET", "from-env"},
+		{"empty when neither set", SourceConfig{}, "path", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.source.Option(tt.key, tt.fallback); got != tt.expected {
+				t.Errorf("Option(%q) = %q, want %q", tt.key, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadSources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	content := "sources:\n  - type: csv\n    name: papers\n    path: ./papers.csv\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Sources) != 1 {
+		t.Fatalf("expected 1 source, got %d", len(cfg.Sources))
+	}
+	if cfg.Sources[0].Type != "csv" || cfg.Sources[0].Name != "papers" {
+		t.Errorf("unexpected source %+v", cfg.Sources[0])
+	}
+	if got := cfg.Sources[0].Option("path", ""); got != "./papers.csv" {
+		t.Errorf("expected inline path option, got %q", got)
+	}
+}
+
+func TestApplyTimezone(t *testing.T) {
+	original := time.Local
+	t.Cleanup(func() { time.Local = original })
+
+	if err := ApplyTimezone(""); err != nil || time.Local != original {
+		t.Errorf("expected an empty timezone to keep the local zone, got %v, %v", time.Local, err)
+	}
+	if err := ApplyTimezone("Mars/Olympus_Mons"); err == nil {
+		t.Error("expected an error for an unknown zone")
+	}
+	if err := ApplyTimezone("America/Vancouver"); err != nil {
+		t.Fatalf("ApplyTimezone() error = %v", err)
+	}
+	// 06:30 UTC on April 1 is still March 31 in Vancouver
+	if got := time.Date(2026, 4, 1, 6, 30, 0, 0, time.UTC).Local().Format("2006-01-02"); got != "2026-03-31" {
+		t.Errorf("expected the local date in Vancouver, got %s", got)
+	}
+}
+
+func TestPathsConfig(t *testing.T) {
+	t.Setenv("THEME_DIR", "")
+	var empty PathsConfig
+	if empty.MetricsDir() != DefaultMetricsDir || empty.OutputDir() != DefaultOutputDir || empty.TemplatesDir() != "" {
+		t.Errorf("expected the default directories, got %q, %q, %q", empty.MetricsDir(), empty.OutputDir(), empty.TemplatesDir())
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	content := "paths:\n  metrics: data/snapshots\n  output: public\n  templates: theme\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Paths.MetricsDir() != "data/snapshots" || cfg.Paths.OutputDir() != "public" || cfg.Paths.TemplatesDir() != "theme" {
+		t.Errorf("unexpected paths %+v", cfg.Paths)
+	}
+
+	t.Setenv("THEME_DIR", "env-theme")
+	if got := cfg.Paths.TemplatesDir(); got != "env-theme" {
+		t.Errorf("expected THEME_DIR to override the configured theme, got %q", got)
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProfileEnv names the profile used when --profile is not given
+const ProfileEnv = "PROFILE"
+
+// activeProfile is the profile Load applies; empty keeps the top-level settings
+var activeProfile string
+
+// ProfileConfig is one reader's dashboard on a shared deployment. Its snapshots and site go to a
+// subdirectory named after it, such as metrics/partner/ and dist/partner/.
+type ProfileConfig struct {
+	Name    string         `yaml:"name"`    // subdirectory name, letters, digits, - and _ only
+	Title   string         `yaml:"title"`   // dashboard title, default branding.title
+	Sources []SourceConfig `yaml:"sources"` // article sources, default the top-level sources
+}
+
+// SetProfile makes Load apply the named profile; empty restores the top-level settings
+func SetProfile(name string) {
+	activeProfile = name
+}
+
+// Profile returns the profile Load applies
+func Profile() string {
+	return activeProfile
+}
+
+// InitProfile removes --profile from anywhere in args, falling back to PROFILE, makes Load apply it
+// and returns the remaining arguments
+func InitProfile(args []string) ([]string, error) {
+	name := os.Getenv(ProfileEnv)
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		flagName, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if flagName != "profile" || !strings.HasPrefix(arg, "-") {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("flag needs an argument: --profile")
+			}
+			i++
+			value = args[i]
+		}
+		name = value
+	}
+	SetProfile(name)
+	return rest, nil
+}
+
+// WithProfile returns cfg with the named profile's sources and title, and its snapshot and site
+// directories moved into a subdirectory named after the profile
+func (c Config) WithProfile(name string) (Config, error) {
+	for _, profile := range c.Profiles {
+		if profile.Name != name {
+			continue
+		}
+		if len(profile.Sources) > 0 {
+			c.Sources = profile.Sources
+		}
+		if profile.Title != "" {
+			c.Branding.Title = profile.Title
+		}
+		c.Paths.Metrics = filepath.Join(c.Paths.MetricsDir(), name)
+		c.Paths.Output = filepath.Join(c.Paths.OutputDir(), name)
+		return c, nil
+	}
+	return c, fmt.Errorf("unknown profile %q (available: %v)", name, c.ProfileNames())
+}
+
+// ProfileNames lists the configured profiles in config order
+func (c Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for _, profile := range c.Profiles {
+		names = append(names, profile.Name)
+	}
+	return names
+}
+
+// validateProfiles rejects profiles without a usable, unique directory name
+func validateProfiles(profiles []ProfileConfig) error {
+	seen := make(map[string]bool, len(profiles))
+	for i, profile := range profiles {
+		if profile.Name == "" || strings.Trim(profile.Name, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_") != "" {
+			return fmt.Errorf("profile %d: name %q must be letters, digits, - and _ only", i+1, profile.Name)
+		}
+		if seen[profile.Name] {
+			return fmt.Errorf("profile %q is listed twice", profile.Name)
+		}
+		seen[profile.Name] = true
+	}
+	return nil
+}
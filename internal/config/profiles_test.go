@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const profilesYAML = `branding:
+  title: Household Reading
+sources:
+  - type: csv
+    path: shared.csv
+paths:
+  output: public
+profiles:
+  - name: victoria
+    title: Victoria's Reading
+  - name: partner
+    sources:
+      - type: csv
+        path: partner.csv
+`
+
+func TestWithProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(profilesYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer SetProfile("")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(cfg.ProfileNames(), []string{"victoria", "partner"}) || cfg.Paths.OutputDir() != "public" {
+		t.Fatalf("expected the top-level settings without a profile, got %v and %+v", cfg.ProfileNames(), cfg.Paths)
+	}
+
+	SetProfile("victoria")
+	cfg, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Branding.Title != "Victoria's Reading" || cfg.Sources[0].Options["path"] != "shared.csv" {
+		t.Errorf("expected the profile title over the shared sources, got %q and %+v", cfg.Branding.Title, cfg.Sources)
+	}
+	if cfg.Paths.MetricsDir() != filepath.Join("metrics", "victoria") || cfg.Paths.OutputDir() != filepath.Join("public", "victoria") {
+		t.Errorf("expected profile subdirectories, got %+v", cfg.Paths)
+	}
+
+	SetProfile("partner")
+	cfg, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Branding.Title != "Household Reading" || cfg.Sources[0].Options["path"] != "partner.csv" {
+		t.Errorf("expected the profile's own sources, got %q and %+v", cfg.Branding.Title, cfg.Sources)
+	}
+
+	SetProfile("guest")
+	if _, err := Load(path); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yml")); err == nil {
+		t.Error("expected an error for a profile without a config")
+	}
+}
+
+func TestLoadInvalidProfiles(t *testing.T) {
+	for _, content := range []string{
+		"profiles:\n  - title: No name\n",
+		"profiles:\n  - name: ../escape\n",
+		"profiles:\n  - name: twin\n  - name: twin\n",
+	} {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("expected an error for %q", content)
+		}
+	}
+}
+
+func TestInitProfile(t *testing.T) {
+	defer SetProfile("")
+	t.Setenv(ProfileEnv, "from-env")
+
+	rest, err := InitProfile([]string{"--fetch", "--profile", "partner", "--dry-run"})
+	if err != nil {
+		t.Fatalf("InitProfile() error = %v", err)
+	}
+	if Profile() != "partner" || !reflect.DeepEqual(rest, []string{"--fetch", "--dry-run"}) {
+		t.Errorf("expected the flag to be consumed, got %q and %v", Profile(), rest)
+	}
+
+	if _, err := InitProfile([]string{"wrapped", "-profile=victoria"}); err != nil || Profile() != "victoria" {
+		t.Errorf("expected -profile=victoria after a subcommand, got %q (%v)", Profile(), err)
+	}
+	if _, err := InitProfile(nil); err != nil || Profile() != "from-env" {
+		t.Errorf("expected PROFILE as the fallback, got %q (%v)", Profile(), err)
+	}
+	if _, err := InitProfile([]string{"--profile"}); err == nil {
+		t.Error("expected an error for --profile without a name")
+	}
+}
@@ -0,0 +1,66 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+)
+
+// Deploy targets selectable with --target
+const (
+	TargetGitHubPages = "github-pages"
+	TargetNetlify     = "netlify"
+)
+
+// Target publishes a built site directory
+type Target interface {
+	// Describe names where the site goes, for logs and dry runs
+	Describe() string
+	Deploy(ctx context.Context, dir string) error
+}
+
+// SiteFile is one file a deploy publishes
+type SiteFile struct {
+	Path string // slash-separated, relative to the site directory
+	Size int64
+}
+
+// ListFiles returns every regular file under dir, sorted by path. Hidden files and directories
+// are skipped; temporary files from an interrupted build start with a dot.
+func ListFiles(dir string) ([]SiteFile, error) {
+	var files []SiteFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && d.Name()[0] == '.' {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, SiteFile{Path: filepath.ToSlash(rel), Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s has no files to deploy; build the site first", dir)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
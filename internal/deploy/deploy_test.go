@@ -0,0 +1,167 @@
+package deploy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+func writeSite(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"index.html":                        "<html>home</html>",
+		"history/2025-01-05/analytics.html": "<html>archive</html>",
+		".index.html.tmp-1":                 "partial",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestListFiles(t *testing.T) {
+	files, err := ListFiles(writeSite(t))
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+	expected := []SiteFile{{"history/2025-01-05/analytics.html", 20}, {"index.html", 17}}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("ListFiles() = %v, expected %v", files, expected)
+	}
+
+	if _, err := ListFiles(t.TempDir()); err == nil {
+		t.Error("expected an error for an empty site")
+	}
+}
+
+func TestNetlifyDeploy(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sites/reading/deploys" || r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Content-Type") != "application/zip" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Query().Get("title") != "Deploy 2025-01-05" {
+			http.Error(w, "missing title", http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, f := range zr.File {
+			received = append(received, f.Name)
+		}
+		w.Write([]byte(`{"id":"1","state":"uploaded"}`))
+	}))
+	defer server.Close()
+
+	n := NewNetlify("reading", "token", "Deploy 2025-01-05")
+	n.BaseURL = server.URL
+	if err := n.Deploy(context.Background(), writeSite(t)); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+	if strings.Join(received, ",") != "history/2025-01-05/analytics.html,index.html" {
+		t.Errorf("uploaded %v, expected the site files only", received)
+	}
+
+	n.Token = "wrong"
+	if err := n.Deploy(context.Background(), writeSite(t)); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("expected the server error, got %v", err)
+	}
+}
+
+func TestGitHubPagesDeploy(t *testing.T) {
+	ctx := context.Background()
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	bare, err := git.PlainInit(remote, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	local, err := git.PlainInit(t.TempDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := local.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.User.Name, cfg.User.Email = "Test", "test@example.com"
+	cfg.Remotes["origin"] = &config.RemoteConfig{Name: "origin", URLs: []string{remote}}
+	if err := local.SetConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	worktree, _ := local.Worktree()
+
+	// The second deploy replaces the first rather than adding to the branch
+	g := GitHubPages{RepoDir: worktree.Filesystem.Root(), Remote: "origin", Branch: "gh-pages", CNAME: "reading.example.com"}
+	for _, message := range []string{"Deploy 2025-01-04", "Deploy 2025-01-05"} {
+		g.Message = message
+		if err := g.Deploy(ctx, writeSite(t)); err != nil {
+			t.Fatalf("Deploy() error = %v", err)
+		}
+	}
+
+	ref, err := bare.Reference(plumbing.NewBranchReferenceName("gh-pages"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err := bare.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var files []string
+	tree, _ := commit.Tree()
+	tree.Files().ForEach(func(f *object.File) error {
+		files = append(files, f.Name)
+		return nil
+	})
+	expected := ".nojekyll,CNAME,history/2025-01-05/analytics.html,index.html"
+	if strings.Join(files, ",") != expected {
+		t.Errorf("gh-pages files = %v, expected %s", files, expected)
+	}
+	if commit.NumParents() != 0 {
+		t.Errorf("expected a single commit on gh-pages, got %d parents", commit.NumParents())
+	}
+	if commit.Author.Name != "Test" || commit.Message != "Deploy 2025-01-05" {
+		t.Errorf("expected the repository identity and message, got %q %q", commit.Author.Name, commit.Message)
+	}
+
+	g.Remote = "upstream"
+	if err := g.Deploy(ctx, writeSite(t)); err == nil || !strings.Contains(err.Error(), "upstream") {
+		t.Errorf("expected an unknown remote error, got %v", err)
+	}
+}
+
+func TestGitHubPagesAuth(t *testing.T) {
+	g := GitHubPages{Token: "secret"}
+	if auth, ok := g.auth("https://github.com/owner/repo.git").(*githttp.BasicAuth); !ok || auth.Password != "secret" {
+		t.Errorf("expected token auth for an HTTPS remote, got %v", auth)
+	}
+	if auth := g.auth("git@github.com:owner/repo.git"); auth != nil {
+		t.Errorf("expected no token auth for an SSH remote, got %v", auth)
+	}
+}
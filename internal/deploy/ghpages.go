@@ -0,0 +1,142 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/gitcommit"
+)
+
+// GitHubPages force-pushes the site as a single commit to a branch GitHub Pages serves, so the
+// branch never accumulates the history of every build
+type GitHubPages struct {
+	RepoDir string // repository whose remote is pushed to, normally "."
+	Remote  string // remote name or URL
+	Branch  string
+	Message string
+	CNAME   string // custom domain written to CNAME; empty keeps any CNAME the site has
+	Token   string // GitHub token for HTTPS remotes, such as GITHUB_TOKEN; SSH remotes use the SSH agent
+}
+
+// Describe names the remote branch
+func (g GitHubPages) Describe() string {
+	return fmt.Sprintf("%s branch of %s", g.Branch, g.Remote)
+}
+
+// Deploy copies dir into a temporary repository with a .nojekyll marker, so Pages serves files
+// as built, and pushes its commit over the branch
+func (g GitHubPages) Deploy(ctx context.Context, dir string) error {
+	repo, err := git.PlainOpenWithOptions(g.RepoDir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+	remote := g.Remote
+	if !strings.Contains(remote, "/") && !strings.Contains(remote, ":") {
+		r, err := repo.Remote(remote)
+		if err != nil {
+			return fmt.Errorf("unknown remote %q: %w", remote, err)
+		}
+		remote = r.Config().URLs[0]
+	}
+	// Reuse the repository's identity, which a fresh repository has no config for
+	author, err := gitcommit.Author(repo)
+	if err != nil {
+		return err
+	}
+
+	work, err := os.MkdirTemp("", "gh-pages-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(work)
+
+	files, err := ListFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := copyFile(filepath.Join(dir, filepath.FromSlash(file.Path)), filepath.Join(work, filepath.FromSlash(file.Path))); err != nil {
+			return fmt.Errorf("failed to stage %s: %w", file.Path, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(work, ".nojekyll"), nil, 0644); err != nil {
+		return err
+	}
+	if g.CNAME != "" {
+		if err := os.WriteFile(filepath.Join(work, "CNAME"), []byte(g.CNAME+"\n"), 0644); err != nil {
+			return err
+		}
+	}
+
+	// A fresh repository whose HEAD names the branch, so its only commit is the site
+	pages, err := git.PlainInit(work, false)
+	if err != nil {
+		return fmt.Errorf("failed to create staging repository: %w", err)
+	}
+	branch := plumbing.NewBranchReferenceName(g.Branch)
+	if err := pages.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, branch)); err != nil {
+		return fmt.Errorf("failed to check out %s: %w", g.Branch, err)
+	}
+	worktree, err := pages.Worktree()
+	if err != nil {
+		return err
+	}
+	if err := worktree.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return fmt.Errorf("failed to stage the site: %w", err)
+	}
+	if _, err := worktree.Commit(g.Message, &git.CommitOptions{Author: author}); err != nil {
+		return fmt.Errorf("failed to commit the site: %w", err)
+	}
+
+	target, err := pages.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{remote}})
+	if err != nil {
+		return fmt.Errorf("invalid remote %q: %w", remote, err)
+	}
+	err = target.PushContext(ctx, &git.PushOptions{
+		RefSpecs: []config.RefSpec{config.RefSpec("+" + branch + ":" + branch)},
+		Auth:     g.auth(remote),
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed to push %s to %s: %w", g.Branch, remote, err)
+	}
+	return nil
+}
+
+// auth returns token credentials for an HTTPS remote, leaving other remotes to go-git's defaults
+func (g GitHubPages) auth(remote string) transport.AuthMethod {
+	if g.Token == "" || !strings.HasPrefix(remote, "https://") {
+		return nil
+	}
+	return &githttp.BasicAuth{Username: "x-access-token", Password: g.Token}
+}
+
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,104 @@
+package deploy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// NetlifyAPI is the Netlify REST API root
+const NetlifyAPI = "https://api.netlify.com/api/v1"
+
+// Netlify uploads the site as a zip to the Netlify deploy API, which publishes it once processed
+type Netlify struct {
+	HTTP    *http.Client
+	BaseURL string
+	SiteID  string
+	Token   string
+	Title   string
+}
+
+// NewNetlify returns a Netlify target for the site with a personal access token
+func NewNetlify(siteID, token, title string) Netlify {
+	return Netlify{HTTP: &http.Client{Timeout: 5 * time.Minute}, BaseURL: NetlifyAPI, SiteID: siteID, Token: token, Title: title}
+}
+
+// Describe names the Netlify site
+func (n Netlify) Describe() string {
+	return "Netlify site " + n.SiteID
+}
+
+// Deploy zips dir and posts it as a new production deploy
+func (n Netlify) Deploy(ctx context.Context, dir string) error {
+	if n.SiteID == "" || n.Token == "" {
+		return fmt.Errorf("netlify deploys need a site ID and NETLIFY_AUTH_TOKEN")
+	}
+	archive, err := zipSite(dir)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/sites/%s/deploys", strings.TrimRight(n.BaseURL, "/"), n.SiteID)
+	if n.Title != "" {
+		endpoint += "?title=" + url.QueryEscape(n.Title)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(archive))
+	if err != nil {
+		return fmt.Errorf("failed to build Netlify request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/zip")
+	req.Header.Set("Authorization", "Bearer "+n.Token)
+
+	resp, err := n.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deploy to Netlify: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to deploy to Netlify: server returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	var deploy struct {
+		State string `json:"state"`
+	}
+	if err := json.Unmarshal(body, &deploy); err == nil && deploy.State == "error" {
+		return fmt.Errorf("netlify rejected the deploy: %s", strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// zipSite archives every file ListFiles finds under dir
+func zipSite(dir string) ([]byte, error) {
+	files, err := ListFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file.Path)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file.Path, err)
+		}
+		w, err := zw.Create(file.Path)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to zip %s: %w", dir, err)
+	}
+	return buf.Bytes(), nil
+}
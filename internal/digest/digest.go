@@ -0,0 +1,131 @@
+package digest
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// MaxSources caps the per-source breakdown of the week's reads
+const MaxSources = 5
+
+// Digest summarizes the change between two snapshots
+type Digest struct {
+	From time.Time
+	To   time.Time
+
+	Reads        int // articles read since the previous snapshot
+	Added        int // articles saved since the previous snapshot
+	Backlog      int
+	BacklogDelta int // change in unread articles; negative when the backlog shrank
+	ReadRate     float64
+
+	// ReadsBySource holds the sources read this week, most reads first, at most MaxSources
+	ReadsBySource []SourceReads
+
+	// OldestUnread is the nudge: the longest-waiting unread article and its age in days
+	OldestUnread     *schema.ArticleMeta
+	OldestUnreadDays int
+}
+
+// SourceReads is the number of articles read from one source
+type SourceReads struct {
+	Name  string
+	Reads int
+}
+
+// Build compares the latest snapshot against the previous one
+func Build(prev, latest schema.Metrics) Digest {
+	d := Digest{
+		From:         prev.LastUpdated,
+		To:           latest.LastUpdated,
+		Reads:        latest.ReadCount - prev.ReadCount,
+		Added:        latest.TotalArticles - prev.TotalArticles,
+		Backlog:      latest.UnreadCount,
+		BacklogDelta: latest.UnreadCount - prev.UnreadCount,
+		ReadRate:     latest.ReadRate,
+	}
+
+	for name, counts := range latest.BySourceReadStatus {
+		if reads := counts[0] - prev.BySourceReadStatus[name][0]; reads > 0 {
+			d.ReadsBySource = append(d.ReadsBySource, SourceReads{Name: name, Reads: reads})
+		}
+	}
+	sort.Slice(d.ReadsBySource, func(i, j int) bool {
+		if d.ReadsBySource[i].Reads != d.ReadsBySource[j].Reads {
+			return d.ReadsBySource[i].Reads > d.ReadsBySource[j].Reads
+		}
+		return d.ReadsBySource[i].Name < d.ReadsBySource[j].Name
+	})
+	if len(d.ReadsBySource) > MaxSources {
+		d.ReadsBySource = d.ReadsBySource[:MaxSources]
+	}
+
+	if oldest := latest.OldestUnreadArticle; oldest != nil {
+		d.OldestUnread = oldest
+		if saved, err := time.Parse("2006-01-02", oldest.Date); err == nil && !latest.LastUpdated.IsZero() {
+			d.OldestUnreadDays = int(latest.LastUpdated.Sub(saved).Hours() / 24)
+		}
+	}
+	return d
+}
+
+// Subject is the email subject line
+func Subject(d Digest) string {
+	return fmt.Sprintf("📚 Reading digest: %d read, backlog %s", d.Reads, signed(d.BacklogDelta))
+}
+
+func signed(n int) string {
+	if n > 0 {
+		return fmt.Sprintf("+%d", n)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// emailTemplate uses inline styles and a single table, since most mail clients drop stylesheets
+var emailTemplate = template.Must(template.New("digest").Funcs(template.FuncMap{"signed": signed}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="UTF-8"><title>Reading digest</title></head>
+<body style="margin:0;padding:24px;background:#f8fafc;font-family:-apple-system,Segoe UI,Roboto,sans-serif;color:#0f172a;">
+<table role="presentation" width="100%" style="max-width:560px;margin:0 auto;background:#ffffff;border:1px solid #e2e8f0;border-radius:8px;border-collapse:separate;">
+<tr><td style="padding:24px;">
+<h1 style="margin:0 0 4px;font-size:20px;color:#0369a1;">📚 Your reading week</h1>
+<p style="margin:0 0 20px;font-size:13px;color:#64748b;">{{if not .From.IsZero}}{{.From.Format "Jan 2"}} – {{end}}{{.To.Format "Jan 2, 2006"}}</p>
+<table role="presentation" width="100%" style="border-collapse:collapse;text-align:center;">
+<tr>
+<td style="padding:8px;"><div style="font-size:28px;font-weight:bold;">{{.Reads}}</div><div style="font-size:12px;color:#64748b;">read</div></td>
+<td style="padding:8px;"><div style="font-size:28px;font-weight:bold;">{{.Added}}</div><div style="font-size:12px;color:#64748b;">saved</div></td>
+<td style="padding:8px;"><div style="font-size:28px;font-weight:bold;color:{{if gt .BacklogDelta 0}}#ea580c{{else}}#16a34a{{end}};">{{signed .BacklogDelta}}</div><div style="font-size:12px;color:#64748b;">backlog ({{.Backlog}} unread)</div></td>
+</tr>
+</table>
+<p style="margin:16px 0;font-size:14px;">Overall read rate: <strong>{{printf "%.1f" .ReadRate}}%</strong></p>
+{{if .ReadsBySource}}
+<h2 style="margin:20px 0 8px;font-size:15px;">This week's reads</h2>
+<table role="presentation" width="100%" style="border-collapse:collapse;font-size:14px;">
+{{range .ReadsBySource}}<tr><td style="padding:4px 0;border-bottom:1px solid #f1f5f9;">{{.Name}}</td><td style="padding:4px 0;border-bottom:1px solid #f1f5f9;text-align:right;">{{.Reads}}</td></tr>
+{{end}}</table>
+{{end}}
+{{with .OldestUnread}}
+<div style="margin-top:20px;padding:12px 16px;background:#fff7ed;border-left:4px solid #fb923c;font-size:14px;">
+<strong>Still waiting{{if $.OldestUnreadDays}} after {{$.OldestUnreadDays}} days{{end}}:</strong><br>
+{{if .Link}}<a href="{{.Link}}" style="color:#0369a1;">{{.Title}}</a>{{else}}{{.Title}}{{end}} <span style="color:#64748b;">({{.Category}})</span>
+</div>
+{{end}}
+</td></tr>
+</table>
+</body>
+</html>
+`))
+
+// HTML renders the digest as a compact HTML email body
+func HTML(d Digest) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := emailTemplate.Execute(&buf, d); err != nil {
+		return nil, fmt.Errorf("failed to render digest: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,134 @@
+package digest
+
+import (
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func testSnapshots() (schema.Metrics, schema.Metrics) {
+	prev := schema.Metrics{
+		TotalArticles:      100,
+		ReadCount:          40,
+		UnreadCount:        60,
+		LastUpdated:        time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+		BySourceReadStatus: map[string][2]int{"GitHub": {20, 30}, "Stripe": {20, 30}},
+	}
+	latest := schema.Metrics{
+		TotalArticles:       103,
+		ReadCount:           48,
+		UnreadCount:         55,
+		ReadRate:            46.6,
+		LastUpdated:         time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC),
+		BySourceReadStatus:  map[string][2]int{"GitHub": {25, 26}, "Stripe": {20, 30}, "Substack": {3, 0}},
+		OldestUnreadArticle: &schema.ArticleMeta{Title: "Old <post>", Date: "2025-03-13", Link: "https://a.com/old", Category: "GitHub"},
+	}
+	return prev, latest
+}
+
+func TestBuild(t *testing.T) {
+	prev, latest := testSnapshots()
+	d := Build(prev, latest)
+
+	if d.Reads != 8 || d.Added != 3 || d.Backlog != 55 || d.BacklogDelta != -5 {
+		t.Errorf("unexpected totals: %+v", d)
+	}
+	want := []SourceReads{{"GitHub", 5}, {"Substack", 3}}
+	if len(d.ReadsBySource) != len(want) {
+		t.Fatalf("ReadsBySource = %v, want %v", d.ReadsBySource, want)
+	}
+	for i := range want {
+		if d.ReadsBySource[i] != want[i] {
+			t.Errorf("ReadsBySource[%d] = %v, want %v", i, d.ReadsBySource[i], want[i])
+		}
+	}
+	if d.OldestUnreadDays != 365 {
+		t.Errorf("OldestUnreadDays = %d, want 365", d.OldestUnreadDays)
+	}
+}
+
+func TestHTML(t *testing.T) {
+	prev, latest := testSnapshots()
+	body, err := HTML(Build(prev, latest))
+	if err != nil {
+		t.Fatalf("HTML() error = %v", err)
+	}
+	for _, want := range []string{"Mar 6 – Mar 13, 2026", ">-5<", "55 unread", "46.6", "Substack", "after 365 days", "Old &lt;post&gt;", `href="https://a.com/old"`} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("expected digest to contain %q", want)
+		}
+	}
+
+	empty, err := HTML(Build(schema.Metrics{}, schema.Metrics{}))
+	if err != nil {
+		t.Fatalf("HTML() error = %v", err)
+	}
+	if strings.Contains(string(empty), "Still waiting") || strings.Contains(string(empty), "This week's reads") {
+		t.Errorf("expected empty sections to be left out")
+	}
+}
+
+func TestSubject(t *testing.T) {
+	tests := []struct {
+		digest Digest
+		want   string
+	}{
+		{Digest{Reads: 8, BacklogDelta: -5}, "📚 Reading digest: 8 read, backlog -5"},
+		{Digest{Reads: 0, BacklogDelta: 3}, "📚 Reading digest: 0 read, backlog +3"},
+	}
+	for _, tt := range tests {
+		if got := Subject(tt.digest); got != tt.want {
+			t.Errorf("Subject() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	originalSend := sendMailFunc
+	defer func() { sendMailFunc = originalSend }()
+
+	var gotAddr string
+	var gotAuth smtp.Auth
+	var gotMsg []byte
+	sendMailFunc = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotAuth, gotMsg = addr, a, msg
+		return nil
+	}
+
+	tests := []struct {
+		name        string
+		cfg         SMTPConfig
+		wantAddr    string
+		wantAuth    bool
+		expectError bool
+	}{
+		{name: "missing host", cfg: SMTPConfig{From: "a@b.c", To: []string{"d@e.f"}}, expectError: true},
+		{name: "missing recipients", cfg: SMTPConfig{Host: "smtp.example.com", From: "a@b.c"}, expectError: true},
+		{name: "default port without auth", cfg: SMTPConfig{Host: "smtp.example.com", From: "a@b.c", To: []string{"d@e.f"}}, wantAddr: "smtp.example.com:587"},
+		{name: "custom port with auth", cfg: SMTPConfig{Host: "smtp.example.com", Port: 2525, Username: "u", Password: "p", From: "a@b.c", To: []string{"d@e.f"}}, wantAddr: "smtp.example.com:2525", wantAuth: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAddr, gotAuth, gotMsg = "", nil, nil
+			err := Send(tt.cfg, "📚 Digest", []byte("<p>hi</p>\n"), time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC))
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Send() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+			if gotAddr != tt.wantAddr || (gotAuth != nil) != tt.wantAuth {
+				t.Errorf("sent to %s with auth %v", gotAddr, gotAuth)
+			}
+			for _, want := range []string{"Subject: =?utf-8?q?", "Content-Type: text/html", "\r\n\r\n<p>hi</p>\r\n"} {
+				if !strings.Contains(string(gotMsg), want) {
+					t.Errorf("expected message to contain %q, got:\n%s", want, gotMsg)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package digest
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSMTPPort is the submission port; net/smtp upgrades it with STARTTLS when offered
+const DefaultSMTPPort = 587
+
+// SMTPConfig is where and to whom the digest is sent
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// Validate reports the first missing setting needed to send mail
+func (c SMTPConfig) Validate() error {
+	switch {
+	case c.Host == "":
+		return fmt.Errorf("SMTP host is not set (digest.smtp_host or SMTP_HOST)")
+	case c.From == "":
+		return fmt.Errorf("sender is not set (digest.from or DIGEST_FROM)")
+	case len(c.To) == 0:
+		return fmt.Errorf("no recipients are set (digest.to or DIGEST_TO)")
+	}
+	return nil
+}
+
+// sendMailFunc is a package-level variable that can be mocked in tests
+var sendMailFunc = smtp.SendMail
+
+// Send delivers an HTML email, authenticating with PLAIN auth when a username is set
+func Send(cfg SMTPConfig, subject string, html []byte, now time.Time) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	port := cfg.Port
+	if port == 0 {
+		port = DefaultSMTPPort
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(port))
+	if err := sendMailFunc(addr, auth, cfg.From, cfg.To, Message(cfg.From, cfg.To, subject, html, now)); err != nil {
+		return fmt.Errorf("failed to send digest via %s: %w", addr, err)
+	}
+	return nil
+}
+
+// Message builds the MIME message for an HTML body
+func Message(from string, to []string, subject string, html []byte, now time.Time) []byte {
+	var b bytes.Buffer
+	header := func(name, value string) {
+		fmt.Fprintf(&b, "%s: %s\r\n", name, value)
+	}
+	header("From", from)
+	header("To", strings.Join(to, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", subject))
+	header("Date", now.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", `text/html; charset="UTF-8"`)
+	header("Content-Transfer-Encoding", "8bit")
+	b.WriteString("\r\n")
+	b.Write(bytes.ReplaceAll(bytes.ReplaceAll(html, []byte("\r\n"), []byte("\n")), []byte("\n"), []byte("\r\n")))
+	return b.Bytes()
+}
@@ -0,0 +1,175 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// Aggregate formats, written from the latest snapshot rather than article data
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// XLSXFile is the workbook written by FormatXLSX, one worksheet per table
+const XLSXFile = "reading-aggregates.xlsx"
+
+// Table is one aggregate export: a CSV file or a worksheet named Name. The first column is a label;
+// the others hold numbers.
+type Table struct {
+	Name   string
+	Header []string
+	Rows   [][]string
+}
+
+// IsAggregateFormat reports whether format exports snapshot aggregates instead of a bibliography
+func IsAggregateFormat(format string) bool {
+	return format == FormatCSV || format == FormatXLSX
+}
+
+// AggregateTables flattens a snapshot into per-source, per-month and per-year tables
+func AggregateTables(m schema.Metrics) []Table {
+	sources := Table{Name: "sources", Header: []string{"source", "articles", "read", "unread", "read_rate_pct"}}
+	for _, name := range sortedKeys(m.BySource) {
+		status := m.BySourceReadStatus[name]
+		rate := 0.0
+		if count := m.BySource[name]; count > 0 {
+			rate = float64(status[0]) / float64(count) * 100
+		}
+		sources.Rows = append(sources.Rows, []string{name, itoa(m.BySource[name]), itoa(status[0]), itoa(status[1]), strconv.FormatFloat(rate, 'f', 1, 64)})
+	}
+
+	months := Table{Name: "months", Header: []string{"month", "articles"}}
+	for _, year := range sortedKeys(m.ByYearAndMonth) {
+		for _, month := range sortedKeys(m.ByYearAndMonth[year]) {
+			months.Rows = append(months.Rows, []string{year + "-" + month, itoa(m.ByYearAndMonth[year][month])})
+		}
+	}
+
+	years := Table{Name: "years", Header: []string{"year", "articles", "read", "unread"}}
+	for _, year := range sortedKeys(m.ByYear) {
+		unread := m.UnreadByYear[year]
+		years.Rows = append(years.Rows, []string{year, itoa(m.ByYear[year]), itoa(m.ByYear[year] - unread), itoa(unread)})
+	}
+
+	return []Table{sources, months, years}
+}
+
+// CSV renders a table with its header row
+func CSV(t Table) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(t.Header); err != nil {
+		return nil, fmt.Errorf("failed to write %s header: %w", t.Name, err)
+	}
+	if err := w.WriteAll(t.Rows); err != nil {
+		return nil, fmt.Errorf("failed to write %s rows: %w", t.Name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// XLSX renders the tables as a minimal Office Open XML workbook, one worksheet per table
+func XLSX(tables []Table) ([]byte, error) {
+	var sheets, rels, overrides strings.Builder
+	files := map[string]string{}
+	for i, t := range tables {
+		id := i + 1
+		fmt.Fprintf(&sheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(t.Name), id, id)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, id, id)
+		fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, id)
+		files[fmt.Sprintf("xl/worksheets/sheet%d.xml", id)] = worksheetXML(t)
+	}
+
+	files["[Content_Types].xml"] = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		overrides.String() + `</Types>`
+	files["_rels/.rels"] = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	files["xl/workbook.xml"] = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets>` + sheets.String() + `</sheets></workbook>`
+	files["xl/_rels/workbook.xml.rels"] = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		rels.String() + `</Relationships>`
+
+	// [Content_Types].xml sorts first, where spreadsheet apps expect it
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range names {
+		f, err := zw.Create(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s to workbook: %w", name, err)
+		}
+		if _, err := f.Write([]byte(files[name])); err != nil {
+			return nil, fmt.Errorf("failed to write %s to workbook: %w", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close workbook: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// worksheetXML lays a table out from A1, header first, with inline strings for labels
+func worksheetXML(t Table) string {
+	var b strings.Builder
+	b.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	rows := append([][]string{t.Header}, t.Rows...)
+	for r, row := range rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, value := range row {
+			ref := columnName(c) + strconv.Itoa(r+1)
+			if _, err := strconv.ParseFloat(value, 64); err == nil && r > 0 && c > 0 {
+				fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, value)
+				continue
+			}
+			fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, xmlEscape(value))
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// columnName converts a zero-based column index to its spreadsheet letters (A, B, ..., AA)
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
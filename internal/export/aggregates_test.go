@@ -0,0 +1,101 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestAggregateTables(t *testing.T) {
+	m := schema.Metrics{
+		BySource:           map[string]int{"Stripe": 4, "GitHub": 3},
+		BySourceReadStatus: map[string][2]int{"Stripe": {1, 3}, "GitHub": {3, 0}},
+		ByYear:             map[string]int{"2025": 5, "2024": 2},
+		UnreadByYear:       map[string]int{"2025": 3},
+		ByYearAndMonth:     map[string]map[string]int{"2025": {"02": 1, "01": 4}, "2024": {"12": 2}},
+	}
+
+	tables := AggregateTables(m)
+	if len(tables) != 3 || tables[0].Name != "sources" || tables[1].Name != "months" || tables[2].Name != "years" {
+		t.Fatalf("expected sources, months and years tables, got %+v", tables)
+	}
+
+	tests := []struct {
+		table    Table
+		expected string
+	}{
+		{tables[0], "source,articles,read,unread,read_rate_pct\nGitHub,3,3,0,100.0\nStripe,4,1,3,25.0\n"},
+		{tables[1], "month,articles\n2024-12,2\n2025-01,4\n2025-02,1\n"},
+		{tables[2], "year,articles,read,unread\n2024,2,2,0\n2025,5,2,3\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.table.Name, func(t *testing.T) {
+			got, err := CSV(tt.table)
+			if err != nil {
+				t.Fatalf("CSV() error = %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("CSV() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestXLSX(t *testing.T) {
+	tables := []Table{
+		{Name: "sources", Header: []string{"source", "articles"}, Rows: [][]string{{"A & B", "3"}, {"2024", "1"}}},
+		{Name: "years", Header: []string{"year", "articles"}, Rows: [][]string{{"2025", "4"}}},
+	}
+
+	content, err := XLSX(tables)
+	if err != nil {
+		t.Fatalf("XLSX() error = %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatalf("expected a zip archive: %v", err)
+	}
+
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(data)
+	}
+
+	if zr.File[0].Name != "[Content_Types].xml" {
+		t.Errorf("expected [Content_Types].xml first, got %s", zr.File[0].Name)
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `<sheet name="sources" sheetId="1" r:id="rId1"/><sheet name="years" sheetId="2" r:id="rId2"/>`) {
+		t.Errorf("expected both worksheets in the workbook, got %s", parts["xl/workbook.xml"])
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A2" t="inlineStr"><is><t>A &amp; B</t></is></c>`,
+		`<c r="B2"><v>3</v></c>`,
+		`<c r="A3" t="inlineStr"><is><t>2024</t></is></c>`,
+		`<c r="B1" t="inlineStr"><is><t>articles</t></is></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("expected %s in the worksheet", want)
+		}
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for index, expected := range tests {
+		if got := columnName(index); got != expected {
+			t.Errorf("columnName(%d) = %q, want %q", index, got, expected)
+		}
+	}
+}
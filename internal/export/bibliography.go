@@ -0,0 +1,290 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// Bibliography formats
+const (
+	FormatBibTeX = "bibtex"
+	FormatCSL    = "csl"
+
+	// FormatArticles keeps every tracked field, notes and highlights included, as an ArticleMeta JSON array
+	FormatArticles = "articles"
+)
+
+// ArticlesExtension is the file extension of FormatArticles exports
+const ArticlesExtension = ".articles.json"
+
+// ReadByYear groups read articles by the year of their date, each year sorted by date then title.
+// Years start in startMonth, labelled as metrics.FiscalYear does; 0 or 1 groups by calendar year.
+func ReadByYear(articles []schema.ArticleMeta, startMonth int) map[string][]schema.ArticleMeta {
+	byYear := make(map[string][]schema.ArticleMeta)
+	for _, article := range articles {
+		if !article.Read {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", article.Date)
+		if err != nil {
+			continue
+		}
+		year := metrics.FiscalYear(date, startMonth)
+		byYear[year] = append(byYear[year], article)
+	}
+	for _, items := range byYear {
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].Date != items[j].Date {
+				return items[i].Date < items[j].Date
+			}
+			return items[i].Title < items[j].Title
+		})
+	}
+	return byYear
+}
+
+// BibliographyExtension returns the file extension for a bibliography format
+func BibliographyExtension(format string) (string, error) {
+	switch format {
+	case FormatBibTeX:
+		return ".bib", nil
+	case FormatCSL:
+		return ".json", nil
+	case FormatArticles:
+		return ArticlesExtension, nil
+	default:
+		return "", fmt.Errorf("unknown bibliography format %q (expected %s, %s or %s)", format, FormatBibTeX, FormatCSL, FormatArticles)
+	}
+}
+
+// Bibliography renders articles in the given format
+func Bibliography(articles []schema.ArticleMeta, format string) ([]byte, error) {
+	switch format {
+	case FormatBibTeX:
+		return []byte(BibTeX(articles)), nil
+	case FormatCSL:
+		return CSLJSON(articles)
+	case FormatArticles:
+		return json.MarshalIndent(articles, "", "  ")
+	default:
+		_, err := BibliographyExtension(format)
+		return nil, err
+	}
+}
+
+// BibTeX renders articles as BibTeX entries: @article for DOIs, @book for ISBNs and @misc for web pages
+func BibTeX(articles []schema.ArticleMeta) string {
+	var b strings.Builder
+	keys := make(map[string]int)
+
+	for _, article := range articles {
+		id := identity.Parse(article.Link)
+		entryType := "misc"
+		switch id.Kind {
+		case identity.KindDOI:
+			entryType = "article"
+		case identity.KindISBN:
+			entryType = "book"
+		}
+
+		key := citationKey(article)
+		key = uniqueKey(keys, key)
+
+		fields := [][2]string{{"title", "{" + bibEscape(article.Title) + "}"}}
+		if len(article.Authors) > 0 {
+			var authors []string
+			for _, author := range article.Authors {
+				authors = append(authors, bibEscape(author))
+			}
+			fields = append(fields, [2]string{"author", "{" + strings.Join(authors, " and ") + "}"})
+		}
+		if year, month := dateYearMonth(article.Date); year != "" {
+			fields = append(fields, [2]string{"year", year})
+			if month != "" {
+				fields = append(fields, [2]string{"month", month})
+			}
+		}
+		switch id.Kind {
+		case identity.KindDOI:
+			fields = append(fields, [2]string{"doi", "{" + id.Value + "}"})
+		case identity.KindISBN:
+			fields = append(fields, [2]string{"isbn", "{" + id.Value + "}"})
+		default:
+			if article.Category != "" {
+				fields = append(fields, [2]string{"howpublished", "{" + bibEscape(article.Category) + "}"})
+			}
+		}
+		if link := id.URL(); link != "" {
+			fields = append(fields, [2]string{"url", "{" + link + "}"})
+		}
+
+		fmt.Fprintf(&b, "@%s{%s,\n", entryType, key)
+		for i, field := range fields {
+			sep := ","
+			if i == len(fields)-1 {
+				sep = ""
+			}
+			fmt.Fprintf(&b, "  %s = %s%s\n", field[0], field[1], sep)
+		}
+		b.WriteString("}\n\n")
+	}
+
+	return b.String()
+}
+
+// cslItem is a CSL-JSON item as consumed by Zotero, Pandoc and citeproc
+type cslItem struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	Title          string    `json:"title"`
+	Author         []cslName `json:"author,omitempty"`
+	Issued         *cslDate  `json:"issued,omitempty"`
+	ContainerTitle string    `json:"container-title,omitempty"`
+	URL            string    `json:"URL,omitempty"`
+	DOI            string    `json:"DOI,omitempty"`
+	ISBN           string    `json:"ISBN,omitempty"`
+}
+
+type cslName struct {
+	Family  string `json:"family,omitempty"`
+	Given   string `json:"given,omitempty"`
+	Literal string `json:"literal,omitempty"`
+}
+
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// uniqueKey counts key in keys and suffixes its repeats with a, b, ... z, aa, ab, ..., letters
+// being valid in both BibTeX keys and CSL ids
+func uniqueKey(keys map[string]int, key string) string {
+	keys[key]++
+	n := keys[key] - 1
+	if n == 0 {
+		return key
+	}
+	var suffix []byte
+	for ; n > 0; n = (n - 1) / 26 {
+		suffix = append([]byte{byte('a' + (n-1)%26)}, suffix...)
+	}
+	return key + string(suffix)
+}
+
+// CSLJSON renders articles as a CSL-JSON array
+func CSLJSON(articles []schema.ArticleMeta) ([]byte, error) {
+	items := make([]cslItem, 0, len(articles))
+	keys := make(map[string]int)
+
+	for _, article := range articles {
+		id := identity.Parse(article.Link)
+		key := citationKey(article)
+		key = uniqueKey(keys, key)
+
+		item := cslItem{ID: key, Type: "webpage", Title: article.Title, URL: id.URL(), ContainerTitle: article.Category}
+		switch id.Kind {
+		case identity.KindDOI:
+			item.Type, item.DOI = "article-journal", id.Value
+		case identity.KindISBN:
+			item.Type, item.ISBN, item.ContainerTitle = "book", id.Value, ""
+		}
+		for _, author := range article.Authors {
+			item.Author = append(item.Author, cslAuthor(author))
+		}
+		if parts := dateParts(article.Date); parts != nil {
+			item.Issued = &cslDate{DateParts: [][]int{parts}}
+		}
+		items = append(items, item)
+	}
+
+	return json.MarshalIndent(items, "", "  ")
+}
+
+// cslAuthor splits "Given Family" into CSL name parts, keeping single-word names literal
+func cslAuthor(name string) cslName {
+	fields := strings.Fields(name)
+	if len(fields) < 2 {
+		return cslName{Literal: name}
+	}
+	return cslName{Given: strings.Join(fields[:len(fields)-1], " "), Family: fields[len(fields)-1]}
+}
+
+// citationKey builds a key like "vaswani2017attention" from the first author (or source), year and title
+func citationKey(article schema.ArticleMeta) string {
+	prefix := article.Category
+	if len(article.Authors) > 0 {
+		fields := strings.Fields(article.Authors[0])
+		if len(fields) > 0 {
+			prefix = fields[len(fields)-1]
+		}
+	}
+
+	year, _ := dateYearMonth(article.Date)
+	word := ""
+	for _, w := range strings.Fields(article.Title) {
+		if w = keySlug(w); len(w) > 3 {
+			word = w
+			break
+		}
+	}
+
+	key := keySlug(prefix) + year + word
+	if key == "" {
+		key = "item"
+	}
+	return key
+}
+
+// keySlug lowercases and keeps only ASCII letters and digits
+func keySlug(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// bibEscape escapes characters that BibTeX treats specially
+func bibEscape(s string) string {
+	replacer := strings.NewReplacer(`\`, `\textbackslash{}`, "{", `\{`, "}", `\}`, "&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`)
+	return replacer.Replace(s)
+}
+
+// dateYearMonth returns the year and BibTeX month macro for a YYYY-MM-DD date
+func dateYearMonth(date string) (string, string) {
+	months := []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	parts := dateParts(date)
+	if parts == nil {
+		return "", ""
+	}
+	year := fmt.Sprintf("%04d", parts[0])
+	if len(parts) > 1 && parts[1] >= 1 && parts[1] <= 12 {
+		return year, months[parts[1]-1]
+	}
+	return year, ""
+}
+
+// dateParts parses YYYY-MM-DD (or a prefix of it) into integer parts
+func dateParts(date string) []int {
+	var parts []int
+	for _, field := range strings.SplitN(date, "-", 3) {
+		var n int
+		if _, err := fmt.Sscanf(field, "%d", &n); err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	return parts
+}
@@ -0,0 +1,134 @@
+package export
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func testArticles() []schema.ArticleMeta {
+	return []schema.ArticleMeta{
+		{Date: "2017-06-12", Title: "Attention Is All You Need", Link: "doi:10.48550/arXiv.1706.03762", Category: "Papers", Read: true, Authors: []string{"Ashish Vaswani", "Noam Shazeer"}},
+		{Date: "2018-01-06", Title: "Effective Java", Link: "isbn:9780134685991", Category: "Books", Read: true, Authors: []string{"Joshua Bloch"}},
+		{Date: "2025-03-01", Title: "Go 1.24 & the new_map", Link: "https://go.dev/blog/swisstable", Category: "Go Blog", Read: true},
+		{Date: "2025-03-02", Title: "Unread post", Link: "https://a.com", Category: "A", Read: false},
+	}
+}
+
+func TestReadByYear(t *testing.T) {
+	byYear := ReadByYear(testArticles(), 0)
+	if len(byYear) != 3 {
+		t.Fatalf("expected 3 years, got %v", byYear)
+	}
+	if len(byYear["2025"]) != 1 {
+		t.Errorf("expected unread articles to be excluded, got %d for 2025", len(byYear["2025"]))
+	}
+
+	byAcademicYear := ReadByYear(testArticles(), 9)
+	if len(byAcademicYear["2016-17"]) != 1 || len(byAcademicYear["2017-18"]) != 1 || len(byAcademicYear["2024-25"]) != 1 {
+		t.Errorf("expected September-to-August years, got %v", byAcademicYear)
+	}
+}
+
+func TestBibTeX(t *testing.T) {
+	output := BibTeX(testArticles()[:3])
+
+	expected := []string{
+		"@article{vaswani2017attention,",
+		"author = {Ashish Vaswani and Noam Shazeer}",
+		"doi = {10.48550/arxiv.1706.03762}",
+		"month = jun",
+		"@book{bloch2018effective,",
+		"isbn = {9780134685991}",
+		"@misc{goblog2025newmap,",
+		`title = {Go 1.24 \& the new\_map}`,
+		"howpublished = {Go Blog}",
+		"url = {https://go.dev/blog/swisstable}",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected BibTeX to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestBibTeXDuplicateKeys(t *testing.T) {
+	article := schema.ArticleMeta{Date: "2025-01-01", Title: "Weekly notes", Category: "Blog", Read: true}
+	output := BibTeX([]schema.ArticleMeta{article, article})
+	if !strings.Contains(output, "@misc{blog2025weekly,") || !strings.Contains(output, "@misc{blog2025weeklya,") {
+		t.Errorf("expected disambiguated keys, got:\n%s", output)
+	}
+}
+
+func TestUniqueKey(t *testing.T) {
+	keys := make(map[string]int)
+	var got []string
+	for range 30 {
+		got = append(got, uniqueKey(keys, "blog2025"))
+	}
+	for i, expected := range map[int]string{0: "blog2025", 1: "blog2025a", 26: "blog2025z", 27: "blog2025aa", 29: "blog2025ac"} {
+		if got[i] != expected {
+			t.Errorf("key %d = %q, expected %q", i, got[i], expected)
+		}
+	}
+}
+
+func TestCSLJSON(t *testing.T) {
+	content, err := CSLJSON(testArticles()[:3])
+	if err != nil {
+		t.Fatalf("CSLJSON() error = %v", err)
+	}
+
+	var items []map[string]interface{}
+	if err := json.Unmarshal(content, &items); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+
+	types := []string{"article-journal", "book", "webpage"}
+	for i, want := range types {
+		if items[i]["type"] != want {
+			t.Errorf("item %d type = %v, want %s", i, items[i]["type"], want)
+		}
+	}
+	if items[0]["DOI"] != "10.48550/arxiv.1706.03762" || items[1]["ISBN"] != "9780134685991" {
+		t.Errorf("unexpected identifiers: %v / %v", items[0]["DOI"], items[1]["ISBN"])
+	}
+	authors := items[0]["author"].([]interface{})
+	if first := authors[0].(map[string]interface{}); first["family"] != "Vaswani" || first["given"] != "Ashish" {
+		t.Errorf("unexpected author %v", first)
+	}
+}
+
+func TestBibliographyArticles(t *testing.T) {
+	articles := testArticles()[:1]
+	articles[0].Notes = "Worth a reread"
+	content, err := Bibliography(articles, FormatArticles)
+	if err != nil {
+		t.Fatalf("Bibliography() error = %v", err)
+	}
+
+	var decoded []schema.ArticleMeta
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(decoded) != 1 || decoded[0].Link != articles[0].Link || decoded[0].Notes != "Worth a reread" {
+		t.Errorf("expected the article to round-trip, got %+v", decoded)
+	}
+	if ext, _ := BibliographyExtension(FormatArticles); ext != ArticlesExtension {
+		t.Errorf("unexpected extension %q", ext)
+	}
+}
+
+func TestBibliographyUnknownFormat(t *testing.T) {
+	if _, err := Bibliography(testArticles(), "ris"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if _, err := BibliographyExtension("ris"); err == nil {
+		t.Error("expected error for unknown extension")
+	}
+}
@@ -0,0 +1,292 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// DefaultEventsFile is the committed activity log the feed is built from
+const DefaultEventsFile = "feed/events.json"
+
+// File is the RSS feed published with the site
+const File = "feed.xml"
+
+// JSONFile is the JSON Feed published alongside File
+const JSONFile = "feed.json"
+
+// MaxItems caps the feed at the newest events
+const MaxItems = 50
+
+// Event kinds
+const (
+	KindAdded = "added"
+	KindRead  = "read"
+)
+
+// Event is one article being added to or read from the reading list
+type Event struct {
+	GUID     string    `json:"guid"`
+	Kind     string    `json:"kind"`
+	Title    string    `json:"title"`
+	Link     string    `json:"link,omitempty"`
+	Category string    `json:"category,omitempty"`
+	Date     time.Time `json:"date"`
+}
+
+// Channel describes the feed itself; Link is the absolute URL of the dashboard
+type Channel struct {
+	Title       string
+	Link        string
+	Description string
+}
+
+// Update appends an added event for every article not logged before and a read event for every
+// read article not logged as read, returning the sorted log and the number of new events.
+// Added events are dated by the article date. The articles carry no read date, so read events are
+// dated now, except when seeding an empty log, where they fall back to the article date rather
+// than stamping the whole history with one day.
+func Update(events []Event, articles []schema.ArticleMeta, now time.Time) ([]Event, int) {
+	seeding := len(events) == 0
+	known := make(map[string]bool, len(events))
+	for _, event := range events {
+		known[event.GUID] = true
+	}
+
+	added := 0
+	appendEvent := func(kind string, article schema.ArticleMeta, date time.Time) {
+		guid := kind + ":" + articleKey(article)
+		if known[guid] {
+			return
+		}
+		known[guid] = true
+		events = append(events, Event{
+			GUID:     guid,
+			Kind:     kind,
+			Title:    article.Title,
+			Link:     article.Link,
+			Category: article.Category,
+			Date:     date,
+		})
+		added++
+	}
+
+	for _, article := range articles {
+		articleDate, err := time.Parse("2006-01-02", article.Date)
+		if err != nil {
+			articleDate = now
+		}
+		appendEvent(KindAdded, article, articleDate)
+		if article.Read {
+			readDate := now
+			if seeding {
+				readDate = articleDate
+			}
+			appendEvent(KindRead, article, readDate)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Date.Equal(events[j].Date) {
+			return events[i].Date.Before(events[j].Date)
+		}
+		return events[i].GUID < events[j].GUID
+	})
+	return events, added
+}
+
+// articleKey identifies an article across runs by its link, or by source and title when it has none
+func articleKey(article schema.ArticleMeta) string {
+	if article.Link != "" {
+		return article.Link
+	}
+	return article.Category + "/" + article.Title
+}
+
+// LoadEvents reads the activity log at path; a missing file is an empty log
+func LoadEvents(path string) ([]Event, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var events []Event
+	if err := json.Unmarshal(content, &events); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return events, nil
+}
+
+// SaveEvents writes the activity log to path
+func SaveEvents(path string, events []Event) error {
+	content, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal feed events: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create feed directory: %w", err)
+	}
+	if err := safefile.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Self          atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title    string  `xml:"title"`
+	Link     string  `xml:"link"`
+	GUID     rssGUID `xml:"guid"`
+	PubDate  string  `xml:"pubDate"`
+	Category string  `xml:"category,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RSS renders the newest MaxItems events as an RSS 2.0 feed, newest first. Items link to the
+// article, or to the dashboard when the article has no web link.
+func RSS(events []Event, channel Channel) ([]byte, error) {
+	site := strings.TrimRight(channel.Link, "/")
+	feed := rss{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       channel.Title,
+			Link:        site + "/",
+			Description: channel.Description,
+			Self:        atomLink{Href: site + "/" + File, Rel: "self", Type: "application/rss+xml"},
+		},
+	}
+
+	for _, event := range recent(events) {
+		if feed.Channel.LastBuildDate == "" {
+			feed.Channel.LastBuildDate = event.Date.UTC().Format(time.RFC1123Z)
+		}
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:    itemTitle(event),
+			Link:     itemLink(event, site),
+			GUID:     rssGUID{Value: event.GUID},
+			PubDate:  event.Date.UTC().Format(time.RFC1123Z),
+			Category: event.Category,
+		})
+	}
+
+	content, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", File, err)
+	}
+	return append([]byte(xml.Header), append(content, '\n')...), nil
+}
+
+// JSONFeedVersion is the JSON Feed spec JSONFeed follows
+const JSONFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Description string         `json:"description,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string   `json:"id"`
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	ContentText   string   `json:"content_text"`
+	DatePublished string   `json:"date_published"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// JSONFeed renders the same items as RSS as a JSON Feed 1.1 document
+func JSONFeed(events []Event, channel Channel) ([]byte, error) {
+	site := strings.TrimRight(channel.Link, "/")
+	feed := jsonFeed{
+		Version:     JSONFeedVersion,
+		Title:       channel.Title,
+		HomePageURL: site + "/",
+		FeedURL:     site + "/" + JSONFile,
+		Description: channel.Description,
+		Items:       []jsonFeedItem{},
+	}
+
+	for _, event := range recent(events) {
+		item := jsonFeedItem{
+			ID:            event.GUID,
+			URL:           itemLink(event, site),
+			Title:         itemTitle(event),
+			ContentText:   itemTitle(event),
+			DatePublished: event.Date.UTC().Format(time.RFC3339),
+		}
+		if event.Category != "" {
+			item.Tags = []string{event.Category}
+		}
+		feed.Items = append(feed.Items, item)
+	}
+
+	content, err := json.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", JSONFile, err)
+	}
+	return content, nil
+}
+
+// recent returns the newest MaxItems events, newest first
+func recent(events []Event) []Event {
+	var items []Event
+	for i := len(events) - 1; i >= 0 && len(items) < MaxItems; i-- {
+		items = append(items, events[i])
+	}
+	return items
+}
+
+func itemTitle(event Event) string {
+	if event.Kind == KindRead {
+		return "Read: " + event.Title
+	}
+	return "Added: " + event.Title
+}
+
+// itemLink is the article link, or the dashboard at site when the article has no web link
+func itemLink(event Event, site string) string {
+	if strings.HasPrefix(event.Link, "http://") || strings.HasPrefix(event.Link, "https://") {
+		return event.Link
+	}
+	return site + "/"
+}
@@ -0,0 +1,192 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+var now = time.Date(2026, 3, 13, 9, 0, 0, 0, time.UTC)
+
+func testArticles() []schema.ArticleMeta {
+	return []schema.ArticleMeta{
+		{Title: "Swiss tables", Date: "2025-03-01", Link: "https://go.dev/blog/swisstable", Category: "Go Blog", Read: true},
+		{Title: "Unread post", Date: "2025-03-02", Link: "https://a.com/post", Category: "A"},
+		{Title: "Effective Java", Date: "2018-01-06", Link: "isbn:9780134685991", Category: "Books"},
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  []Event
+		articles  []schema.ArticleMeta
+		wantNew   int
+		wantDates map[string]time.Time
+	}{
+		{
+			name:     "seeding dates read events by article date",
+			articles: testArticles(),
+			wantNew:  4,
+			wantDates: map[string]time.Time{
+				"added:https://go.dev/blog/swisstable": time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+				"read:https://go.dev/blog/swisstable":  time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "newly read article is dated now",
+			existing: []Event{{GUID: "added:https://a.com/post", Kind: KindAdded, Date: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)}},
+			articles: []schema.ArticleMeta{{Title: "Unread post", Date: "2025-03-02", Link: "https://a.com/post", Category: "A", Read: true}},
+			wantNew:  1,
+			wantDates: map[string]time.Time{
+				"read:https://a.com/post": now,
+			},
+		},
+		{
+			name:     "articles without links are keyed by source and title",
+			articles: []schema.ArticleMeta{{Title: "Notes", Date: "2025-01-01", Category: "Blog"}},
+			wantNew:  1,
+			wantDates: map[string]time.Time{
+				"added:Blog/Notes": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, added := Update(tt.existing, tt.articles, now)
+			if added != tt.wantNew {
+				t.Errorf("Update() added %d events, want %d", added, tt.wantNew)
+			}
+			dates := make(map[string]time.Time)
+			for _, event := range events {
+				dates[event.GUID] = event.Date
+			}
+			for guid, want := range tt.wantDates {
+				if got, ok := dates[guid]; !ok || !got.Equal(want) {
+					t.Errorf("expected %s dated %v, got %v", guid, want, got)
+				}
+			}
+
+			again, added := Update(events, tt.articles, now.AddDate(0, 0, 7))
+			if added != 0 || len(again) != len(events) {
+				t.Errorf("expected a second run to add nothing, added %d", added)
+			}
+		})
+	}
+}
+
+func TestSaveAndLoadEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed", "events.json")
+	if events, err := LoadEvents(path); err != nil || events != nil {
+		t.Fatalf("expected a missing log to be empty, got %v, %v", events, err)
+	}
+
+	events, _ := Update(nil, testArticles(), now)
+	if err := SaveEvents(path, events); err != nil {
+		t.Fatalf("SaveEvents() error = %v", err)
+	}
+	loaded, err := LoadEvents(path)
+	if err != nil {
+		t.Fatalf("LoadEvents() error = %v", err)
+	}
+	if len(loaded) != len(events) || !loaded[0].Date.Equal(events[0].Date) {
+		t.Errorf("expected the log to round-trip, got %v", loaded)
+	}
+}
+
+func TestRSS(t *testing.T) {
+	events, _ := Update(nil, testArticles(), now)
+	content, err := RSS(events, Channel{Title: "Reading", Link: "https://example.com/reading/", Description: "What I read"})
+	if err != nil {
+		t.Fatalf("RSS() error = %v", err)
+	}
+
+	var parsed struct {
+		Channel struct {
+			Link  string `xml:"link"`
+			Items []struct {
+				Title   string `xml:"title"`
+				Link    string `xml:"link"`
+				GUID    string `xml:"guid"`
+				PubDate string `xml:"pubDate"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err := xml.Unmarshal(content, &parsed); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+
+	items := parsed.Channel.Items
+	if len(items) != 4 {
+		t.Fatalf("expected 4 items, got %d", len(items))
+	}
+	if items[0].Title != "Added: Unread post" || items[0].PubDate != "Sun, 02 Mar 2025 00:00:00 +0000" {
+		t.Errorf("expected the newest event first, got %+v", items[0])
+	}
+	if items[len(items)-1].Link != "https://example.com/reading/" {
+		t.Errorf("expected non-web links to point at the dashboard, got %q", items[len(items)-1].Link)
+	}
+	for _, want := range []string{`<guid isPermaLink="false">read:https://go.dev/blog/swisstable</guid>`, `<atom:link href="https://example.com/reading/feed.xml" rel="self"`} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected feed to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestRSSCapsItems(t *testing.T) {
+	var events []Event
+	for i := 0; i < MaxItems+10; i++ {
+		events = append(events, Event{GUID: "added:" + string(rune('a'+i%26)), Kind: KindAdded, Date: now.AddDate(0, 0, i)})
+	}
+	content, err := RSS(events, Channel{Link: "https://example.com"})
+	if err != nil {
+		t.Fatalf("RSS() error = %v", err)
+	}
+	if got := strings.Count(string(content), "<item>"); got != MaxItems {
+		t.Errorf("expected %d items, got %d", MaxItems, got)
+	}
+}
+
+func TestJSONFeed(t *testing.T) {
+	events, _ := Update(nil, testArticles(), now)
+	content, err := JSONFeed(events, Channel{Title: "Reading", Link: "https://example.com/reading"})
+	if err != nil {
+		t.Fatalf("JSONFeed() error = %v", err)
+	}
+
+	var parsed struct {
+		Version string `json:"version"`
+		FeedURL string `json:"feed_url"`
+		Items   []struct {
+			ID            string   `json:"id"`
+			URL           string   `json:"url"`
+			Title         string   `json:"title"`
+			DatePublished string   `json:"date_published"`
+			Tags          []string `json:"tags"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(content, &parsed); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if parsed.Version != JSONFeedVersion || parsed.FeedURL != "https://example.com/reading/feed.json" {
+		t.Errorf("unexpected feed header: %+v", parsed)
+	}
+	if len(parsed.Items) != 4 {
+		t.Fatalf("expected 4 items, got %d", len(parsed.Items))
+	}
+	first := parsed.Items[0]
+	if first.ID != "added:https://a.com/post" || first.DatePublished != "2025-03-02T00:00:00Z" || first.Tags[0] != "A" {
+		t.Errorf("expected the newest event first, got %+v", first)
+	}
+
+	empty, _ := JSONFeed(nil, Channel{Link: "https://example.com"})
+	if !strings.Contains(string(empty), `"items": []`) {
+		t.Errorf("expected an empty items array, got %s", empty)
+	}
+}
@@ -0,0 +1,112 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// Planning defaults, used for any option left at zero
+const (
+	DefaultDailyMinutes      = 30
+	DefaultMinutesPerArticle = 10
+	DefaultBlockStart        = "07:30"
+
+	// MaxPlanDays bounds the blocks written to a calendar; the clear-by date is still computed past it
+	MaxPlanDays = 365
+)
+
+// Options sizes the reading plan
+type Options struct {
+	DailyMinutes      int    // minutes set aside for reading each day
+	MinutesPerArticle int    // estimated reading time of an unread article without a duration
+	BlockStart        string // local time (HH:MM) each daily block starts
+}
+
+// withDefaults fills zero options with the package defaults
+func (o Options) withDefaults() Options {
+	if o.DailyMinutes <= 0 {
+		o.DailyMinutes = DefaultDailyMinutes
+	}
+	if o.MinutesPerArticle <= 0 {
+		o.MinutesPerArticle = DefaultMinutesPerArticle
+	}
+	if o.BlockStart == "" {
+		o.BlockStart = DefaultBlockStart
+	}
+	return o
+}
+
+// Validate reports options that cannot produce a plan
+func (o Options) Validate() error {
+	if o.DailyMinutes < 0 || o.MinutesPerArticle < 0 {
+		return fmt.Errorf("planning minutes must not be negative")
+	}
+	if o.BlockStart != "" {
+		if _, err := time.Parse("15:04", o.BlockStart); err != nil {
+			return fmt.Errorf("invalid planning start_time %q (expected HH:MM)", o.BlockStart)
+		}
+	}
+	return nil
+}
+
+// Plan spreads the estimated backlog over daily reading blocks
+type Plan struct {
+	Start             time.Time `json:"start"`    // day of the first block
+	ClearBy           time.Time `json:"clear_by"` // day of the last block
+	BacklogMinutes    int       `json:"backlog_minutes"`
+	UnreadArticles    int       `json:"unread_articles"`
+	DailyMinutes      int       `json:"daily_minutes"`
+	MinutesPerArticle int       `json:"minutes_per_article"`
+	BlockStart        string    `json:"block_start"`
+	Days              int       `json:"days"`
+}
+
+// EstimateBacklogMinutes sizes the unread backlog: minutesPerArticle for every unread article, plus
+// the recorded durations of unread videos and podcasts. Snapshots that do not split media types
+// count every unread item as an article.
+func EstimateBacklogMinutes(m schema.Metrics, minutesPerArticle int) int {
+	unreadArticles := m.UnreadCount
+	if len(m.ByMediaType) > 0 {
+		unreadArticles = m.ByMediaType[metrics.MediaArticle][1]
+	}
+
+	minutes := unreadArticles * minutesPerArticle
+	if m.Consumption != nil {
+		minutes += m.Consumption.BacklogMinutes
+	}
+	return minutes
+}
+
+// NewPlan plans daily blocks from start (a calendar day) until the snapshot's backlog is cleared.
+// An empty backlog gives a plan with no days.
+func NewPlan(m schema.Metrics, opts Options, start time.Time) Plan {
+	opts = opts.withDefaults()
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+
+	plan := Plan{
+		Start:             start,
+		BacklogMinutes:    EstimateBacklogMinutes(m, opts.MinutesPerArticle),
+		UnreadArticles:    m.UnreadCount,
+		DailyMinutes:      opts.DailyMinutes,
+		MinutesPerArticle: opts.MinutesPerArticle,
+		BlockStart:        opts.BlockStart,
+	}
+	plan.Days = int(math.Ceil(float64(plan.BacklogMinutes) / float64(plan.DailyMinutes)))
+	if plan.Days > 0 {
+		plan.ClearBy = start.AddDate(0, 0, plan.Days-1)
+	}
+	return plan
+}
+
+// BlockMinutes returns the length of the block on day i (0-based): the daily budget, or what is
+// left of the backlog on the last day
+func (p Plan) BlockMinutes(day int) int {
+	if day < 0 || day >= p.Days {
+		return 0
+	}
+	return min(p.DailyMinutes, p.BacklogMinutes-day*p.DailyMinutes)
+}
@@ -0,0 +1,79 @@
+package forecast
+
+import (
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestEstimateBacklogMinutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics schema.Metrics
+		want    int
+	}{
+		{name: "empty backlog", metrics: schema.Metrics{}, want: 0},
+		{name: "articles only", metrics: schema.Metrics{UnreadCount: 12}, want: 120},
+		{
+			name: "videos and podcasts use their durations",
+			metrics: schema.Metrics{
+				UnreadCount: 12,
+				ByMediaType: map[string][2]int{"article": {3, 10}, "video": {1, 2}},
+				Consumption: &schema.ConsumptionStats{BacklogMinutes: 45},
+			},
+			want: 145,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateBacklogMinutes(tt.metrics, 10); got != tt.want {
+				t.Errorf("EstimateBacklogMinutes() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPlan(t *testing.T) {
+	start := time.Date(2026, 1, 30, 18, 0, 0, 0, time.UTC)
+
+	plan := NewPlan(schema.Metrics{UnreadCount: 10}, Options{DailyMinutes: 30}, start)
+	if plan.BacklogMinutes != 100 || plan.Days != 4 {
+		t.Fatalf("expected 100 minutes over 4 days, got %+v", plan)
+	}
+	if got := plan.ClearBy.Format("2006-01-02"); got != "2026-02-02" {
+		t.Errorf("ClearBy = %s, want 2026-02-02", got)
+	}
+	if plan.BlockStart != DefaultBlockStart || plan.MinutesPerArticle != DefaultMinutesPerArticle {
+		t.Errorf("expected defaults for unset options, got %+v", plan)
+	}
+	if plan.BlockMinutes(0) != 30 || plan.BlockMinutes(3) != 10 || plan.BlockMinutes(4) != 0 {
+		t.Errorf("unexpected block lengths %d %d %d", plan.BlockMinutes(0), plan.BlockMinutes(3), plan.BlockMinutes(4))
+	}
+
+	if empty := NewPlan(schema.Metrics{}, Options{}, start); empty.Days != 0 || !empty.ClearBy.IsZero() {
+		t.Errorf("expected no days for an empty backlog, got %+v", empty)
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{name: "defaults", opts: Options{}},
+		{name: "custom", opts: Options{DailyMinutes: 45, MinutesPerArticle: 8, BlockStart: "21:15"}},
+		{name: "negative minutes", opts: Options{DailyMinutes: -5}, wantErr: true},
+		{name: "bad start time", opts: Options{BlockStart: "7am"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package forecast
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CalendarFile is the reading plan calendar published with the site
+const CalendarFile = "reading-plan.ics"
+
+// ICS renders the plan as an iCalendar file with one event per daily reading block, at most
+// MaxPlanDays of them. Blocks use floating local times so they land at BlockStart in any time zone,
+// and stamped is written as each event's DTSTAMP.
+func ICS(p Plan, stamped time.Time) []byte {
+	blockStart, err := time.Parse("15:04", p.BlockStart)
+	if err != nil {
+		blockStart, _ = time.Parse("15:04", DefaultBlockStart)
+	}
+
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		b.WriteString(foldLine(fmt.Sprintf(format, args...)))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//personal-reading-analytics//reading plan//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:Reading Plan")
+	for day := 0; day < min(p.Days, MaxPlanDays); day++ {
+		date := p.Start.AddDate(0, 0, day)
+		start := time.Date(date.Year(), date.Month(), date.Day(), blockStart.Hour(), blockStart.Minute(), 0, 0, time.UTC)
+		minutes := p.BlockMinutes(day)
+
+		line("BEGIN:VEVENT")
+		line("UID:reading-plan-%s@personal-reading-analytics", date.Format("20060102"))
+		line("DTSTAMP:%s", stamped.UTC().Format("20060102T150405Z"))
+		line("DTSTART:%s", start.Format("20060102T150405"))
+		line("DTEND:%s", start.Add(time.Duration(minutes)*time.Minute).Format("20060102T150405"))
+		line("SUMMARY:📚 Reading block (%d min)", minutes)
+		line("DESCRIPTION:%s", escapeText(fmt.Sprintf("Day %d of %d. Clears the backlog of about %d minutes by %s.",
+			day+1, p.Days, p.BacklogMinutes, p.ClearBy.Format("2006-01-02"))))
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// escapeText escapes iCalendar TEXT values
+func escapeText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(value)
+}
+
+// foldLine splits content lines longer than 75 octets, continuing them on lines starting with a
+// space, without breaking UTF-8 characters
+func foldLine(content string) string {
+	const limit = 75
+	var b strings.Builder
+	width := 0
+	for _, r := range content {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package forecast
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestICS(t *testing.T) {
+	plan := NewPlan(schema.Metrics{UnreadCount: 10}, Options{DailyMinutes: 30, BlockStart: "21:15"}, time.Date(2026, 1, 30, 0, 0, 0, 0, time.UTC))
+	content := string(ICS(plan, time.Date(2026, 1, 29, 12, 0, 0, 0, time.UTC)))
+
+	if strings.Count(content, "BEGIN:VEVENT") != 4 {
+		t.Fatalf("expected 4 reading blocks, got:\n%s", content)
+	}
+	expected := []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20260130T211500\r\n",
+		"DTEND:20260130T214500\r\n",
+		"DTSTART:20260202T211500\r\nDTEND:20260202T212500\r\n",
+		"UID:reading-plan-20260202@personal-reading-analytics",
+		"DTSTAMP:20260129T120000Z",
+		"SUMMARY:📚 Reading block (10 min)",
+		"END:VCALENDAR\r\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected calendar to contain %q", want)
+		}
+	}
+	for _, line := range strings.Split(content, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+
+	if empty := string(ICS(Plan{}, time.Now())); strings.Contains(empty, "VEVENT") {
+		t.Errorf("expected no events for an empty plan, got:\n%s", empty)
+	}
+}
+
+func TestICSCapsBlocks(t *testing.T) {
+	plan := NewPlan(schema.Metrics{UnreadCount: 5000}, Options{}, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if plan.Days <= MaxPlanDays {
+		t.Fatalf("expected a plan longer than %d days, got %d", MaxPlanDays, plan.Days)
+	}
+	if got := strings.Count(string(ICS(plan, time.Now())), "BEGIN:VEVENT"); got != MaxPlanDays {
+		t.Errorf("expected %d blocks, got %d", MaxPlanDays, got)
+	}
+}
+
+func TestFoldLine(t *testing.T) {
+	folded := foldLine("DESCRIPTION:" + strings.Repeat("é", 60))
+	for _, line := range strings.Split(folded, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("folded line longer than 75 octets: %q", line)
+		}
+	}
+	if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != "DESCRIPTION:"+strings.Repeat("é", 60) {
+		t.Errorf("unfolding changed the content: %q", unfolded)
+	}
+}
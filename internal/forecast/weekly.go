@@ -0,0 +1,144 @@
+package forecast
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// WeeklyPlanFile is where the weekly plan is written when no path is given
+const WeeklyPlanFile = "plan/next-week.md"
+
+// Goals steer which unread items the weekly plan picks first
+type Goals struct {
+	Focus       []string // sources or categories read before everything else
+	WeeklyItems int      // items to plan per week; 0 fills every daily block
+}
+
+// PlannedItem is an unread article scheduled on a day of the weekly plan
+type PlannedItem struct {
+	Article schema.ArticleMeta
+	Minutes int
+	Focus   bool
+}
+
+// PlanDay is one day of the weekly plan
+type PlanDay struct {
+	Date    time.Time
+	Items   []PlannedItem
+	Minutes int
+}
+
+// EstimateMinutes returns an item's reading time: the recorded duration of a video or podcast,
+// otherwise minutesPerArticle
+func EstimateMinutes(article schema.ArticleMeta, minutesPerArticle int) int {
+	if article.DurationMinutes > 0 {
+		return article.DurationMinutes
+	}
+	return minutesPerArticle
+}
+
+// isFocus reports whether the article's source is one of the goal's focus sources or categories
+func (g Goals) isFocus(article schema.ArticleMeta) bool {
+	for _, focus := range g.Focus {
+		if strings.EqualFold(strings.TrimSpace(focus), article.Category) {
+			return true
+		}
+	}
+	return false
+}
+
+// Prioritize orders unread articles for reading: focus sources first, then oldest first so old
+// backlog is cleared before new saves. Read articles are dropped.
+func Prioritize(articles []schema.ArticleMeta, goals Goals) []schema.ArticleMeta {
+	var unread []schema.ArticleMeta
+	for _, article := range articles {
+		if !article.Read {
+			unread = append(unread, article)
+		}
+	}
+	sort.SliceStable(unread, func(i, j int) bool {
+		if fi, fj := goals.isFocus(unread[i]), goals.isFocus(unread[j]); fi != fj {
+			return fi
+		}
+		return unread[i].Date < unread[j].Date
+	})
+	return unread
+}
+
+// PlanWeek fills seven daily reading blocks from weekStart with the prioritized unread articles.
+// Each day takes items in priority order while they fit its DailyMinutes; an item longer than a
+// whole block gets a day to itself. Planning stops at goals.WeeklyItems when set.
+func PlanWeek(articles []schema.ArticleMeta, opts Options, goals Goals, weekStart time.Time) []PlanDay {
+	opts = opts.withDefaults()
+	queue := Prioritize(articles, goals)
+
+	days := make([]PlanDay, 7)
+	planned := 0
+	for i := range days {
+		days[i].Date = weekStart.AddDate(0, 0, i)
+		for len(queue) > 0 && (goals.WeeklyItems <= 0 || planned < goals.WeeklyItems) {
+			minutes := EstimateMinutes(queue[0], opts.MinutesPerArticle)
+			if days[i].Minutes > 0 && days[i].Minutes+minutes > opts.DailyMinutes {
+				break
+			}
+			days[i].Items = append(days[i].Items, PlannedItem{Article: queue[0], Minutes: minutes, Focus: goals.isFocus(queue[0])})
+			days[i].Minutes += minutes
+			queue = queue[1:]
+			planned++
+			if days[i].Minutes >= opts.DailyMinutes {
+				break
+			}
+		}
+	}
+	return days
+}
+
+// NextWeekStart returns the Monday after now, the first day of next week's plan
+func NextWeekStart(now time.Time) time.Time {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, 7-(int(day.Weekday())+6)%7)
+}
+
+// WeeklyMarkdown renders the weekly plan as a Markdown checklist, one section per day
+func WeeklyMarkdown(days []PlanDay, goals Goals) []byte {
+	var b strings.Builder
+	if len(days) > 0 {
+		fmt.Fprintf(&b, "# Reading Plan: Week of %s\n\n", days[0].Date.Format("Jan 2, 2006"))
+	}
+
+	items, minutes := 0, 0
+	for _, day := range days {
+		items += len(day.Items)
+		minutes += day.Minutes
+	}
+	fmt.Fprintf(&b, "%d items, about %d minutes of reading.", items, minutes)
+	if len(goals.Focus) > 0 {
+		fmt.Fprintf(&b, " Focus: %s.", strings.Join(goals.Focus, ", "))
+	}
+	b.WriteString("\n")
+
+	for _, day := range days {
+		fmt.Fprintf(&b, "\n## %s (%d min)\n\n", day.Date.Format("Monday, Jan 2"), day.Minutes)
+		if len(day.Items) == 0 {
+			b.WriteString("Nothing planned.\n")
+			continue
+		}
+		for _, item := range day.Items {
+			title := strings.NewReplacer("[", `\[`, "]", `\]`).Replace(item.Article.Title)
+			fmt.Fprintf(&b, "- [ ] [%s](%s)", title, item.Article.Link)
+			if item.Article.Category != "" {
+				fmt.Fprintf(&b, " · %s", item.Article.Category)
+			}
+			fmt.Fprintf(&b, " · %d min", item.Minutes)
+			if item.Focus {
+				b.WriteString(" · focus")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,91 @@
+package forecast
+
+import (
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestPrioritize(t *testing.T) {
+	articles := []schema.ArticleMeta{
+		{Title: "new", Date: "2026-01-01", Category: "GitHub"},
+		{Title: "read", Date: "2020-01-01", Category: "GitHub", Read: true},
+		{Title: "old", Date: "2024-01-01", Category: "GitHub"},
+		{Title: "focus", Date: "2026-02-01", Category: "Stripe"},
+	}
+
+	got := Prioritize(articles, Goals{Focus: []string{" stripe "}})
+	want := []string{"focus", "old", "new"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d unread articles, got %v", len(want), got)
+	}
+	for i, title := range want {
+		if got[i].Title != title {
+			t.Errorf("position %d = %q, want %q", i, got[i].Title, title)
+		}
+	}
+}
+
+func TestPlanWeek(t *testing.T) {
+	start := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	articles := []schema.ArticleMeta{
+		{Title: "a", Date: "2024-01-01"},
+		{Title: "b", Date: "2024-01-02"},
+		{Title: "long video", Date: "2024-01-03", DurationMinutes: 95},
+		{Title: "c", Date: "2024-01-04"},
+		{Title: "d", Date: "2024-01-05"},
+	}
+
+	tests := []struct {
+		name  string
+		goals Goals
+		want  [][]string // titles per day
+	}{
+		{
+			name: "fills daily blocks",
+			want: [][]string{{"a", "b"}, {"long video"}, {"c", "d"}, nil, nil, nil, nil},
+		},
+		{
+			name:  "stops at the weekly goal",
+			goals: Goals{WeeklyItems: 3},
+			want:  [][]string{{"a", "b"}, {"long video"}, nil, nil, nil, nil, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days := PlanWeek(articles, Options{DailyMinutes: 25}, tt.goals, start)
+			if len(days) != 7 || !days[6].Date.Equal(start.AddDate(0, 0, 6)) {
+				t.Fatalf("expected seven days from %s, got %d", start, len(days))
+			}
+			for i, titles := range tt.want {
+				if len(days[i].Items) != len(titles) {
+					t.Fatalf("day %d has %d items, want %v", i, len(days[i].Items), titles)
+				}
+				for j, title := range titles {
+					if days[i].Items[j].Article.Title != title {
+						t.Errorf("day %d item %d = %q, want %q", i, j, days[i].Items[j].Article.Title, title)
+					}
+				}
+			}
+			if days[1].Minutes != 95 {
+				t.Errorf("expected the video's duration as its estimate, got %d minutes", days[1].Minutes)
+			}
+		})
+	}
+}
+
+func TestNextWeekStart(t *testing.T) {
+	tests := map[string]string{
+		"2026-10-16": "2026-10-19", // Friday
+		"2026-10-18": "2026-10-19", // Sunday
+		"2026-10-19": "2026-10-26", // Monday plans the week after
+	}
+	for now, want := range tests {
+		date, _ := time.Parse("2006-01-02", now)
+		if got := NextWeekStart(date).Format("2006-01-02"); got != want {
+			t.Errorf("NextWeekStart(%s) = %s, want %s", now, got, want)
+		}
+	}
+}
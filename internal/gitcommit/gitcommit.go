@@ -0,0 +1,271 @@
+package gitcommit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// DefaultMessage renders as "metrics: 2025-12-21, +14 articles"
+const DefaultMessage = "metrics: {{.Date}}, {{signed .Added}} articles"
+
+// DefaultPaths returns the run outputs committed when config.yml lists none: the snapshot
+// directory, plan and feed
+func DefaultPaths(metricsDir string) []string {
+	return []string{metricsDir, "plan", "feed"}
+}
+
+// MessageData is what a commit message template can use
+type MessageData struct {
+	Date     string // YYYY-MM-DD of the newest snapshot
+	Total    int
+	Added    int // articles since the previous snapshot
+	Read     int
+	ReadNew  int // articles read since the previous snapshot
+	Unread   int
+	ReadRate float64
+}
+
+// NewMessageData describes curr against prev, which is nil for the first snapshot
+func NewMessageData(curr schema.Metrics, prev *schema.Metrics) MessageData {
+	data := MessageData{
+		Date:     curr.LastUpdated.Format("2006-01-02"),
+		Total:    curr.TotalArticles,
+		Added:    curr.TotalArticles,
+		Read:     curr.ReadCount,
+		ReadNew:  curr.ReadCount,
+		Unread:   curr.UnreadCount,
+		ReadRate: curr.ReadRate,
+	}
+	if prev != nil {
+		data.Added -= prev.TotalArticles
+		data.ReadNew -= prev.ReadCount
+	}
+	return data
+}
+
+// RenderMessage executes a commit message template; signed formats a count with its sign
+func RenderMessage(text string, data MessageData) (string, error) {
+	if text == "" {
+		text = DefaultMessage
+	}
+	tmpl, err := template.New("message").Funcs(template.FuncMap{"signed": signed}).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid commit message template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render commit message: %w", err)
+	}
+	message := strings.TrimSpace(buf.String())
+	if message == "" {
+		return "", fmt.Errorf("commit message template rendered an empty message")
+	}
+	return message, nil
+}
+
+func signed(n int) string {
+	if n >= 0 {
+		return fmt.Sprintf("+%d", n)
+	}
+	return fmt.Sprint(n)
+}
+
+// Commit stages paths under the repository rooted at dir and commits them alone with message,
+// leaving anything else staged untouched. Missing paths are skipped. The author comes from the
+// repository's git config, as for the git command. It reports whether a commit was made.
+func Commit(ctx context.Context, dir string, paths []string, message string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	var existing []string
+	for _, path := range paths {
+		if _, err := os.Stat(filepath.Join(dir, path)); err == nil {
+			existing = append(existing, filepath.ToSlash(filepath.Clean(path)))
+		}
+	}
+	if len(existing) == 0 {
+		return false, nil
+	}
+
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return false, fmt.Errorf("failed to open repository: %w", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return false, err
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return false, fmt.Errorf("failed to read git status: %w", err)
+	}
+	changed := false
+	for file, s := range status {
+		if underAny(file, existing) && (s.Staging != git.Unmodified || s.Worktree != git.Unmodified) {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+	author, err := Author(repo)
+	if err != nil {
+		return false, err
+	}
+	if author == nil {
+		return false, fmt.Errorf("set user.name and user.email in git config to commit")
+	}
+
+	// Commit an index holding HEAD outside paths, then put the other staged changes back
+	original, err := repo.Storer.Index()
+	if err != nil {
+		return false, err
+	}
+	head, err := headEntries(repo)
+	if err != nil {
+		return false, err
+	}
+	staged := append([]*index.Entry(nil), original.Entries...)
+	original.Entries = mergeEntries(staged, head, existing)
+	if err := repo.Storer.SetIndex(original); err != nil {
+		return false, err
+	}
+	committed, err := commitPaths(worktree, existing, message, author)
+
+	idx, indexErr := repo.Storer.Index()
+	if indexErr == nil {
+		idx.Entries = mergeEntries(idx.Entries, staged, existing)
+		indexErr = repo.Storer.SetIndex(idx)
+	}
+	if err != nil {
+		return false, err
+	}
+	if indexErr != nil {
+		return false, fmt.Errorf("failed to restore staged changes: %w", indexErr)
+	}
+	return committed, nil
+}
+
+// Author returns the user.name and user.email of repo, read from its own, global and system
+// git config, or nil when either is unset
+func Author(repo *git.Repository) (*object.Signature, error) {
+	cfg, err := repo.ConfigScoped(config.SystemScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git config: %w", err)
+	}
+	if cfg.User.Name == "" || cfg.User.Email == "" {
+		return nil, nil
+	}
+	return &object.Signature{Name: cfg.User.Name, Email: cfg.User.Email, When: time.Now()}, nil
+}
+
+// commitPaths stages every change under paths, deletions included, and commits the index
+func commitPaths(worktree *git.Worktree, paths []string, message string, author *object.Signature) (bool, error) {
+	for _, path := range paths {
+		if _, err := worktree.Add(path); err != nil {
+			return false, fmt.Errorf("failed to stage %s: %w", path, err)
+		}
+	}
+	if _, err := worktree.Commit(message, &git.CommitOptions{Author: author}); err != nil {
+		if errors.Is(err, git.ErrEmptyCommit) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to commit: %w", err)
+	}
+	return true, nil
+}
+
+// headEntries lists the files of HEAD's tree as index entries, none before the first commit
+func headEntries(repo *git.Repository) ([]*index.Entry, error) {
+	ref, err := repo.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*index.Entry
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if entry.Mode != filemode.Dir {
+			entries = append(entries, &index.Entry{Name: name, Hash: entry.Hash, Mode: entry.Mode})
+		}
+	}
+}
+
+// mergeEntries takes the entries under paths from inside and every other entry from outside,
+// keeping an inside entry outside paths where it matches, so its cached file stats survive
+func mergeEntries(inside, outside []*index.Entry, paths []string) []*index.Entry {
+	kept := make(map[string]*index.Entry)
+	for _, entry := range inside {
+		if underAny(entry.Name, paths) {
+			kept[entry.Name] = entry
+		}
+	}
+	current := make(map[string]*index.Entry)
+	for _, entry := range inside {
+		current[entry.Name] = entry
+	}
+	for _, entry := range outside {
+		if underAny(entry.Name, paths) {
+			continue
+		}
+		if match := current[entry.Name]; match != nil && match.Hash == entry.Hash && match.Mode == entry.Mode {
+			entry = match
+		}
+		kept[entry.Name] = entry
+	}
+
+	entries := make([]*index.Entry, 0, len(kept))
+	for _, entry := range kept {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// underAny reports whether name is one of paths or inside one of them
+func underAny(name string, paths []string) bool {
+	for _, path := range paths {
+		if path == "." || name == path || strings.HasPrefix(name, path+"/") {
+			return true
+		}
+	}
+	return false
+}
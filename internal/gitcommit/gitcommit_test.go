@@ -0,0 +1,138 @@
+package gitcommit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestRenderMessage(t *testing.T) {
+	curr := schema.Metrics{LastUpdated: time.Date(2025, 12, 21, 8, 0, 0, 0, time.UTC), TotalArticles: 214, ReadCount: 120, UnreadCount: 94, ReadRate: 56.07}
+	prev := &schema.Metrics{TotalArticles: 200, ReadCount: 125}
+
+	tests := []struct {
+		name        string
+		text        string
+		prev        *schema.Metrics
+		expected    string
+		expectError bool
+	}{
+		{"default", "", prev, "metrics: 2025-12-21, +14 articles", false},
+		{"first snapshot", "", nil, "metrics: 2025-12-21, +214 articles", false},
+		{"custom", "chore: {{.Date}} read {{signed .ReadNew}}, {{printf \"%.1f\" .ReadRate}}%", prev, "chore: 2025-12-21 read -5, 56.1%", false},
+		{"invalid", "{{.Date", prev, "", true},
+		{"unknown field", "{{.Missing}}", prev, "", true},
+		{"empty", "{{if false}}x{{end}}", prev, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderMessage(tt.text, NewMessageData(curr, tt.prev))
+			if (err != nil) != tt.expectError {
+				t.Fatalf("RenderMessage() error = %v, expectError %v", err, tt.expectError)
+			}
+			if got != tt.expected {
+				t.Errorf("RenderMessage() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCommit(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.User.Name, cfg.User.Email = "Test", "test@example.com"
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	committedFiles := func() []string {
+		t.Helper()
+		ref, err := repo.Head()
+		if err != nil {
+			t.Fatal(err)
+		}
+		commit, err := repo.CommitObject(ref.Hash())
+		if err != nil {
+			t.Fatal(err)
+		}
+		stats, err := commit.Stats()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, stat := range stats {
+			names = append(names, stat.Name)
+		}
+		return names
+	}
+	ctx := context.Background()
+
+	write("metrics/2025-12-14.json", "{}")
+	write("notes.txt", "unrelated")
+	if _, err := worktree.Add("notes.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	committed, err := Commit(ctx, dir, DefaultPaths("metrics"), "metrics: 2025-12-14, +14 articles")
+	if err != nil || !committed {
+		t.Fatalf("Commit() = %v, %v, expected a commit", committed, err)
+	}
+	if files := committedFiles(); strings.Join(files, ",") != "metrics/2025-12-14.json" {
+		t.Errorf("expected only the snapshot committed, got %v", files)
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.File("notes.txt").Staging != git.Added {
+		t.Errorf("expected notes.txt to stay staged, got %+v", status.File("notes.txt"))
+	}
+
+	// Additions and deletions under the paths are both committed
+	write("metrics/2025-12-21.json", `{"total_articles": 214}`)
+	write("feed/events.json", "[]")
+	if err := os.Remove(filepath.Join(dir, "metrics", "2025-12-14.json")); err != nil {
+		t.Fatal(err)
+	}
+	committed, err = Commit(ctx, dir, DefaultPaths("metrics"), "metrics: 2025-12-21, +14 articles")
+	if err != nil || !committed {
+		t.Fatalf("Commit() = %v, %v, expected a commit", committed, err)
+	}
+	if files := committedFiles(); strings.Join(files, ",") != "feed/events.json,metrics/2025-12-14.json,metrics/2025-12-21.json" {
+		t.Errorf("expected the new files and the deletion committed, got %v", files)
+	}
+
+	if committed, err := Commit(ctx, dir, DefaultPaths("metrics"), "again"); err != nil || committed {
+		t.Errorf("Commit() without changes = %v, %v, expected no commit", committed, err)
+	}
+	if committed, err := Commit(ctx, dir, []string{"missing"}, "missing"); err != nil || committed {
+		t.Errorf("Commit() of a missing path = %v, %v, expected no commit", committed, err)
+	}
+}
@@ -0,0 +1,259 @@
+package googleauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// CredentialsPathEnv names the service account key or OAuth client ID to authenticate with
+const CredentialsPathEnv = "CREDENTIALS_PATH"
+
+// DefaultCredentialsPath is the credentials file used when CREDENTIALS_PATH is not set
+const DefaultCredentialsPath = "./credentials.json"
+
+// TokenPathEnv names the file user-consent tokens are cached in
+const TokenPathEnv = "TOKEN_PATH"
+
+// DefaultTokenPath is the token cache used when TOKEN_PATH is not set
+const DefaultTokenPath = "token.json"
+
+// consentTimeout bounds how long the consent flow waits for the browser redirect
+const consentTimeout = 5 * time.Minute
+
+// prompt receives the consent URL; replaced in tests
+var prompt io.Writer = os.Stderr
+
+// CredentialsPath returns the credentials file from CREDENTIALS_PATH, falling back to
+// DefaultCredentialsPath when it exists. An empty path means Application Default Credentials.
+func CredentialsPath() string {
+	if path := os.Getenv(CredentialsPathEnv); path != "" {
+		return path
+	}
+	if _, err := os.Stat(DefaultCredentialsPath); err == nil {
+		return DefaultCredentialsPath
+	}
+	return ""
+}
+
+// TokenPath returns the token cache from TOKEN_PATH, falling back to DefaultTokenPath
+func TokenPath() string {
+	if path := os.Getenv(TokenPathEnv); path != "" {
+		return path
+	}
+	return DefaultTokenPath
+}
+
+// tokenCache is the token a user consented to, with the scopes it was granted for
+type tokenCache struct {
+	Scopes []string      `json:"scopes"`
+	Token  *oauth2.Token `json:"token"`
+}
+
+// credentialsFile tells a service account key from an OAuth client ID downloaded from the Cloud console
+type credentialsFile struct {
+	Installed json.RawMessage `json:"installed"`
+	Web       json.RawMessage `json:"web"`
+}
+
+// IsOAuthClient reports whether the credentials JSON is an OAuth client ID rather than a service
+// account key
+func IsOAuthClient(data []byte) bool {
+	var file credentialsFile
+	if json.Unmarshal(data, &file) != nil {
+		return false
+	}
+	return file.Installed != nil || file.Web != nil
+}
+
+// ClientOption authenticates Google API clients with the credentials file at path. A service account
+// key is used as is. An OAuth client ID for a desktop app asks the user to consent in a browser the
+// first time, then reuses the token cached at TokenPath until it lacks one of the scopes. An empty
+// path falls back to Application Default Credentials.
+func ClientOption(ctx context.Context, path string, scopes ...string) (option.ClientOption, error) {
+	if path == "" {
+		return defaultCredentials(ctx, scopes)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read credentials %s: %w", path, err)
+	}
+	if !IsOAuthClient(data) {
+		return option.WithCredentialsFile(path), nil
+	}
+	source, err := userTokenSource(ctx, data, TokenPath(), scopes)
+	if err != nil {
+		return nil, err
+	}
+	return option.WithTokenSource(source), nil
+}
+
+// defaultCredentials looks up Application Default Credentials: the file at
+// GOOGLE_APPLICATION_CREDENTIALS, which may be a service account key or a workload identity
+// federation config, then the gcloud user credentials, then the metadata server on Google Cloud
+func defaultCredentials(ctx context.Context, scopes []string) (option.ClientOption, error) {
+	creds, err := google.FindDefaultCredentials(ctx, scopes...)
+	if err != nil {
+		return nil, fmt.Errorf("no credentials: set %s or configure Application Default Credentials: %w", CredentialsPathEnv, err)
+	}
+	return option.WithCredentials(creds), nil
+}
+
+// userTokenSource returns a token source for the cached user token, running the consent flow when
+// there is none or it was granted for fewer scopes. Consent asks for the cached scopes too, so
+// commands needing different scopes do not keep replacing each other's token.
+func userTokenSource(ctx context.Context, clientJSON []byte, tokenPath string, scopes []string) (oauth2.TokenSource, error) {
+	cache, err := readTokenCache(tokenPath)
+	if err != nil {
+		return nil, err
+	}
+	if cache.Token != nil && covers(cache.Scopes, scopes) {
+		cfg, err := google.ConfigFromJSON(clientJSON, cache.Scopes...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OAuth client: %w", err)
+		}
+		return cfg.TokenSource(ctx, cache.Token), nil
+	}
+
+	granted := union(cache.Scopes, scopes)
+	cfg, err := google.ConfigFromJSON(clientJSON, granted...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OAuth client: %w", err)
+	}
+	token, err := authorize(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeTokenCache(tokenPath, tokenCache{Scopes: granted, Token: token}); err != nil {
+		return nil, err
+	}
+	return cfg.TokenSource(ctx, token), nil
+}
+
+// authorize runs the loopback redirect flow: it prints the consent URL, waits for the browser to
+// come back to a local listener with the code, and exchanges it for a token
+func authorize(ctx context.Context, cfg *oauth2.Config) (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen for the OAuth redirect: %w", err)
+	}
+	cfg.RedirectURL = "http://" + listener.Addr().String() + "/"
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	codes := make(chan string, 1)
+	failures := make(chan error, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Get("state") != state:
+			http.Error(w, "Unexpected state, start the sign-in again.", http.StatusBadRequest)
+			return
+		case query.Get("error") != "":
+			http.Error(w, "Access was not granted.", http.StatusForbidden)
+			failures <- fmt.Errorf("consent denied: %s", query.Get("error"))
+			return
+		}
+		fmt.Fprintln(w, "Signed in, you can close this tab.")
+		codes <- query.Get("code")
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	url := cfg.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier))
+	fmt.Fprintf(prompt, "Open this URL in a browser to let the dashboard read your sheet:\n\n%s\n\n", url)
+
+	ctx, cancel := context.WithTimeout(ctx, consentTimeout)
+	defer cancel()
+	select {
+	case code := <-codes:
+		token, err := cfg.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+		if err != nil {
+			return nil, fmt.Errorf("unable to exchange the authorization code: %w", err)
+		}
+		return token, nil
+	case err := <-failures:
+		return nil, err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("no consent received: %w", ctx.Err())
+	}
+}
+
+// readTokenCache loads the cached token; a missing cache yields an empty one
+func readTokenCache(path string) (tokenCache, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokenCache{}, nil
+	}
+	if err != nil {
+		return tokenCache{}, fmt.Errorf("unable to read token cache %s: %w", path, err)
+	}
+	var cache tokenCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return tokenCache{}, fmt.Errorf("unable to parse token cache %s: %w", path, err)
+	}
+	return cache, nil
+}
+
+// writeTokenCache saves the token readable by the owner only, as it grants access to the sheet
+func writeTokenCache(path string, cache tokenCache) error {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal token cache: %w", err)
+	}
+	if err := safefile.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write token cache: %w", err)
+	}
+	return nil
+}
+
+// covers reports whether every wanted scope was granted
+func covers(granted, wanted []string) bool {
+	for _, scope := range wanted {
+		if !slices.Contains(granted, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// union returns the sorted scopes of both lists without duplicates
+func union(a, b []string) []string {
+	var scopes []string
+	for _, scope := range append(append([]string(nil), a...), b...) {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// randomState returns an unguessable state parameter tying the redirect to this flow
+func randomState() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("unable to generate OAuth state: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
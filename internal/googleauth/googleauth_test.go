@@ -0,0 +1,182 @@
+package googleauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// browser plays the user: it follows the consent URL printed to it straight back to the redirect
+type browser struct {
+	t       *testing.T
+	visits  int
+	outcome string // query added to the redirect, such as code=abc or error=access_denied
+}
+
+func (b *browser) Write(p []byte) (int, error) {
+	match := regexp.MustCompile(`https://\S+`).Find(p)
+	if match == nil {
+		return len(p), nil
+	}
+	b.visits++
+	consent, err := url.Parse(string(match))
+	if err != nil {
+		b.t.Errorf("invalid consent URL %s: %v", match, err)
+		return len(p), nil
+	}
+	query := consent.Query()
+	if query.Get("code_challenge") == "" || query.Get("access_type") != "offline" {
+		b.t.Errorf("expected a PKCE challenge and offline access, got %s", consent)
+	}
+	redirect := query.Get("redirect_uri") + "?state=" + query.Get("state") + "&" + b.outcome
+	go func() {
+		if resp, err := http.Get(redirect); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	return len(p), nil
+}
+
+func writeClient(t *testing.T, tokenURL string) string {
+	path := filepath.Join(t.TempDir(), "client.json")
+	client := fmt.Sprintf(`{"installed": {"client_id": "id", "client_secret": "secret", "auth_uri": "https://accounts.example.com/auth", "token_uri": %q, "redirect_uris": ["http://localhost"]}}`, tokenURL)
+	if err := os.WriteFile(path, []byte(client), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestUserConsent(t *testing.T) {
+	var exchanges int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("code") != "abc" || r.Form.Get("code_verifier") == "" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		exchanges++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "access", "refresh_token": "refresh", "token_type": "Bearer", "expires_in": 3600}`)
+	}))
+	defer server.Close()
+
+	clientPath := writeClient(t, server.URL)
+	t.Setenv(TokenPathEnv, filepath.Join(t.TempDir(), "token.json"))
+	b := &browser{t: t, outcome: "code=abc"}
+	original := prompt
+	prompt = b
+	defer func() { prompt = original }()
+
+	ctx := context.Background()
+	if _, err := ClientOption(ctx, clientPath, "scope/read"); err != nil {
+		t.Fatalf("ClientOption() error = %v", err)
+	}
+	if b.visits != 1 || exchanges != 1 {
+		t.Fatalf("expected one consent, got %d visits and %d exchanges", b.visits, exchanges)
+	}
+	info, err := os.Stat(TokenPath())
+	if err != nil {
+		t.Fatalf("expected a cached token: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected the token cache to be private, got %v", info.Mode().Perm())
+	}
+
+	// The cached token is reused for the same scopes
+	if _, err := ClientOption(ctx, clientPath, "scope/read"); err != nil {
+		t.Fatalf("ClientOption() error = %v", err)
+	}
+	if b.visits != 1 {
+		t.Errorf("expected the cached token to be reused, got %d consents", b.visits)
+	}
+
+	// A new scope asks again, for both scopes
+	if _, err := ClientOption(ctx, clientPath, "scope/write"); err != nil {
+		t.Fatalf("ClientOption() error = %v", err)
+	}
+	cache, err := readTokenCache(TokenPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.visits != 2 || len(cache.Scopes) != 2 || cache.Token.RefreshToken != "refresh" {
+		t.Errorf("expected a second consent covering both scopes, got %d visits and %+v", b.visits, cache)
+	}
+}
+
+func TestUserConsentDenied(t *testing.T) {
+	clientPath := writeClient(t, "http://127.0.0.1:0/token")
+	t.Setenv(TokenPathEnv, filepath.Join(t.TempDir(), "token.json"))
+	original := prompt
+	prompt = &browser{t: t, outcome: "error=access_denied"}
+	defer func() { prompt = original }()
+
+	if _, err := ClientOption(context.Background(), clientPath, "scope/read"); err == nil {
+		t.Fatal("expected an error when consent is denied")
+	}
+	if _, err := os.Stat(TokenPath()); !os.IsNotExist(err) {
+		t.Errorf("expected no token to be cached, got %v", err)
+	}
+}
+
+func TestServiceAccount(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(path, []byte(`{"type": "service_account", "client_email": "bot@example.iam.gserviceaccount.com"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	original := prompt
+	b := &browser{t: t}
+	prompt = b
+	defer func() { prompt = original }()
+
+	if _, err := ClientOption(context.Background(), path, "scope/read"); err != nil {
+		t.Fatalf("ClientOption() error = %v", err)
+	}
+	if b.visits != 0 {
+		t.Error("expected a service account key to need no consent")
+	}
+	if _, err := ClientOption(context.Background(), filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing credentials file")
+	}
+}
+
+func TestCredentialsPath(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(CredentialsPathEnv, "")
+	if path := CredentialsPath(); path != "" {
+		t.Errorf("expected Application Default Credentials without a credentials file, got %q", path)
+	}
+	if err := os.WriteFile(DefaultCredentialsPath, []byte(`{}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if path := CredentialsPath(); path != DefaultCredentialsPath {
+		t.Errorf("expected %s, got %q", DefaultCredentialsPath, path)
+	}
+	t.Setenv(CredentialsPathEnv, "/secrets/key.json")
+	if path := CredentialsPath(); path != "/secrets/key.json" {
+		t.Errorf("expected CREDENTIALS_PATH to win, got %q", path)
+	}
+}
+
+func TestApplicationDefaultCredentials(t *testing.T) {
+	dir := t.TempDir()
+	federation := filepath.Join(dir, "federation.json")
+	config := `{"type": "external_account", "audience": "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/github/providers/actions", "subject_token_type": "urn:ietf:params:oauth:token-type:jwt", "token_url": "https://sts.googleapis.com/v1/token", "credential_source": {"file": "token.txt"}}`
+	if err := os.WriteFile(federation, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", federation)
+	if _, err := ClientOption(context.Background(), "", "scope/read"); err != nil {
+		t.Fatalf("ClientOption() error = %v", err)
+	}
+
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", filepath.Join(dir, "missing.json"))
+	if _, err := ClientOption(context.Background(), "", "scope/read"); err == nil {
+		t.Error("expected an error when the default credentials are missing")
+	}
+}
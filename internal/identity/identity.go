@@ -0,0 +1,126 @@
+package identity
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Kind is the type of identifier stored in an article's link column
+type Kind string
+
+const (
+	KindURL  Kind = "url"
+	KindDOI  Kind = "doi"
+	KindISBN Kind = "isbn"
+)
+
+// doiPattern matches a bare DOI such as 10.1145/3297858.3304013
+var doiPattern = regexp.MustCompile(`^10\.\d{4,9}/\S+$`)
+
+// Identifier is a parsed article identity: a web URL, a DOI or an ISBN
+type Identifier struct {
+	Kind  Kind
+	Value string // URL as given, lowercase DOI, or ISBN digits without separators
+}
+
+// Parse recognizes doi:/isbn: prefixes, doi.org links, bare DOIs and checksummed ISBN-10/13 values.
+// Anything else is treated as a URL.
+func Parse(raw string) Identifier {
+	value := strings.TrimSpace(raw)
+	lower := strings.ToLower(value)
+
+	switch {
+	case strings.HasPrefix(lower, "doi:"):
+		return Identifier{Kind: KindDOI, Value: strings.TrimSpace(lower[len("doi:"):])}
+	case strings.HasPrefix(lower, "isbn:"):
+		if isbn, ok := normalizeISBN(value[len("isbn:"):]); ok {
+			return Identifier{Kind: KindISBN, Value: isbn}
+		}
+	case doiPattern.MatchString(lower):
+		return Identifier{Kind: KindDOI, Value: lower}
+	}
+
+	if parsed, err := url.Parse(value); err == nil {
+		host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+		if host == "doi.org" || host == "dx.doi.org" {
+			if doi, err := url.PathUnescape(strings.TrimPrefix(parsed.Path, "/")); err == nil && doiPattern.MatchString(strings.ToLower(doi)) {
+				return Identifier{Kind: KindDOI, Value: strings.ToLower(doi)}
+			}
+		}
+	}
+
+	if isbn, ok := normalizeISBN(value); ok {
+		return Identifier{Kind: KindISBN, Value: isbn}
+	}
+
+	return Identifier{Kind: KindURL, Value: value}
+}
+
+// URL returns a clickable link for the identifier
+func (id Identifier) URL() string {
+	switch id.Kind {
+	case KindDOI:
+		return "https://doi.org/" + id.Value
+	case KindISBN:
+		return "https://openlibrary.org/isbn/" + id.Value
+	default:
+		return id.Value
+	}
+}
+
+// String returns the identifier in its prefixed form (doi:..., isbn:...) or the URL
+func (id Identifier) String() string {
+	if id.Kind == KindURL {
+		return id.Value
+	}
+	return string(id.Kind) + ":" + id.Value
+}
+
+// normalizeISBN strips separators and validates the ISBN-10 or ISBN-13 checksum
+func normalizeISBN(raw string) (string, bool) {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(raw) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == 'x' || r == 'X':
+			b.WriteRune('X')
+		case r == '-' || r == ' ':
+		default:
+			return "", false
+		}
+	}
+	isbn := b.String()
+
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i, r := range isbn {
+			digit := int(r - '0')
+			if r == 'X' {
+				if i != 9 {
+					return "", false
+				}
+				digit = 10
+			}
+			sum += digit * (10 - i)
+		}
+		return isbn, sum%11 == 0
+	case 13:
+		if strings.Contains(isbn, "X") {
+			return "", false
+		}
+		sum := 0
+		for i, r := range isbn {
+			digit := int(r - '0')
+			if i%2 == 1 {
+				digit *= 3
+			}
+			sum += digit
+		}
+		return isbn, sum%10 == 0
+	default:
+		return "", false
+	}
+}
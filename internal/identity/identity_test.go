@@ -0,0 +1,43 @@
+package identity
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name         string
+		raw          string
+		expectedKind Kind
+		expectedVal  string
+		expectedURL  string
+	}{
+		{"prefixed doi", "doi:10.1145/3297858.3304013", KindDOI, "10.1145/3297858.3304013", "https://doi.org/10.1145/3297858.3304013"},
+		{"bare doi is lowercased", "10.1000/ABC.123", KindDOI, "10.1000/abc.123", "https://doi.org/10.1000/abc.123"},
+		{"doi.org link", "https://doi.org/10.48550/arXiv.1706.03762", KindDOI, "10.48550/arxiv.1706.03762", "https://doi.org/10.48550/arxiv.1706.03762"},
+		{"prefixed isbn-13 with hyphens", "isbn:978-0-13-468599-1", KindISBN, "9780134685991", "https://openlibrary.org/isbn/9780134685991"},
+		{"bare isbn-10 with X", "0-8044-2957-X", KindISBN, "080442957X", "https://openlibrary.org/isbn/080442957X"},
+		{"bad checksum stays a url", "9780134685992", KindURL, "9780134685992", "9780134685992"},
+		{"web url", "https://github.blog/post", KindURL, "https://github.blog/post", "https://github.blog/post"},
+		{"empty", "", KindURL, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := Parse(tt.raw)
+			if id.Kind != tt.expectedKind || id.Value != tt.expectedVal {
+				t.Errorf("Parse(%q) = %+v, want %s %q", tt.raw, id, tt.expectedKind, tt.expectedVal)
+			}
+			if got := id.URL(); got != tt.expectedURL {
+				t.Errorf("URL() = %q, want %q", got, tt.expectedURL)
+			}
+		})
+	}
+}
+
+func TestIdentifierString(t *testing.T) {
+	if got := Parse("10.1000/xyz").String(); got != "doi:10.1000/xyz" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := Parse("https://a.com").String(); got != "https://a.com" {
+		t.Errorf("String() = %q", got)
+	}
+}
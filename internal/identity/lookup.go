@@ -0,0 +1,207 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// CrossrefBaseURL is the Crossref REST API used for DOI metadata
+	CrossrefBaseURL = "https://api.crossref.org"
+	// OpenLibraryBaseURL is the Open Library API used for ISBN metadata
+	OpenLibraryBaseURL = "https://openlibrary.org"
+)
+
+// Metadata is the bibliographic information returned for a DOI or ISBN
+type Metadata struct {
+	Title     string
+	Authors   []string
+	Publisher string
+	Published string // YYYY-MM-DD, YYYY-MM or YYYY depending on what the registry knows
+	Canonical string // canonical page URL, only set for web pages
+
+	// Duration is the running time of a video, only set for YouTube links looked up with an API key
+	Duration time.Duration
+}
+
+// Resolver looks up DOI metadata via Crossref, ISBN metadata via Open Library and YouTube videos via
+// oEmbed (or the Data API when YouTubeAPIKey is set), caching results per run
+type Resolver struct {
+	Client           *http.Client
+	CrossrefURL      string
+	OpenLibraryURL   string
+	YouTubeOEmbedURL string
+	YouTubeAPIURL    string
+	YouTubeAPIKey    string
+
+	mu    sync.Mutex
+	cache map[string]Metadata
+}
+
+// NewResolver returns a Resolver pointed at the public Crossref, Open Library and YouTube APIs,
+// reading the optional YouTube Data API key from YOUTUBE_API_KEY
+func NewResolver() *Resolver {
+	return &Resolver{
+		Client:           &http.Client{Timeout: 15 * time.Second},
+		CrossrefURL:      CrossrefBaseURL,
+		OpenLibraryURL:   OpenLibraryBaseURL,
+		YouTubeOEmbedURL: YouTubeOEmbedBaseURL,
+		YouTubeAPIURL:    YouTubeAPIBaseURL,
+		YouTubeAPIKey:    os.Getenv("YOUTUBE_API_KEY"),
+	}
+}
+
+// Lookup returns metadata for a DOI or ISBN identifier, or the page metadata of a plain URL
+func (r *Resolver) Lookup(ctx context.Context, id Identifier) (Metadata, error) {
+	key := id.String()
+	r.mu.Lock()
+	if meta, exists := r.cache[key]; exists {
+		r.mu.Unlock()
+		return meta, nil
+	}
+	r.mu.Unlock()
+
+	var meta Metadata
+	var err error
+	switch id.Kind {
+	case KindDOI:
+		meta, err = r.lookupDOI(ctx, id.Value)
+	case KindISBN:
+		meta, err = r.lookupISBN(ctx, id.Value)
+	case KindURL:
+		if videoID := YouTubeVideoID(id.Value); videoID != "" {
+			meta, err = r.lookupYouTube(ctx, videoID)
+		} else {
+			meta, err = r.lookupPage(ctx, id.Value)
+		}
+	default:
+		return Metadata{}, fmt.Errorf("no metadata lookup for %s identifiers", id.Kind)
+	}
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	r.mu.Lock()
+	if r.cache == nil {
+		r.cache = make(map[string]Metadata)
+	}
+	r.cache[key] = meta
+	r.mu.Unlock()
+	return meta, nil
+}
+
+// lookupDOI reads title, authors and issue date from Crossref's works endpoint
+func (r *Resolver) lookupDOI(ctx context.Context, doi string) (Metadata, error) {
+	var body struct {
+		Message struct {
+			Title     []string `json:"title"`
+			Publisher string   `json:"publisher"`
+			Author    []struct {
+				Given  string `json:"given"`
+				Family string `json:"family"`
+				Name   string `json:"name"`
+			} `json:"author"`
+			Issued struct {
+				DateParts [][]int `json:"date-parts"`
+			} `json:"issued"`
+		} `json:"message"`
+	}
+
+	endpoint := strings.TrimSuffix(r.CrossrefURL, "/") + "/works/" + url.PathEscape(doi)
+	if err := r.getJSON(ctx, endpoint, &body); err != nil {
+		return Metadata{}, fmt.Errorf("crossref lookup for %s failed: %w", doi, err)
+	}
+
+	meta := Metadata{Publisher: body.Message.Publisher}
+	if len(body.Message.Title) > 0 {
+		meta.Title = strings.TrimSpace(body.Message.Title[0])
+	}
+	for _, author := range body.Message.Author {
+		name := strings.TrimSpace(author.Given + " " + author.Family)
+		if name == "" {
+			name = author.Name
+		}
+		if name != "" {
+			meta.Authors = append(meta.Authors, name)
+		}
+	}
+	if len(body.Message.Issued.DateParts) > 0 {
+		meta.Published = formatDateParts(body.Message.Issued.DateParts[0])
+	}
+	return meta, nil
+}
+
+// lookupISBN reads title, authors and publish date from Open Library's books API
+func (r *Resolver) lookupISBN(ctx context.Context, isbn string) (Metadata, error) {
+	var body map[string]struct {
+		Title   string `json:"title"`
+		Authors []struct {
+			Name string `json:"name"`
+		} `json:"authors"`
+		Publishers []struct {
+			Name string `json:"name"`
+		} `json:"publishers"`
+		PublishDate string `json:"publish_date"`
+	}
+
+	bibkey := "ISBN:" + isbn
+	endpoint := strings.TrimSuffix(r.OpenLibraryURL, "/") + "/api/books?format=json&jscmd=data&bibkeys=" + url.QueryEscape(bibkey)
+	if err := r.getJSON(ctx, endpoint, &body); err != nil {
+		return Metadata{}, fmt.Errorf("open library lookup for %s failed: %w", isbn, err)
+	}
+
+	book, exists := body[bibkey]
+	if !exists {
+		return Metadata{}, fmt.Errorf("open library has no record for ISBN %s", isbn)
+	}
+
+	meta := Metadata{Title: book.Title, Published: book.PublishDate}
+	for _, author := range book.Authors {
+		meta.Authors = append(meta.Authors, author.Name)
+	}
+	if len(book.Publishers) > 0 {
+		meta.Publisher = book.Publishers[0].Name
+	}
+	return meta, nil
+}
+
+// getJSON performs a GET request and decodes a 200 response into out
+func (r *Resolver) getJSON(ctx context.Context, endpoint string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// formatDateParts turns Crossref's [year, month, day] parts into a zero-padded date string
+func formatDateParts(parts []int) string {
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("%04d", parts[0])
+	case 2:
+		return fmt.Sprintf("%04d-%02d", parts[0], parts[1])
+	default:
+		return fmt.Sprintf("%04d-%02d-%02d", parts[0], parts[1], parts[2])
+	}
+}
@@ -0,0 +1,135 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestResolver(t *testing.T, handler http.HandlerFunc) (*Resolver, *int) {
+	t.Helper()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	resolver := NewResolver()
+	resolver.CrossrefURL = server.URL
+	resolver.OpenLibraryURL = server.URL
+	return resolver, &calls
+}
+
+func TestLookupDOI(t *testing.T) {
+	resolver, calls := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/works/10.1145/3297858.3304013" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"message": {"title": ["Attention Is All You Need"], "publisher": "ACM",
+			"author": [{"given": "Ashish", "family": "Vaswani"}, {"name": "Google Brain"}],
+			"issued": {"date-parts": [[2017, 6]]}}}`)
+	})
+
+	id := Parse("doi:10.1145/3297858.3304013")
+	meta, err := resolver.Lookup(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if meta.Title != "Attention Is All You Need" || meta.Publisher != "ACM" || meta.Published != "2017-06" {
+		t.Errorf("unexpected metadata %+v", meta)
+	}
+	if len(meta.Authors) != 2 || meta.Authors[0] != "Ashish Vaswani" || meta.Authors[1] != "Google Brain" {
+		t.Errorf("unexpected authors %v", meta.Authors)
+	}
+
+	if _, err := resolver.Lookup(context.Background(), id); err != nil {
+		t.Fatal(err)
+	}
+	if *calls != 1 {
+		t.Errorf("expected cached second lookup, got %d calls", *calls)
+	}
+}
+
+func TestLookupISBN(t *testing.T) {
+	resolver, _ := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("bibkeys") != "ISBN:9780134685991" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		fmt.Fprint(w, `{"ISBN:9780134685991": {"title": "Effective Java", "authors": [{"name": "Joshua Bloch"}],
+			"publishers": [{"name": "Addison-Wesley"}], "publish_date": "2018"}}`)
+	})
+
+	meta, err := resolver.Lookup(context.Background(), Parse("isbn:9780134685991"))
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if meta.Title != "Effective Java" || meta.Publisher != "Addison-Wesley" || len(meta.Authors) != 1 {
+		t.Errorf("unexpected metadata %+v", meta)
+	}
+
+	if _, err := resolver.Lookup(context.Background(), Parse("isbn:0-8044-2957-X")); err == nil {
+		t.Error("expected error for unknown ISBN")
+	}
+}
+
+func TestLookupErrors(t *testing.T) {
+	resolver, _ := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	if _, err := resolver.Lookup(context.Background(), Parse("doi:10.1000/x")); err == nil {
+		t.Error("expected error for server failure")
+	}
+	if _, err := resolver.Lookup(context.Background(), Parse(resolver.CrossrefURL+"/page")); err == nil {
+		t.Error("expected error for a failing page")
+	}
+}
+
+func TestLookupPage(t *testing.T) {
+	resolver, calls := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/og":
+			fmt.Fprint(w, `<html><head><title>Fallback | Blog</title>
+				<meta property="og:title" content="  Range   over func ">
+				<meta property="og:site_name" content="Go Blog">
+				<link rel="canonical" href="/blog/range-functions">
+				<meta name="author" content="Ian Lance Taylor">
+				<meta property="article:published_time" content="2024-08-20T10:00:00Z">
+				</head><body><title>Not this</title></body></html>`)
+		case "/plain":
+			fmt.Fprint(w, `<html><head><title>
+				Plain title
+			</title></head><body></body></html>`)
+		default:
+			fmt.Fprint(w, `<html><body>No title</body></html>`)
+		}
+	})
+
+	meta, err := resolver.Lookup(context.Background(), Parse(resolver.CrossrefURL+"/og"))
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if meta.Title != "Range over func" || meta.Publisher != "Go Blog" || meta.Published != "2024-08-20" || len(meta.Authors) != 1 {
+		t.Errorf("unexpected metadata %+v", meta)
+	}
+	if meta.Canonical != resolver.CrossrefURL+"/blog/range-functions" {
+		t.Errorf("expected relative canonical resolved against the page, got %q", meta.Canonical)
+	}
+
+	meta, err = resolver.Lookup(context.Background(), Parse(resolver.CrossrefURL+"/plain"))
+	if err != nil || meta.Title != "Plain title" || meta.Canonical != resolver.CrossrefURL+"/plain" {
+		t.Errorf("expected plain <title> and the page URL as canonical, got %+v, %v", meta, err)
+	}
+
+	if _, err := resolver.Lookup(context.Background(), Parse(resolver.CrossrefURL+"/untitled")); err == nil {
+		t.Error("expected error for a page without a title")
+	}
+	if *calls != 3 {
+		t.Errorf("expected 3 page requests, got %d", *calls)
+	}
+}
@@ -0,0 +1,126 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// maxPageBytes bounds how much of a web page is read when looking for its metadata
+const maxPageBytes = 2 << 20
+
+// lookupPage reads a web page's title, author, site name and publish date from its <head>,
+// preferring Open Graph tags over the plain <title>
+func (r *Resolver) lookupPage(ctx context.Context, link string) (Metadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return Metadata{}, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; personal-reading-analytics)")
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return Metadata{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Metadata{}, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	meta, err := ParsePageMetadata(io.LimitReader(resp.Body, maxPageBytes))
+	if err != nil {
+		return Metadata{}, err
+	}
+	if meta.Title == "" {
+		return Metadata{}, fmt.Errorf("page %s has no title", link)
+	}
+
+	// Resolve a relative canonical link against the final URL, which also stands in when none is declared
+	final := resp.Request.URL
+	if canonical, err := final.Parse(meta.Canonical); err == nil && meta.Canonical != "" {
+		meta.Canonical = canonical.String()
+	} else {
+		meta.Canonical = final.String()
+	}
+	return meta, nil
+}
+
+// ParsePageMetadata extracts metadata from an HTML document. Canonical is taken as written,
+// so it may be relative.
+func ParsePageMetadata(r io.Reader) (Metadata, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	var meta Metadata
+	var title, ogTitle, ogURL string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "title":
+				if title == "" && n.FirstChild != nil {
+					title = n.FirstChild.Data
+				}
+			case "link":
+				if meta.Canonical == "" && strings.EqualFold(attrValue(n, "rel"), "canonical") {
+					meta.Canonical = strings.TrimSpace(attrValue(n, "href"))
+				}
+			case "meta":
+				key := strings.ToLower(attrValue(n, "property"))
+				if key == "" {
+					key = strings.ToLower(attrValue(n, "name"))
+				}
+				content := strings.TrimSpace(attrValue(n, "content"))
+				switch {
+				case content == "":
+				case key == "og:title" && ogTitle == "":
+					ogTitle = content
+				case key == "og:url" && ogURL == "":
+					ogURL = content
+				case key == "og:site_name" && meta.Publisher == "":
+					meta.Publisher = content
+				case key == "author" && len(meta.Authors) == 0:
+					meta.Authors = []string{content}
+				case key == "article:published_time" && meta.Published == "":
+					if len(content) >= len("2006-01-02") {
+						meta.Published = content[:len("2006-01-02")]
+					}
+				}
+			case "body":
+				// Metadata lives in <head>; skip the (possibly large) body
+				return
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+
+	if meta.Canonical == "" {
+		meta.Canonical = ogURL
+	}
+	meta.Title = strings.Join(strings.Fields(ogTitle), " ")
+	if meta.Title == "" {
+		meta.Title = strings.Join(strings.Fields(title), " ")
+	}
+	return meta, nil
+}
+
+// attrValue returns an HTML attribute value
+func attrValue(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
+		}
+	}
+	return ""
+}
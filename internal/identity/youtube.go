@@ -0,0 +1,146 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// YouTubeOEmbedBaseURL is YouTube's oEmbed endpoint, which needs no API key
+	YouTubeOEmbedBaseURL = "https://www.youtube.com/oembed"
+	// YouTubeAPIBaseURL is the YouTube Data API, used for durations when an API key is set
+	YouTubeAPIBaseURL = "https://www.googleapis.com/youtube/v3"
+)
+
+var (
+	youTubeIDPattern       = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	iso8601DurationPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+)
+
+// YouTubeVideoID returns the video ID of a YouTube watch, shorts, live, embed or youtu.be link,
+// or "" when the link is not a YouTube video
+func YouTubeVideoID(link string) string {
+	parsed, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return ""
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for _, prefix := range []string{"www.", "m.", "music."} {
+		host = strings.TrimPrefix(host, prefix)
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+
+	var id string
+	switch host {
+	case "youtu.be":
+		id = segments[0]
+	case "youtube.com", "youtube-nocookie.com":
+		switch segments[0] {
+		case "watch":
+			id = parsed.Query().Get("v")
+		case "shorts", "live", "embed", "v":
+			if len(segments) > 1 {
+				id = segments[1]
+			}
+		}
+	}
+	if !youTubeIDPattern.MatchString(id) {
+		return ""
+	}
+	return id
+}
+
+// YouTubeWatchURL returns the canonical watch page of a video ID
+func YouTubeWatchURL(videoID string) string {
+	return "https://www.youtube.com/watch?v=" + videoID
+}
+
+// lookupYouTube reads a video's title, channel (as Publisher) and, with an API key, its duration and
+// publish date from the Data API. Without a key, or when the API fails, oEmbed supplies title and channel.
+func (r *Resolver) lookupYouTube(ctx context.Context, videoID string) (Metadata, error) {
+	if r.YouTubeAPIKey != "" {
+		if meta, err := r.lookupYouTubeAPI(ctx, videoID); err == nil {
+			return meta, nil
+		}
+	}
+
+	var body struct {
+		Title      string `json:"title"`
+		AuthorName string `json:"author_name"`
+	}
+	endpoint := strings.TrimSuffix(r.YouTubeOEmbedURL, "/") + "?format=json&url=" + url.QueryEscape(YouTubeWatchURL(videoID))
+	if err := r.getJSON(ctx, endpoint, &body); err != nil {
+		return Metadata{}, fmt.Errorf("youtube oembed lookup for %s failed: %w", videoID, err)
+	}
+	if body.Title == "" {
+		return Metadata{}, fmt.Errorf("youtube video %s has no title", videoID)
+	}
+	return Metadata{Title: body.Title, Publisher: body.AuthorName, Canonical: YouTubeWatchURL(videoID)}, nil
+}
+
+// lookupYouTubeAPI reads a video's snippet and duration from the YouTube Data API
+func (r *Resolver) lookupYouTubeAPI(ctx context.Context, videoID string) (Metadata, error) {
+	var body struct {
+		Items []struct {
+			Snippet struct {
+				Title        string `json:"title"`
+				ChannelTitle string `json:"channelTitle"`
+				PublishedAt  string `json:"publishedAt"`
+			} `json:"snippet"`
+			ContentDetails struct {
+				Duration string `json:"duration"`
+			} `json:"contentDetails"`
+		} `json:"items"`
+	}
+
+	query := url.Values{"part": {"snippet,contentDetails"}, "id": {videoID}, "key": {r.YouTubeAPIKey}}
+	endpoint := strings.TrimSuffix(r.YouTubeAPIURL, "/") + "/videos?" + query.Encode()
+	if err := r.getJSON(ctx, endpoint, &body); err != nil {
+		return Metadata{}, fmt.Errorf("youtube api lookup for %s failed: %w", videoID, err)
+	}
+	if len(body.Items) == 0 {
+		return Metadata{}, fmt.Errorf("youtube api has no video %s", videoID)
+	}
+
+	item := body.Items[0]
+	meta := Metadata{
+		Title:     item.Snippet.Title,
+		Publisher: item.Snippet.ChannelTitle,
+		Canonical: YouTubeWatchURL(videoID),
+	}
+	if len(item.Snippet.PublishedAt) >= len("2006-01-02") {
+		meta.Published = item.Snippet.PublishedAt[:len("2006-01-02")]
+	}
+	if duration, err := ParseISO8601Duration(item.ContentDetails.Duration); err == nil {
+		meta.Duration = duration
+	}
+	return meta, nil
+}
+
+// ParseISO8601Duration parses the day/hour/minute/second durations the YouTube Data API reports,
+// such as PT1H2M3S
+func ParseISO8601Duration(value string) (time.Duration, error) {
+	match := iso8601DurationPattern.FindStringSubmatch(value)
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", value)
+	}
+
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var total time.Duration
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q: %w", value, err)
+		}
+		total += time.Duration(n) * unit
+	}
+	return total, nil
+}
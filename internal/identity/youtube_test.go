@@ -0,0 +1,119 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestYouTubeVideoID(t *testing.T) {
+	tests := []struct {
+		link     string
+		expected string
+	}{
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42", "dQw4w9WgXcQ"},
+		{"https://m.youtube.com/watch?v=dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"https://youtu.be/dQw4w9WgXcQ?si=abc", "dQw4w9WgXcQ"},
+		{"https://www.youtube.com/shorts/dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"https://www.youtube.com/embed/dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"https://www.youtube.com/@channel", ""},
+		{"https://www.youtube.com/watch?v=short", ""},
+		{"https://vimeo.com/123456", ""},
+		{"not a link", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			if got := YouTubeVideoID(tt.link); got != tt.expected {
+				t.Errorf("YouTubeVideoID(%q) = %q, want %q", tt.link, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseISO8601Duration(t *testing.T) {
+	tests := []struct {
+		value     string
+		expected  time.Duration
+		expectErr bool
+	}{
+		{value: "PT1H2M3S", expected: time.Hour + 2*time.Minute + 3*time.Second},
+		{value: "PT45M", expected: 45 * time.Minute},
+		{value: "P1DT2H", expected: 26 * time.Hour},
+		{value: "PT0S", expected: 0},
+		{value: "PT", expectErr: true},
+		{value: "P", expectErr: true},
+		{value: "1:00", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseISO8601Duration(tt.value)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseISO8601Duration(%q) error = %v, expectErr %v", tt.value, err, tt.expectErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseISO8601Duration(%q) = %v, want %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLookupYouTube(t *testing.T) {
+	const videoID = "dQw4w9WgXcQ"
+	resolver, calls := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oembed":
+			if r.URL.Query().Get("url") != YouTubeWatchURL(videoID) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, `{"title": "A Great Talk", "author_name": "GopherCon"}`)
+		case "/api/videos":
+			if r.URL.Query().Get("key") != "good-key" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, `{"items": [{"snippet": {"title": "A Great Talk", "channelTitle": "GopherCon",
+				"publishedAt": "2024-09-01T12:00:00Z"}, "contentDetails": {"duration": "PT42M10S"}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	resolver.YouTubeOEmbedURL = resolver.CrossrefURL + "/oembed"
+	resolver.YouTubeAPIURL = resolver.CrossrefURL + "/api"
+
+	tests := []struct {
+		name             string
+		apiKey           string
+		link             string
+		expectedDuration time.Duration
+		expectedCalls    int
+	}{
+		{name: "oembed without a key", link: "https://youtu.be/" + videoID, expectedCalls: 1},
+		{name: "data api with a key", apiKey: "good-key", link: "https://www.youtube.com/watch?v=" + videoID + "&t=1", expectedDuration: 42*time.Minute + 10*time.Second, expectedCalls: 1},
+		{name: "oembed when the api fails", apiKey: "bad-key", link: "https://m.youtube.com/watch?v=" + videoID, expectedCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*calls = 0
+			resolver.YouTubeAPIKey = tt.apiKey
+			meta, err := resolver.Lookup(context.Background(), Parse(tt.link))
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			if meta.Title != "A Great Talk" || meta.Publisher != "GopherCon" || meta.Canonical != YouTubeWatchURL(videoID) {
+				t.Errorf("unexpected metadata %+v", meta)
+			}
+			if meta.Duration != tt.expectedDuration {
+				t.Errorf("Duration = %v, want %v", meta.Duration, tt.expectedDuration)
+			}
+			if *calls != tt.expectedCalls {
+				t.Errorf("expected %d requests, got %d", tt.expectedCalls, *calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package influx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+// Measurements written for each snapshot
+const (
+	MeasurementTotals = "reading"
+	MeasurementSource = "reading_source"
+)
+
+// Lines renders a snapshot's aggregates as InfluxDB line protocol with second precision: one
+// reading point with the totals and one reading_source point per source
+func Lines(m schema.Metrics) []string {
+	ts := strconv.FormatInt(m.LastUpdated.Unix(), 10)
+	lines := []string{fmt.Sprintf("%s total_articles=%di,read_count=%di,unread_count=%di,read_rate=%s,sources=%di %s",
+		MeasurementTotals, m.TotalArticles, m.ReadCount, m.UnreadCount,
+		strconv.FormatFloat(m.ReadRate, 'f', -1, 64), len(m.BySource), ts)}
+
+	names := make([]string, 0, len(m.BySourceReadStatus))
+	for name := range m.BySourceReadStatus {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		counts := m.BySourceReadStatus[name]
+		lines = append(lines, fmt.Sprintf("%s,source=%s read=%di,unread=%di %s", MeasurementSource, tagValue(name), counts[0], counts[1], ts))
+	}
+	return lines
+}
+
+// tagValue escapes commas, equals signs and spaces in a tag value
+func tagValue(value string) string {
+	return strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `).Replace(value)
+}
+
+// Write writes lines to w, one point per line
+func Write(w io.Writer, lines []string) error {
+	for _, line := range lines {
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return fmt.Errorf("failed to write line protocol: %w", err)
+		}
+	}
+	return nil
+}
+
+// Client writes points to an InfluxDB 2.x bucket
+type Client struct {
+	HTTP   *http.Client
+	URL    string
+	Org    string
+	Bucket string
+	Token  string
+}
+
+// NewClient returns a Client with a short timeout, so an unreachable server never holds up a
+// metrics run
+func NewClient(serverURL, org, bucket, token string) *Client {
+	return &Client{HTTP: &http.Client{Timeout: 15 * time.Second}, URL: serverURL, Org: org, Bucket: bucket, Token: token}
+}
+
+// Push writes lines through the /api/v2/write endpoint
+func (c *Client) Push(ctx context.Context, lines []string) error {
+	if c.Bucket == "" {
+		return fmt.Errorf("no InfluxDB bucket is set (influxdb.bucket or INFLUX_BUCKET)")
+	}
+	var body bytes.Buffer
+	if err := Write(&body, lines); err != nil {
+		return err
+	}
+
+	query := url.Values{"bucket": {c.Bucket}, "precision": {"s"}}
+	if c.Org != "" {
+		query.Set("org", c.Org)
+	}
+	endpoint := strings.TrimRight(c.URL, "/") + "/api/v2/write?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if err != nil {
+		return fmt.Errorf("failed to build InfluxDB request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Token "+c.Token)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to write to InfluxDB: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to write to InfluxDB: server returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// FromConfig builds a Client from config, with INFLUX_URL, INFLUX_ORG, INFLUX_BUCKET and
+// INFLUX_TOKEN taking precedence. It returns nil when no server URL is set.
+func FromConfig(cfg config.InfluxConfig) *Client {
+	serverURL := envOr("INFLUX_URL", cfg.URL)
+	if serverURL == "" {
+		return nil
+	}
+	return NewClient(serverURL, envOr("INFLUX_ORG", cfg.Org), envOr("INFLUX_BUCKET", cfg.Bucket), os.Getenv("INFLUX_TOKEN"))
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,117 @@
+package influx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+var snapshot = schema.Metrics{
+	TotalArticles:      12,
+	ReadCount:          5,
+	UnreadCount:        7,
+	ReadRate:           41.67,
+	LastUpdated:        time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC),
+	BySource:           map[string]int{"GitHub": 7, "Lenny's Newsletter": 5},
+	BySourceReadStatus: map[string][2]int{"GitHub": {3, 4}, "Lenny's Newsletter": {2, 3}},
+}
+
+func TestLines(t *testing.T) {
+	expected := []string{
+		"reading total_articles=12i,read_count=5i,unread_count=7i,read_rate=41.67,sources=2i 1773360000",
+		"reading_source,source=GitHub read=3i,unread=4i 1773360000",
+		`reading_source,source=Lenny's\ Newsletter read=2i,unread=3i 1773360000`,
+	}
+	lines := Lines(snapshot)
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, got %d: %v", len(expected), len(lines), lines)
+	}
+	for i, want := range expected {
+		if lines[i] != want {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want)
+		}
+	}
+}
+
+func TestTagValue(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"GitHub", "GitHub"},
+		{"a b", `a\ b`},
+		{"x,y=z", `x\,y\=z`},
+	}
+	for _, tt := range tests {
+		if got := tagValue(tt.input); got != tt.expected {
+			t.Errorf("tagValue(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestPush(t *testing.T) {
+	var query, auth, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/write" {
+			http.NotFound(w, r)
+			return
+		}
+		content, _ := io.ReadAll(r.Body)
+		query, auth, body = r.URL.RawQuery, r.Header.Get("Authorization"), string(content)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", "home", "reading", "secret")
+	if err := client.Push(context.Background(), Lines(snapshot)); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if query != "bucket=reading&org=home&precision=s" {
+		t.Errorf("unexpected query %q", query)
+	}
+	if auth != "Token secret" {
+		t.Errorf("unexpected Authorization %q", auth)
+	}
+	if strings.Count(body, "\n") != 3 {
+		t.Errorf("expected 3 points, got:\n%s", body)
+	}
+
+	client.Bucket = ""
+	if err := client.Push(context.Background(), nil); err == nil {
+		t.Error("expected an error without a bucket")
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized access", http.StatusUnauthorized)
+	}))
+	defer failing.Close()
+	err := NewClient(failing.URL, "", "reading", "").Push(context.Background(), Lines(snapshot))
+	if err == nil || !strings.Contains(err.Error(), "unauthorized access") {
+		t.Errorf("expected the server's error, got %v", err)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	t.Setenv("INFLUX_URL", "")
+	t.Setenv("INFLUX_BUCKET", "")
+	if client := FromConfig(config.InfluxConfig{}); client != nil {
+		t.Error("expected no client without a URL")
+	}
+
+	t.Setenv("INFLUX_BUCKET", "from-env")
+	t.Setenv("INFLUX_TOKEN", "token")
+	client := FromConfig(config.InfluxConfig{URL: "http://localhost:8086", Org: "home", Bucket: "reading"})
+	if client == nil {
+		t.Fatal("expected a client")
+	}
+	if client.Bucket != "from-env" || client.Org != "home" || client.Token != "token" {
+		t.Errorf("unexpected client: %+v", client)
+	}
+}
@@ -0,0 +1,184 @@
+package linkcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// Link states recorded in a report
+const (
+	StatusOK         = "ok"
+	StatusRedirected = "redirected" // reachable, but the link now resolves to a different URL
+	StatusDead       = "dead"       // gone for good: 404/410 or the host no longer resolves
+	StatusError      = "error"      // possibly transient: timeouts, server errors, blocked requests
+)
+
+// DefaultReportFile is where checklinks writes its report and the site generator looks for it
+const DefaultReportFile = "link-report.json"
+
+// Result is the outcome of checking one article link
+type Result struct {
+	Date       string `json:"date"`
+	Title      string `json:"title"`
+	Link       string `json:"link"`
+	Source     string `json:"source"`
+	Status     string `json:"status"`
+	StatusCode int    `json:"status_code,omitempty"`
+	FinalURL   string `json:"final_url,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Report lists every link that was not plainly reachable, with totals per status
+type Report struct {
+	CheckedAt time.Time      `json:"checked_at"`
+	Checked   int            `json:"checked"`
+	Counts    map[string]int `json:"counts"`
+	Results   []Result       `json:"results"`
+}
+
+// Checker probes links with HEAD requests, falling back to GET for servers that reject HEAD
+type Checker struct {
+	Client      *http.Client
+	Concurrency int
+}
+
+// NewChecker returns a Checker with a per-request timeout and concurrency limit
+func NewChecker(timeout time.Duration, concurrency int) *Checker {
+	return &Checker{Client: &http.Client{Timeout: timeout}, Concurrency: concurrency}
+}
+
+// CheckAll checks every article link, at most Concurrency at a time
+func (c *Checker) CheckAll(ctx context.Context, articles []schema.ArticleMeta, checkedAt time.Time) Report {
+	results := make([]Result, len(articles))
+	slots := make(chan struct{}, max(c.Concurrency, 1))
+	var wg sync.WaitGroup
+	for i, article := range articles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			results[i] = c.Check(ctx, article)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{CheckedAt: checkedAt, Checked: len(articles), Counts: make(map[string]int)}
+	for _, result := range results {
+		report.Counts[result.Status]++
+		if result.Status != StatusOK {
+			report.Results = append(report.Results, result)
+		}
+	}
+
+	// Dead links first, then redirects and errors, oldest first within each
+	rank := map[string]int{StatusDead: 0, StatusRedirected: 1, StatusError: 2}
+	sort.SliceStable(report.Results, func(i, j int) bool {
+		a, b := report.Results[i], report.Results[j]
+		if rank[a.Status] != rank[b.Status] {
+			return rank[a.Status] < rank[b.Status]
+		}
+		return a.Date < b.Date
+	})
+	return report
+}
+
+// Check probes a single article link
+func (c *Checker) Check(ctx context.Context, article schema.ArticleMeta) Result {
+	result := Result{Date: article.Date, Title: article.Title, Link: article.Link, Source: article.Category}
+
+	resp, err := c.request(ctx, http.MethodHead, article.Link)
+	if err == nil && rejectsHead(resp.StatusCode) {
+		resp.Body.Close()
+		resp, err = c.request(ctx, http.MethodGet, article.Link)
+	}
+	if err != nil {
+		result.Status = StatusError
+		if isUnresolvable(err) {
+			result.Status = StatusDead
+		}
+		result.Error = err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		result.Status = StatusDead
+	case resp.StatusCode >= 400:
+		result.Status = StatusError
+	case isMoved(article.Link, resp.Request.URL.String()):
+		result.Status = StatusRedirected
+		result.FinalURL = resp.Request.URL.String()
+	default:
+		result.Status = StatusOK
+	}
+	return result
+}
+
+func (c *Checker) request(ctx context.Context, method, link string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; personal-reading-analytics)")
+	return c.Client.Do(req)
+}
+
+// rejectsHead reports statuses some servers return for HEAD while serving GET fine
+func rejectsHead(code int) bool {
+	return code == http.StatusMethodNotAllowed || code == http.StatusForbidden || code == http.StatusNotImplemented
+}
+
+// isUnresolvable reports a host that no longer exists
+func isUnresolvable(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// isMoved reports whether a redirect landed somewhere meaningfully different,
+// ignoring http to https upgrades, www. prefixes and trailing slashes
+func isMoved(original, final string) bool {
+	normalize := func(link string) string {
+		link = strings.TrimPrefix(strings.TrimPrefix(link, "https://"), "http://")
+		return strings.TrimSuffix(strings.TrimPrefix(link, "www."), "/")
+	}
+	return normalize(original) != normalize(final)
+}
+
+// Write saves a report as indented JSON
+func Write(path string, report Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal link report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Read loads a report written by Write
+func Read(path string) (Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return Report{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return report, nil
+}
@@ -0,0 +1,121 @@
+package linkcheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestCheckAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok", "/new", "/slash/":
+		case "/missing":
+			http.NotFound(w, r)
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		case "/moved":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		case "/slash":
+			http.Redirect(w, r, "/slash/", http.StatusMovedPermanently)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	article := func(date, path string) schema.ArticleMeta {
+		return schema.ArticleMeta{Date: date, Title: path, Link: server.URL + path, Category: "Test"}
+	}
+	articles := []schema.ArticleMeta{
+		article("2024-01-01", "/ok"),
+		article("2024-01-06", "/missing"),
+		article("2024-01-02", "/gone"),
+		article("2024-01-03", "/moved"),
+		article("2024-01-04", "/slash"),
+		article("2024-01-05", "/no-head"),
+		article("2024-01-07", "/broken"),
+		{Date: "2024-01-08", Title: "bad", Link: "::not a url"},
+	}
+
+	checkedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	report := NewChecker(5*time.Second, 3).CheckAll(context.Background(), articles, checkedAt)
+
+	if report.Checked != len(articles) || !report.CheckedAt.Equal(checkedAt) {
+		t.Errorf("unexpected report header %+v", report)
+	}
+	expectedCounts := map[string]int{StatusOK: 3, StatusDead: 2, StatusRedirected: 1, StatusError: 2}
+	if fmt.Sprint(report.Counts) != fmt.Sprint(expectedCounts) {
+		t.Errorf("counts = %v, want %v", report.Counts, expectedCounts)
+	}
+
+	expected := []struct {
+		title, status string
+		code          int
+	}{
+		{"/gone", StatusDead, http.StatusGone},
+		{"/missing", StatusDead, http.StatusNotFound},
+		{"/moved", StatusRedirected, http.StatusOK},
+		{"/broken", StatusError, http.StatusInternalServerError},
+		{"bad", StatusError, 0},
+	}
+	if len(report.Results) != len(expected) {
+		t.Fatalf("expected %d results, got %+v", len(expected), report.Results)
+	}
+	for i, want := range expected {
+		got := report.Results[i]
+		if got.Title != want.title || got.Status != want.status || got.StatusCode != want.code {
+			t.Errorf("result %d = %+v, want %s %s %d", i, got, want.title, want.status, want.code)
+		}
+	}
+	if report.Results[2].FinalURL != server.URL+"/new" {
+		t.Errorf("expected the redirect target to be recorded, got %q", report.Results[2].FinalURL)
+	}
+	if report.Results[4].Error == "" {
+		t.Error("expected an error message for an invalid link")
+	}
+}
+
+func TestIsUnresolvable(t *testing.T) {
+	notFound := &net.DNSError{Err: "no such host", Name: "gone.example", IsNotFound: true}
+	if !isUnresolvable(fmt.Errorf("dial: %w", notFound)) {
+		t.Error("expected a missing host to count as unresolvable")
+	}
+	if isUnresolvable(&net.DNSError{Err: "timeout", IsTimeout: true}) || isUnresolvable(fmt.Errorf("reset")) {
+		t.Error("expected transient failures not to count as unresolvable")
+	}
+}
+
+func TestReportRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultReportFile)
+	report := Report{
+		CheckedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Checked:   2,
+		Counts:    map[string]int{StatusOK: 1, StatusDead: 1},
+		Results:   []Result{{Title: "Gone", Link: "https://a.com", Status: StatusDead, StatusCode: 404}},
+	}
+	if err := Write(path, report); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	loaded, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if fmt.Sprint(loaded) != fmt.Sprint(report) {
+		t.Errorf("loaded %+v, want %+v", loaded, report)
+	}
+	if _, err := Read(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error for a missing report")
+	}
+}
@@ -0,0 +1,101 @@
+package locale
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// Default is used when no locale is configured
+const Default = "en-US"
+
+// dateLayouts holds the date and date-time layouts per language; languages not listed use ISO dates.
+// Month names stay English, since Go's time package has no translations.
+var dateLayouts = map[string][2]string{
+	"en": {"Jan 2, 2006", "Jan 2, 2006 at 3:04 PM"},
+	"de": {"02.01.2006", "02.01.2006, 15:04"},
+	"fr": {"02/01/2006", "02/01/2006 15:04"},
+	"es": {"02/01/2006", "02/01/2006 15:04"},
+	"it": {"02/01/2006", "02/01/2006 15:04"},
+	"pt": {"02/01/2006", "02/01/2006 15:04"},
+	"nl": {"02-01-2006", "02-01-2006 15:04"},
+	"ja": {"2006/01/02", "2006/01/02 15:04"},
+	"zh": {"2006/01/02", "2006/01/02 15:04"},
+	"ko": {"2006. 01. 02.", "2006. 01. 02. 15:04"},
+}
+
+// dayFirstEnglish lists English regions that write the day before the month
+var dayFirstEnglish = map[string]bool{"GB": true, "IE": true, "AU": true, "NZ": true, "IN": true, "ZA": true}
+
+// Locale formats numbers and dates for one BCP 47 language tag
+type Locale struct {
+	tag      language.Tag
+	printer  *message.Printer
+	date     string
+	dateTime string
+}
+
+// Parse builds a Locale from a tag such as en-US or de-DE; an empty tag gives Default
+func Parse(tag string) (Locale, error) {
+	if tag == "" {
+		tag = Default
+	}
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return Locale{}, fmt.Errorf("invalid locale %q: %w", tag, err)
+	}
+
+	base, _ := parsed.Base()
+	layouts, exists := dateLayouts[base.String()]
+	if !exists {
+		layouts = [2]string{"2006-01-02", "2006-01-02 15:04"}
+	}
+	if region, _ := parsed.Region(); base.String() == "en" && dayFirstEnglish[region.String()] {
+		layouts = [2]string{"2 Jan 2006", "2 Jan 2006, 15:04"}
+	}
+	return Locale{tag: parsed, printer: message.NewPrinter(parsed), date: layouts[0], dateTime: layouts[1]}, nil
+}
+
+// MustParse is Parse for tags known to be valid, falling back to Default otherwise
+func MustParse(tag string) Locale {
+	l, err := Parse(tag)
+	if err != nil {
+		l, _ = Parse(Default)
+	}
+	return l
+}
+
+// orDefault fills a zero Locale with Default
+func (l Locale) orDefault() Locale {
+	if l.printer == nil {
+		return MustParse(Default)
+	}
+	return l
+}
+
+// Tag is the locale's BCP 47 tag, as used in the html lang attribute
+func (l Locale) Tag() string {
+	return l.orDefault().tag.String()
+}
+
+// Int formats n with the locale's digit grouping, such as 1,234 or 1.234
+func (l Locale) Int(n int) string {
+	return l.orDefault().printer.Sprintf("%d", n)
+}
+
+// Decimal formats v with the given number of decimal places and the locale's separators
+func (l Locale) Decimal(v float64, places int) string {
+	return l.orDefault().printer.Sprintf("%.*f", places, v)
+}
+
+// Date formats the day of t
+func (l Locale) Date(t time.Time) string {
+	return t.Format(l.orDefault().date)
+}
+
+// DateTime formats the day and time of t
+func (l Locale) DateTime(t time.Time) string {
+	return t.Format(l.orDefault().dateTime)
+}
@@ -0,0 +1,54 @@
+package locale
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocale(t *testing.T) {
+	when := time.Date(2026, 3, 13, 14, 5, 0, 0, time.UTC)
+	tests := []struct {
+		tag      string
+		integer  string
+		decimal  string
+		date     string
+		dateTime string
+	}{
+		{"", "1,234,567", "46.6", "Mar 13, 2026", "Mar 13, 2026 at 2:05 PM"},
+		{"en-GB", "1,234,567", "46.6", "13 Mar 2026", "13 Mar 2026, 14:05"},
+		{"de-DE", "1.234.567", "46,6", "13.03.2026", "13.03.2026, 14:05"},
+		{"fr-FR", "1 234 567", "46,6", "13/03/2026", "13/03/2026 14:05"},
+		{"sv-SE", "1 234 567", "46,6", "2026-03-13", "2026-03-13 14:05"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			l, err := Parse(tt.tag)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := l.Int(1234567); got != tt.integer {
+				t.Errorf("Int() = %q, want %q", got, tt.integer)
+			}
+			if got := l.Decimal(46.6, 1); got != tt.decimal {
+				t.Errorf("Decimal() = %q, want %q", got, tt.decimal)
+			}
+			if got := l.Date(when); got != tt.date {
+				t.Errorf("Date() = %q, want %q", got, tt.date)
+			}
+			if got := l.DateTime(when); got != tt.dateTime {
+				t.Errorf("DateTime() = %q, want %q", got, tt.dateTime)
+			}
+		})
+	}
+
+	var zero Locale
+	if got := zero.Int(1234); got != "1,234" {
+		t.Errorf("zero Locale Int() = %q, want the default formatting", got)
+	}
+	if _, err := Parse("not a locale!"); err == nil {
+		t.Error("expected an error for an invalid tag")
+	}
+	if got := MustParse("not a locale!").Tag(); got != Default {
+		t.Errorf("MustParse() fallback = %q, want %q", got, Default)
+	}
+}
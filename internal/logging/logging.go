@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Output formats accepted by --log-format
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options selects the minimum level and the output format of the default logger
+type Options struct {
+	Level  string
+	Format string
+}
+
+// ParseArgs removes --log-level and --log-format from anywhere in args, so they work before or
+// after a subcommand. Unset options fall back to LOG_LEVEL and LOG_FORMAT, then info and text.
+func ParseArgs(args []string) ([]string, Options, error) {
+	opts := Options{Level: os.Getenv("LOG_LEVEL"), Format: os.Getenv("LOG_FORMAT")}
+	targets := map[string]*string{"log-level": &opts.Level, "log-format": &opts.Format}
+
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		target, ok := targets[name]
+		if !ok || !strings.HasPrefix(arg, "-") {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, opts, fmt.Errorf("flag needs an argument: --%s", name)
+			}
+			i++
+			value = args[i]
+		}
+		*target = value
+	}
+	return rest, opts, nil
+}
+
+// ParseLevel maps debug, info, warn or error to its slog level; empty means info
+func ParseLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	if level == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q: expected debug, info, warn or error", level)
+	}
+	return l, nil
+}
+
+// New returns a logger writing to w at the options' level and format
+func New(w io.Writer, opts Options) (*slog.Logger, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+	handlerOpts := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(opts.Format) {
+	case "", FormatText:
+		return slog.New(slog.NewTextHandler(w, handlerOpts)), nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, handlerOpts)), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q: expected %s or %s", opts.Format, FormatText, FormatJSON)
+	}
+}
+
+// Init reads the logging flags from args, installs the logger on stderr as the slog default and
+// returns the remaining arguments
+func Init(args []string) ([]string, error) {
+	rest, opts, err := ParseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	logger, err := New(os.Stderr, opts)
+	if err != nil {
+		return nil, err
+	}
+	slog.SetDefault(logger)
+	return rest, nil
+}
+
+// Fatal logs msg at error level with its attributes and exits with status 1
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("LOG_FORMAT", "")
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectedRest  []string
+		expectedOpts  Options
+		expectedError bool
+	}{
+		{"defaults from environment", []string{"--fetch"}, []string{"--fetch"}, Options{Level: "warn"}, false},
+		{"separate values", []string{"--log-level", "debug", "--log-format", "json", "--fetch"}, []string{"--fetch"}, Options{Level: "debug", Format: "json"}, false},
+		{"after a subcommand", []string{"prune", "--dry-run", "-log-level=error"}, []string{"prune", "--dry-run"}, Options{Level: "error"}, false},
+		{"stops at terminator", []string{"--", "--log-level", "debug"}, []string{"--", "--log-level", "debug"}, Options{Level: "warn"}, false},
+		{"missing value", []string{"--log-format"}, nil, Options{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rest, opts, err := ParseArgs(tt.args)
+			if (err != nil) != tt.expectedError {
+				t.Fatalf("ParseArgs() error = %v, expectedError %v", err, tt.expectedError)
+			}
+			if tt.expectedError {
+				return
+			}
+			if !reflect.DeepEqual(rest, tt.expectedRest) {
+				t.Errorf("ParseArgs() rest = %v, expected %v", rest, tt.expectedRest)
+			}
+			if opts != tt.expectedOpts {
+				t.Errorf("ParseArgs() opts = %+v, expected %+v", opts, tt.expectedOpts)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, Options{Level: "warn", Format: "json"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	logger.Info("hidden")
+	logger.Warn("shown", "file", "metrics/2025-01-05.json")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected a single JSON record, got %q: %v", buf.String(), err)
+	}
+	if record["msg"] != "shown" || record["level"] != "WARN" || record["file"] != "metrics/2025-01-05.json" {
+		t.Errorf("unexpected record %v", record)
+	}
+
+	buf.Reset()
+	logger, _ = New(&buf, Options{})
+	logger.Info("text", "count", 3)
+	if !strings.Contains(buf.String(), "level=INFO msg=text count=3") {
+		t.Errorf("expected a text record, got %q", buf.String())
+	}
+
+	for _, opts := range []Options{{Level: "verbose"}, {Format: "xml"}} {
+		if _, err := New(&buf, opts); err == nil {
+			t.Errorf("New(%+v) expected an error", opts)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// Alert kinds
+const (
+	AlertAnomaly   = "anomaly"
+	AlertMilestone = "milestone"
+)
+
+// AdditionSpikeFactor is how many times the average month's saves a month needs to be flagged
+const AdditionSpikeFactor = 3
+
+// AdditionSpikeMinMonths is how many earlier months the average needs before a spike is flagged
+const AdditionSpikeMinMonths = 3
+
+// ReadRateDropPoints is the fall in read rate since the previous snapshot, in percentage points,
+// that is flagged
+const ReadRateDropPoints = 10
+
+// MilestoneCounts are the article totals and read counts that are celebrated when crossed
+var MilestoneCounts = []int{100, 500, 1000, 5000, 10000}
+
+// detectAdditionSpike flags the month of referenceDate when it saved at least AdditionSpikeFactor
+// times the average of the earlier months with saves
+func detectAdditionSpike(m schema.Metrics, referenceDate time.Time) []schema.Alert {
+	year, month := referenceDate.Format("2006"), referenceDate.Format("01")
+	current := m.ByYearAndMonth[year][month]
+	if current == 0 {
+		return nil
+	}
+
+	key := year + "-" + month
+	earlier, total := 0, 0
+	for y, byMonth := range m.ByYearAndMonth {
+		for mo, count := range byMonth {
+			if y+"-"+mo < key && count > 0 {
+				earlier++
+				total += count
+			}
+		}
+	}
+	if earlier < AdditionSpikeMinMonths {
+		return nil
+	}
+	average := float64(total) / float64(earlier)
+	if float64(current) < AdditionSpikeFactor*average {
+		return nil
+	}
+	return []schema.Alert{{Kind: AlertAnomaly, Message: fmt.Sprintf("%d articles saved in %s, %.1fx the monthly average of %.1f", current, referenceDate.Format("January 2006"), float64(current)/average, average)}}
+}
+
+// ApplyAlerts adds the alerts that need the previous snapshot: a read rate drop of at least
+// ReadRateDropPoints, and the MilestoneCounts crossed in total articles or articles read. Without a
+// previous snapshot there is nothing to compare against, so none are added.
+func ApplyAlerts(m *schema.Metrics, prev *schema.Metrics) {
+	if prev == nil {
+		return
+	}
+	if drop := prev.ReadRate - m.ReadRate; drop >= ReadRateDropPoints {
+		m.Alerts = append(m.Alerts, schema.Alert{Kind: AlertAnomaly, Message: fmt.Sprintf("Read rate dropped %.1f points since %s, from %.1f%% to %.1f%%", drop, prev.LastUpdated.Format("2006-01-02"), prev.ReadRate, m.ReadRate)})
+	}
+	for _, count := range MilestoneCounts {
+		if prev.TotalArticles < count && m.TotalArticles >= count {
+			m.Alerts = append(m.Alerts, schema.Alert{Kind: AlertMilestone, Message: fmt.Sprintf("Crossed %d articles saved", count)})
+		}
+		if prev.ReadCount < count && m.ReadCount >= count {
+			m.Alerts = append(m.Alerts, schema.Alert{Kind: AlertMilestone, Message: fmt.Sprintf("Crossed %d articles read", count)})
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestDetectAdditionSpike(t *testing.T) {
+	reference := time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC)
+	byYearAndMonth := func(april int) map[string]map[string]int {
+		return map[string]map[string]int{
+			"2025": {"12": 4},
+			"2026": {"01": 2, "02": 3, "03": 3, "04": april},
+		}
+	}
+
+	alerts := detectAdditionSpike(schema.Metrics{ByYearAndMonth: byYearAndMonth(9)}, reference)
+	if len(alerts) != 1 || alerts[0].Kind != AlertAnomaly {
+		t.Fatalf("expected a spike alert for 9 saves against an average of 3, got %+v", alerts)
+	}
+	if want := "9 articles saved in April 2026, 3.0x the monthly average of 3.0"; alerts[0].Message != want {
+		t.Errorf("Message = %q, want %q", alerts[0].Message, want)
+	}
+
+	if alerts := detectAdditionSpike(schema.Metrics{ByYearAndMonth: byYearAndMonth(8)}, reference); alerts != nil {
+		t.Errorf("expected no alert below %dx the average, got %+v", AdditionSpikeFactor, alerts)
+	}
+
+	short := schema.Metrics{ByYearAndMonth: map[string]map[string]int{"2026": {"03": 1, "04": 20}}}
+	if alerts := detectAdditionSpike(short, reference); alerts != nil {
+		t.Errorf("expected no alert with fewer than %d earlier months, got %+v", AdditionSpikeMinMonths, alerts)
+	}
+}
+
+func TestApplyAlerts(t *testing.T) {
+	prev := &schema.Metrics{TotalArticles: 990, ReadCount: 480, ReadRate: 62, LastUpdated: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)}
+	m := schema.Metrics{TotalArticles: 1010, ReadCount: 510, ReadRate: 50.5, Alerts: []schema.Alert{{Kind: AlertAnomaly, Message: "spike"}}}
+
+	ApplyAlerts(&m, prev)
+	expected := []schema.Alert{
+		{Kind: AlertAnomaly, Message: "spike"},
+		{Kind: AlertAnomaly, Message: "Read rate dropped 11.5 points since 2026-04-01, from 62.0% to 50.5%"},
+		{Kind: AlertMilestone, Message: "Crossed 500 articles read"},
+		{Kind: AlertMilestone, Message: "Crossed 1000 articles saved"},
+	}
+	if !reflect.DeepEqual(m.Alerts, expected) {
+		t.Errorf("ApplyAlerts() = %+v, expected %+v", m.Alerts, expected)
+	}
+
+	first := schema.Metrics{TotalArticles: 1010}
+	ApplyAlerts(&first, nil)
+	if first.Alerts != nil {
+		t.Errorf("expected no alerts without a previous snapshot, got %+v", first.Alerts)
+	}
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// legacySubstackAuthorCountKey is where snapshots written before Metrics.Substack kept the Substack
+// feed count, inside BySourceReadStatus
+const legacySubstackAuthorCountKey = "substack_author_count"
+
+// updateMetricsBySubstackAuthor attributes a Substack article to its publication, read from the
+// link's subdomain (or custom domain)
+func updateMetricsBySubstackAuthor(metrics *schema.Metrics, article *ParsedArticle) {
+	if !strings.EqualFold(article.Category, SubstackProvider) || article.Domain == "" {
+		return
+	}
+	if metrics.Substack == nil {
+		metrics.Substack = &schema.SubstackStats{}
+	}
+	if metrics.Substack.ByAuthor == nil {
+		metrics.Substack.ByAuthor = make(map[string][2]int)
+	}
+	metrics.Substack.ByAuthor[article.Domain] = addReadStatus(metrics.Substack.ByAuthor[article.Domain], article.IsRead)
+}
+
+// seedSubstackAuthors records the Substack feeds of the providers sheet, listing every feed's
+// publication even before any of its articles are saved
+func seedSubstackAuthors(metrics *schema.Metrics, feedURLs []string) {
+	if len(feedURLs) == 0 && metrics.Substack == nil {
+		return
+	}
+	if metrics.Substack == nil {
+		metrics.Substack = &schema.SubstackStats{}
+	}
+	if metrics.Substack.ByAuthor == nil {
+		metrics.Substack.ByAuthor = make(map[string][2]int)
+	}
+
+	metrics.Substack.AuthorCount = len(feedURLs)
+	for _, feedURL := range feedURLs {
+		if publication := RegistrableDomain(feedURL); publication != "" {
+			metrics.Substack.ByAuthor[publication] = metrics.Substack.ByAuthor[publication]
+		}
+	}
+}
+
+// SubstackAuthorCount returns the number of Substack feeds behind a snapshot, falling back to the
+// count older snapshots stored inside BySourceReadStatus
+func SubstackAuthorCount(m schema.Metrics) int {
+	if m.Substack != nil {
+		return m.Substack.AuthorCount
+	}
+	return m.BySourceReadStatus[legacySubstackAuthorCountKey][0]
+}
+
+// AuthorCount is one Substack publication's read and unread totals
+type AuthorCount struct {
+	Author string
+	Read   int
+	Unread int
+}
+
+// Total returns the articles saved from the publication
+func (a AuthorCount) Total() int {
+	return a.Read + a.Unread
+}
+
+// RankAuthors orders publications by articles saved, then by read count, ties broken by name
+func RankAuthors(byAuthor map[string][2]int) []AuthorCount {
+	authors := make([]AuthorCount, 0, len(byAuthor))
+	for author, status := range byAuthor {
+		authors = append(authors, AuthorCount{Author: author, Read: status[0], Unread: status[1]})
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].Total() != authors[j].Total() {
+			return authors[i].Total() > authors[j].Total()
+		}
+		if authors[i].Read != authors[j].Read {
+			return authors[i].Read > authors[j].Read
+		}
+		return authors[i].Author < authors[j].Author
+	})
+	return authors
+}
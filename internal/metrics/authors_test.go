@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestComputeMetricsSubstackAuthors(t *testing.T) {
+	articleRows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2025-01-01", "A", "https://one.substack.com/p/a", "Substack", "TRUE"},
+		{"2025-01-02", "B", "https://one.substack.com/p/b", "substack", "FALSE"},
+		{"2025-01-03", "C", "https://newsletter.custom.dev/p/c", "Substack", "TRUE"},
+		{"2025-01-04", "D", "https://github.blog/d", "GitHub", "TRUE"},
+	}
+	providerRows := [][]interface{}{
+		{"Name", "URL"},
+		{"Substack", "https://one.substack.com/feed"},
+		{"Substack", "https://quiet.substack.com/feed"},
+		{"GitHub", "https://github.blog/feed"},
+	}
+
+	m, err := ComputeMetrics(articleRows, providerRows, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ComputeMetrics() error = %v", err)
+	}
+	if m.Substack == nil || m.Substack.AuthorCount != 2 {
+		t.Fatalf("expected 2 Substack authors, got %+v", m.Substack)
+	}
+
+	expected := map[string][2]int{
+		"one.substack.com":   {1, 1},
+		"quiet.substack.com": {0, 0},
+		"custom.dev":         {1, 0},
+	}
+	if len(m.Substack.ByAuthor) != len(expected) {
+		t.Fatalf("ByAuthor = %v, want %v", m.Substack.ByAuthor, expected)
+	}
+	for author, want := range expected {
+		if got, ok := m.Substack.ByAuthor[author]; !ok || got != want {
+			t.Errorf("ByAuthor[%s] = %v, want %v", author, got, want)
+		}
+	}
+	if _, exists := m.BySourceReadStatus[legacySubstackAuthorCountKey]; exists {
+		t.Errorf("the author count should no longer be stored in by_source_read_status")
+	}
+}
+
+func TestSubstackAuthorCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		metrics  schema.Metrics
+		expected int
+	}{
+		{"substack stats", schema.Metrics{Substack: &schema.SubstackStats{AuthorCount: 4}}, 4},
+		{"legacy snapshot", schema.Metrics{BySourceReadStatus: map[string][2]int{legacySubstackAuthorCountKey: {6, 0}}}, 6},
+		{"no substack", schema.Metrics{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SubstackAuthorCount(tt.metrics); got != tt.expected {
+				t.Errorf("SubstackAuthorCount() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRankAuthors(t *testing.T) {
+	authors := RankAuthors(map[string][2]int{"b.substack.com": {1, 1}, "a.substack.com": {0, 2}, "c.substack.com": {3, 0}})
+	want := []string{"c.substack.com", "b.substack.com", "a.substack.com"}
+	for i, author := range authors {
+		if author.Author != want[i] {
+			t.Fatalf("RankAuthors() order = %v, want %v", authors, want)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/googleauth"
+)
+
+// Backfill intervals supported by BackfillDates
+const (
+	IntervalWeekly  = "weekly"
+	IntervalMonthly = "monthly"
+)
+
+// FetchSheetRows retrieves the raw article and provider rows (header rows included) from Google Sheets
+func FetchSheetRows(ctx context.Context, spreadsheetID, credentialsPath string) ([][]interface{}, [][]interface{}, error) {
+	auth, err := googleauth.ClientOption(ctx, credentialsPath, sheets.SpreadsheetsReadonlyScope)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := sheets.NewService(ctx, auth)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create sheets client: %w", err)
+	}
+
+	return fetchSheetRowsWithFetcher(spreadsheetID, &SheetServiceFetcher{service: client})
+}
+
+// fetchSheetRowsWithFetcher reads article and provider rows with a pluggable sheet fetcher for testability
+func fetchSheetRowsWithFetcher(spreadsheetID string, fetcher SheetsFetcher) ([][]interface{}, [][]interface{}, error) {
+	spreadsheet, err := fetcher.GetSpreadsheet(spreadsheetID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to retrieve spreadsheet: %w", err)
+	}
+
+	articlesSheet, providersSheet := findSheetNames(spreadsheet)
+
+	providerRows, err := fetcher.GetProvidersSheet(spreadsheetID, providersSheet)
+	if err != nil {
+		slog.Warn("Unable to read providers sheet", "err", err)
+	}
+
+	articleRows, err := fetcher.GetArticleRows(spreadsheetID, articlesSheet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to retrieve data from sheet: %w", err)
+	}
+
+	return articleRows, providerRows, nil
+}
+
+// BackfillDates returns the snapshot dates between since and until (inclusive), oldest first.
+// Dates are stepped backwards from until so the newest backfilled snapshot lines up with it.
+func BackfillDates(since, until time.Time, interval string) ([]time.Time, error) {
+	if until.Before(since) {
+		return nil, fmt.Errorf("until date %s is before since date %s", until.Format("2006-01-02"), since.Format("2006-01-02"))
+	}
+
+	// Each date is computed from until rather than the previous date, so month-end clamping
+	// (May 31 to Apr 30) does not drift the later dates
+	var nth func(k int) time.Time
+	switch interval {
+	case IntervalWeekly:
+		nth = func(k int) time.Time { return until.AddDate(0, 0, -7*k) }
+	case IntervalMonthly:
+		nth = func(k int) time.Time { return AddMonths(until, -k) }
+	default:
+		return nil, fmt.Errorf("unsupported backfill interval %q (expected %q or %q)", interval, IntervalWeekly, IntervalMonthly)
+	}
+
+	var dates []time.Time
+	for k := 0; !nth(k).Before(since); k++ {
+		dates = append([]time.Time{nth(k)}, dates...)
+	}
+
+	return dates, nil
+}
+
+// AddMonths adds months to t, clamping the day to the last day of the resulting month rather
+// than overflowing into the next one as time.AddDate does
+func AddMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()).AddDate(0, months, 0)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// FilterRowsUntil keeps the header row plus every article row dated on or before asOf.
+// Rows with unparseable dates are dropped since they cannot be placed in time.
+func FilterRowsUntil(rows [][]interface{}, asOf time.Time) [][]interface{} {
+	if len(rows) == 0 {
+		return rows
+	}
+
+	filtered := [][]interface{}{rows[0]}
+	for _, row := range rows[1:] {
+		if len(row) <= ColDate {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", fmt.Sprintf("%v", row[ColDate]))
+		if err != nil || date.After(asOf) {
+			continue
+		}
+		filtered = append(filtered, row)
+	}
+
+	return filtered
+}
+
+// EarliestArticleDate returns the oldest valid article date in rows, or the zero time when none parse
+func EarliestArticleDate(rows [][]interface{}) time.Time {
+	var earliest time.Time
+	for i := 1; i < len(rows); i++ {
+		if len(rows[i]) <= ColDate {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", fmt.Sprintf("%v", rows[i][ColDate]))
+		if err != nil {
+			continue
+		}
+		if earliest.IsZero() || date.Before(earliest) {
+			earliest = date
+		}
+	}
+	return earliest
+}
+
+// BackfillSnapshot synthesizes the snapshot the collector would have produced on asOf.
+// Read status cannot be reconstructed from the sheet, so every article keeps its current status.
+func BackfillSnapshot(articleRows, providerRows [][]interface{}, asOf time.Time, opts ComputeOptions) (schema.Metrics, error) {
+	rows := FilterRowsUntil(articleRows, asOf)
+	if len(rows) <= 1 {
+		return schema.Metrics{}, fmt.Errorf("no articles dated on or before %s", asOf.Format("2006-01-02"))
+	}
+	return ComputeMetricsWithOptions(rows, providerRows, asOf, opts)
+}
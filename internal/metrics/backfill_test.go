@@ -0,0 +1,191 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// ============================================================================
+// BackfillDates: Generates past snapshot dates for a given interval
+// ============================================================================
+
+func TestBackfillDates(t *testing.T) {
+	tests := []struct {
+		name        string
+		since       string
+		until       string
+		interval    string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "weekly steps back from until",
+			since:    "2025-01-01",
+			until:    "2025-01-24",
+			interval: IntervalWeekly,
+			expected: []string{"2025-01-03", "2025-01-10", "2025-01-17", "2025-01-24"},
+		},
+		{
+			name:     "monthly steps back from until",
+			since:    "2025-01-01",
+			until:    "2025-03-15",
+			interval: IntervalMonthly,
+			expected: []string{"2025-01-15", "2025-02-15", "2025-03-15"},
+		},
+		{
+			name:     "monthly from a 31st keeps month ends",
+			since:    "2025-01-01",
+			until:    "2025-05-31",
+			interval: IntervalMonthly,
+			expected: []string{"2025-01-31", "2025-02-28", "2025-03-31", "2025-04-30", "2025-05-31"},
+		},
+		{
+			name:     "single date when since equals until",
+			since:    "2025-01-01",
+			until:    "2025-01-01",
+			interval: IntervalWeekly,
+			expected: []string{"2025-01-01"},
+		},
+		{
+			name:        "until before since",
+			since:       "2025-02-01",
+			until:       "2025-01-01",
+			interval:    IntervalWeekly,
+			expectError: true,
+		},
+		{
+			name:        "unsupported interval",
+			since:       "2025-01-01",
+			until:       "2025-02-01",
+			interval:    "daily",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			since, _ := time.Parse("2006-01-02", tt.since)
+			until, _ := time.Parse("2006-01-02", tt.until)
+
+			dates, err := BackfillDates(since, until, tt.interval)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("BackfillDates() error = %v, expectError %v", err, tt.expectError)
+			}
+			if len(dates) != len(tt.expected) {
+				t.Fatalf("expected %d dates, got %d: %v", len(tt.expected), len(dates), dates)
+			}
+			for i, expected := range tt.expected {
+				if got := dates[i].Format("2006-01-02"); got != expected {
+					t.Errorf("dates[%d] = %s, want %s", i, got, expected)
+				}
+			}
+		})
+	}
+}
+
+// ============================================================================
+// FilterRowsUntil / EarliestArticleDate: Place article rows in time
+// ============================================================================
+
+func TestFilterRowsUntil(t *testing.T) {
+	rows := createTestArticleRows()
+	rows = append(rows, []interface{}{"not-a-date", "Broken", "https://example.com/broken", "GitHub", "FALSE"})
+	asOf := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	filtered := FilterRowsUntil(rows, asOf)
+
+	// Header + 2024-12-18, 2024-06-20, 2025-06-18
+	if len(filtered) != 4 {
+		t.Fatalf("expected 4 rows (header + 3 articles), got %d", len(filtered))
+	}
+	if filtered[0][0] != "Date" {
+		t.Errorf("expected header row to be preserved, got %v", filtered[0])
+	}
+	for _, row := range filtered[1:] {
+		date, _ := time.Parse("2006-01-02", fmt.Sprintf("%v", row[ColDate]))
+		if date.After(asOf) {
+			t.Errorf("row dated %v should have been filtered out", row[ColDate])
+		}
+	}
+
+	if got := FilterRowsUntil(nil, asOf); len(got) != 0 {
+		t.Errorf("expected empty result for nil rows, got %v", got)
+	}
+}
+
+func TestEarliestArticleDate(t *testing.T) {
+	got := EarliestArticleDate(createTestArticleRows())
+	if got.Format("2006-01-02") != "2024-06-20" {
+		t.Errorf("EarliestArticleDate() = %s, want 2024-06-20", got.Format("2006-01-02"))
+	}
+
+	if got := EarliestArticleDate([][]interface{}{{"Date"}}); !got.IsZero() {
+		t.Errorf("expected zero time for header-only rows, got %v", got)
+	}
+}
+
+// ============================================================================
+// BackfillSnapshot: Synthesizes a snapshot as of a past date
+// ============================================================================
+
+func TestBackfillSnapshot(t *testing.T) {
+	asOf := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	m, err := BackfillSnapshot(createTestArticleRows(), nil, asOf, ComputeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if m.TotalArticles != 3 {
+		t.Errorf("expected 3 articles as of %s, got %d", asOf.Format("2006-01-02"), m.TotalArticles)
+	}
+	if !m.LastUpdated.Equal(asOf) {
+		t.Errorf("expected LastUpdated %v, got %v", asOf, m.LastUpdated)
+	}
+	// 2025-06-18 is under a month old relative to asOf, not relative to today
+	if m.UnreadArticleAgeDistribution["less_than_1_month"] != 1 {
+		t.Errorf("expected ages measured against asOf, got %v", m.UnreadArticleAgeDistribution)
+	}
+
+	if _, err := BackfillSnapshot(createTestArticleRows(), nil, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), ComputeOptions{}); err == nil {
+		t.Error("expected error when no articles exist before asOf")
+	}
+}
+
+func TestFetchSheetRowsWithFetcher(t *testing.T) {
+	spreadsheet := &sheets.Spreadsheet{
+		Sheets: []*sheets.Sheet{{Properties: &sheets.SheetProperties{Title: "Articles"}}},
+	}
+
+	t.Run("returns article and provider rows", func(t *testing.T) {
+		fetcher := &MockSheetsFetcher{
+			spreadsheet:  spreadsheet,
+			articleRows:  createTestArticleRows(),
+			providerRows: [][]interface{}{{"Name"}, {"Substack"}},
+		}
+		articles, providers, err := fetchSheetRowsWithFetcher("sheet", fetcher)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(articles) != len(createTestArticleRows()) || len(providers) != 2 {
+			t.Errorf("unexpected row counts: %d articles, %d providers", len(articles), len(providers))
+		}
+	})
+
+	t.Run("article error is returned", func(t *testing.T) {
+		fetcher := &MockSheetsFetcher{spreadsheet: spreadsheet, articleErr: fmt.Errorf("boom")}
+		if _, _, err := fetchSheetRowsWithFetcher("sheet", fetcher); err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("spreadsheet error is returned", func(t *testing.T) {
+		fetcher := &MockSheetsFetcher{spreadsheetErr: fmt.Errorf("boom")}
+		if _, _, err := fetchSheetRowsWithFetcher("sheet", fetcher); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// Comparison is how the headline metrics of a snapshot changed since an earlier one
+type Comparison struct {
+	Since               time.Time // LastUpdated of the earlier snapshot
+	TotalArticles       int
+	ReadCount           int
+	UnreadCount         int
+	ReadRate            float64 // percentage points
+	AvgArticlesPerMonth float64
+}
+
+// Compare returns the change of the headline metrics from prev to latest
+func Compare(prev, latest schema.Metrics) Comparison {
+	return Comparison{
+		Since:               prev.LastUpdated,
+		TotalArticles:       latest.TotalArticles - prev.TotalArticles,
+		ReadCount:           latest.ReadCount - prev.ReadCount,
+		UnreadCount:         latest.UnreadCount - prev.UnreadCount,
+		ReadRate:            latest.ReadRate - prev.ReadRate,
+		AvgArticlesPerMonth: latest.AvgArticlesPerMonth - prev.AvgArticlesPerMonth,
+	}
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestCompare(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	prev := schema.Metrics{TotalArticles: 100, ReadCount: 60, UnreadCount: 40, ReadRate: 60, AvgArticlesPerMonth: 10, LastUpdated: since}
+	latest := schema.Metrics{TotalArticles: 105, ReadCount: 64, UnreadCount: 41, ReadRate: 60.95, AvgArticlesPerMonth: 9.5}
+
+	change := Compare(prev, latest)
+	if !change.Since.Equal(since) {
+		t.Errorf("expected the earlier snapshot's date, got %v", change.Since)
+	}
+	if change.TotalArticles != 5 || change.ReadCount != 4 || change.UnreadCount != 1 {
+		t.Errorf("unexpected count changes %+v", change)
+	}
+	if math.Abs(change.ReadRate-0.95) > 1e-9 || change.AvgArticlesPerMonth != -0.5 {
+		t.Errorf("unexpected rate changes %+v", change)
+	}
+}
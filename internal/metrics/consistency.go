@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// CheckConsistency verifies that a snapshot's totals agree with its breakdowns and returns one
+// message per violated invariant. An empty result means the snapshot is internally consistent.
+func CheckConsistency(m schema.Metrics) []string {
+	var violations []string
+	add := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	if m.ReadCount+m.UnreadCount != m.TotalArticles {
+		add("read_count (%d) + unread_count (%d) != total_articles (%d)", m.ReadCount, m.UnreadCount, m.TotalArticles)
+	}
+	if m.ReadUnreadTotals != ([2]int{}) && m.ReadUnreadTotals != [2]int{m.ReadCount, m.UnreadCount} {
+		add("read_unread_totals %v != [read_count, unread_count] [%d %d]", m.ReadUnreadTotals, m.ReadCount, m.UnreadCount)
+	}
+
+	// Every counted article has a valid date, so the date breakdowns cover all of them
+	if sum := sumInts(m.ByYear); len(m.ByYear) > 0 && sum != m.TotalArticles {
+		add("by_year sums to %d, total_articles is %d", sum, m.TotalArticles)
+	}
+	if sum := sumInts(m.ByMonth); len(m.ByMonth) > 0 && sum != m.TotalArticles {
+		add("by_month sums to %d, total_articles is %d", sum, m.TotalArticles)
+	}
+	for _, period := range []struct {
+		name   string
+		counts map[string][2]int
+	}{{"by_quarter", m.ByQuarter}, {"by_iso_week", m.ByISOWeek}, {"by_weekday", m.ByWeekday}} {
+		if sum := sumReadStatus(period.counts); len(period.counts) > 0 && sum != m.TotalArticles {
+			add("%s sums to %d, total_articles is %d", period.name, sum, m.TotalArticles)
+		}
+	}
+	for _, year := range sortedKeys(m.ByYearAndMonth) {
+		if sum := sumInts(m.ByYearAndMonth[year]); sum != m.ByYear[year] {
+			add("by_year_and_month[%s] sums to %d, by_year[%s] is %d", year, sum, year, m.ByYear[year])
+		}
+	}
+
+	// Articles without a source are left out of the source breakdowns, so those may fall short of the total
+	if sum := sumInts(m.BySource); sum > m.TotalArticles {
+		add("by_source sums to %d, more than total_articles (%d)", sum, m.TotalArticles)
+	}
+	monthSourceTotals := make(map[string]int)
+	for _, sources := range m.ByMonthAndSource {
+		for source, status := range sources {
+			monthSourceTotals[source] += status[0] + status[1]
+		}
+	}
+	for _, source := range sortedKeys(m.BySource) {
+		count := m.BySource[source]
+		if status, exists := m.BySourceReadStatus[source]; exists && status[0]+status[1] != count {
+			add("by_source_read_status[%s] sums to %d, by_source[%s] is %d", source, status[0]+status[1], source, count)
+		}
+		if len(m.ByMonthAndSource) > 0 && monthSourceTotals[source] != count {
+			add("by_month_and_source_read_status for %s sums to %d, by_source[%s] is %d", source, monthSourceTotals[source], source, count)
+		}
+	}
+
+	if sum := sumInts(m.UnreadByYear); len(m.UnreadByYear) > 0 && sum != m.UnreadCount {
+		add("unread_by_year sums to %d, unread_count is %d", sum, m.UnreadCount)
+	}
+	if sum := sumInts(m.UnreadByMonth); len(m.UnreadByMonth) > 0 && sum != m.UnreadCount {
+		add("unread_by_month sums to %d, unread_count is %d", sum, m.UnreadCount)
+	}
+	if sum := sumInts(m.UnreadBySource); sum > m.UnreadCount {
+		add("unread_by_source sums to %d, more than unread_count (%d)", sum, m.UnreadCount)
+	}
+
+	return violations
+}
+
+// ReportConsistency logs the invariant violations of a snapshot, if any, and returns how many were found
+func ReportConsistency(label string, m schema.Metrics) int {
+	violations := CheckConsistency(m)
+	if len(violations) == 0 {
+		return 0
+	}
+
+	slog.Warn("🩺 Diagnostics found consistency issues", "snapshot", label, "count", len(violations))
+	for _, violation := range violations {
+		slog.Warn("🩺 Consistency issue", "snapshot", label, "issue", violation)
+	}
+	return len(violations)
+}
+
+func sumInts(values map[string]int) int {
+	total := 0
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
+
+func sumReadStatus(values map[string][2]int) int {
+	total := 0
+	for _, status := range values {
+		total += status[0] + status[1]
+	}
+	return total
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func consistentMetrics() schema.Metrics {
+	return schema.Metrics{
+		TotalArticles:      3,
+		ReadCount:          1,
+		UnreadCount:        2,
+		ReadUnreadTotals:   [2]int{1, 2},
+		BySource:           map[string]int{"GitHub": 2, "Stripe": 1},
+		BySourceReadStatus: map[string][2]int{"GitHub": {1, 1}, "Stripe": {0, 1}, "substack_author_count": {4, 0}},
+		ByYear:             map[string]int{"2024": 2, "2025": 1},
+		ByMonth:            map[string]int{"01": 2, "02": 1},
+		ByYearAndMonth:     map[string]map[string]int{"2024": {"01": 2}, "2025": {"02": 1}},
+		ByMonthAndSource: map[string]map[string][2]int{
+			"01": {"GitHub": {1, 1}},
+			"02": {"Stripe": {0, 1}},
+		},
+		ByQuarter:      map[string][2]int{"2024-Q1": {1, 1}, "2025-Q1": {0, 1}},
+		ByISOWeek:      map[string][2]int{"2024-W01": {1, 0}, "2024-W02": {0, 1}, "2025-W06": {0, 1}},
+		UnreadByYear:   map[string]int{"2024": 1, "2025": 1},
+		UnreadByMonth:  map[string]int{"01": 1, "02": 1},
+		UnreadBySource: map[string]int{"GitHub": 1, "Stripe": 1},
+	}
+}
+
+func TestCheckConsistency(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(m *schema.Metrics)
+		expected []string
+	}{
+		{name: "consistent snapshot", mutate: func(m *schema.Metrics) {}},
+		{name: "empty snapshot", mutate: func(m *schema.Metrics) { *m = schema.Metrics{} }},
+		{
+			name:     "read and unread do not add up",
+			mutate:   func(m *schema.Metrics) { m.ReadCount = 2; m.ReadUnreadTotals = [2]int{2, 2} },
+			expected: []string{"read_count (2) + unread_count (2) != total_articles (3)"},
+		},
+		{
+			name:     "stale read_unread_totals",
+			mutate:   func(m *schema.Metrics) { m.ReadUnreadTotals = [2]int{2, 1} },
+			expected: []string{"read_unread_totals [2 1]"},
+		},
+		{
+			name: "month counted under the year key",
+			mutate: func(m *schema.Metrics) {
+				m.ByYear = map[string]int{"2024": 2, "01": 1}
+			},
+			expected: []string{"by_year_and_month[2025] sums to 1, by_year[2025] is 0"},
+		},
+		{
+			name:     "by_month misses an article",
+			mutate:   func(m *schema.Metrics) { m.ByMonth["02"] = 0 },
+			expected: []string{"by_month sums to 2, total_articles is 3"},
+		},
+		{
+			name:     "quarters miss an article",
+			mutate:   func(m *schema.Metrics) { delete(m.ByQuarter, "2025-Q1") },
+			expected: []string{"by_quarter sums to 2, total_articles is 3"},
+		},
+		{
+			name: "monthly source breakdown disagrees with by_source",
+			mutate: func(m *schema.Metrics) {
+				m.ByMonthAndSource["02"]["Stripe"] = [2]int{1, 1}
+			},
+			expected: []string{"by_month_and_source_read_status for Stripe sums to 2, by_source[Stripe] is 1"},
+		},
+		{
+			name:     "source read status disagrees with by_source",
+			mutate:   func(m *schema.Metrics) { m.BySourceReadStatus["GitHub"] = [2]int{2, 1} },
+			expected: []string{"by_source_read_status[GitHub] sums to 3, by_source[GitHub] is 2"},
+		},
+		{
+			name:     "unread breakdowns disagree with unread_count",
+			mutate:   func(m *schema.Metrics) { m.UnreadByYear["2025"] = 3; m.UnreadBySource["Stripe"] = 5 },
+			expected: []string{"unread_by_year sums to 4, unread_count is 2", "unread_by_source sums to 6, more than unread_count (2)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := consistentMetrics()
+			tt.mutate(&m)
+			violations := CheckConsistency(m)
+			if len(violations) != len(tt.expected) {
+				t.Fatalf("expected %d violations, got %v", len(tt.expected), violations)
+			}
+			for i, want := range tt.expected {
+				if !strings.Contains(violations[i], want) {
+					t.Errorf("violation %d = %q, want it to contain %q", i, violations[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestComputeMetricsIsConsistent(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-01-15", "A", "https://a.com/1", "github", "TRUE"},
+		{"2024-03-02", "B", "https://b.com/2", "Stripe", "FALSE"},
+		{"2025-01-20", "C", "https://c.com/3", "", "FALSE"},
+		{"bad-date", "D", "https://d.com/4", "Stripe", "FALSE"},
+	}
+
+	m, err := ComputeMetrics(rows, nil, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if violations := CheckConsistency(m); len(violations) != 0 {
+		t.Errorf("aggregated metrics violate invariants: %v", violations)
+	}
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// hostedPlatforms give each publication its own subdomain, so the subdomain is kept to tell
+// newsletters apart (one.substack.com and two.substack.com are different publications)
+var hostedPlatforms = []string{"substack.com", "medium.com", "ghost.io", "beehiiv.com", "hashnode.dev", "wordpress.com", "buttondown.email"}
+
+// RegistrableDomain returns the domain a link was registered under (eng.shopify.com becomes
+// shopify.com), keeping the publication subdomain on hosted newsletter platforms
+func RegistrableDomain(link string) string {
+	host := LinkDomain(link)
+	if host == "" {
+		return ""
+	}
+	for _, platform := range hostedPlatforms {
+		if prefix, found := strings.CutSuffix(host, "."+platform); found {
+			labels := strings.Split(prefix, ".")
+			return labels[len(labels)-1] + "." + platform
+		}
+	}
+	if domain, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return domain
+	}
+	return host
+}
+
+// updateMetricsByDomain tallies the read status of the article's registrable domain
+func updateMetricsByDomain(metrics *schema.Metrics, article *ParsedArticle) {
+	if article.Domain == "" {
+		return
+	}
+	if metrics.ByDomain == nil {
+		metrics.ByDomain = make(map[string][2]int)
+	}
+	metrics.ByDomain[article.Domain] = addReadStatus(metrics.ByDomain[article.Domain], article.IsRead)
+}
+
+// DomainCount is one domain's read and unread totals
+type DomainCount struct {
+	Domain string
+	Read   int
+	Unread int
+}
+
+// Total returns the articles saved from the domain
+func (d DomainCount) Total() int {
+	return d.Read + d.Unread
+}
+
+// TopDomains returns the n domains with the most articles, ties broken by name
+func TopDomains(byDomain map[string][2]int, n int) []DomainCount {
+	domains := make([]DomainCount, 0, len(byDomain))
+	for domain, status := range byDomain {
+		domains = append(domains, DomainCount{Domain: domain, Read: status[0], Unread: status[1]})
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		if domains[i].Total() != domains[j].Total() {
+			return domains[i].Total() > domains[j].Total()
+		}
+		return domains[i].Domain < domains[j].Domain
+	})
+	if len(domains) > n {
+		domains = domains[:n]
+	}
+	return domains
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRegistrableDomain(t *testing.T) {
+	tests := []struct {
+		link     string
+		expected string
+	}{
+		{"https://eng.shopify.com/blog/post", "shopify.com"},
+		{"https://www.bbc.co.uk/news/1", "bbc.co.uk"},
+		{"https://github.blog/2024/post", "github.blog"},
+		{"https://someone.github.io/post", "someone.github.io"},
+		{"https://newsletter.substack.com/p/post", "newsletter.substack.com"},
+		{"https://www.deep.newsletter.substack.com/p/post", "newsletter.substack.com"},
+		{"https://substack.com/@writer", "substack.com"},
+		{"http://localhost:8080/page", "localhost"},
+		{"not a link", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			if got := RegistrableDomain(tt.link); got != tt.expected {
+				t.Errorf("RegistrableDomain(%q) = %q, want %q", tt.link, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTopDomains(t *testing.T) {
+	byDomain := map[string][2]int{
+		"a.substack.com": {1, 4},
+		"b.substack.com": {1, 0},
+		"shopify.com":    {3, 2},
+		"stripe.com":     {0, 1},
+	}
+
+	top := TopDomains(byDomain, 3)
+	if fmt.Sprint(top) != "[{a.substack.com 1 4} {shopify.com 3 2} {b.substack.com 1 0}]" {
+		t.Errorf("unexpected top domains %v", top)
+	}
+	if len(TopDomains(nil, 5)) != 0 {
+		t.Error("expected no domains for an empty map")
+	}
+}
+
+func TestComputeMetricsByDomain(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2025-01-01", "One", "https://one.substack.com/p/a", "Substack", "TRUE"},
+		{"2025-01-02", "Two", "https://one.substack.com/p/b", "Substack", "FALSE"},
+		{"2025-01-03", "Three", "https://two.substack.com/p/c", "Substack", "FALSE"},
+		{"2025-01-04", "Paper", "doi:10.1000/xyz", "Papers", "TRUE"},
+		{"2025-01-05", "No link", "", "Notes", "TRUE"},
+	}
+
+	m, err := ComputeMetrics(rows, nil, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ComputeMetrics() error = %v", err)
+	}
+	expected := map[string][2]int{"one.substack.com": {1, 1}, "two.substack.com": {0, 1}, "doi.org": {1, 0}}
+	if fmt.Sprint(m.ByDomain) != fmt.Sprint(expected) {
+		t.Errorf("ByDomain = %v, want %v", m.ByDomain, expected)
+	}
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// ParseDurationMinutes reads a duration cell as whole minutes, rounding up. It accepts clock
+// durations (1:02:03 or 45:30), Go durations (1h20m, 45m) and bare minute counts (45); anything
+// else, including a blank cell, is zero.
+func ParseDurationMinutes(cell string) int {
+	cell = strings.TrimSpace(cell)
+	if cell == "" {
+		return 0
+	}
+
+	if minutes, err := strconv.ParseFloat(cell, 64); err == nil {
+		return roundUpMinutes(time.Duration(minutes * float64(time.Minute)))
+	}
+	if d, err := time.ParseDuration(cell); err == nil {
+		return roundUpMinutes(d)
+	}
+
+	// Clock durations: [hours:]minutes:seconds
+	parts := strings.Split(cell, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0
+	}
+	var seconds int
+	for _, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil || value < 0 {
+			return 0
+		}
+		seconds = seconds*60 + value
+	}
+	return roundUpMinutes(time.Duration(seconds) * time.Second)
+}
+
+func roundUpMinutes(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int((d + time.Minute - 1) / time.Minute)
+}
+
+// updateConsumption adds a video or podcast's duration to the watch/listen totals, by month saved
+// and by source for finished items and to the backlog for the rest
+func updateConsumption(metrics *schema.Metrics, article *ParsedArticle) {
+	if article.DurationMinutes == 0 || article.MediaType == MediaArticle {
+		return
+	}
+	if metrics.Consumption == nil {
+		metrics.Consumption = &schema.ConsumptionStats{
+			MinutesByMonth:  make(map[string]int),
+			MinutesBySource: make(map[string]int),
+		}
+	}
+
+	if !article.IsRead {
+		metrics.Consumption.BacklogMinutes += article.DurationMinutes
+		return
+	}
+	metrics.Consumption.TotalMinutes += article.DurationMinutes
+	if !article.Date.IsZero() {
+		metrics.Consumption.MinutesByMonth[article.Date.Format("2006-01")] += article.DurationMinutes
+	}
+	if article.Category != "" {
+		metrics.Consumption.MinutesBySource[article.Category] += article.DurationMinutes
+	}
+}
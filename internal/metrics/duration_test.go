@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDurationMinutes(t *testing.T) {
+	tests := []struct {
+		cell     string
+		expected int
+	}{
+		{"1:02:03", 63},
+		{"45:00", 45},
+		{"45:01", 46},
+		{"1h20m", 80},
+		{"90s", 2},
+		{"45", 45},
+		{"12.5", 13},
+		{" 30 ", 30},
+		{"", 0},
+		{"soon", 0},
+		{"1:xx", 0},
+		{"-10", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cell, func(t *testing.T) {
+			if got := ParseDurationMinutes(tt.cell); got != tt.expected {
+				t.Errorf("ParseDurationMinutes(%q) = %d, want %d", tt.cell, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComputeMetricsConsumption(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read", "Authors", "Notes", "Highlights", "Archive", "Media Type", "Duration"},
+		{"2025-01-05", "Talk", "https://youtu.be/a", "GitHub", "TRUE", "", "", "", "", "", "1:00:00"},
+		{"2025-02-05", "Episode", "https://overcast.fm/+b", "Stripe", "TRUE", "", "", "", "", "", "30m"},
+		{"2025-02-06", "Later", "https://youtu.be/c", "GitHub", "FALSE", "", "", "", "", "", "20"},
+		{"2025-02-07", "Article with a duration", "https://a.com/d", "GitHub", "TRUE", "", "", "", "", "", "15"},
+		{"2025-02-08", "Untimed talk", "https://youtu.be/e", "GitHub", "TRUE"},
+	}
+
+	m, err := ComputeMetrics(rows, nil, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ComputeMetrics() error = %v", err)
+	}
+	c := m.Consumption
+	if c == nil {
+		t.Fatal("expected consumption stats")
+	}
+	if c.TotalMinutes != 90 || c.BacklogMinutes != 20 {
+		t.Errorf("total %d / backlog %d minutes, want 90 / 20", c.TotalMinutes, c.BacklogMinutes)
+	}
+	if c.MinutesByMonth["2025-01"] != 60 || c.MinutesByMonth["2025-02"] != 30 {
+		t.Errorf("unexpected minutes by month %v", c.MinutesByMonth)
+	}
+	if c.MinutesBySource["GitHub"] != 60 || c.MinutesBySource["Stripe"] != 30 {
+		t.Errorf("unexpected minutes by source %v", c.MinutesBySource)
+	}
+
+	articlesOnly, err := ComputeMetrics(rows[:1], nil, time.Now())
+	if err != nil {
+		t.Fatalf("ComputeMetrics() error = %v", err)
+	}
+	if articlesOnly.Consumption != nil {
+		t.Errorf("expected no consumption stats without durations, got %+v", articlesOnly.Consumption)
+	}
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+// CalculateEnergyScore combines reads, source diversity, backlog change and streak
+// since the previous snapshot into a single weighted 0-100 score.
+// Without a previous snapshot there is no baseline, so only the neutral backlog signal contributes.
+func CalculateEnergyScore(curr schema.Metrics, prev *schema.Metrics, cfg config.EnergyConfig) schema.EnergyScore {
+	score := schema.EnergyScore{}
+
+	if prev != nil {
+		score.Reads = max(curr.ReadCount-prev.ReadCount, 0)
+		score.BacklogDelta = prev.UnreadCount - curr.UnreadCount
+
+		// Count sources whose read total grew since the previous snapshot
+		for name, counts := range curr.BySourceReadStatus {
+			if name == legacySubstackAuthorCountKey {
+				continue
+			}
+			if counts[0] > prev.BySourceReadStatus[name][0] {
+				score.Diversity++
+			}
+		}
+
+		if score.Reads > 0 {
+			score.Streak = 1
+			if prev.EnergyScore != nil {
+				score.Streak = prev.EnergyScore.Streak + 1
+			}
+		}
+	}
+
+	w := cfg.Weights
+	totalWeight := w.Reads + w.Diversity + w.Backlog + w.Streak
+	if totalWeight <= 0 {
+		return score
+	}
+
+	// The backlog component is centred on 50 so a growing backlog pulls the score down
+	backlog := 50 + 50*clamp(ratio(score.BacklogDelta, cfg.Targets.Backlog), -1, 1)
+
+	weighted := w.Reads*100*clamp(ratio(score.Reads, cfg.Targets.Reads), 0, 1) +
+		w.Diversity*100*clamp(ratio(score.Diversity, cfg.Targets.Diversity), 0, 1) +
+		w.Backlog*backlog +
+		w.Streak*100*clamp(ratio(score.Streak, cfg.Targets.Streak), 0, 1)
+
+	score.Score = math.Round(weighted/totalWeight*10) / 10
+	return score
+}
+
+// BuildEnergyHistory extracts dated energy scores from snapshots keyed by YYYY-MM-DD, sorted oldest first
+func BuildEnergyHistory(snapshots map[string]schema.Metrics) []schema.EnergyPoint {
+	var points []schema.EnergyPoint
+	for date, m := range snapshots {
+		if m.EnergyScore == nil {
+			continue
+		}
+		points = append(points, schema.EnergyPoint{Date: date, Score: m.EnergyScore.Score})
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Date < points[j].Date
+	})
+	return points
+}
+
+// ratio divides value by target, treating a non-positive target as a disabled component
+func ratio(value, target int) float64 {
+	if target <= 0 {
+		return 0
+	}
+	return float64(value) / float64(target)
+}
+
+// clamp limits v to the [lo, hi] range
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
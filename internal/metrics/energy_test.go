@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+// ============================================================================
+// CalculateEnergyScore: Combines weekly reading signals into one weighted score
+// ============================================================================
+
+func TestCalculateEnergyScore(t *testing.T) {
+	cfg := config.Default().Energy
+
+	tests := []struct {
+		name     string
+		curr     schema.Metrics
+		prev     *schema.Metrics
+		expected schema.EnergyScore
+	}{
+		{
+			name: "no previous snapshot only scores neutral backlog",
+			curr: schema.Metrics{ReadCount: 10, UnreadCount: 5},
+			prev: nil,
+			// backlog weight 0.2 * 50
+			expected: schema.EnergyScore{Score: 10},
+		},
+		{
+			name: "all targets met",
+			curr: schema.Metrics{
+				ReadCount:   40,
+				UnreadCount: 10,
+				BySourceReadStatus: map[string][2]int{
+					"GitHub":                {10, 0},
+					"Substack":              {20, 5},
+					"Stripe":                {10, 5},
+					"substack_author_count": {9, 0},
+				},
+			},
+			prev: &schema.Metrics{
+				ReadCount:   20,
+				UnreadCount: 20,
+				BySourceReadStatus: map[string][2]int{
+					"GitHub":   {5, 0},
+					"Substack": {10, 5},
+					"Stripe":   {5, 5},
+				},
+				EnergyScore: &schema.EnergyScore{Streak: 3},
+			},
+			expected: schema.EnergyScore{Score: 100, Reads: 20, Diversity: 3, BacklogDelta: 10, Streak: 4},
+		},
+		{
+			name: "growing backlog with no reads resets streak",
+			curr: schema.Metrics{ReadCount: 20, UnreadCount: 30},
+			prev: &schema.Metrics{
+				ReadCount:   20,
+				UnreadCount: 20,
+				EnergyScore: &schema.EnergyScore{Streak: 5},
+			},
+			expected: schema.EnergyScore{Score: 0, BacklogDelta: -10},
+		},
+		{
+			name: "first read starts a streak without previous score",
+			curr: schema.Metrics{ReadCount: 15, UnreadCount: 20},
+			prev: &schema.Metrics{ReadCount: 5, UnreadCount: 20},
+			// reads 0.4*50 + backlog 0.2*50 + streak 0.2*25
+			expected: schema.EnergyScore{Score: 35, Reads: 10, Streak: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateEnergyScore(tt.curr, tt.prev, cfg)
+			if got != tt.expected {
+				t.Errorf("CalculateEnergyScore() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCalculateEnergyScoreZeroWeights(t *testing.T) {
+	cfg := config.EnergyConfig{Targets: config.Default().Energy.Targets}
+	got := CalculateEnergyScore(schema.Metrics{ReadCount: 5}, &schema.Metrics{}, cfg)
+	if got.Score != 0 {
+		t.Errorf("expected score 0 with zero weights, got %v", got.Score)
+	}
+	if got.Reads != 5 {
+		t.Errorf("expected components to still be filled, got %+v", got)
+	}
+}
+
+func TestBuildEnergyHistory(t *testing.T) {
+	snapshots := map[string]schema.Metrics{
+		"2026-01-15": {EnergyScore: &schema.EnergyScore{Score: 60}},
+		"2026-01-01": {EnergyScore: &schema.EnergyScore{Score: 40}},
+		"2026-01-08": {},
+	}
+
+	points := BuildEnergyHistory(snapshots)
+	if len(points) != 2 {
+		t.Fatalf("expected 2 points, got %d", len(points))
+	}
+	if points[0].Date != "2026-01-01" || points[1].Date != "2026-01-15" {
+		t.Errorf("expected points sorted oldest first, got %+v", points)
+	}
+}
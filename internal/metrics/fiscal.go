@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// FiscalYear labels the year a date falls in when years start in startMonth (1-12). Calendar
+// years keep their plain label ("2025"); other years are named by the span they cover
+// ("2025-26" for September 2025 to August 2026 when startMonth is 9).
+func FiscalYear(date time.Time, startMonth int) string {
+	if startMonth <= 1 || startMonth > 12 {
+		return date.Format("2006")
+	}
+	startYear := date.Year()
+	if int(date.Month()) < startMonth {
+		startYear--
+	}
+	return fmt.Sprintf("%d-%02d", startYear, (startYear+1)%100)
+}
+
+// computeFiscalYears tallies the read status of dated articles per fiscal year. Calendar years
+// need no separate breakdown, so nothing is computed unless startMonth is 2-12.
+func computeFiscalYears(articles []schema.ArticleMeta, startMonth int) map[string][2]int {
+	if startMonth <= 1 || startMonth > 12 {
+		return nil
+	}
+
+	byFiscalYear := make(map[string][2]int)
+	for _, article := range articles {
+		date, err := time.Parse("2006-01-02", article.Date)
+		if err != nil {
+			continue
+		}
+		year := FiscalYear(date, startMonth)
+		byFiscalYear[year] = addReadStatus(byFiscalYear[year], article.Read)
+	}
+	return byFiscalYear
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFiscalYear(t *testing.T) {
+	tests := []struct {
+		name       string
+		date       string
+		startMonth int
+		want       string
+	}{
+		{"calendar year", "2025-03-01", 0, "2025"},
+		{"january start is the calendar year", "2025-03-01", 1, "2025"},
+		{"out of range falls back to calendar", "2025-03-01", 13, "2025"},
+		{"before the start month", "2025-08-31", 9, "2024-25"},
+		{"on the start month", "2025-09-01", 9, "2025-26"},
+		{"century rollover", "2099-10-01", 9, "2099-00"},
+		{"april start", "2026-03-31", 4, "2025-26"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, _ := time.Parse("2006-01-02", tt.date)
+			if got := FiscalYear(date, tt.startMonth); got != tt.want {
+				t.Errorf("FiscalYear(%s, %d) = %q, want %q", tt.date, tt.startMonth, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeMetricsFiscalYears(t *testing.T) {
+	articleRows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-09-15", "A", "https://a.com/1", "Substack", "TRUE"},
+		{"2025-08-20", "B", "https://a.com/2", "Substack", "FALSE"},
+		{"2025-09-01", "C", "https://a.com/3", "Substack", "TRUE"},
+	}
+	ref := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	calendar, err := ComputeMetrics(articleRows, nil, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calendar.ByFiscalYear != nil || calendar.YearStartMonth != 0 {
+		t.Errorf("expected no fiscal years by default, got %v", calendar.ByFiscalYear)
+	}
+
+	academic, err := ComputeMetricsWithOptions(articleRows, nil, ref, ComputeOptions{YearStartMonth: 9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if academic.ByFiscalYear["2024-25"] != [2]int{1, 1} || academic.ByFiscalYear["2025-26"] != [2]int{1, 0} {
+		t.Errorf("unexpected fiscal years %v", academic.ByFiscalYear)
+	}
+	if academic.YearStartMonth != 9 || academic.ByYear["2025"] != 2 {
+		t.Errorf("expected calendar years to be kept alongside fiscal years, got %v", academic.ByYear)
+	}
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"sort"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// BuildBacklogFlow compares consecutive snapshots and totals the articles added and read per month
+// (YYYY-MM of the later snapshot), since the sheet records no read dates. The earliest snapshot is
+// the baseline, so its articles are not counted as added.
+func BuildBacklogFlow(snapshots map[string]schema.Metrics) []schema.BacklogFlowPoint {
+	dates := make([]string, 0, len(snapshots))
+	for date := range snapshots {
+		if len(date) >= len("2006-01") {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+
+	var flow []schema.BacklogFlowPoint
+	for i := 1; i < len(dates); i++ {
+		prev, curr := snapshots[dates[i-1]], snapshots[dates[i]]
+		month := dates[i][:7]
+		if len(flow) == 0 || flow[len(flow)-1].Month != month {
+			flow = append(flow, schema.BacklogFlowPoint{Month: month})
+		}
+		p := &flow[len(flow)-1]
+		p.Added += curr.TotalArticles - prev.TotalArticles
+		p.Read += curr.ReadCount - prev.ReadCount
+		p.Net = p.Added - p.Read
+	}
+	return flow
+}
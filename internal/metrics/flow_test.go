@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestBuildBacklogFlow(t *testing.T) {
+	snapshots := map[string]schema.Metrics{
+		"2026-01-25": {TotalArticles: 100, ReadCount: 60},
+		"2026-02-01": {TotalArticles: 110, ReadCount: 62},
+		"2026-02-08": {TotalArticles: 115, ReadCount: 70},
+		"2026-03-01": {TotalArticles: 116, ReadCount: 80},
+	}
+
+	expected := []schema.BacklogFlowPoint{
+		{Month: "2026-02", Added: 15, Read: 10, Net: 5},
+		{Month: "2026-03", Added: 1, Read: 10, Net: -9},
+	}
+	if flow := BuildBacklogFlow(snapshots); !reflect.DeepEqual(flow, expected) {
+		t.Errorf("BuildBacklogFlow() = %+v, expected %+v", flow, expected)
+	}
+
+	if flow := BuildBacklogFlow(map[string]schema.Metrics{"2026-01-25": {TotalArticles: 100}}); flow != nil {
+		t.Errorf("expected no flow from a single snapshot, got %+v", flow)
+	}
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+// Fuzz targets run their seed corpus with go test; explore further with e.g.
+// go test ./internal/metrics/ -run '^$' -fuzz FuzzParseArticleRow -fuzztime 30s
+
+func FuzzParseArticleRow(f *testing.F) {
+	f.Add("2024-01-15", "Title", "https://github.blog/post", "github", "TRUE", "Jane; John")
+	f.Add("", "", "", "", "", "")
+	f.Add("2024-02-30", "Bad day", "doi:10.1000/xyz", "Papers", "false", "")
+	f.Add("15/01/2024", "Other layout", "isbn 978-0-13-468599-1", "Books", "yes", ",,;")
+	f.Add("2024-01-15T10:00:00Z", "\x00", "::not a url", "SUBSTACK", "TRUE", "\ufeff")
+
+	f.Fuzz(func(t *testing.T, date, title, link, category, read, authors string) {
+		row := []interface{}{date, title, link, category, read, authors}
+		sourceMap := BuildSourceMap(nil)
+
+		article, err := parseArticleRow(row, sourceMap)
+		if err == nil {
+			if _, parseErr := time.Parse("2006-01-02", date); parseErr != nil {
+				t.Fatalf("accepted invalid date %q", date)
+			}
+			if article.Date.Format("2006-01-02") != date {
+				t.Fatalf("date %q parsed as %v", date, article.Date)
+			}
+		}
+
+		detail, err := parseArticleRowWithDetails(row, sourceMap)
+		if err == nil && detail.Date != date {
+			t.Fatalf("detail date %q, want %q", detail.Date, date)
+		}
+
+		// Aggregating the row must never panic and must stay internally consistent
+		m, err := ComputeMetrics([][]interface{}{{"Date", "Title", "Link", "Category", "Read"}, row, row[:3]}, nil, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatalf("ComputeMetrics failed: %v", err)
+		}
+		if violations := CheckConsistency(m); len(violations) != 0 {
+			t.Fatalf("invariants violated for row %q: %v", row, violations)
+		}
+	})
+}
+
+func FuzzLoadSnapshot(f *testing.F) {
+	f.Add([]byte(`{"total_articles":2,"read_count":1,"unread_count":1,"by_source":{"GitHub":2},"by_year":{"2024":2}}`))
+	f.Add([]byte(`{}`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`{"by_year_and_month":{"2024":null},"by_month_and_source_read_status":{"01":null}}`))
+	f.Add([]byte(`{"top_oldest_unread_articles":[{"date":"not-a-date"}],"oldest_unread_article":null}`))
+	f.Add([]byte(`{"total_articles":-5,"read_unread_totals":[1],"last_updated":"2024-01-01T00:00:00Z"}`))
+	f.Add([]byte(`{"energy_score":{"score":1e308},"source_metadata":{"":{"added":""}}}`))
+	if files, err := ListSnapshotFiles(filepath.Join("..", "..", "metrics")); err == nil && len(files) > 0 {
+		if latest, err := os.ReadFile(filepath.Join("..", "..", "metrics", files[len(files)-1])); err == nil {
+			f.Add(latest)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "2025-01-01.json"), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		m, err := LoadSnapshot(dir, "2025-01-01.json")
+		if err != nil {
+			return
+		}
+
+		// Everything run on a loaded snapshot must tolerate missing and nonsensical fields
+		CheckConsistency(*m)
+		ApplySourceAliases(m, map[string]string{"github": "GitHub", "": "Empty"})
+		CalculateEnergyScore(*m, m, config.EnergyConfig{})
+		BuildEnergyHistory(map[string]schema.Metrics{"2025-01-01": *m})
+		CalculateTopReadRateSource(*m)
+		CalculateMostUnreadSource(*m)
+		CalculateThisMonthArticles(*m, "01")
+	})
+}
@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// Media types an article row can be tagged with in Column J
+const (
+	MediaArticle = "article"
+	MediaVideo   = "video"
+	MediaPodcast = "podcast"
+)
+
+// mediaHosts infer the media type of untagged rows from where the link points
+var mediaHosts = map[string]string{
+	"youtube.com":        MediaVideo,
+	"youtu.be":           MediaVideo,
+	"vimeo.com":          MediaVideo,
+	"podcasts.apple.com": MediaPodcast,
+	"overcast.fm":        MediaPodcast,
+	"pca.st":             MediaPodcast,
+	"pocketcasts.com":    MediaPodcast,
+}
+
+// MediaTypeOf returns the normalized media type of a row: the tagged type when it is one of
+// article/video/podcast, otherwise one inferred from the link (articles by default)
+func MediaTypeOf(tagged, link string) string {
+	switch tagged = strings.ToLower(strings.TrimSpace(tagged)); tagged {
+	case MediaArticle, MediaVideo, MediaPodcast:
+		return tagged
+	}
+
+	host := LinkDomain(link)
+	for suffix, mediaType := range mediaHosts {
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return mediaType
+		}
+	}
+	if host == "open.spotify.com" && strings.Contains(link, "/episode/") {
+		return MediaPodcast
+	}
+	return MediaArticle
+}
+
+// rowMediaType reads the media type of a sheet row from Columns J and C
+func rowMediaType(row []interface{}) string {
+	var tagged, link string
+	if len(row) > ColMediaType {
+		tagged = fmt.Sprintf("%v", row[ColMediaType])
+	}
+	if len(row) > ColLink {
+		link = fmt.Sprintf("%v", row[ColLink])
+	}
+	return MediaTypeOf(tagged, link)
+}
+
+// updateMetricsByMediaType tallies the read status of the article's media type
+func updateMetricsByMediaType(metrics *schema.Metrics, article *ParsedArticle) {
+	if metrics.ByMediaType == nil {
+		metrics.ByMediaType = make(map[string][2]int)
+	}
+	metrics.ByMediaType[article.MediaType] = addReadStatus(metrics.ByMediaType[article.MediaType], article.IsRead)
+}
+
+// computeMediaTypes aggregates each media type's rows into its own snapshot so every chart can be
+// filtered by type. Nothing is computed when the sheet holds a single media type.
+func computeMediaTypes(articleRows, providerRows [][]interface{}, referenceDate time.Time, opts ComputeOptions) (map[string]schema.Metrics, error) {
+	if len(articleRows) < 2 {
+		return nil, nil
+	}
+
+	byType := make(map[string][][]interface{})
+	for _, row := range articleRows[1:] {
+		mediaType := rowMediaType(row)
+		if byType[mediaType] == nil {
+			byType[mediaType] = [][]interface{}{articleRows[0]}
+		}
+		byType[mediaType] = append(byType[mediaType], row)
+	}
+	if len(byType) < 2 {
+		return nil, nil
+	}
+
+	mediaTypes := make(map[string]schema.Metrics, len(byType))
+	for mediaType, rows := range byType {
+		typeMetrics, err := ComputeMetricsWithOptions(rows, providerRows, referenceDate, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute %s metrics: %w", mediaType, err)
+		}
+		// Source metadata and providers are shared with the parent snapshot
+		typeMetrics.SourceMetadata = nil
+		typeMetrics.Providers = nil
+		mediaTypes[mediaType] = typeMetrics
+	}
+	return mediaTypes, nil
+}
+
+// MediaTypeNames returns the media types of a snapshot, articles first then alphabetically
+func MediaTypeNames(byMediaType map[string][2]int) []string {
+	names := make([]string, 0, len(byMediaType))
+	for name := range byMediaType {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == MediaArticle) != (names[j] == MediaArticle) {
+			return names[i] == MediaArticle
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMediaTypeOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		tagged   string
+		link     string
+		expected string
+	}{
+		{"tagged type wins over link", "Podcast", "https://youtube.com/watch?v=1", MediaPodcast},
+		{"youtube link", "", "https://www.youtube.com/watch?v=1", MediaVideo},
+		{"short youtube link", "", "https://youtu.be/abc", MediaVideo},
+		{"vimeo link", "", "https://vimeo.com/123", MediaVideo},
+		{"apple podcasts link", "", "https://podcasts.apple.com/us/podcast/x/id1", MediaPodcast},
+		{"spotify episode", "", "https://open.spotify.com/episode/abc", MediaPodcast},
+		{"spotify track is not a podcast", "", "https://open.spotify.com/track/abc", MediaArticle},
+		{"unknown tag falls back to link", "book", "https://overcast.fm/+abc", MediaPodcast},
+		{"plain article", "", "https://eng.shopify.com/post", MediaArticle},
+		{"no link", "", "", MediaArticle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MediaTypeOf(tt.tagged, tt.link); got != tt.expected {
+				t.Errorf("MediaTypeOf(%q, %q) = %q, want %q", tt.tagged, tt.link, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComputeMetricsByMediaType(t *testing.T) {
+	header := []interface{}{"Date", "Title", "Link", "Category", "Read", "Authors", "Notes", "Highlights", "Archive", "Media Type"}
+	ref := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		rows          [][]interface{}
+		expectedTypes map[string][2]int
+		expectSplit   bool
+	}{
+		{
+			name: "articles only are not split",
+			rows: [][]interface{}{
+				header,
+				{"2025-01-01", "A", "https://a.com/1", "GitHub", "TRUE"},
+				{"2025-01-02", "B", "https://a.com/2", "GitHub", "FALSE"},
+			},
+			expectedTypes: map[string][2]int{MediaArticle: {1, 1}},
+		},
+		{
+			name: "mixed media are split per type",
+			rows: [][]interface{}{
+				header,
+				{"2025-01-01", "A", "https://a.com/1", "GitHub", "TRUE"},
+				{"2025-01-02", "Talk", "https://youtu.be/x", "GitHub", "FALSE"},
+				{"2025-02-03", "Episode", "https://example.fm/1", "GitHub", "TRUE", "", "", "", "", "podcast"},
+			},
+			expectedTypes: map[string][2]int{MediaArticle: {1, 0}, MediaVideo: {0, 1}, MediaPodcast: {1, 0}},
+			expectSplit:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ComputeMetrics(tt.rows, nil, ref)
+			if err != nil {
+				t.Fatalf("ComputeMetrics() error = %v", err)
+			}
+			if len(m.ByMediaType) != len(tt.expectedTypes) {
+				t.Fatalf("ByMediaType = %v, want %v", m.ByMediaType, tt.expectedTypes)
+			}
+			for mediaType, want := range tt.expectedTypes {
+				if got := m.ByMediaType[mediaType]; got != want {
+					t.Errorf("ByMediaType[%s] = %v, want %v", mediaType, got, want)
+				}
+			}
+
+			if !tt.expectSplit {
+				if m.MediaTypes != nil {
+					t.Errorf("expected no per-type snapshots, got %d", len(m.MediaTypes))
+				}
+				return
+			}
+			if len(m.MediaTypes) != len(tt.expectedTypes) {
+				t.Fatalf("expected %d per-type snapshots, got %d", len(tt.expectedTypes), len(m.MediaTypes))
+			}
+			for mediaType, want := range tt.expectedTypes {
+				sub := m.MediaTypes[mediaType]
+				if sub.TotalArticles != want[0]+want[1] || sub.MediaTypes != nil || sub.SourceMetadata != nil {
+					t.Errorf("unexpected %s snapshot: total %d, nested %v, metadata %v", mediaType, sub.TotalArticles, sub.MediaTypes, sub.SourceMetadata)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaTypeNames(t *testing.T) {
+	names := MediaTypeNames(map[string][2]int{MediaVideo: {1, 0}, MediaPodcast: {0, 1}, MediaArticle: {2, 2}})
+	want := []string{MediaArticle, MediaPodcast, MediaVideo}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("MediaTypeNames() = %v, want %v", names, want)
+		}
+	}
+}
@@ -3,15 +3,16 @@ package metrics
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"sort"
 	"strings"
 	"time"
 
-	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
 
 	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/googleauth"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
 )
 
 // SheetsClient interface for dependency injection in testing
@@ -32,11 +33,18 @@ func (s *SheetsServiceClient) GetValues(spreadsheetID, readRange string) (*sheet
 // Constants for Google Sheets column indices
 const (
 	// Column indices in the Articles sheet
-	ColDate     = 0 // Column A: date (YYYY-MM-DD format)
-	ColTitle    = 1 // Column B: article title
-	ColLink     = 2 // Column C: article link
-	ColCategory = 3 // Column D: source/category
-	ColRead     = 4 // Column E: read status (TRUE/FALSE)
+	ColDate       = 0  // Column A: date (YYYY-MM-DD format)
+	ColTitle      = 1  // Column B: article title
+	ColLink       = 2  // Column C: article link
+	ColCategory   = 3  // Column D: source/category
+	ColRead       = 4  // Column E: read status (TRUE/FALSE)
+	ColAuthors    = 5  // Column F: optional authors, separated by semicolons
+	ColNotes      = 6  // Column G: optional notes
+	ColHighlights = 7  // Column H: optional highlights, one per line
+	ColArchive    = 8  // Column I: optional Wayback Machine capture of the link
+	ColMediaType  = 9  // Column J: optional media type (article/video/podcast), inferred from the link when blank
+	ColDuration   = 10 // Column K: optional duration of a video or podcast (1:02:03, 45:00, 1h20m or minutes)
+	ColTopic      = 11 // Column L: optional topic the article is categorized under instead of its source
 
 	// Sheet names
 	DefaultArticlesSheet  = "articles"
@@ -75,7 +83,7 @@ func countSubstackProviders(client SheetsClient, spreadsheetID, providersSheet s
 	resp, err := client.GetValues(spreadsheetID, readRange)
 	if err != nil {
 		// Log error but don't fail - provider counting is optional
-		log.Printf("Warning: Unable to read providers sheet: %v\n", err)
+		slog.Warn("Unable to read providers sheet", "err", err)
 		return 0, nil
 	}
 
@@ -146,9 +154,14 @@ func NormalizeSourceName(name string, sourceMap map[string]string) string {
 
 // ParsedArticle represents parsed data from a single article row
 type ParsedArticle struct {
-	Date     time.Time
-	Category string // normalized source name
-	IsRead   bool
+	Date      time.Time
+	Category  string // normalized source name
+	Domain    string // registrable domain of the link
+	MediaType string // article, video or podcast
+	Topic     string // optional topic, the category instead of the source
+	IsRead    bool
+
+	DurationMinutes int // watch/listen time of a video or podcast, 0 when unknown
 }
 
 // parseArticleRow extracts relevant data from a single article row
@@ -169,6 +182,11 @@ func parseArticleRow(row []interface{}, sourceMap map[string]string) (*ParsedArt
 		article.Date = parsedTime
 	}
 
+	// Parse link domain (Column C); DOI and ISBN identifiers count under their resolver
+	if len(row) > ColLink {
+		article.Domain = RegistrableDomain(identity.Parse(fmt.Sprintf("%v", row[ColLink])).URL())
+	}
+
 	// Parse category/source (Column D)
 	if len(row) > ColCategory {
 		article.Category = NormalizeSourceName(fmt.Sprintf("%v", row[ColCategory]), sourceMap)
@@ -180,6 +198,17 @@ func parseArticleRow(row []interface{}, sourceMap map[string]string) (*ParsedArt
 		article.IsRead = (readStatus == "TRUE" || readStatus == "true")
 	}
 
+	// Parse media type (Column J)
+	article.MediaType = rowMediaType(row)
+
+	// Parse optional duration (Column K)
+	if len(row) > ColDuration {
+		article.DurationMinutes = ParseDurationMinutes(fmt.Sprintf("%v", row[ColDuration]))
+	}
+
+	// Parse optional topic (Column L)
+	article.Topic = rowTopic(row)
+
 	return article, nil
 }
 
@@ -201,9 +230,9 @@ func parseArticleRowWithDetails(row []interface{}, sourceMap map[string]string)
 		article.Title = fmt.Sprintf("%v", row[ColTitle])
 	}
 
-	// Parse link (Column C)
+	// Parse link (Column C); DOI and ISBN identifiers become resolvable links
 	if len(row) > ColLink {
-		article.Link = fmt.Sprintf("%v", row[ColLink])
+		article.Link = identity.Parse(fmt.Sprintf("%v", row[ColLink])).URL()
 	}
 
 	// Parse category/source (Column D)
@@ -217,9 +246,90 @@ func parseArticleRowWithDetails(row []interface{}, sourceMap map[string]string)
 		article.Read = (readStatus == "TRUE" || readStatus == "true")
 	}
 
+	// Parse optional authors (Column F)
+	if len(row) > ColAuthors {
+		article.Authors = SplitAuthors(fmt.Sprintf("%v", row[ColAuthors]))
+	}
+
+	// Parse optional notes and highlights (Columns G and H)
+	if len(row) > ColNotes {
+		article.Notes = strings.TrimSpace(fmt.Sprintf("%v", row[ColNotes]))
+	}
+	if len(row) > ColHighlights {
+		article.Highlights = SplitHighlights(fmt.Sprintf("%v", row[ColHighlights]))
+	}
+
+	// Parse optional archive URL (Column I)
+	if len(row) > ColArchive {
+		article.ArchiveURL = strings.TrimSpace(fmt.Sprintf("%v", row[ColArchive]))
+	}
+
+	// Parse media type (Column J)
+	article.MediaType = rowMediaType(row)
+
+	// Parse optional duration (Column K)
+	if len(row) > ColDuration {
+		article.DurationMinutes = ParseDurationMinutes(fmt.Sprintf("%v", row[ColDuration]))
+	}
+
+	// Parse optional topic (Column L)
+	article.Topic = rowTopic(row)
+
 	return article, nil
 }
 
+// rowTopic returns the trimmed topic of an article row, empty when the column is missing
+func rowTopic(row []interface{}) string {
+	if len(row) <= ColTopic {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprintf("%v", row[ColTopic]))
+}
+
+// ArticleCategory returns the topic an article is categorized under, falling back to its source
+func ArticleCategory(article schema.ArticleMeta) string {
+	if article.Topic != "" {
+		return article.Topic
+	}
+	return article.Category
+}
+
+// SplitAuthors splits a semicolon-separated authors cell, dropping blanks
+func SplitAuthors(cell string) []string {
+	var authors []string
+	for _, author := range strings.Split(cell, ";") {
+		if author = strings.TrimSpace(author); author != "" {
+			authors = append(authors, author)
+		}
+	}
+	return authors
+}
+
+// SplitHighlights splits a highlights cell into one highlight per line, dropping blanks
+func SplitHighlights(cell string) []string {
+	var highlights []string
+	for _, highlight := range strings.Split(cell, "\n") {
+		if highlight = strings.TrimSpace(highlight); highlight != "" {
+			highlights = append(highlights, highlight)
+		}
+	}
+	return highlights
+}
+
+// ParseArticles converts article rows (header row included) into ArticleMeta, skipping incomplete or invalid rows
+func ParseArticles(rows [][]interface{}, sourceMap map[string]string) []schema.ArticleMeta {
+	var articles []schema.ArticleMeta
+	for i := 1; i < len(rows); i++ {
+		if _, err := parseArticleRow(rows[i], sourceMap); err != nil {
+			continue
+		}
+		if article, err := parseArticleRowWithDetails(rows[i], sourceMap); err == nil {
+			articles = append(articles, *article)
+		}
+	}
+	return articles
+}
+
 // updateMetricsByDate updates yearly and monthly aggregate metrics
 func updateMetricsByDate(metrics *schema.Metrics, article *ParsedArticle, earliestDate, latestDate *time.Time) {
 	if article.Date.IsZero() {
@@ -267,24 +377,42 @@ func updateMetricsBySource(metrics *schema.Metrics, category string) {
 	}
 }
 
-// updateMetricsByCategory updates category-level aggregate metrics
+// updateMetricsByCategory updates category-level aggregate metrics; the category is the
+// article's topic, or its source without one
 func updateMetricsByCategory(metrics *schema.Metrics, article *ParsedArticle) {
-	if article.Category != "" {
-		status := metrics.ByCategory[article.Category]
+	category := article.Topic
+	if category == "" {
+		category = article.Category
+	}
+	if category != "" {
+		status := metrics.ByCategory[category]
 		if article.IsRead {
 			status[0]++
 		} else {
 			status[1]++
 		}
-		metrics.ByCategory[article.Category] = status
+		metrics.ByCategory[category] = status
 
 		// Track unread by category
 		if !article.IsRead {
-			metrics.UnreadByCategory[article.Category]++
+			metrics.UnreadByCategory[category]++
+		}
+
+		// Track which sources feed the category
+		if article.Category != "" {
+			addCategorySource(metrics, category, article.Category, article.IsRead)
 		}
 	}
 }
 
+// addCategorySource counts an article under its category and source in ByCategoryAndSource
+func addCategorySource(metrics *schema.Metrics, category, source string, read bool) {
+	if metrics.ByCategoryAndSource[category] == nil {
+		metrics.ByCategoryAndSource[category] = make(map[string][2]int)
+	}
+	metrics.ByCategoryAndSource[category][source] = addReadStatus(metrics.ByCategoryAndSource[category][source], read)
+}
+
 // updateMetricsReadStatus updates read/unread counts and status by source
 func updateMetricsReadStatus(metrics *schema.Metrics, article *ParsedArticle) {
 	if article.IsRead {
@@ -342,6 +470,11 @@ func updateUnreadArticleAgeDistribution(metrics *schema.Metrics, article *Parsed
 
 // processArticleRows processes all article rows and updates metrics
 func processArticleRows(rows [][]interface{}, metrics *schema.Metrics, earliestDate, latestDate *time.Time, sourceMap map[string]string) ([]schema.ArticleMeta, *schema.ArticleMeta) {
+	return processArticleRowsAt(rows, metrics, earliestDate, latestDate, sourceMap, time.Now())
+}
+
+// processArticleRowsAt processes all article rows, measuring unread article ages against referenceDate
+func processArticleRowsAt(rows [][]interface{}, metrics *schema.Metrics, earliestDate, latestDate *time.Time, sourceMap map[string]string, referenceDate time.Time) ([]schema.ArticleMeta, *schema.ArticleMeta) {
 	var unreadArticles []schema.ArticleMeta
 	var oldestUnreadArticle *schema.ArticleMeta
 
@@ -352,7 +485,8 @@ func processArticleRows(rows [][]interface{}, metrics *schema.Metrics, earliestD
 		// Parse the article row into structured data
 		article, err := parseArticleRow(row, sourceMap)
 		if err != nil {
-			// Skip incomplete or invalid rows
+			// Skip incomplete or invalid rows, counting them for the run summary
+			metrics.SkippedRows++
 			continue
 		}
 
@@ -367,6 +501,21 @@ func processArticleRows(rows [][]interface{}, metrics *schema.Metrics, earliestD
 		// Update category-level aggregates
 		updateMetricsByCategory(metrics, article)
 
+		// Update link domain aggregates
+		updateMetricsByDomain(metrics, article)
+
+		// Update per-author aggregates of Substack articles
+		updateMetricsBySubstackAuthor(metrics, article)
+
+		// Update media type aggregates
+		updateMetricsByMediaType(metrics, article)
+
+		// Update quarterly, ISO week and weekday read status
+		updateMetricsByPeriod(metrics, article)
+
+		// Update watch/listen time of videos and podcasts
+		updateConsumption(metrics, article)
+
 		// Update read/unread counts and by-source read status
 		updateMetricsReadStatus(metrics, article)
 
@@ -380,7 +529,7 @@ func processArticleRows(rows [][]interface{}, metrics *schema.Metrics, earliestD
 			metrics.UnreadByYear[year]++
 
 			// Update age distribution for unread articles
-			updateUnreadArticleAgeDistribution(metrics, article, time.Now())
+			updateUnreadArticleAgeDistribution(metrics, article, referenceDate)
 
 			// Collect unread article details
 			articleDetail, _ := parseArticleRowWithDetails(row, sourceMap)
@@ -407,6 +556,11 @@ func processArticleRows(rows [][]interface{}, metrics *schema.Metrics, earliestD
 
 // calculateDerivedMetrics computes read rate and average articles per month
 func calculateDerivedMetrics(metrics *schema.Metrics, earliestDate, latestDate time.Time) {
+	calculateDerivedMetricsAt(metrics, earliestDate, latestDate, time.Now())
+}
+
+// calculateDerivedMetricsAt computes derived metrics, treating now as the end of the data span
+func calculateDerivedMetricsAt(metrics *schema.Metrics, earliestDate, latestDate, now time.Time) {
 	if metrics.TotalArticles > 0 {
 		metrics.ReadRate = (float64(metrics.ReadCount) / float64(metrics.TotalArticles)) * 100
 	}
@@ -418,7 +572,6 @@ func calculateDerivedMetrics(metrics *schema.Metrics, earliestDate, latestDate t
 
 		// Handle partial month for the latest month
 		// If latestDate is in the current month, we calculate the fraction of the month passed
-		now := time.Now()
 		if latestDate.Year() == now.Year() && latestDate.Month() == now.Month() {
 			daysInMonth := time.Date(now.Year(), now.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
 			fraction := float64(now.Day()) / float64(daysInMonth)
@@ -427,7 +580,7 @@ func calculateDerivedMetrics(metrics *schema.Metrics, earliestDate, latestDate t
 			monthsSpan = float64(monthsDiff) + 1.0
 		}
 
-		log.Printf("📊 Data span: %s to %s (%.2f months)\n", earliestDate.Format("2006-01-02"), latestDate.Format("2006-01-02"), monthsSpan)
+		slog.Debug("📊 Data span", "from", earliestDate.Format("2006-01-02"), "to", latestDate.Format("2006-01-02"), "months", monthsSpan)
 	}
 
 	if monthsSpan > 0 {
@@ -477,7 +630,7 @@ func (s *SheetServiceFetcher) GetSpreadsheet(spreadsheetID string) (*sheets.Spre
 
 // GetArticleRows retrieves article data from the Articles sheet
 func (s *SheetServiceFetcher) GetArticleRows(spreadsheetID, articlesSheet string) ([][]interface{}, error) {
-	readRange := fmt.Sprintf("%s!A:E", articlesSheet)
+	readRange := fmt.Sprintf("%s!A:%c", articlesSheet, 'A'+ColTopic)
 	resp, err := s.service.Spreadsheets.Values.Get(spreadsheetID, readRange).Do()
 	if err != nil {
 		return nil, err
@@ -517,7 +670,7 @@ func findSheetNames(spreadsheet *sheets.Spreadsheet) (string, string) {
 func getSubstackProviderCount(fetcher SheetsFetcher, spreadsheetID, providersSheet string) int {
 	rows, err := fetcher.GetProvidersSheet(spreadsheetID, providersSheet)
 	if err != nil {
-		log.Printf("Warning: Unable to read providers sheet: %v\n", err)
+		slog.Warn("Unable to read providers sheet", "err", err)
 		return 0
 	}
 
@@ -560,9 +713,32 @@ func fetchMetricsWithFetcher(spreadsheetID string, fetcher SheetsFetcher) (schem
 	// Read provider data for metadata and Substack count
 	providerRows, err := fetcher.GetProvidersSheet(spreadsheetID, providersSheet)
 	if err != nil {
-		log.Printf("Warning: Unable to read providers sheet: %v\n", err)
+		slog.Warn("Unable to read providers sheet", "err", err)
 	}
 
+	// Read all articles data
+	articleRows, err := fetcher.GetArticleRows(spreadsheetID, articlesSheet)
+	if err != nil {
+		return schema.Metrics{}, fmt.Errorf("unable to retrieve data from sheet: %w", err)
+	}
+
+	return ComputeMetrics(articleRows, providerRows, time.Now())
+}
+
+// ComputeOptions tunes aggregation beyond the raw rows
+type ComputeOptions struct {
+	Rules          *RuleSet // category rules applied to every article; nil keeps the source as the category
+	YearStartMonth int      // first month (1-12) of the fiscal year rollups; 0 or 1 keeps calendar years only
+}
+
+// ComputeMetrics aggregates article and provider rows (header row included) into a Metrics snapshot.
+// Unread ages, the partial-month average and LastUpdated are all measured against referenceDate.
+func ComputeMetrics(articleRows, providerRows [][]interface{}, referenceDate time.Time) (schema.Metrics, error) {
+	return ComputeMetricsWithOptions(articleRows, providerRows, referenceDate, ComputeOptions{})
+}
+
+// ComputeMetricsWithOptions is ComputeMetrics with category rules and other aggregation options
+func ComputeMetricsWithOptions(articleRows, providerRows [][]interface{}, referenceDate time.Time, opts ComputeOptions) (schema.Metrics, error) {
 	// Build normalization map from providers
 	sourceMap := BuildSourceMap(providerRows)
 
@@ -576,6 +752,8 @@ func fetchMetricsWithFetcher(spreadsheetID string, fetcher SheetsFetcher) (schem
 		ByMonthAndSource:             make(map[string]map[string][2]int),
 		ByCategory:                   make(map[string][2]int),
 		ByCategoryAndSource:          make(map[string]map[string][2]int),
+		ByDomain:                     make(map[string][2]int),
+		ByMediaType:                  make(map[string][2]int),
 		UnreadByMonth:                make(map[string]int),
 		UnreadByCategory:             make(map[string]int),
 		UnreadBySource:               make(map[string]int),
@@ -585,7 +763,7 @@ func fetchMetricsWithFetcher(spreadsheetID string, fetcher SheetsFetcher) (schem
 	}
 
 	// Populate source metadata and count Substack authors
-	substackCount := 0
+	var substackFeeds []string
 	if len(providerRows) > 1 {
 		for i := 1; i < len(providerRows); i++ {
 			row := providerRows[i]
@@ -608,20 +786,30 @@ func fetchMetricsWithFetcher(spreadsheetID string, fetcher SheetsFetcher) (schem
 					metrics.SourceMetadata[name] = meta
 				}
 
-				// Count Substack entries for the "author count" metric
+				// Count every provider row so sources fed by several feeds report per-feed averages
+				meta := metrics.SourceMetadata[name]
+				meta.Feeds++
+				metrics.SourceMetadata[name] = meta
+
+				// Keep every row so provider churn can be tracked across snapshots
+				provider := schema.ProviderEntry{Name: name}
+				if len(row) > ProvidersColURL {
+					provider.URL = strings.TrimSpace(fmt.Sprintf("%v", row[ProvidersColURL]))
+				}
+				metrics.Providers = append(metrics.Providers, provider)
+
+				// Collect Substack feeds, one per author, for the per-author breakdown
 				if strings.EqualFold(fmt.Sprintf("%v", row[ProvidersColName]), SubstackProvider) {
-					substackCount++
+					feedURL := ""
+					if len(row) > ProvidersColURL {
+						feedURL = fmt.Sprintf("%v", row[ProvidersColURL])
+					}
+					substackFeeds = append(substackFeeds, feedURL)
 				}
 			}
 		}
 	}
 
-	// Read all articles data
-	articleRows, err := fetcher.GetArticleRows(spreadsheetID, articlesSheet)
-	if err != nil {
-		return schema.Metrics{}, fmt.Errorf("unable to retrieve data from sheet: %w", err)
-	}
-
 	if len(articleRows) == 0 {
 		return schema.Metrics{}, fmt.Errorf("no data found in sheet")
 	}
@@ -629,10 +817,10 @@ func fetchMetricsWithFetcher(spreadsheetID string, fetcher SheetsFetcher) (schem
 	var earliestDate, latestDate time.Time
 
 	// Process all articles
-	unreadArticles, oldestUnreadArticle := processArticleRows(articleRows, &metrics, &earliestDate, &latestDate, sourceMap)
+	unreadArticles, oldestUnreadArticle := processArticleRowsAt(articleRows, &metrics, &earliestDate, &latestDate, sourceMap, referenceDate)
 
 	// Calculate derived metrics
-	calculateDerivedMetrics(&metrics, earliestDate, latestDate)
+	calculateDerivedMetricsAt(&metrics, earliestDate, latestDate, referenceDate)
 
 	// Populate read/unread totals
 	metrics.ReadUnreadTotals = [2]int{metrics.ReadCount, metrics.UnreadCount}
@@ -640,11 +828,40 @@ func fetchMetricsWithFetcher(spreadsheetID string, fetcher SheetsFetcher) (schem
 	// Populate top articles
 	populateTopArticles(&metrics, unreadArticles, oldestUnreadArticle)
 
-	// Store substack count for later use in display
-	metrics.BySourceReadStatus["substack_author_count"] = [2]int{substackCount, 0}
+	// Count Substack authors and list their publications
+	seedSubstackAuthors(&metrics, substackFeeds)
+
+	articles := ParseArticles(articleRows, sourceMap)
+
+	// Re-categorize articles matched by config rules
+	if opts.Rules != nil {
+		applyCategoryRules(&metrics, articles, opts.Rules)
+	}
+
+	// Count recent saves; reads and backlog change come from earlier snapshots
+	metrics.Rolling = computeRollingWindows(articles, referenceDate)
+
+	// Suggest unread articles that are quick to clear
+	metrics.QuickWins = RecommendQuickWins(articles)
+
+	// Flag an unusually busy month; alerts against the previous snapshot come from ApplyAlerts
+	metrics.Alerts = detectAdditionSpike(metrics, referenceDate)
+
+	// Roll articles up into fiscal years when years start after January
+	if byFiscalYear := computeFiscalYears(articles, opts.YearStartMonth); byFiscalYear != nil {
+		metrics.ByFiscalYear = byFiscalYear
+		metrics.YearStartMonth = opts.YearStartMonth
+	}
+
+	// Break the snapshot down per media type when videos or podcasts are mixed in
+	mediaTypes, err := computeMediaTypes(articleRows, providerRows, referenceDate, opts)
+	if err != nil {
+		return schema.Metrics{}, err
+	}
+	metrics.MediaTypes = mediaTypes
 
 	// Set timestamp
-	metrics.LastUpdated = time.Now()
+	metrics.LastUpdated = referenceDate
 
 	return metrics, nil
 }
@@ -652,8 +869,12 @@ func fetchMetricsWithFetcher(spreadsheetID string, fetcher SheetsFetcher) (schem
 // FetchMetricsFromSheets is a backward-compatible wrapper that creates a Sheets service
 // and delegates to FetchMetricsFromSheetsWithService.
 func FetchMetricsFromSheets(ctx context.Context, spreadsheetID, credentialsPath string) (schema.Metrics, error) {
-	// Create Sheets service
-	client, err := sheets.NewService(ctx, option.WithCredentialsFile(credentialsPath))
+	// Create Sheets service from a service account key or a user's OAuth consent
+	auth, err := googleauth.ClientOption(ctx, credentialsPath, sheets.SpreadsheetsReadonlyScope)
+	if err != nil {
+		return schema.Metrics{}, err
+	}
+	client, err := sheets.NewService(ctx, auth)
 	if err != nil {
 		return schema.Metrics{}, fmt.Errorf("unable to create sheets client: %w", err)
 	}
@@ -699,8 +699,9 @@ func TestUpdateMetricsBySource(t *testing.T) {
 
 func TestUpdateMetricsByCategory(t *testing.T) {
 	metrics := &schema.Metrics{
-		ByCategory:       make(map[string][2]int),
-		UnreadByCategory: make(map[string]int),
+		ByCategory:          make(map[string][2]int),
+		ByCategoryAndSource: make(map[string]map[string][2]int),
+		UnreadByCategory:    make(map[string]int),
 	}
 
 	// Add read article
@@ -724,6 +725,15 @@ func TestUpdateMetricsByCategory(t *testing.T) {
 	if metrics.UnreadByCategory["Substack"] != 1 {
 		t.Errorf("updateMetricsByCategory() UnreadByCategory = %d, want 1", metrics.UnreadByCategory["Substack"])
 	}
+
+	// A topic becomes the category, fed by the article's source
+	updateMetricsByCategory(metrics, &ParsedArticle{Category: "Substack", Topic: "Databases", IsRead: false})
+	if metrics.ByCategory["Databases"] != [2]int{0, 1} || metrics.ByCategory["Substack"] != [2]int{1, 1} {
+		t.Errorf("updateMetricsByCategory() ByCategory = %v, want the topic counted apart from the source", metrics.ByCategory)
+	}
+	if metrics.ByCategoryAndSource["Databases"]["Substack"] != [2]int{0, 1} || metrics.ByCategoryAndSource["Substack"]["Substack"] != [2]int{1, 1} {
+		t.Errorf("updateMetricsByCategory() ByCategoryAndSource = %v", metrics.ByCategoryAndSource)
+	}
 }
 
 // ============================================================================
@@ -1573,6 +1583,19 @@ func TestProcessArticleRows(t *testing.T) {
 				return len(unread) == 7 && m.UnreadCount == 7
 			},
 		},
+		{
+			name:        "counts skipped rows",
+			description: "Validates incomplete and invalid rows are counted but not aggregated",
+			rows: [][]interface{}{
+				{"Date", "Title", "Link", "Category", "Read"},
+				{"2025-01-01", "Valid", "https://a.com", "GitHub", "TRUE"},
+				{"2025-01-02", "Too short"},
+				{"01/03/2025", "Bad date", "https://b.com", "GitHub", "FALSE"},
+			},
+			validate: func(m *schema.Metrics, _ []schema.ArticleMeta, _ *schema.ArticleMeta) bool {
+				return m.TotalArticles == 1 && m.SkippedRows == 2
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -2044,8 +2067,10 @@ func TestFetchMetricsWithFetcher(t *testing.T) {
 			},
 			expectErr: false,
 			validate: func(m *schema.Metrics) bool {
-				substackCount := m.BySourceReadStatus["substack_author_count"]
-				return substackCount[0] == 2 // Should count 2 Substack providers
+				return m.Substack != nil && m.Substack.AuthorCount == 2 && // Should count 2 Substack providers
+					m.BySourceReadStatus["substack_author_count"] == [2]int{} &&
+					m.SourceMetadata["Substack"].Feeds == 2 &&
+					m.SourceMetadata["GitHub"].Feeds == 1
 			},
 		},
 	}
@@ -2977,3 +3002,78 @@ func TestGetProvidersSheetInterface(t *testing.T) {
 		})
 	}
 }
+
+// ============================================================================
+// ParseArticles: Converts article rows into ArticleMeta
+// ============================================================================
+
+func TestParseArticles(t *testing.T) {
+	rows := createTestArticleRows()
+	rows = append(rows,
+		[]interface{}{"bad-date", "Broken", "https://example.com/broken", "GitHub", "FALSE"},
+		[]interface{}{"2025-01-01", "Short Row"},
+	)
+
+	articles := ParseArticles(rows, nil)
+	if len(articles) != len(createTestArticleRows())-1 {
+		t.Fatalf("expected %d articles, got %d", len(createTestArticleRows())-1, len(articles))
+	}
+
+	last := articles[len(articles)-1]
+	if last.Category != "GitHub" {
+		t.Errorf("expected source to be normalized to GitHub, got %q", last.Category)
+	}
+	if articles[5].Title != "Read Recently" || !articles[5].Read {
+		t.Errorf("expected read article details to be preserved, got %+v", articles[5])
+	}
+}
+
+func TestParseArticlesIdentifiersAndAuthors(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read", "Authors"},
+		{"2025-01-01", "Paper", "doi:10.1000/xyz", "Papers", "FALSE", "Ada Lovelace; Alan Turing"},
+		{"2025-01-02", "Book", "isbn:978-0-13-468599-1", "Books", "TRUE"},
+	}
+
+	articles := ParseArticles(rows, nil)
+	if len(articles) != 2 {
+		t.Fatalf("expected 2 articles, got %d", len(articles))
+	}
+	if articles[0].Link != "https://doi.org/10.1000/xyz" {
+		t.Errorf("expected DOI link, got %s", articles[0].Link)
+	}
+	if len(articles[0].Authors) != 2 || articles[0].Authors[1] != "Alan Turing" {
+		t.Errorf("expected 2 authors, got %v", articles[0].Authors)
+	}
+	if articles[1].Link != "https://openlibrary.org/isbn/9780134685991" {
+		t.Errorf("expected ISBN link, got %s", articles[1].Link)
+	}
+	if articles[1].Authors != nil {
+		t.Errorf("expected no authors without column F, got %v", articles[1].Authors)
+	}
+}
+
+func TestParseArticlesNotesAndHighlights(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read", "Authors", "Notes", "Highlights", "Archive"},
+		{"2025-01-01", "Annotated", "https://example.com/a", "GitHub", "TRUE", "", "  Worth a reread ", "First point\n\n  Second point  ", "https://web.archive.org/web/2025/https://example.com/a"},
+		{"2025-01-02", "Plain", "https://example.com/b", "GitHub", "TRUE"},
+	}
+
+	articles := ParseArticles(rows, nil)
+	if len(articles) != 2 {
+		t.Fatalf("expected 2 articles, got %d", len(articles))
+	}
+	if articles[0].Notes != "Worth a reread" {
+		t.Errorf("expected trimmed notes, got %q", articles[0].Notes)
+	}
+	if len(articles[0].Highlights) != 2 || articles[0].Highlights[1] != "Second point" {
+		t.Errorf("expected 2 highlights, got %q", articles[0].Highlights)
+	}
+	if articles[0].ArchiveURL != "https://web.archive.org/web/2025/https://example.com/a" {
+		t.Errorf("expected the archive URL from column I, got %q", articles[0].ArchiveURL)
+	}
+	if articles[1].Notes != "" || articles[1].Highlights != nil || articles[1].ArchiveURL != "" {
+		t.Errorf("expected no annotations without columns G and H, got %+v", articles[1])
+	}
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// QuarterOf labels the calendar quarter of a date, such as 2025-Q1
+func QuarterOf(date time.Time) string {
+	return fmt.Sprintf("%d-Q%d", date.Year(), (int(date.Month())-1)/3+1)
+}
+
+// ISOWeekOf labels the ISO 8601 week of a date, such as 2025-W07. Early January days can belong
+// to the last week of the previous year, and late December days to week 1 of the next.
+func ISOWeekOf(date time.Time) string {
+	year, week := date.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// Weekdays are the ByWeekday keys, Monday first
+var Weekdays = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// WeekdayOf labels the weekday of a date with its ByWeekday key
+func WeekdayOf(date time.Time) string {
+	return Weekdays[(int(date.Weekday())+6)%7]
+}
+
+// updateMetricsByPeriod tallies the read status of the article's quarter, ISO week and weekday
+func updateMetricsByPeriod(metrics *schema.Metrics, article *ParsedArticle) {
+	if article.Date.IsZero() {
+		return
+	}
+	if metrics.ByQuarter == nil {
+		metrics.ByQuarter = make(map[string][2]int)
+	}
+	if metrics.ByISOWeek == nil {
+		metrics.ByISOWeek = make(map[string][2]int)
+	}
+	if metrics.ByWeekday == nil {
+		metrics.ByWeekday = make(map[string][2]int)
+	}
+
+	quarter := QuarterOf(article.Date)
+	metrics.ByQuarter[quarter] = addReadStatus(metrics.ByQuarter[quarter], article.IsRead)
+	week := ISOWeekOf(article.Date)
+	metrics.ByISOWeek[week] = addReadStatus(metrics.ByISOWeek[week], article.IsRead)
+	weekday := WeekdayOf(article.Date)
+	metrics.ByWeekday[weekday] = addReadStatus(metrics.ByWeekday[weekday], article.IsRead)
+}
+
+// ISOWeekStart returns the Monday an ISO week label such as 2025-W07 starts on
+func ISOWeekStart(label string) (time.Time, error) {
+	var year, week int
+	if _, err := fmt.Sscanf(label, "%d-W%d", &year, &week); err != nil || week < 1 || week > 53 {
+		return time.Time{}, fmt.Errorf("invalid ISO week %q", label)
+	}
+	// January 4th is always in week 1
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	return monday.AddDate(0, 0, (week-1)*7), nil
+}
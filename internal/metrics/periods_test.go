@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodLabels(t *testing.T) {
+	tests := []struct {
+		date        string
+		wantQuarter string
+		wantWeek    string
+		wantWeekday string
+	}{
+		{"2025-01-01", "2025-Q1", "2025-W01", "Wed"},
+		{"2025-03-31", "2025-Q1", "2025-W14", "Mon"},
+		{"2025-04-01", "2025-Q2", "2025-W14", "Tue"},
+		{"2025-12-28", "2025-Q4", "2025-W52", "Sun"},
+		{"2025-12-29", "2025-Q4", "2026-W01", "Mon"},
+		{"2027-01-01", "2027-Q1", "2026-W53", "Fri"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			date, _ := time.Parse("2006-01-02", tt.date)
+			if got := QuarterOf(date); got != tt.wantQuarter {
+				t.Errorf("QuarterOf(%s) = %q, want %q", tt.date, got, tt.wantQuarter)
+			}
+			if got := ISOWeekOf(date); got != tt.wantWeek {
+				t.Errorf("ISOWeekOf(%s) = %q, want %q", tt.date, got, tt.wantWeek)
+			}
+			if got := WeekdayOf(date); got != tt.wantWeekday {
+				t.Errorf("WeekdayOf(%s) = %q, want %q", tt.date, got, tt.wantWeekday)
+			}
+			start, err := ISOWeekStart(tt.wantWeek)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if start.Weekday() != time.Monday || date.Before(start) || !date.Before(start.AddDate(0, 0, 7)) {
+				t.Errorf("ISOWeekStart(%s) = %s, want the Monday of the week of %s", tt.wantWeek, start.Format("2006-01-02"), tt.date)
+			}
+		})
+	}
+}
+
+func TestComputeMetricsByPeriod(t *testing.T) {
+	articleRows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2025-01-06", "A", "https://a.com/1", "GitHub", "TRUE"},
+		{"2025-01-07", "B", "https://a.com/2", "GitHub", "FALSE"},
+		{"2025-04-01", "C", "https://a.com/3", "GitHub", "TRUE"},
+	}
+
+	m, err := ComputeMetrics(articleRows, nil, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.ByQuarter["2025-Q1"] != [2]int{1, 1} || m.ByQuarter["2025-Q2"] != [2]int{1, 0} {
+		t.Errorf("unexpected quarters %v", m.ByQuarter)
+	}
+	if m.ByISOWeek["2025-W02"] != [2]int{1, 1} || m.ByISOWeek["2025-W14"] != [2]int{1, 0} {
+		t.Errorf("unexpected ISO weeks %v", m.ByISOWeek)
+	}
+	if m.ByWeekday["Mon"] != [2]int{1, 0} || m.ByWeekday["Tue"] != [2]int{1, 1} || len(m.ByWeekday) != 2 {
+		t.Errorf("unexpected weekdays %v", m.ByWeekday)
+	}
+}
+
+func TestISOWeekStartInvalid(t *testing.T) {
+	for _, label := range []string{"", "2025", "2025-Q1", "2025-W00", "2025-W54"} {
+		if _, err := ISOWeekStart(label); err == nil {
+			t.Errorf("ISOWeekStart(%q) expected an error", label)
+		}
+	}
+}
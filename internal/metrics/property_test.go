@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"pgregory.net/rapid"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// Property tests generate random article sheets and check aggregation invariants.
+// Run more cases with: go test ./internal/metrics/ -run Property -rapid.checks=5000
+
+var (
+	propertyReference = time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	propertySources   = []string{"GitHub", "github", "Stripe", "Substack", "freeCodeCamp", "fcc", ""}
+	propertyReadCells = []interface{}{"TRUE", "FALSE", "true", "false", "", true}
+)
+
+// dateCellGen produces mostly valid YYYY-MM-DD dates with the occasional malformed one
+func dateCellGen() *rapid.Generator[interface{}] {
+	return rapid.Custom(func(t *rapid.T) interface{} {
+		if rapid.IntRange(0, 19).Draw(t, "malformed") == 0 {
+			return rapid.SampledFrom([]interface{}{"", "2024/01/02", "not-a-date", "2024-13-01"}).Draw(t, "badDate")
+		}
+		offset := rapid.IntRange(0, 6*365).Draw(t, "daysAgo")
+		return propertyReference.AddDate(0, 0, -offset).Format("2006-01-02")
+	})
+}
+
+// articleRowGen produces a sheet row; a few rows are truncated like partially filled sheet lines
+func articleRowGen() *rapid.Generator[[]interface{}] {
+	return rapid.Custom(func(t *rapid.T) []interface{} {
+		n := rapid.IntRange(0, 999).Draw(t, "n")
+		row := []interface{}{
+			dateCellGen().Draw(t, "date"),
+			fmt.Sprintf("Article %d", n),
+			fmt.Sprintf("https://example.com/%d", n),
+			rapid.SampledFrom(propertySources).Draw(t, "source"),
+			rapid.SampledFrom(propertyReadCells).Draw(t, "read"),
+		}
+		if rapid.IntRange(0, 29).Draw(t, "truncated") == 0 {
+			row = row[:rapid.IntRange(0, ColRead).Draw(t, "width")]
+		}
+		return row
+	})
+}
+
+// articleRowsGen produces an Articles sheet with its header row
+func articleRowsGen() *rapid.Generator[[][]interface{}] {
+	return rapid.Custom(func(t *rapid.T) [][]interface{} {
+		rows := [][]interface{}{{"Date", "Title", "Link", "Category", "Read"}}
+		return append(rows, rapid.SliceOfN(articleRowGen(), 0, 60).Draw(t, "rows")...)
+	})
+}
+
+// providerRowsGen produces a Providers sheet mapping lowercase labels to canonical source names
+func providerRowsGen() *rapid.Generator[[][]interface{}] {
+	return rapid.Custom(func(t *rapid.T) [][]interface{} {
+		rows := [][]interface{}{{"name", "url"}}
+		for _, name := range rapid.SliceOfNDistinct(rapid.SampledFrom([]string{"GitHub", "Stripe", "Substack", "freeCodeCamp"}), 0, 4, rapid.ID[string]).Draw(t, "providers") {
+			rows = append(rows, []interface{}{name, "https://" + name + ".example"})
+		}
+		return rows
+	})
+}
+
+// validRows counts the rows (header excluded) that aggregation should accept
+func validRows(rows [][]interface{}) int {
+	count := 0
+	for _, row := range rows[1:] {
+		if len(row) <= ColRead {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", fmt.Sprintf("%v", row[ColDate])); err == nil {
+			count++
+		}
+	}
+	return count
+}
+
+func computeProperty(t *rapid.T, rows, providers [][]interface{}) schema.Metrics {
+	m, err := ComputeMetrics(rows, providers, propertyReference)
+	if err != nil {
+		t.Fatalf("ComputeMetrics failed: %v", err)
+	}
+	return m
+}
+
+// withoutOrderedFields clears the fields whose contents depend on row order when dates tie
+func withoutOrderedFields(m schema.Metrics) schema.Metrics {
+	m.OldestUnreadArticle = nil
+	m.TopOldestUnreadArticles = nil
+	return m
+}
+
+func TestPropertyAggregationInvariants(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		rows := articleRowsGen().Draw(t, "articles")
+		m := computeProperty(t, rows, providerRowsGen().Draw(t, "providers"))
+
+		if violations := CheckConsistency(m); len(violations) != 0 {
+			t.Fatalf("invariants violated: %v", violations)
+		}
+		if want := validRows(rows); m.TotalArticles != want {
+			t.Fatalf("TotalArticles = %d, want %d valid rows", m.TotalArticles, want)
+		}
+		if m.ReadRate < 0 || m.ReadRate > 100 {
+			t.Fatalf("ReadRate = %f, want 0-100", m.ReadRate)
+		}
+		if len(m.TopOldestUnreadArticles) > TopUnreadArticlesCount || len(m.TopOldestUnreadArticles) > m.UnreadCount {
+			t.Fatalf("%d top unread articles for %d unread", len(m.TopOldestUnreadArticles), m.UnreadCount)
+		}
+	})
+}
+
+func TestPropertyAggregationIsDeterministic(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		rows := articleRowsGen().Draw(t, "articles")
+		providers := providerRowsGen().Draw(t, "providers")
+
+		first := computeProperty(t, rows, providers)
+		second := computeProperty(t, rows, providers)
+		if !reflect.DeepEqual(first, second) {
+			t.Fatalf("aggregating the same rows twice differs:\n%+v\n%+v", first, second)
+		}
+	})
+}
+
+func TestPropertyAggregationIgnoresRowOrder(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		rows := articleRowsGen().Draw(t, "articles")
+		providers := providerRowsGen().Draw(t, "providers")
+
+		shuffled := append([][]interface{}{rows[0]}, rows[1:]...)
+		r := rand.New(rand.NewSource(rapid.Int64().Draw(t, "seed")))
+		r.Shuffle(len(shuffled)-1, func(i, j int) { shuffled[i+1], shuffled[j+1] = shuffled[j+1], shuffled[i+1] })
+
+		original := withoutOrderedFields(computeProperty(t, rows, providers))
+		reordered := withoutOrderedFields(computeProperty(t, shuffled, providers))
+		if !reflect.DeepEqual(original, reordered) {
+			t.Fatalf("row order changed the aggregate:\n%+v\n%+v", original, reordered)
+		}
+	})
+}
+
+func TestPropertySourceAliasesAreIdempotent(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		m := computeProperty(t, articleRowsGen().Draw(t, "articles"), providerRowsGen().Draw(t, "providers"))
+		aliases := map[string]string{"fcc": "freeCodeCamp", "github": "GitHub"}
+
+		ApplySourceAliases(&m, aliases)
+		if violations := CheckConsistency(m); len(violations) != 0 {
+			t.Fatalf("invariants violated after aliasing: %v", violations)
+		}
+		once := withoutOrderedFields(m)
+
+		ApplySourceAliases(&m, aliases)
+		if twice := withoutOrderedFields(m); !reflect.DeepEqual(once, twice) {
+			t.Fatalf("applying aliases twice differs:\n%+v\n%+v", once, twice)
+		}
+	})
+}
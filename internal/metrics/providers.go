@@ -0,0 +1,154 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// hasProviderData reports whether a snapshot recorded any providers, either row by row or as
+// source metadata in snapshots written before the rows were kept
+func hasProviderData(m schema.Metrics) bool {
+	return len(m.Providers) > 0 || len(m.SourceMetadata) > 0
+}
+
+// providerSet returns the snapshot's providers keyed for comparison with another snapshot, mapped
+// to the name shown for them. Feed rows are compared when both snapshots recorded them; otherwise
+// only source names can be compared.
+func providerSet(m schema.Metrics, byFeed bool) map[string]string {
+	set := make(map[string]string)
+	if byFeed {
+		for _, provider := range m.Providers {
+			set[strings.ToLower(provider.Name)+"|"+provider.URL] = provider.Name
+		}
+		return set
+	}
+
+	for name := range m.SourceMetadata {
+		set[name] = name
+	}
+	for _, provider := range m.Providers {
+		set[provider.Name] = provider.Name
+	}
+	return set
+}
+
+// providerTotal counts a snapshot's subscriptions: its provider rows, or one per feed of each source
+func providerTotal(m schema.Metrics) int {
+	if len(m.Providers) > 0 {
+		return len(m.Providers)
+	}
+	total := 0
+	for _, meta := range m.SourceMetadata {
+		total += max(meta.Feeds, 1)
+	}
+	return total
+}
+
+// BuildProviderTimeline compares consecutive snapshots with provider data and totals the
+// subscriptions added and removed per month (YYYY-MM of the later snapshot). The earliest snapshot
+// is the baseline, so its providers are not counted as added.
+func BuildProviderTimeline(snapshots map[string]schema.Metrics) []schema.ProviderTimelinePoint {
+	dates := providerSnapshotDates(snapshots)
+	if len(dates) == 0 {
+		return nil
+	}
+
+	var timeline []schema.ProviderTimelinePoint
+	point := func(month string) *schema.ProviderTimelinePoint {
+		if len(timeline) == 0 || timeline[len(timeline)-1].Month != month {
+			timeline = append(timeline, schema.ProviderTimelinePoint{Month: month})
+		}
+		return &timeline[len(timeline)-1]
+	}
+
+	point(dates[0][:7]).Total = providerTotal(snapshots[dates[0]])
+	for i := 1; i < len(dates); i++ {
+		prev, curr := snapshots[dates[i-1]], snapshots[dates[i]]
+		byFeed := len(prev.Providers) > 0 && len(curr.Providers) > 0
+		before, after := providerSet(prev, byFeed), providerSet(curr, byFeed)
+
+		p := point(dates[i][:7])
+		for _, key := range sortedStringKeys(after) {
+			if _, existed := before[key]; !existed {
+				p.Added++
+				p.AddedNames = appendUnique(p.AddedNames, after[key])
+			}
+		}
+		for _, key := range sortedStringKeys(before) {
+			if _, exists := after[key]; !exists {
+				p.Removed++
+				p.RemovedNames = appendUnique(p.RemovedNames, before[key])
+			}
+		}
+		p.Total = providerTotal(curr)
+	}
+	return timeline
+}
+
+// ProviderFirstSeen returns the date of the first snapshot listing each source. Sources already in
+// the earliest snapshot with provider data are left out, since when they were added is unknown.
+func ProviderFirstSeen(snapshots map[string]schema.Metrics) map[string]string {
+	dates := providerSnapshotDates(snapshots)
+	if len(dates) == 0 {
+		return nil
+	}
+
+	seen := providerSet(snapshots[dates[0]], false)
+	firstSeen := make(map[string]string)
+	for _, date := range dates[1:] {
+		for name := range providerSet(snapshots[date], false) {
+			if _, exists := seen[name]; !exists {
+				seen[name] = name
+				firstSeen[name] = date
+			}
+		}
+	}
+	return firstSeen
+}
+
+// ApplyProviderAddedDates replaces each source's recorded "added" date with the date of the first
+// snapshot listing it, for every snapshot taken since
+func ApplyProviderAddedDates(snapshots map[string]schema.Metrics) {
+	firstSeen := ProviderFirstSeen(snapshots)
+	for date, m := range snapshots {
+		for name, meta := range m.SourceMetadata {
+			if added, exists := firstSeen[name]; exists && added <= date {
+				meta.Added = added
+				m.SourceMetadata[name] = meta
+			}
+		}
+		snapshots[date] = m
+	}
+}
+
+// providerSnapshotDates returns the ascending dates of the snapshots with provider data
+func providerSnapshotDates(snapshots map[string]schema.Metrics) []string {
+	var dates []string
+	for date, m := range snapshots {
+		if hasProviderData(m) && len(date) >= len("2006-01") {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+	return dates
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestBuildProviderTimeline(t *testing.T) {
+	legacy := func(names ...string) schema.Metrics {
+		meta := make(map[string]schema.SourceMeta)
+		for _, name := range names {
+			meta[name] = schema.SourceMeta{Added: "initial"}
+		}
+		return schema.Metrics{SourceMetadata: meta}
+	}
+	withFeeds := func(providers ...schema.ProviderEntry) schema.Metrics {
+		m := schema.Metrics{SourceMetadata: make(map[string]schema.SourceMeta), Providers: providers}
+		for _, p := range providers {
+			meta := m.SourceMetadata[p.Name]
+			meta.Feeds++
+			m.SourceMetadata[p.Name] = meta
+		}
+		return m
+	}
+	substack := func(url string) schema.ProviderEntry { return schema.ProviderEntry{Name: "Substack", URL: url} }
+	github := schema.ProviderEntry{Name: "GitHub", URL: "https://github.blog/feed"}
+
+	snapshots := map[string]schema.Metrics{
+		"2025-11-28": {}, // no provider data
+		"2025-11-30": legacy("GitHub", "Substack"),
+		"2025-12-05": legacy("GitHub", "Substack", "Netflix"),
+		"2026-01-02": withFeeds(github, substack("https://a.substack.com/feed"), substack("https://b.substack.com/feed")),
+		"2026-01-09": withFeeds(substack("https://a.substack.com/feed"), substack("https://c.substack.com/feed")),
+	}
+
+	timeline := BuildProviderTimeline(snapshots)
+	got := fmt.Sprint(timeline)
+	want := fmt.Sprint([]schema.ProviderTimelinePoint{
+		{Month: "2025-11", Total: 2},
+		{Month: "2025-12", Added: 1, Total: 3, AddedNames: []string{"Netflix"}},
+		// Legacy to feed rows compares names only; then feed rows are compared one by one
+		{Month: "2026-01", Added: 1, Removed: 3, Total: 2, AddedNames: []string{"Substack"}, RemovedNames: []string{"Netflix", "GitHub", "Substack"}},
+	})
+	if got != want {
+		t.Errorf("BuildProviderTimeline() = %s, want %s", got, want)
+	}
+
+	if timeline := BuildProviderTimeline(map[string]schema.Metrics{"2025-11-28": {}}); timeline != nil {
+		t.Errorf("expected no timeline without provider data, got %v", timeline)
+	}
+}
+
+func TestApplyProviderAddedDates(t *testing.T) {
+	snapshots := map[string]schema.Metrics{
+		"2025-11-30": {SourceMetadata: map[string]schema.SourceMeta{"GitHub": {Added: "2024-03-18"}}},
+		"2026-02-20": {SourceMetadata: map[string]schema.SourceMeta{"GitHub": {Added: "2024-03-18"}, "Netflix": {Added: "2026-02-15"}}},
+		"2026-02-27": {SourceMetadata: map[string]schema.SourceMeta{"GitHub": {Added: "2024-03-18"}, "Netflix": {Added: "initial"}}},
+	}
+
+	ApplyProviderAddedDates(snapshots)
+
+	if added := snapshots["2026-02-27"].SourceMetadata["Netflix"].Added; added != "2026-02-20" {
+		t.Errorf("Netflix added = %q, want the first snapshot listing it", added)
+	}
+	if added := snapshots["2026-02-27"].SourceMetadata["GitHub"].Added; added != "2024-03-18" {
+		t.Errorf("GitHub added = %q, baseline sources should keep the recorded date", added)
+	}
+}
@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"sort"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+const (
+	// EstimatedArticleMinutes is the reading time assumed for an unread article without a duration
+	EstimatedArticleMinutes = 10
+
+	// QuickWinsCount is the number of articles in each quick wins list
+	QuickWinsCount = 5
+
+	// DomainClustersCount is the number of domain clusters suggested, each listing up to
+	// TopUnreadArticlesCount articles
+	DomainClustersCount = 3
+)
+
+// EstimateMinutes returns the recorded duration of a video or podcast, or EstimatedArticleMinutes
+func EstimateMinutes(article schema.ArticleMeta) int {
+	if article.DurationMinutes > 0 {
+		return article.DurationMinutes
+	}
+	return EstimatedArticleMinutes
+}
+
+// RecommendQuickWins picks unread articles likely to be fast to clear: the shortest ones, the
+// oldest of every source, and domains with several unread articles that can be read together.
+// Ties go to the older article, as clearing it shrinks the backlog's age too, then to link, title
+// and source, so the picks never depend on row order. Nil means nothing is unread.
+func RecommendQuickWins(articles []schema.ArticleMeta) *schema.QuickWins {
+	var unread []schema.QuickWin
+	for _, article := range articles {
+		if !article.Read {
+			unread = append(unread, schema.QuickWin{ArticleMeta: article, EstimatedMinutes: EstimateMinutes(article)})
+		}
+	}
+	if len(unread) == 0 {
+		return nil
+	}
+	// Oldest first, so every stable sort below breaks ties by age
+	sort.SliceStable(unread, func(i, j int) bool { return olderQuickWin(unread[i], unread[j]) })
+
+	return &schema.QuickWins{
+		Shortest:        shortestUnread(unread),
+		OldestPerSource: oldestPerSource(unread),
+		DomainClusters:  domainClusters(unread),
+	}
+}
+
+// olderQuickWin orders articles by date, then link, title and source
+func olderQuickWin(a, b schema.QuickWin) bool {
+	if a.Date != b.Date {
+		return a.Date < b.Date
+	}
+	if a.Link != b.Link {
+		return a.Link < b.Link
+	}
+	if a.Title != b.Title {
+		return a.Title < b.Title
+	}
+	return a.Category < b.Category
+}
+
+// shortestUnread returns the QuickWinsCount articles with the lowest estimated reading time
+func shortestUnread(unread []schema.QuickWin) []schema.QuickWin {
+	shortest := append([]schema.QuickWin(nil), unread...)
+	sort.SliceStable(shortest, func(i, j int) bool { return shortest[i].EstimatedMinutes < shortest[j].EstimatedMinutes })
+	return firstQuickWins(shortest, QuickWinsCount)
+}
+
+// oldestPerSource returns the oldest unread article of each source, oldest first
+func oldestPerSource(unread []schema.QuickWin) []schema.QuickWin {
+	seen := make(map[string]bool)
+	var oldest []schema.QuickWin
+	for _, article := range unread {
+		if article.Category == "" || seen[article.Category] {
+			continue
+		}
+		seen[article.Category] = true
+		oldest = append(oldest, article)
+	}
+	return firstQuickWins(oldest, QuickWinsCount)
+}
+
+// domainClusters returns the domains with at least two unread articles, most unread first
+func domainClusters(unread []schema.QuickWin) []schema.DomainCluster {
+	byDomain := make(map[string][]schema.QuickWin)
+	var domains []string
+	for _, article := range unread {
+		domain := RegistrableDomain(article.Link)
+		if domain == "" {
+			continue
+		}
+		if byDomain[domain] == nil {
+			domains = append(domains, domain)
+		}
+		byDomain[domain] = append(byDomain[domain], article)
+	}
+
+	var clusters []schema.DomainCluster
+	for _, domain := range domains {
+		articles := byDomain[domain]
+		if len(articles) < 2 {
+			continue
+		}
+		cluster := schema.DomainCluster{Domain: domain, Unread: len(articles), Articles: firstQuickWins(articles, TopUnreadArticlesCount)}
+		for _, article := range cluster.Articles {
+			cluster.EstimatedMinutes += article.EstimatedMinutes
+		}
+		clusters = append(clusters, cluster)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if clusters[i].Unread != clusters[j].Unread {
+			return clusters[i].Unread > clusters[j].Unread
+		}
+		return clusters[i].Domain < clusters[j].Domain
+	})
+	if len(clusters) > DomainClustersCount {
+		clusters = clusters[:DomainClustersCount]
+	}
+	return clusters
+}
+
+// firstQuickWins returns at most n articles
+func firstQuickWins(articles []schema.QuickWin, n int) []schema.QuickWin {
+	if len(articles) > n {
+		return articles[:n]
+	}
+	return articles
+}
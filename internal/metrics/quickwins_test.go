@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestRecommendQuickWins(t *testing.T) {
+	articles := []schema.ArticleMeta{
+		{Title: "Long video", Date: "2025-01-01", Link: "https://youtube.com/watch?v=1", Category: "YouTube", DurationMinutes: 45},
+		{Title: "Short clip", Date: "2025-03-01", Link: "https://youtube.com/watch?v=2", Category: "YouTube", DurationMinutes: 3},
+		{Title: "Blog post", Date: "2024-06-01", Link: "https://blog.example.com/a", Category: "Example"},
+		{Title: "Another post", Date: "2024-08-01", Link: "https://www.example.com/b", Category: "Example"},
+		{Title: "Third post", Date: "2024-09-01", Link: "https://example.com/c", Category: "Example"},
+		{Title: "Read already", Date: "2020-01-01", Link: "https://stripe.com/blog/x", Category: "Stripe", Read: true},
+		{Title: "Lone article", Date: "2025-02-01", Link: "https://stripe.com/blog/y", Category: "Stripe"},
+	}
+
+	quickWins := RecommendQuickWins(articles)
+	if quickWins == nil {
+		t.Fatal("expected recommendations for unread articles")
+	}
+
+	shortest := titles(quickWins.Shortest)
+	expected := []string{"Short clip", "Blog post", "Another post", "Third post", "Lone article"}
+	if !reflect.DeepEqual(shortest, expected) {
+		t.Errorf("expected shortest %v, got %v", expected, shortest)
+	}
+	if quickWins.Shortest[0].EstimatedMinutes != 3 || quickWins.Shortest[1].EstimatedMinutes != EstimatedArticleMinutes {
+		t.Errorf("expected durations or the default estimate, got %+v", quickWins.Shortest[:2])
+	}
+
+	oldest := titles(quickWins.OldestPerSource)
+	expected = []string{"Blog post", "Long video", "Lone article"}
+	if !reflect.DeepEqual(oldest, expected) {
+		t.Errorf("expected oldest per source %v, got %v", expected, oldest)
+	}
+
+	if len(quickWins.DomainClusters) != 2 {
+		t.Fatalf("expected clusters for example.com and youtube.com, got %+v", quickWins.DomainClusters)
+	}
+	cluster := quickWins.DomainClusters[0]
+	if cluster.Domain != "example.com" || cluster.Unread != 3 || cluster.EstimatedMinutes != 3*EstimatedArticleMinutes {
+		t.Errorf("expected the largest cluster first, got %+v", cluster)
+	}
+	if quickWins.DomainClusters[1].Domain != "youtube.com" || quickWins.DomainClusters[1].EstimatedMinutes != 48 {
+		t.Errorf("expected youtube.com with 48 minutes, got %+v", quickWins.DomainClusters[1])
+	}
+
+	if RecommendQuickWins([]schema.ArticleMeta{{Date: "2025-01-01", Read: true}}) != nil {
+		t.Error("expected no recommendations without unread articles")
+	}
+}
+
+func TestRecommendQuickWinsTiedDates(t *testing.T) {
+	// Two unread articles saved the same day, as found by TestPropertyAggregationIgnoresRowOrder
+	github := schema.ArticleMeta{Title: "Article 291", Date: "2025-06-15", Link: "https://github.com/a", Category: "GitHub"}
+	stripe := schema.ArticleMeta{Title: "Article 6", Date: "2025-06-15", Link: "https://stripe.com/b", Category: "Stripe"}
+
+	forward := RecommendQuickWins([]schema.ArticleMeta{github, stripe})
+	backward := RecommendQuickWins([]schema.ArticleMeta{stripe, github})
+	if !reflect.DeepEqual(forward, backward) {
+		t.Errorf("row order changed the quick wins:\n%+v\n%+v", forward, backward)
+	}
+	if shortest := titles(forward.Shortest); !reflect.DeepEqual(shortest, []string{"Article 291", "Article 6"}) {
+		t.Errorf("expected ties broken by link, got %v", shortest)
+	}
+}
+
+func titles(quickWins []schema.QuickWin) []string {
+	var titles []string
+	for _, quickWin := range quickWins {
+		titles = append(titles, quickWin.Title)
+	}
+	return titles
+}
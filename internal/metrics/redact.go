@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// Redact strips what identifies an article, its title, link, authors, notes, highlights and archived
+// copy, from a snapshot so it can be published. Counts, dates and sources are kept, so the backlog
+// tables still show how old the oldest unread articles are and where they came from.
+func Redact(metrics *schema.Metrics) {
+	if metrics == nil {
+		return
+	}
+	if metrics.OldestUnreadArticle != nil {
+		article := RedactArticle(*metrics.OldestUnreadArticle)
+		metrics.OldestUnreadArticle = &article
+	}
+	if metrics.TopOldestUnreadArticles != nil {
+		articles := make([]schema.ArticleMeta, len(metrics.TopOldestUnreadArticles))
+		for i, article := range metrics.TopOldestUnreadArticles {
+			articles[i] = RedactArticle(article)
+		}
+		metrics.TopOldestUnreadArticles = articles
+	}
+	if metrics.QuickWins != nil {
+		quickWins := schema.QuickWins{
+			Shortest:        redactQuickWins(metrics.QuickWins.Shortest),
+			OldestPerSource: redactQuickWins(metrics.QuickWins.OldestPerSource),
+		}
+		for _, cluster := range metrics.QuickWins.DomainClusters {
+			cluster.Articles = redactQuickWins(cluster.Articles)
+			quickWins.DomainClusters = append(quickWins.DomainClusters, cluster)
+		}
+		metrics.QuickWins = &quickWins
+	}
+	if metrics.MediaTypes != nil {
+		mediaTypes := make(map[string]schema.Metrics, len(metrics.MediaTypes))
+		for mediaType, snapshot := range metrics.MediaTypes {
+			Redact(&snapshot)
+			mediaTypes[mediaType] = snapshot
+		}
+		metrics.MediaTypes = mediaTypes
+	}
+}
+
+// RedactArticle keeps an article's date, source, read status, media type and duration only
+func RedactArticle(article schema.ArticleMeta) schema.ArticleMeta {
+	return schema.ArticleMeta{
+		Date:            article.Date,
+		Category:        article.Category,
+		Read:            article.Read,
+		MediaType:       article.MediaType,
+		DurationMinutes: article.DurationMinutes,
+		Topic:           article.Topic,
+	}
+}
+
+// redactQuickWins redacts the articles of a quick wins list, keeping their estimated reading time
+func redactQuickWins(quickWins []schema.QuickWin) []schema.QuickWin {
+	if quickWins == nil {
+		return nil
+	}
+	redacted := make([]schema.QuickWin, len(quickWins))
+	for i, quickWin := range quickWins {
+		redacted[i] = schema.QuickWin{ArticleMeta: RedactArticle(quickWin.ArticleMeta), EstimatedMinutes: quickWin.EstimatedMinutes}
+	}
+	return redacted
+}
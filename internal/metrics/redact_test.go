@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestRedact(t *testing.T) {
+	article := schema.ArticleMeta{
+		Title: "Private title", Date: "2020-01-01", Link: "https://example.com/private", Category: "GitHub",
+		Authors: []string{"Ada"}, Notes: "note", Highlights: []string{"quote"}, ArchiveURL: "https://web.archive.org/x",
+		MediaType: "video", DurationMinutes: 12,
+	}
+	top := []schema.ArticleMeta{article}
+	m := schema.Metrics{
+		TotalArticles:           3,
+		BySource:                map[string]int{"GitHub": 3},
+		OldestUnreadArticle:     &article,
+		TopOldestUnreadArticles: top,
+		MediaTypes:              map[string]schema.Metrics{"video": {TotalArticles: 1, TopOldestUnreadArticles: top}},
+		QuickWins: &schema.QuickWins{
+			Shortest:       []schema.QuickWin{{ArticleMeta: article, EstimatedMinutes: 12}},
+			DomainClusters: []schema.DomainCluster{{Domain: "example.com", Unread: 2, Articles: []schema.QuickWin{{ArticleMeta: article}}}},
+		},
+	}
+	quickWins := m.QuickWins
+
+	Redact(&m)
+
+	expected := schema.ArticleMeta{Date: "2020-01-01", Category: "GitHub", MediaType: "video", DurationMinutes: 12}
+	if !reflect.DeepEqual(*m.OldestUnreadArticle, expected) || !reflect.DeepEqual(m.TopOldestUnreadArticles, []schema.ArticleMeta{expected}) {
+		t.Errorf("expected only the date, source and media details, got %+v and %+v", *m.OldestUnreadArticle, m.TopOldestUnreadArticles)
+	}
+	if !reflect.DeepEqual(m.MediaTypes["video"].TopOldestUnreadArticles, []schema.ArticleMeta{expected}) {
+		t.Errorf("expected media type snapshots to be redacted too, got %+v", m.MediaTypes["video"])
+	}
+	if !reflect.DeepEqual(m.QuickWins.Shortest, []schema.QuickWin{{ArticleMeta: expected, EstimatedMinutes: 12}}) {
+		t.Errorf("expected quick wins to be redacted, got %+v", m.QuickWins.Shortest)
+	}
+	if cluster := m.QuickWins.DomainClusters[0]; cluster.Domain != "example.com" || !reflect.DeepEqual(cluster.Articles[0].ArticleMeta, expected) {
+		t.Errorf("expected domain clusters to keep the domain but redact their articles, got %+v", cluster)
+	}
+	if m.TotalArticles != 3 || m.BySource["GitHub"] != 3 {
+		t.Errorf("expected aggregates to be kept, got %+v", m)
+	}
+	if article.Title != "Private title" || top[0].Link == "" || quickWins.Shortest[0].Title == "" {
+		t.Error("expected the caller's articles to be left untouched")
+	}
+
+	Redact(nil)
+}
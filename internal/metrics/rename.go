@@ -0,0 +1,190 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// ApplySourceAliases renames every aliased source in a snapshot, in alphabetical order of the old label
+func ApplySourceAliases(metrics *schema.Metrics, aliases map[string]string) {
+	var froms []string
+	for from := range aliases {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+
+	for _, from := range froms {
+		RenameSource(metrics, from, aliases[from])
+	}
+}
+
+// RenameSource merges every source-keyed count for from (matched case-insensitively) into to,
+// relabels unread articles, and records from as an alias in the source metadata, in the snapshot
+// and in each of its media type snapshots.
+// It reports whether the snapshot contained the old source.
+func RenameSource(metrics *schema.Metrics, from, to string) bool {
+	if metrics == nil || from == "" || to == "" || from == to {
+		return false
+	}
+
+	matches := func(key string) bool {
+		return key != to && strings.EqualFold(key, from)
+	}
+	addCounts := func(a, b int) int { return a + b }
+	addStatus := func(a, b [2]int) [2]int { return [2]int{a[0] + b[0], a[1] + b[1]} }
+
+	renamed := renameKeys(metrics.BySource, matches, to, addCounts)
+	renamed = renameKeys(metrics.BySourceReadStatus, matches, to, addStatus) || renamed
+	renamed = renameKeys(metrics.ByCategory, matches, to, addStatus) || renamed
+	renamed = renameKeys(metrics.UnreadBySource, matches, to, addCounts) || renamed
+	renamed = renameKeys(metrics.UnreadByCategory, matches, to, addCounts) || renamed
+	if metrics.Consumption != nil {
+		renamed = renameKeys(metrics.Consumption.MinutesBySource, matches, to, addCounts) || renamed
+	}
+
+	for _, bySource := range metrics.ByMonthAndSource {
+		renamed = renameKeys(bySource, matches, to, addStatus) || renamed
+	}
+	for _, bySource := range metrics.ByCategoryAndSource {
+		renamed = renameKeys(bySource, matches, to, addStatus) || renamed
+	}
+	mergeNested := func(a, b map[string][2]int) map[string][2]int {
+		for key, status := range b {
+			a[key] = addStatus(a[key], status)
+		}
+		return a
+	}
+	renamed = renameKeys(metrics.ByCategoryAndSource, matches, to, mergeNested) || renamed
+
+	if metrics.OldestUnreadArticle != nil && matches(metrics.OldestUnreadArticle.Category) {
+		metrics.OldestUnreadArticle.Category = to
+		renamed = true
+	}
+	for i := range metrics.TopOldestUnreadArticles {
+		if matches(metrics.TopOldestUnreadArticles[i].Category) {
+			metrics.TopOldestUnreadArticles[i].Category = to
+			renamed = true
+		}
+	}
+
+	if quickWins := metrics.QuickWins; quickWins != nil {
+		lists := [][]schema.QuickWin{quickWins.Shortest, quickWins.OldestPerSource}
+		for _, cluster := range quickWins.DomainClusters {
+			lists = append(lists, cluster.Articles)
+		}
+		for _, list := range lists {
+			for i := range list {
+				if matches(list[i].Category) {
+					list[i].Category = to
+					renamed = true
+				}
+			}
+		}
+	}
+
+	// Each media type's charts read its own nested snapshot
+	for mediaType, nested := range metrics.MediaTypes {
+		if RenameSource(&nested, from, to) {
+			metrics.MediaTypes[mediaType] = nested
+			renamed = true
+		}
+	}
+
+	renamed = renameSourceMetadata(metrics, matches, from, to) || renamed
+	return renamed
+}
+
+// RenameSourceInSnapshots applies RenameSource to every snapshot in dir and rewrites the files
+// that changed, returning how many were rewritten
+func RenameSourceInSnapshots(dir, from, to string) (int, error) {
+	files, err := ListSnapshotFiles(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	rewritten := 0
+	for _, filename := range files {
+		snapshot, err := LoadSnapshot(dir, filename)
+		if err != nil {
+			return rewritten, err
+		}
+		if !RenameSource(snapshot, from, to) {
+			continue
+		}
+		if err := saveMetrics(dir, filename, snapshot); err != nil {
+			return rewritten, fmt.Errorf("failed to rewrite %s: %w", filename, err)
+		}
+		rewritten++
+	}
+
+	return rewritten, nil
+}
+
+// renameSourceMetadata keeps the canonical source's metadata (or adopts the old one) and appends the alias
+func renameSourceMetadata(metrics *schema.Metrics, matches func(string) bool, from, to string) bool {
+	if metrics.SourceMetadata == nil {
+		return false
+	}
+
+	found := false
+	for key, meta := range metrics.SourceMetadata {
+		if !matches(key) {
+			continue
+		}
+		found = true
+		target, exists := metrics.SourceMetadata[to]
+		if !exists {
+			target = meta
+			target.Aliases = nil
+		}
+		target.Aliases = appendAlias(target.Aliases, meta.Aliases...)
+		target.Aliases = appendAlias(target.Aliases, key)
+		metrics.SourceMetadata[to] = target
+		delete(metrics.SourceMetadata, key)
+	}
+
+	// Record the rename even when the old label never had its own provider row
+	if target, exists := metrics.SourceMetadata[to]; exists && !found {
+		target.Aliases = appendAlias(target.Aliases, from)
+		metrics.SourceMetadata[to] = target
+	}
+
+	return found
+}
+
+// appendAlias adds aliases that are not already present, case-insensitively
+func appendAlias(aliases []string, add ...string) []string {
+	for _, alias := range add {
+		duplicate := false
+		for _, existing := range aliases {
+			if strings.EqualFold(existing, alias) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+// renameKeys moves every matching key of m onto to, combining values with merge when to already exists
+func renameKeys[V any](m map[string]V, matches func(string) bool, to string, merge func(a, b V) V) bool {
+	renamed := false
+	for key, value := range m {
+		if !matches(key) {
+			continue
+		}
+		if existing, exists := m[to]; exists {
+			value = merge(existing, value)
+		}
+		m[to] = value
+		delete(m, key)
+		renamed = true
+	}
+	return renamed
+}
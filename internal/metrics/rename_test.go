@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func newRenameFixture() *schema.Metrics {
+	return &schema.Metrics{
+		BySource:           map[string]int{"fcc": 2, "freeCodeCamp": 3, "GitHub": 1},
+		BySourceReadStatus: map[string][2]int{"fcc": {1, 1}, "freeCodeCamp": {2, 1}},
+		ByCategory:         map[string][2]int{"fcc": {1, 1}},
+		UnreadBySource:     map[string]int{"fcc": 1, "freeCodeCamp": 1},
+		Consumption:        &schema.ConsumptionStats{MinutesBySource: map[string]int{"fcc": 15, "freeCodeCamp": 30}},
+		ByMonthAndSource: map[string]map[string][2]int{
+			"01": {"fcc": {1, 0}, "freeCodeCamp": {1, 1}},
+		},
+		ByCategoryAndSource: map[string]map[string][2]int{
+			"fcc":          {"fcc": {1, 1}},
+			"freeCodeCamp": {"freeCodeCamp": {2, 1}},
+		},
+		TopOldestUnreadArticles: []schema.ArticleMeta{{Title: "A", Category: "fcc"}},
+		OldestUnreadArticle:     &schema.ArticleMeta{Title: "A", Category: "fcc"},
+		QuickWins: &schema.QuickWins{
+			DomainClusters: []schema.DomainCluster{{Domain: "freecodecamp.org", Articles: []schema.QuickWin{{ArticleMeta: schema.ArticleMeta{Title: "A", Category: "fcc"}}}}},
+		},
+		SourceMetadata: map[string]schema.SourceMeta{
+			"fcc":          {Added: "2023-01-01"},
+			"freeCodeCamp": {Added: "2024-01-01", Color: "#0a0a23"},
+		},
+		MediaTypes: map[string]schema.Metrics{
+			"video": {
+				BySource:            map[string]int{"fcc": 1, "freeCodeCamp": 2},
+				OldestUnreadArticle: &schema.ArticleMeta{Title: "V", Category: "fcc"},
+			},
+		},
+	}
+}
+
+func TestRenameSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		expected bool
+		validate func(t *testing.T, m *schema.Metrics)
+	}{
+		{
+			name:     "merges counts into canonical name",
+			from:     "fcc",
+			expected: true,
+			validate: func(t *testing.T, m *schema.Metrics) {
+				if _, exists := m.BySource["fcc"]; exists {
+					t.Error("expected old source to be removed")
+				}
+				if m.BySource["freeCodeCamp"] != 5 {
+					t.Errorf("expected 5 articles, got %d", m.BySource["freeCodeCamp"])
+				}
+				if minutes := m.Consumption.MinutesBySource; minutes["freeCodeCamp"] != 45 || len(minutes) != 1 {
+					t.Errorf("expected reading minutes to be summed, got %v", minutes)
+				}
+				if m.BySourceReadStatus["freeCodeCamp"] != [2]int{3, 2} {
+					t.Errorf("unexpected read status %v", m.BySourceReadStatus["freeCodeCamp"])
+				}
+				if m.ByMonthAndSource["01"]["freeCodeCamp"] != [2]int{2, 1} {
+					t.Errorf("unexpected monthly status %v", m.ByMonthAndSource["01"]["freeCodeCamp"])
+				}
+				if m.ByCategoryAndSource["freeCodeCamp"]["freeCodeCamp"] != [2]int{3, 2} {
+					t.Errorf("unexpected category status %v", m.ByCategoryAndSource["freeCodeCamp"])
+				}
+				if m.OldestUnreadArticle.Category != "freeCodeCamp" || m.TopOldestUnreadArticles[0].Category != "freeCodeCamp" {
+					t.Error("expected unread articles to be relabeled")
+				}
+				if m.QuickWins.DomainClusters[0].Articles[0].Category != "freeCodeCamp" {
+					t.Error("expected quick wins to be relabeled")
+				}
+				video := m.MediaTypes["video"]
+				if _, exists := video.BySource["fcc"]; exists || video.BySource["freeCodeCamp"] != 3 {
+					t.Errorf("expected the media type snapshot to be merged, got %v", video.BySource)
+				}
+				if video.OldestUnreadArticle.Category != "freeCodeCamp" {
+					t.Error("expected the media type snapshot's articles to be relabeled")
+				}
+			},
+		},
+		{
+			name:     "keeps canonical metadata and records alias",
+			from:     "FCC",
+			expected: true,
+			validate: func(t *testing.T, m *schema.Metrics) {
+				meta := m.SourceMetadata["freeCodeCamp"]
+				if meta.Added != "2024-01-01" || meta.Color != "#0a0a23" {
+					t.Errorf("expected canonical metadata to be kept, got %+v", meta)
+				}
+				if len(meta.Aliases) != 1 || meta.Aliases[0] != "fcc" {
+					t.Errorf("expected alias fcc, got %v", meta.Aliases)
+				}
+			},
+		},
+		{
+			name:     "unknown source leaves snapshot untouched",
+			from:     "medium",
+			expected: false,
+			validate: func(t *testing.T, m *schema.Metrics) {
+				if m.BySource["fcc"] != 2 {
+					t.Error("expected snapshot to be unchanged")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newRenameFixture()
+			if got := RenameSource(m, tt.from, "freeCodeCamp"); got != tt.expected {
+				t.Errorf("RenameSource() = %v, want %v", got, tt.expected)
+			}
+			tt.validate(t, m)
+		})
+	}
+}
+
+func TestApplySourceAliases(t *testing.T) {
+	m := &schema.Metrics{
+		BySource:       map[string]int{"fcc": 1, "gh": 2},
+		SourceMetadata: map[string]schema.SourceMeta{"GitHub": {Added: "2024-01-01"}},
+	}
+
+	ApplySourceAliases(m, map[string]string{"fcc": "freeCodeCamp", "gh": "GitHub"})
+
+	if m.BySource["freeCodeCamp"] != 1 || m.BySource["GitHub"] != 2 {
+		t.Errorf("unexpected sources %v", m.BySource)
+	}
+	if aliases := m.SourceMetadata["GitHub"].Aliases; len(aliases) != 1 || aliases[0] != "gh" {
+		t.Errorf("expected gh alias recorded, got %v", aliases)
+	}
+}
+
+func TestRenameSourceInSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	snapshots := map[string]schema.Metrics{
+		"2025-01-01.json": {BySource: map[string]int{"fcc": 1}},
+		"2025-01-08.json": {BySource: map[string]int{"GitHub": 1}},
+	}
+	for name, m := range snapshots {
+		data, _ := json.Marshal(m)
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rewritten, err := RenameSourceInSnapshots(dir, "fcc", "freeCodeCamp")
+	if err != nil {
+		t.Fatalf("RenameSourceInSnapshots() error = %v", err)
+	}
+	if rewritten != 1 {
+		t.Errorf("expected 1 rewritten snapshot, got %d", rewritten)
+	}
+
+	m, err := LoadSnapshot(dir, "2025-01-01.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.BySource["freeCodeCamp"] != 1 {
+		t.Errorf("expected renamed source in snapshot, got %v", m.BySource)
+	}
+}
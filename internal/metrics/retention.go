@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KeepAll keeps every period of a retention rule
+const KeepAll = -1
+
+// RetentionPolicy keeps the newest snapshot of each of the most recent Daily days, Weekly ISO weeks
+// and Monthly months; KeepAll keeps every period and 0 none. Snapshots hold the whole sheet, so the
+// newest snapshot of a period already consolidates the ones it replaces.
+type RetentionPolicy struct {
+	Daily   int
+	Weekly  int
+	Monthly int
+}
+
+// ParseKeep reads a retention count: a non-negative number or "all"
+func ParseKeep(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, "all") {
+		return KeepAll, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid retention count %q: want a number or all", value)
+	}
+	return n, nil
+}
+
+// SelectRetained splits snapshot filenames into those the policy keeps and those it prunes, both
+// oldest first. The newest snapshot is always kept; names that are not snapshots are ignored.
+func SelectRetained(files []string, policy RetentionPolicy) (keep, prune []string) {
+	var dated []string
+	days := make(map[string]time.Time)
+	for _, file := range files {
+		if !IsSnapshotFilename(file) {
+			continue
+		}
+		day, _ := time.Parse("2006-01-02", strings.TrimSuffix(file, ".json"))
+		dated = append(dated, file)
+		days[file] = day
+	}
+	sort.Strings(dated)
+
+	kept := make(map[string]bool)
+	rules := []struct {
+		count  int
+		period func(time.Time) string
+	}{
+		{policy.Daily, func(t time.Time) string { return t.Format("2006-01-02") }},
+		{policy.Weekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{policy.Monthly, func(t time.Time) string { return t.Format("2006-01") }},
+	}
+	for _, rule := range rules {
+		seen := make(map[string]bool)
+		for i := len(dated) - 1; i >= 0; i-- {
+			period := rule.period(days[dated[i]])
+			if seen[period] {
+				continue
+			}
+			if rule.count != KeepAll && len(seen) >= rule.count {
+				break
+			}
+			seen[period] = true
+			kept[dated[i]] = true
+		}
+	}
+	if len(dated) > 0 {
+		kept[dated[len(dated)-1]] = true
+	}
+
+	for _, file := range dated {
+		if kept[file] {
+			keep = append(keep, file)
+		} else {
+			prune = append(prune, file)
+		}
+	}
+	return keep, prune
+}
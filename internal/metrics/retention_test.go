@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeep(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    int
+		expectError bool
+	}{
+		{"30", 30, false},
+		{"0", 0, false},
+		{"all", KeepAll, false},
+		{" ALL ", KeepAll, false},
+		{"-1", 0, true},
+		{"weekly", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseKeep(tt.value)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("ParseKeep(%q) error = %v, expectError %v", tt.value, err, tt.expectError)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseKeep(%q) = %d, expected %d", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSelectRetained(t *testing.T) {
+	// 2025-03-03 to 2025-03-05 share an ISO week; 2025-02-24 starts the week before
+	files := []string{
+		"2025-01-10.json", "2025-01-31.json", "2025-02-14.json", "2025-02-24.json",
+		"2025-03-03.json", "2025-03-04.json", "2025-03-05.json", "notes.json",
+	}
+	tests := []struct {
+		name          string
+		policy        RetentionPolicy
+		expectedKeep  []string
+		expectedPrune []string
+	}{
+		{
+			name:         "keep everything",
+			policy:       RetentionPolicy{Daily: KeepAll},
+			expectedKeep: []string{"2025-01-10.json", "2025-01-31.json", "2025-02-14.json", "2025-02-24.json", "2025-03-03.json", "2025-03-04.json", "2025-03-05.json"},
+		},
+		{
+			name:          "daily keeps the most recent days",
+			policy:        RetentionPolicy{Daily: 2},
+			expectedKeep:  []string{"2025-03-04.json", "2025-03-05.json"},
+			expectedPrune: []string{"2025-01-10.json", "2025-01-31.json", "2025-02-14.json", "2025-02-24.json", "2025-03-03.json"},
+		},
+		{
+			name:          "weekly keeps the newest of each week",
+			policy:        RetentionPolicy{Weekly: 2},
+			expectedKeep:  []string{"2025-02-24.json", "2025-03-05.json"},
+			expectedPrune: []string{"2025-01-10.json", "2025-01-31.json", "2025-02-14.json", "2025-03-03.json", "2025-03-04.json"},
+		},
+		{
+			name:          "monthly keeps the newest of each month",
+			policy:        RetentionPolicy{Daily: 1, Monthly: KeepAll},
+			expectedKeep:  []string{"2025-01-31.json", "2025-02-24.json", "2025-03-05.json"},
+			expectedPrune: []string{"2025-01-10.json", "2025-02-14.json", "2025-03-03.json", "2025-03-04.json"},
+		},
+		{
+			name:          "newest is always kept",
+			policy:        RetentionPolicy{},
+			expectedKeep:  []string{"2025-03-05.json"},
+			expectedPrune: []string{"2025-01-10.json", "2025-01-31.json", "2025-02-14.json", "2025-02-24.json", "2025-03-03.json", "2025-03-04.json"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keep, prune := SelectRetained(files, tt.policy)
+			if !reflect.DeepEqual(keep, tt.expectedKeep) {
+				t.Errorf("keep = %v, expected %v", keep, tt.expectedKeep)
+			}
+			if !reflect.DeepEqual(prune, tt.expectedPrune) {
+				t.Errorf("prune = %v, expected %v", prune, tt.expectedPrune)
+			}
+		})
+	}
+}
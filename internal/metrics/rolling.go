@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"errors"
+	"io/fs"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// RollingWindowDays are the rolling windows computed for every snapshot, shortest first
+var RollingWindowDays = []int{30, 90}
+
+// inRollingWindow reports whether date falls within the days before referenceDate
+func inRollingWindow(date, referenceDate time.Time, days int) bool {
+	return date.After(referenceDate.AddDate(0, 0, -days)) && !date.After(referenceDate)
+}
+
+// computeRollingWindows counts the articles saved within each rolling window ending at referenceDate.
+// Reads and backlog change need an earlier snapshot and are filled in by ApplyRollingBaselines.
+func computeRollingWindows(articles []schema.ArticleMeta, referenceDate time.Time) []schema.RollingWindow {
+	windows := make([]schema.RollingWindow, len(RollingWindowDays))
+	for i, days := range RollingWindowDays {
+		windows[i].Days = days
+	}
+	for _, article := range articles {
+		date, err := time.Parse("2006-01-02", article.Date)
+		if err != nil {
+			continue
+		}
+		for i := range windows {
+			if inRollingWindow(date, referenceDate, windows[i].Days) {
+				windows[i].Added++
+			}
+		}
+	}
+	return windows
+}
+
+// ApplyRollingBaselines compares the snapshot with the latest snapshot in dir taken at least each
+// window's length before LastUpdated, recording the articles read and the backlog change since.
+// Windows without an old enough snapshot are left without a baseline.
+func ApplyRollingBaselines(m *schema.Metrics, dir string) error {
+	files, err := ListSnapshotFiles(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	for i := range m.Rolling {
+		window := &m.Rolling[i]
+		cutoff := m.LastUpdated.AddDate(0, 0, -window.Days).Format("2006-01-02") + ".json"
+		for j := len(files) - 1; j >= 0; j-- {
+			if files[j] > cutoff {
+				continue
+			}
+			baseline, err := LoadSnapshot(dir, files[j])
+			if err != nil {
+				return err
+			}
+			window.Read = m.ReadCount - baseline.ReadCount
+			window.BacklogChange = m.UnreadCount - baseline.UnreadCount
+			window.Baseline = files[j][:len("2006-01-02")]
+			break
+		}
+	}
+	return nil
+}
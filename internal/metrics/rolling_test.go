@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestComputeRollingWindows(t *testing.T) {
+	reference := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	articles := []schema.ArticleMeta{
+		{Date: "2026-03-31"}, // today
+		{Date: "2026-03-02"}, // 29 days ago
+		{Date: "2026-03-01"}, // 30 days ago, outside the 30-day window
+		{Date: "2026-01-01"}, // 89 days ago
+		{Date: "2025-12-31"}, // 90 days ago
+		{Date: "2026-04-01"}, // after the reference date
+		{Date: "not a date"},
+	}
+
+	windows := computeRollingWindows(articles, reference)
+	if len(windows) != 2 || windows[0].Days != 30 || windows[1].Days != 90 {
+		t.Fatalf("unexpected windows %+v", windows)
+	}
+	if windows[0].Added != 2 || windows[1].Added != 4 {
+		t.Errorf("expected 2 and 4 saves, got %d and %d", windows[0].Added, windows[1].Added)
+	}
+}
+
+func TestApplyRollingBaselines(t *testing.T) {
+	dir := t.TempDir()
+	for name, m := range map[string]schema.Metrics{
+		"2025-12-30.json": {ReadCount: 40, UnreadCount: 100},
+		"2026-02-20.json": {ReadCount: 50, UnreadCount: 90},
+		"2026-03-05.json": {ReadCount: 55, UnreadCount: 95},
+	} {
+		bytes, _ := json.Marshal(m)
+		if err := os.WriteFile(filepath.Join(dir, name), bytes, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := schema.Metrics{
+		ReadCount:   60,
+		UnreadCount: 80,
+		LastUpdated: time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC),
+		Rolling:     []schema.RollingWindow{{Days: 30, Added: 5}, {Days: 90, Added: 9}, {Days: 365}},
+	}
+	if err := ApplyRollingBaselines(&m, dir); err != nil {
+		t.Fatalf("ApplyRollingBaselines() error = %v", err)
+	}
+
+	want := []schema.RollingWindow{
+		{Days: 30, Added: 5, Read: 10, BacklogChange: -10, Baseline: "2026-02-20"},
+		{Days: 90, Added: 9, Read: 20, BacklogChange: -20, Baseline: "2025-12-30"},
+		{Days: 365},
+	}
+	for i := range want {
+		if m.Rolling[i] != want[i] {
+			t.Errorf("window %d = %+v, want %+v", i, m.Rolling[i], want[i])
+		}
+	}
+
+	if err := ApplyRollingBaselines(&m, filepath.Join(dir, "missing")); err != nil {
+		t.Errorf("expected a missing snapshot directory to be skipped, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+// Rule is a compiled category rule
+type Rule struct {
+	Name     string
+	Domain   *regexp.Regexp
+	Title    *regexp.Regexp
+	Category string
+	Tags     []string
+}
+
+// RuleSet holds category rules in config order
+type RuleSet struct {
+	Rules []Rule
+}
+
+// CompileRules validates and compiles category rules from config.
+// It returns nil when no rules are configured.
+func CompileRules(cfgs []config.CategoryRule) (*RuleSet, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+
+	set := &RuleSet{}
+	for i, cfg := range cfgs {
+		rule := Rule{Name: cfg.Name, Category: cfg.Category, Tags: cfg.Tags}
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		if cfg.Domain == "" && cfg.Title == "" {
+			return nil, fmt.Errorf("%s: domain or title pattern is required", rule.Name)
+		}
+		if cfg.Category == "" && len(cfg.Tags) == 0 {
+			return nil, fmt.Errorf("%s: category or tags is required", rule.Name)
+		}
+
+		var err error
+		if cfg.Domain != "" {
+			if rule.Domain, err = regexp.Compile(cfg.Domain); err != nil {
+				return nil, fmt.Errorf("%s: invalid domain pattern: %w", rule.Name, err)
+			}
+		}
+		if cfg.Title != "" {
+			if rule.Title, err = regexp.Compile(cfg.Title); err != nil {
+				return nil, fmt.Errorf("%s: invalid title pattern: %w", rule.Name, err)
+			}
+		}
+		set.Rules = append(set.Rules, rule)
+	}
+
+	return set, nil
+}
+
+// Matches reports whether the rule applies to an article
+func (r Rule) Matches(article schema.ArticleMeta) bool {
+	if r.Domain != nil && !r.Domain.MatchString(LinkDomain(article.Link)) {
+		return false
+	}
+	if r.Title != nil && !r.Title.MatchString(article.Title) {
+		return false
+	}
+	return true
+}
+
+// Classify returns the category from the first matching rule that sets one (or fallback),
+// the tags of every matching rule, and the names of the rules that matched
+func (s *RuleSet) Classify(article schema.ArticleMeta, fallback string) (string, []string, []string) {
+	category := fallback
+	categorized := false
+	var tags, matched []string
+
+	for _, rule := range s.Rules {
+		if !rule.Matches(article) {
+			continue
+		}
+		matched = append(matched, rule.Name)
+		if rule.Category != "" && !categorized {
+			category = rule.Category
+			categorized = true
+		}
+		for _, tag := range rule.Tags {
+			tags = appendAlias(tags, tag)
+		}
+	}
+
+	return category, tags, matched
+}
+
+// LinkDomain returns the lowercase host of a link without a leading www.
+func LinkDomain(link string) string {
+	parsed, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// BuildDomainMap maps the domain of each provider URL (header row included) to the provider name;
+// the first provider listed for a domain wins
+func BuildDomainMap(providerRows [][]interface{}) map[string]string {
+	domains := make(map[string]string)
+	for i := 1; i < len(providerRows); i++ {
+		row := providerRows[i]
+		if len(row) <= ProvidersColURL {
+			continue
+		}
+		domain := LinkDomain(fmt.Sprintf("%v", row[ProvidersColURL]))
+		if _, exists := domains[domain]; domain != "" && !exists {
+			domains[domain] = fmt.Sprintf("%v", row[ProvidersColName])
+		}
+	}
+	return domains
+}
+
+// SourceForDomain returns the provider owning a domain or one of its parent domains
+// (eng.shopify.com falls under shopify.com), else the domain itself
+func SourceForDomain(domain string, domainMap map[string]string) string {
+	for candidate := domain; strings.Contains(candidate, "."); {
+		if name, exists := domainMap[candidate]; exists {
+			return name
+		}
+		_, candidate, _ = strings.Cut(candidate, ".")
+	}
+	return domain
+}
+
+// applyCategoryRules rebuilds the category aggregates from rule-assigned categories,
+// tallies tags, and records how many articles each rule matched. A topic in the sheet
+// outranks the rules.
+func applyCategoryRules(metrics *schema.Metrics, articles []schema.ArticleMeta, rules *RuleSet) {
+	metrics.ByCategory = make(map[string][2]int)
+	metrics.UnreadByCategory = make(map[string]int)
+	metrics.ByCategoryAndSource = make(map[string]map[string][2]int)
+	metrics.ByTag = make(map[string][2]int)
+	metrics.CategoryRuleMatches = make(map[string]int)
+	for _, rule := range rules.Rules {
+		metrics.CategoryRuleMatches[rule.Name] = 0
+	}
+
+	for _, article := range articles {
+		category, tags, matched := rules.Classify(article, article.Category)
+		if article.Topic != "" {
+			category = article.Topic
+		}
+		for _, name := range matched {
+			metrics.CategoryRuleMatches[name]++
+		}
+
+		if category != "" {
+			metrics.ByCategory[category] = addReadStatus(metrics.ByCategory[category], article.Read)
+			if !article.Read {
+				metrics.UnreadByCategory[category]++
+			}
+			if article.Category != "" {
+				addCategorySource(metrics, category, article.Category, article.Read)
+			}
+		}
+		for _, tag := range tags {
+			metrics.ByTag[tag] = addReadStatus(metrics.ByTag[tag], article.Read)
+		}
+	}
+}
+
+// addReadStatus increments the read or unread slot of a [read, unread] pair
+func addReadStatus(status [2]int, read bool) [2]int {
+	if read {
+		status[0]++
+	} else {
+		status[1]++
+	}
+	return status
+}
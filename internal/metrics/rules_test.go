@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func TestCompileRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       []config.CategoryRule
+		expectNil   bool
+		expectError bool
+	}{
+		{name: "no rules", expectNil: true},
+		{name: "valid rule", rules: []config.CategoryRule{{Domain: `github\.blog`, Category: "Engineering"}}},
+		{name: "missing pattern", rules: []config.CategoryRule{{Category: "Engineering"}}, expectError: true},
+		{name: "missing outcome", rules: []config.CategoryRule{{Title: "Go"}}, expectError: true},
+		{name: "invalid regex", rules: []config.CategoryRule{{Title: "(", Category: "X"}}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := CompileRules(tt.rules)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("CompileRules() error = %v, expectError %v", err, tt.expectError)
+			}
+			if !tt.expectError && (set == nil) != tt.expectNil {
+				t.Errorf("CompileRules() nil = %v, want %v", set == nil, tt.expectNil)
+			}
+		})
+	}
+}
+
+func TestRuleSetClassify(t *testing.T) {
+	set, err := CompileRules([]config.CategoryRule{
+		{Name: "ai", Title: `(?i)\b(llm|gpt)\b`, Category: "AI", Tags: []string{"ml"}},
+		{Name: "github", Domain: `^github\.blog$`, Category: "Engineering", Tags: []string{"devtools"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		article          schema.ArticleMeta
+		expectedCategory string
+		expectedTags     int
+		expectedMatches  int
+	}{
+		{"no match keeps fallback", schema.ArticleMeta{Title: "CSS tips", Link: "https://css-tricks.com/a"}, "Source", 0, 0},
+		{"title match", schema.ArticleMeta{Title: "Fine-tuning an LLM", Link: "https://example.com"}, "AI", 1, 1},
+		{"first category wins, tags accumulate", schema.ArticleMeta{Title: "GPT at GitHub", Link: "https://www.github.blog/x"}, "AI", 2, 2},
+		{"domain match", schema.ArticleMeta{Title: "Actions", Link: "https://github.blog/actions"}, "Engineering", 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, tags, matched := set.Classify(tt.article, "Source")
+			if category != tt.expectedCategory {
+				t.Errorf("category = %q, want %q", category, tt.expectedCategory)
+			}
+			if len(tags) != tt.expectedTags {
+				t.Errorf("tags = %v, want %d", tags, tt.expectedTags)
+			}
+			if len(matched) != tt.expectedMatches {
+				t.Errorf("matched = %v, want %d", matched, tt.expectedMatches)
+			}
+		})
+	}
+}
+
+func TestLinkDomain(t *testing.T) {
+	tests := map[string]string{
+		"https://www.GitHub.blog/post": "github.blog",
+		"http://example.com:8080/a":    "example.com",
+		"not a url":                    "",
+		"":                             "",
+	}
+	for link, expected := range tests {
+		if got := LinkDomain(link); got != expected {
+			t.Errorf("LinkDomain(%q) = %q, want %q", link, got, expected)
+		}
+	}
+}
+
+func TestSourceForDomain(t *testing.T) {
+	domains := BuildDomainMap([][]interface{}{
+		{"name", "url"},
+		{"GitHub", "https://github.blog/engineering"},
+		{"Shopify", "https://www.shopify.com/blog"},
+		{"Substack", "https://jane.substack.com"},
+		{"Duplicate", "https://github.blog"},
+		{"No URL"},
+	})
+
+	tests := map[string]string{
+		"github.blog":             "GitHub",
+		"engineering.shopify.com": "Shopify",
+		"jane.substack.com":       "Substack",
+		"john.substack.com":       "john.substack.com",
+		"example.com":             "example.com",
+		"":                        "",
+	}
+	for domain, expected := range tests {
+		if got := SourceForDomain(domain, domains); got != expected {
+			t.Errorf("SourceForDomain(%q) = %q, want %q", domain, got, expected)
+		}
+	}
+}
+
+func TestComputeMetricsWithRules(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2025-01-01", "Building an LLM app", "https://stripe.com/blog/llm", "stripe", "TRUE"},
+		{"2025-01-02", "Payments at scale", "https://stripe.com/blog/scale", "stripe", "FALSE"},
+		{"2025-01-03", "LLM evals", "https://stripe.com/blog/evals", "stripe", "FALSE", "", "", "", "", "", "", "Testing"},
+	}
+	rules, err := CompileRules([]config.CategoryRule{
+		{Name: "ai", Title: "LLM", Category: "AI", Tags: []string{"ml"}},
+		{Name: "unused", Domain: "medium\\.com", Category: "Blogs"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := ComputeMetricsWithOptions(rows, nil, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), ComputeOptions{Rules: rules})
+	if err != nil {
+		t.Fatalf("ComputeMetricsWithOptions() error = %v", err)
+	}
+
+	if m.ByCategory["AI"] != [2]int{1, 0} {
+		t.Errorf("expected AI category [1 0], got %v", m.ByCategory["AI"])
+	}
+	if m.ByCategory["Stripe"] != [2]int{0, 1} {
+		t.Errorf("expected unmatched article to keep source category, got %v", m.ByCategory)
+	}
+	if m.ByCategory["Testing"] != [2]int{0, 1} {
+		t.Errorf("expected the sheet topic to outrank the rules, got %v", m.ByCategory)
+	}
+	if m.ByCategoryAndSource["AI"]["Stripe"] != [2]int{1, 0} || m.ByCategoryAndSource["Testing"]["Stripe"] != [2]int{0, 1} {
+		t.Errorf("expected sources under rule categories and topics, got %v", m.ByCategoryAndSource)
+	}
+	if m.BySource["Stripe"] != 3 {
+		t.Errorf("expected source counts unaffected, got %v", m.BySource)
+	}
+	if m.ByTag["ml"] != [2]int{1, 1} {
+		t.Errorf("expected ml tag [1 0], got %v", m.ByTag)
+	}
+	if m.CategoryRuleMatches["ai"] != 2 || m.CategoryRuleMatches["unused"] != 0 {
+		t.Errorf("unexpected rule report %v", m.CategoryRuleMatches)
+	}
+	if _, exists := m.CategoryRuleMatches["unused"]; !exists {
+		t.Error("expected rules that never matched to be reported")
+	}
+}
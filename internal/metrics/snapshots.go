@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// IsSnapshotFilename reports whether name looks like a YYYY-MM-DD.json metrics snapshot
+func IsSnapshotFilename(name string) bool {
+	if !strings.HasSuffix(name, ".json") {
+		return false
+	}
+	_, err := time.Parse("2006-01-02", strings.TrimSuffix(name, ".json"))
+	return err == nil
+}
+
+// ListSnapshotFiles returns all snapshot filenames in dir, sorted oldest first
+func ListSnapshotFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read metrics directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && IsSnapshotFilename(entry.Name()) {
+			files = append(files, entry.Name())
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// LoadSnapshot reads and parses a single metrics snapshot file
+func LoadSnapshot(dir, filename string) (*schema.Metrics, error) {
+	content, err := os.ReadFile(filepath.Join(dir, filename))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read metrics file %s: %w", filename, err)
+	}
+
+	var m schema.Metrics
+	if err := json.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("unable to parse metrics JSON from %s: %w", filename, err)
+	}
+
+	ReportConsistency(filename, m)
+	return &m, nil
+}
+
+// LoadSnapshotBefore loads the most recent snapshot strictly older than filename.
+// It returns nil without an error when no earlier snapshot exists.
+func LoadSnapshotBefore(dir, filename string) (*schema.Metrics, error) {
+	files, err := ListSnapshotFiles(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for i := len(files) - 1; i >= 0; i-- {
+		if files[i] < filename {
+			return LoadSnapshot(dir, files[i])
+		}
+	}
+
+	return nil, nil
+}
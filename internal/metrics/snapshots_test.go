@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestIsSnapshotFilename(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"2026-01-01.json", true},
+		{"2026-13-01.json", false},
+		{"latest.json", false},
+		{"2026-01-01.txt", false},
+		{".gitkeep", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSnapshotFilename(tt.name); got != tt.expected {
+				t.Errorf("IsSnapshotFilename(%q) = %v, want %v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadSnapshotBefore(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for name, total := range map[string]int{"2026-01-01.json": 100, "2026-01-08.json": 110} {
+		bytes, _ := json.Marshal(schema.Metrics{TotalArticles: total})
+		if err := os.WriteFile(filepath.Join(tmpDir, name), bytes, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".gitkeep"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("file not yet written uses latest earlier snapshot", func(t *testing.T) {
+		prev, err := LoadSnapshotBefore(tmpDir, "2026-01-15.json")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if prev == nil || prev.TotalArticles != 110 {
+			t.Errorf("expected snapshot with 110 articles, got %+v", prev)
+		}
+	})
+
+	t.Run("no earlier snapshot returns nil", func(t *testing.T) {
+		prev, err := LoadSnapshotBefore(tmpDir, "2026-01-01.json")
+		if err != nil || prev != nil {
+			t.Errorf("expected nil, nil; got %+v, %v", prev, err)
+		}
+	})
+
+	t.Run("missing directory returns nil", func(t *testing.T) {
+		prev, err := LoadSnapshotBefore(filepath.Join(tmpDir, "missing"), "2026-01-01.json")
+		if err != nil || prev != nil {
+			t.Errorf("expected nil, nil; got %+v, %v", prev, err)
+		}
+	})
+}
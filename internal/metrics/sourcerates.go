@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// BuildSourceReadRates extracts each source's read rate from snapshots keyed by YYYY-MM-DD, sorted
+// oldest first. Rates are percentages rounded to one decimal place; a source is missing from the
+// snapshots taken before it was tracked.
+func BuildSourceReadRates(snapshots map[string]schema.Metrics) []schema.SourceReadRatePoint {
+	points := make([]schema.SourceReadRatePoint, 0, len(snapshots))
+	for date, m := range snapshots {
+		rates := make(map[string]float64, len(m.BySource))
+		for source, count := range m.BySource {
+			if count > 0 {
+				rates[source] = math.Round(float64(m.BySourceReadStatus[source][0])/float64(count)*1000) / 10
+			}
+		}
+		points = append(points, schema.SourceReadRatePoint{Date: date, Rates: rates})
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Date < points[j].Date
+	})
+	return points
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestBuildSourceReadRates(t *testing.T) {
+	snapshots := map[string]schema.Metrics{
+		"2026-02-01": {
+			BySource:           map[string]int{"GitHub": 3, "Substack": 4},
+			BySourceReadStatus: map[string][2]int{"GitHub": {2, 1}, "Substack": {3, 1}},
+		},
+		"2026-01-25": {
+			BySource:           map[string]int{"GitHub": 2, "Empty": 0},
+			BySourceReadStatus: map[string][2]int{"GitHub": {1, 1}, "substack_author_count": {5, 0}},
+		},
+	}
+
+	expected := []schema.SourceReadRatePoint{
+		{Date: "2026-01-25", Rates: map[string]float64{"GitHub": 50}},
+		{Date: "2026-02-01", Rates: map[string]float64{"GitHub": 66.7, "Substack": 75}},
+	}
+	if points := BuildSourceReadRates(snapshots); !reflect.DeepEqual(points, expected) {
+		t.Errorf("BuildSourceReadRates() = %+v, expected %+v", points, expected)
+	}
+}
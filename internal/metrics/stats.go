@@ -11,7 +11,7 @@ func CalculateTopReadRateSource(metrics schema.Metrics) string {
 	var topSource string
 	var topRate float64
 	for name, counts := range metrics.BySourceReadStatus {
-		if name == "substack_author_count" {
+		if name == legacySubstackAuthorCountKey {
 			continue
 		}
 		total := counts[0] + counts[1]
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/victoriacheng15/personal-reading-analytics/internal"
 	"github.com/victoriacheng15/personal-reading-analytics/internal/ai"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
 )
 
 // GenerateAndSaveDeltaAnalysis generates an AI delta analysis comparing the current metrics with the previous week's.
@@ -18,8 +20,7 @@ func GenerateAndSaveDeltaAnalysis(ctx context.Context, metricsDir string, curren
 	prevMetrics, err := loadPreviousMetrics(metricsDir, currentFilename)
 	if err != nil {
 		// Log warning but don't fail, just return.
-		// In a real logger we'd log this. For now printing to stderr is acceptable for CLI.
-		fmt.Fprintf(os.Stderr, "Warning: Could not load previous metrics for comparison: %v\n", err)
+		slog.Warn("Could not load previous metrics for comparison", "err", err)
 	}
 
 	prompt := constructPrompt(currentMetrics, prevMetrics)
@@ -27,14 +28,14 @@ func GenerateAndSaveDeltaAnalysis(ctx context.Context, metricsDir string, curren
 	client, err := ai.NewClient(ctx)
 	if err != nil {
 		// If client init fails (e.g. no key), we silently skip delta analysis
-		fmt.Fprintf(os.Stderr, "Skipping AI delta analysis: %v\n", err)
+		slog.Info("Skipping AI delta analysis", "err", err)
 		return nil
 	}
 	defer client.Close()
 
 	deltaAnalysis, err := client.GenerateContent(ctx, prompt)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error generating AI delta analysis: %v\n", err)
+		slog.Error("Error generating AI delta analysis", "err", err)
 		currentMetrics.AIDeltaAnalysis = "AI delta analysis unavailable at this time."
 	} else {
 		currentMetrics.AIDeltaAnalysis = deltaAnalysis
@@ -52,7 +53,7 @@ func loadPreviousMetrics(dir, currentFilename string) (*internal.Metrics, error)
 
 	var jsonFiles []string
 	for _, f := range files {
-		if !f.IsDir() && strings.HasSuffix(f.Name(), ".json") && f.Name() != ".gitkeep" {
+		if !f.IsDir() && IsSnapshotFilename(f.Name()) {
 			jsonFiles = append(jsonFiles, f.Name())
 		}
 	}
@@ -92,7 +93,7 @@ func saveMetrics(dir, filename string, metrics *internal.Metrics) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal metrics: %w", err)
 	}
-	return os.WriteFile(path, data, 0644)
+	return safefile.WriteFile(path, data, 0644)
 }
 
 func constructPrompt(curr, prev *internal.Metrics) string {
@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// WrappedTopSources is how many sources the year in review ranks
+const WrappedTopSources = 5
+
+// BuildYearInReview summarizes the snapshots (keyed by YYYY-MM-DD) taken during year. Reads are
+// measured from the last snapshot before the year, or from zero when tracking began that year.
+func BuildYearInReview(snapshots map[string]schema.Metrics, year string) (schema.YearInReview, error) {
+	review := schema.YearInReview{Year: year}
+
+	var dates []string
+	for date := range snapshots {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	var baseline *schema.Metrics
+	var baselineDate string
+	var inYear []string
+	for _, date := range dates {
+		switch {
+		case date < year:
+			m := snapshots[date]
+			baseline, baselineDate = &m, date
+		case strings.HasPrefix(date, year+"-"):
+			inYear = append(inYear, date)
+		}
+	}
+	if len(inYear) == 0 {
+		return review, fmt.Errorf("no snapshots found for %s", year)
+	}
+	review.Snapshots = len(inYear)
+
+	last := snapshots[inYear[len(inYear)-1]]
+	review.Saved = last.ByYear[year]
+	review.Read = last.ReadCount
+	if baseline != nil {
+		review.Read -= baseline.ReadCount
+	}
+	review.TopSources = topSourcesOfYear(last, baseline)
+	review.BusiestMonth, review.BusiestMonthCount = busiestMonth(last.ByYearAndMonth[year])
+
+	// Streaks and backlog drops compare each snapshot of the year with the one before it
+	prev, prevDate := baseline, baselineDate
+	streak, streakStart := 0, ""
+	for _, date := range inYear {
+		curr := snapshots[date]
+		if prev != nil {
+			if curr.ReadCount > prev.ReadCount {
+				if streak == 0 {
+					streakStart = date
+				}
+				streak++
+				if streak > review.LongestStreak {
+					review.LongestStreak, review.StreakStart, review.StreakEnd = streak, streakStart, date
+				}
+			} else {
+				streak = 0
+			}
+			if drop := prev.UnreadCount - curr.UnreadCount; drop > review.BacklogReduction {
+				review.BacklogReduction, review.BacklogReducedFrom, review.BacklogReducedTo = drop, prevDate, date
+			}
+		}
+		prev, prevDate = &curr, date
+	}
+
+	return review, nil
+}
+
+// topSourcesOfYear ranks sources by the articles read between baseline and last, then by articles saved
+func topSourcesOfYear(last schema.Metrics, baseline *schema.Metrics) []schema.SourceInfo {
+	var top []schema.SourceInfo
+	for name, status := range last.BySourceReadStatus {
+		saved, read := last.BySource[name], status[0]
+		if baseline != nil {
+			saved -= baseline.BySource[name]
+			read -= baseline.BySourceReadStatus[name][0]
+		}
+		if read <= 0 && saved <= 0 {
+			continue
+		}
+		top = append(top, schema.SourceInfo{
+			Name:  name,
+			Count: max(saved, 0),
+			Read:  max(read, 0),
+			Color: last.SourceMetadata[name].Color,
+		})
+	}
+
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Read != top[j].Read {
+			return top[i].Read > top[j].Read
+		}
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Name < top[j].Name
+	})
+	if len(top) > WrappedTopSources {
+		top = top[:WrappedTopSources]
+	}
+	return top
+}
+
+// busiestMonth returns the full name and count of the month ("01".."12") with the most articles,
+// preferring the earlier month on ties
+func busiestMonth(byMonth map[string]int) (string, int) {
+	best, count := "", 0
+	for month, n := range byMonth {
+		if n > count || (n == count && month < best) {
+			best, count = month, n
+		}
+	}
+	if best == "" {
+		return "", 0
+	}
+	parsed, err := time.Parse("01", best)
+	if err != nil {
+		return best, count
+	}
+	return parsed.Month().String(), count
+}
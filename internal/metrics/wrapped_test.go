@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestBuildYearInReview(t *testing.T) {
+	snapshot := func(read, unread int, bySource map[string][2]int) schema.Metrics {
+		m := schema.Metrics{ReadCount: read, UnreadCount: unread, BySource: map[string]int{}, BySourceReadStatus: bySource}
+		for name, status := range bySource {
+			m.BySource[name] = status[0] + status[1]
+		}
+		return m
+	}
+
+	snapshots := map[string]schema.Metrics{
+		"2024-12-27": snapshot(10, 20, map[string][2]int{"GitHub": {8, 10}, "Stripe": {2, 10}}),
+		"2025-01-03": snapshot(12, 20, map[string][2]int{"GitHub": {9, 11}, "Stripe": {3, 9}}),
+		"2025-01-10": snapshot(15, 12, map[string][2]int{"GitHub": {9, 5}, "Stripe": {6, 7}}),
+		"2025-01-17": snapshot(15, 14, map[string][2]int{"GitHub": {9, 7}, "Stripe": {6, 7}}),
+		"2025-01-24": snapshot(20, 13, map[string][2]int{"GitHub": {9, 6}, "Stripe": {10, 5}, "Shopify": {1, 2}}),
+		"2026-01-02": snapshot(30, 5, map[string][2]int{"GitHub": {15, 0}, "Stripe": {15, 5}}),
+	}
+	last := snapshots["2025-01-24"]
+	last.ByYear = map[string]int{"2025": 9}
+	last.ByYearAndMonth = map[string]map[string]int{"2025": {"01": 4, "02": 5, "03": 5}}
+	last.SourceMetadata = map[string]schema.SourceMeta{"Stripe": {Color: "#635bff"}}
+	snapshots["2025-01-24"] = last
+
+	review, err := BuildYearInReview(snapshots, "2025")
+	if err != nil {
+		t.Fatalf("BuildYearInReview() error = %v", err)
+	}
+
+	if review.Snapshots != 4 || review.Saved != 9 || review.Read != 10 {
+		t.Errorf("expected 4 snapshots, 9 saved and 10 read, got %+v", review)
+	}
+	if review.BusiestMonth != "February" || review.BusiestMonthCount != 5 {
+		t.Errorf("expected February (5) to win the tie with March, got %s (%d)", review.BusiestMonth, review.BusiestMonthCount)
+	}
+	if len(review.TopSources) != 3 {
+		t.Fatalf("expected 3 sources, got %+v", review.TopSources)
+	}
+	if top := review.TopSources[0]; top.Name != "Stripe" || top.Read != 8 || top.Count != 3 || top.Color != "#635bff" {
+		t.Errorf("expected Stripe with 8 reads first, got %+v", top)
+	}
+	if review.TopSources[1].Name != "Shopify" || review.TopSources[2].Name != "GitHub" {
+		t.Errorf("expected ties broken by articles saved, got %+v", review.TopSources)
+	}
+	if review.LongestStreak != 2 || review.StreakStart != "2025-01-03" || review.StreakEnd != "2025-01-10" {
+		t.Errorf("expected a 2-snapshot streak from the first week, got %d (%s to %s)", review.LongestStreak, review.StreakStart, review.StreakEnd)
+	}
+	if review.BacklogReduction != 8 || review.BacklogReducedFrom != "2025-01-03" || review.BacklogReducedTo != "2025-01-10" {
+		t.Errorf("expected the backlog to drop by 8, got %d (%s to %s)", review.BacklogReduction, review.BacklogReducedFrom, review.BacklogReducedTo)
+	}
+}
+
+func TestBuildYearInReviewWithoutBaseline(t *testing.T) {
+	snapshots := map[string]schema.Metrics{
+		"2025-06-01": {ReadCount: 4, UnreadCount: 6},
+		"2025-06-08": {ReadCount: 4, UnreadCount: 8},
+	}
+
+	review, err := BuildYearInReview(snapshots, "2025")
+	if err != nil {
+		t.Fatalf("BuildYearInReview() error = %v", err)
+	}
+	if review.Read != 4 || review.LongestStreak != 0 || review.BacklogReduction != 0 {
+		t.Errorf("expected reads counted from zero and no streak, got %+v", review)
+	}
+
+	if _, err := BuildYearInReview(snapshots, "2024"); err == nil {
+		t.Error("expected an error for a year without snapshots")
+	}
+}
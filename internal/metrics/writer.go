@@ -0,0 +1,173 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/googleauth"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+)
+
+// SheetsWriter interface abstracts sheet mutations for testability
+type SheetsWriter interface {
+	UpdateCells(spreadsheetID string, updates map[string]interface{}) error
+	AppendRows(spreadsheetID, appendRange string, rows [][]interface{}) error
+	DeleteRows(spreadsheetID string, sheetID int64, rowIndexes []int) error
+}
+
+// SheetServiceWriter implements SheetsWriter using sheets.Service
+type SheetServiceWriter struct {
+	service *sheets.Service
+}
+
+// NewSheetsReadWriter creates a fetcher and writer sharing one read-write Sheets service
+func NewSheetsReadWriter(ctx context.Context, credentialsPath string) (*SheetServiceFetcher, *SheetServiceWriter, error) {
+	auth, err := googleauth.ClientOption(ctx, credentialsPath, sheets.SpreadsheetsScope)
+	if err != nil {
+		return nil, nil, err
+	}
+	service, err := sheets.NewService(ctx, auth, option.WithScopes(sheets.SpreadsheetsScope))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create sheets client: %w", err)
+	}
+	return &SheetServiceFetcher{service: service}, &SheetServiceWriter{service: service}, nil
+}
+
+// UpdateCells writes single values to A1-notation cells (e.g. "articles!E12") in one batch request
+func (s *SheetServiceWriter) UpdateCells(spreadsheetID string, updates map[string]interface{}) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	var data []*sheets.ValueRange
+	for cell, value := range updates {
+		data = append(data, &sheets.ValueRange{Range: cell, Values: [][]interface{}{{value}}})
+	}
+
+	_, err := s.service.Spreadsheets.Values.BatchUpdate(spreadsheetID, &sheets.BatchUpdateValuesRequest{
+		ValueInputOption: "USER_ENTERED",
+		Data:             data,
+	}).Do()
+	return err
+}
+
+// AppendRows appends rows after the last row of data in appendRange
+func (s *SheetServiceWriter) AppendRows(spreadsheetID, appendRange string, rows [][]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	_, err := s.service.Spreadsheets.Values.Append(spreadsheetID, appendRange, &sheets.ValueRange{Values: rows}).
+		ValueInputOption("USER_ENTERED").
+		InsertDataOption("INSERT_ROWS").
+		Do()
+	return err
+}
+
+// DeleteRows removes rows by zero-based index, deleting bottom-up so earlier indexes stay valid.
+// A repeated index is deleted once.
+func (s *SheetServiceWriter) DeleteRows(spreadsheetID string, sheetID int64, rowIndexes []int) error {
+	if len(rowIndexes) == 0 {
+		return nil
+	}
+
+	_, err := s.service.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{Requests: deleteRowRequests(sheetID, rowIndexes)}).Do()
+	return err
+}
+
+// deleteRowRequests builds one delete request per distinct row index, highest index first.
+// Deleting a repeated index twice would remove the unrelated row that moved into its place.
+func deleteRowRequests(sheetID int64, rowIndexes []int) []*sheets.Request {
+	sorted := append([]int(nil), rowIndexes...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+
+	var requests []*sheets.Request
+	for i, idx := range sorted {
+		if i > 0 && idx == sorted[i-1] {
+			continue
+		}
+		requests = append(requests, &sheets.Request{
+			DeleteDimension: &sheets.DeleteDimensionRequest{
+				Range: &sheets.DimensionRange{
+					SheetId:    sheetID,
+					Dimension:  "ROWS",
+					StartIndex: int64(idx),
+					EndIndex:   int64(idx + 1),
+				},
+			},
+		})
+	}
+	return requests
+}
+
+// FindSheetID returns the numeric sheet ID for a sheet title, as required by structural updates
+func FindSheetID(spreadsheet *sheets.Spreadsheet, title string) (int64, error) {
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties != nil && sheet.Properties.Title == title {
+			return sheet.Properties.SheetId, nil
+		}
+	}
+	return 0, fmt.Errorf("sheet %q not found", title)
+}
+
+// FindSheetNames discovers the Articles and Providers sheet names from spreadsheet metadata
+func FindSheetNames(spreadsheet *sheets.Spreadsheet) (string, string) {
+	return findSheetNames(spreadsheet)
+}
+
+// ReadCell returns the A1-notation cell holding the read checkbox for a zero-based row index
+func ReadCell(articlesSheet string, rowIndex int) string {
+	return fmt.Sprintf("%s!%c%d", articlesSheet, 'A'+ColRead, rowIndex+1)
+}
+
+// ArchiveCell returns the A1-notation cell holding the archive URL for a zero-based row index
+func ArchiveCell(articlesSheet string, rowIndex int) string {
+	return fmt.Sprintf("%s!%c%d", articlesSheet, 'A'+ColArchive, rowIndex+1)
+}
+
+// ArticleRow formats an article as an Articles sheet row (date, title, link, source, read), followed
+// by the media type and duration columns for videos and podcasts
+func ArticleRow(article schema.ArticleMeta) []interface{} {
+	read := "FALSE"
+	if article.Read {
+		read = "TRUE"
+	}
+	row := []interface{}{article.Date, article.Title, article.Link, article.Category, read}
+	if article.MediaType == "" && article.DurationMinutes == 0 {
+		return row
+	}
+
+	for len(row) < ColMediaType {
+		row = append(row, "")
+	}
+	duration := ""
+	if article.DurationMinutes > 0 {
+		duration = strconv.Itoa(article.DurationMinutes)
+	}
+	return append(row, article.MediaType, duration)
+}
+
+// ArticlesRange returns the A1 range covering the article columns, used when appending rows
+func ArticlesRange(articlesSheet string) string {
+	return fmt.Sprintf("%s!A:%c", articlesSheet, 'A'+ColTopic)
+}
+
+// ExistingLinks indexes the links already present in article rows (header included),
+// normalizing DOI/ISBN identifiers so both spellings count as the same article
+func ExistingLinks(rows [][]interface{}) map[string]bool {
+	links := make(map[string]bool)
+	for i := 1; i < len(rows); i++ {
+		if len(rows[i]) > ColLink {
+			if link := identity.Parse(fmt.Sprintf("%v", rows[i][ColLink])).URL(); link != "" {
+				links[link] = true
+			}
+		}
+	}
+	return links
+}
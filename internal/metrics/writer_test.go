@@ -0,0 +1,19 @@
+package metrics
+
+import "testing"
+
+func TestDeleteRowRequests(t *testing.T) {
+	requests := deleteRowRequests(7, []int{3, 5, 3, 1})
+
+	var starts []int64
+	for _, request := range requests {
+		r := request.DeleteDimension.Range
+		if r.SheetId != 7 || r.EndIndex != r.StartIndex+1 {
+			t.Errorf("unexpected range %+v", r)
+		}
+		starts = append(starts, r.StartIndex)
+	}
+	if len(starts) != 3 || starts[0] != 5 || starts[1] != 3 || starts[2] != 1 {
+		t.Errorf("expected rows 5, 3, 1 deleted once each bottom-up, got %v", starts)
+	}
+}
@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func init() {
+	Register("discord", NewDiscordNotifier)
+}
+
+// Embed colors: green when the read rate rose, orange when it fell, sky blue otherwise
+const (
+	discordColorUp      = 0x16a34a
+	discordColorDown    = 0xea580c
+	discordColorNeutral = 0x0369a1
+)
+
+// DiscordNotifier posts to a Discord channel webhook
+type DiscordNotifier struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+// NewDiscordNotifier builds a DiscordNotifier from the webhook_url option, falling back to DISCORD_WEBHOOK_URL
+func NewDiscordNotifier(cfg config.NotifierConfig) (Notifier, error) {
+	notifier := &DiscordNotifier{WebhookURL: cfg.Option("webhook_url", "DISCORD_WEBHOOK_URL"), Client: newHTTPClient()}
+	if notifier.WebhookURL == "" {
+		return nil, fmt.Errorf("discord notifier needs a webhook_url option or DISCORD_WEBHOOK_URL")
+	}
+	return notifier, nil
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type discordEmbed struct {
+	Title  string         `json:"title"`
+	Color  int            `json:"color"`
+	Fields []discordField `json:"fields"`
+}
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+// discordPayload formats the summary as one embed, colored by the read rate change
+func discordPayload(s Summary) discordMessage {
+	color := discordColorNeutral
+	switch {
+	case s.HasPrevious && s.ReadRateDelta > 0:
+		color = discordColorUp
+	case s.HasPrevious && s.ReadRateDelta < 0:
+		color = discordColorDown
+	}
+	return discordMessage{Embeds: []discordEmbed{{
+		Title: headline(s),
+		Color: color,
+		Fields: []discordField{
+			{Name: "New articles", Value: newArticles(s), Inline: true},
+			{Name: "Read rate", Value: readRateChange(s), Inline: true},
+			{Name: "Most unread source", Value: mostUnread(s), Inline: true},
+		},
+	}}}
+}
+
+// Notify posts the summary to the webhook
+func (n *DiscordNotifier) Notify(ctx context.Context, s Summary) error {
+	if err := postJSON(ctx, n.Client, n.WebhookURL, discordPayload(s)); err != nil {
+		return fmt.Errorf("failed to notify discord: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/locale"
+)
+
+// Summary is what a metrics run reports to each channel
+type Summary struct {
+	Date          time.Time
+	NewArticles   int // saved since the previous snapshot
+	TotalArticles int
+	ReadRate      float64
+	ReadRateDelta float64 // percentage points since the previous snapshot
+
+	// Backlog is the unread count, and PreviousBacklog the previous snapshot's
+	Backlog         int
+	PreviousBacklog int
+
+	// MostUnreadSource is the source with the largest backlog
+	MostUnreadSource string
+	MostUnreadCount  int
+
+	// HasPrevious is false on the first snapshot, when there is nothing to compare against
+	HasPrevious bool
+
+	// Title replaces the default headline wording, and Locale formats its numbers and dates
+	Title  string
+	Locale locale.Locale
+}
+
+// BuildSummary compares the latest snapshot against the previous one, which may be nil
+func BuildSummary(prev *schema.Metrics, latest schema.Metrics) Summary {
+	s := Summary{
+		Date:          latest.LastUpdated,
+		TotalArticles: latest.TotalArticles,
+		ReadRate:      latest.ReadRate,
+		Backlog:       latest.UnreadCount,
+	}
+	if prev != nil {
+		s.HasPrevious = true
+		s.PreviousBacklog = prev.UnreadCount
+		s.NewArticles = latest.TotalArticles - prev.TotalArticles
+		s.ReadRateDelta = latest.ReadRate - prev.ReadRate
+	}
+
+	names := make([]string, 0, len(latest.UnreadBySource))
+	for name := range latest.UnreadBySource {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if count := latest.UnreadBySource[name]; count > s.MostUnreadCount {
+			s.MostUnreadSource, s.MostUnreadCount = name, count
+		}
+	}
+	return s
+}
+
+// Notifier posts a summary to one channel
+type Notifier interface {
+	Notify(ctx context.Context, s Summary) error
+}
+
+// Factory builds a Notifier from its config entry
+type Factory func(cfg config.NotifierConfig) (Notifier, error)
+
+var registry = map[string]Factory{}
+
+// Register makes a notifier type available to config; it is called from each channel's init
+func Register(notifierType string, factory Factory) {
+	registry[notifierType] = factory
+}
+
+// Types lists the registered notifier types in alphabetical order
+func Types() []string {
+	var types []string
+	for notifierType := range registry {
+		types = append(types, notifierType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// New builds the notifier registered for cfg.Type
+func New(cfg config.NotifierConfig) (Notifier, error) {
+	factory, exists := registry[cfg.Type]
+	if !exists {
+		return nil, fmt.Errorf("unknown notifier type %q (available: %v)", cfg.Type, Types())
+	}
+	return factory(cfg)
+}
+
+// NotifyAll sends the summary to every configured channel. A failing channel does not stop the
+// others; all failures are returned together.
+func NotifyAll(ctx context.Context, cfgs []config.NotifierConfig, s Summary) error {
+	var errs []error
+	for i, cfg := range cfgs {
+		notifier, err := New(cfg)
+		if err == nil {
+			err = notifier.Notify(ctx, s)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("notifier %d (%s): %w", i+1, cfg.Type, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newHTTPClient returns a client with a short timeout, so an unreachable webhook never holds up a
+// metrics run
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: 15 * time.Second}
+}
+
+// postJSON POSTs payload to url, treating any non-2xx response as an error
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// headline is the one-line summary shared by every channel
+func headline(s Summary) string {
+	if s.Title != "" {
+		return fmt.Sprintf("%s: %s", s.Title, s.Locale.Date(s.Date))
+	}
+	return fmt.Sprintf("📚 Reading metrics for %s", s.Locale.Date(s.Date))
+}
+
+// readRateChange formats the read rate with its change, such as "46.6% (+1.2 pts)"
+func readRateChange(s Summary) string {
+	if !s.HasPrevious {
+		return s.Locale.Decimal(s.ReadRate, 1) + "%"
+	}
+	return fmt.Sprintf("%s%% (%s pts)", s.Locale.Decimal(s.ReadRate, 1), signedDecimal(s.Locale, s.ReadRateDelta))
+}
+
+// newArticles formats the articles saved since the previous snapshot against the total
+func newArticles(s Summary) string {
+	if !s.HasPrevious {
+		return fmt.Sprintf("%s tracked", s.Locale.Int(s.TotalArticles))
+	}
+	sign := "+"
+	if s.NewArticles < 0 {
+		sign = ""
+	}
+	return fmt.Sprintf("%s%s (%s tracked)", sign, s.Locale.Int(s.NewArticles), s.Locale.Int(s.TotalArticles))
+}
+
+// signedDecimal formats v with one decimal place and an explicit sign
+func signedDecimal(l locale.Locale, v float64) string {
+	if v < 0 {
+		return l.Decimal(v, 1)
+	}
+	return "+" + l.Decimal(v, 1)
+}
+
+// mostUnread formats the source with the largest backlog
+func mostUnread(s Summary) string {
+	if s.MostUnreadSource == "" {
+		return "none"
+	}
+	return fmt.Sprintf("%s (%d unread)", s.MostUnreadSource, s.MostUnreadCount)
+}
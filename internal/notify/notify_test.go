@@ -0,0 +1,156 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/locale"
+)
+
+func testSummary() Summary {
+	prev := schema.Metrics{TotalArticles: 100, ReadRate: 45.4}
+	latest := schema.Metrics{
+		TotalArticles:  103,
+		ReadRate:       46.6,
+		LastUpdated:    time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC),
+		UnreadBySource: map[string]int{"GitHub": 26, "Stripe": 30, "Substack": 30},
+	}
+	return BuildSummary(&prev, latest)
+}
+
+func TestBuildSummary(t *testing.T) {
+	s := testSummary()
+	if s.NewArticles != 3 || s.MostUnreadSource != "Stripe" || s.MostUnreadCount != 30 {
+		t.Errorf("unexpected summary: %+v", s)
+	}
+	if delta := s.ReadRateDelta; delta < 1.19 || delta > 1.21 {
+		t.Errorf("ReadRateDelta = %v, want 1.2", delta)
+	}
+
+	first := BuildSummary(nil, schema.Metrics{TotalArticles: 5})
+	if first.HasPrevious || readRateChange(first) != "0.0%" || newArticles(first) != "5 tracked" || mostUnread(first) != "none" {
+		t.Errorf("unexpected first summary: %+v", first)
+	}
+}
+
+func TestSummaryFormatting(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		tag      string
+		headline string
+		rate     string
+		articles string
+	}{
+		{"defaults", "", "", "📚 Reading metrics for Mar 13, 2026", "46.6% (+1.2 pts)", "+3 (103 tracked)"},
+		{"branded and localized", "📖 Lesejournal", "de-DE", "📖 Lesejournal: 13.03.2026", "46,6% (+1,2 pts)", "+3 (103 tracked)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testSummary()
+			s.Title = tt.title
+			s.Locale = locale.MustParse(tt.tag)
+			if got := headline(s); got != tt.headline {
+				t.Errorf("headline() = %q, want %q", got, tt.headline)
+			}
+			if got := readRateChange(s); got != tt.rate {
+				t.Errorf("readRateChange() = %q, want %q", got, tt.rate)
+			}
+			if got := newArticles(s); got != tt.articles {
+				t.Errorf("newArticles() = %q, want %q", got, tt.articles)
+			}
+		})
+	}
+}
+
+// captureWebhook records the bodies posted to it, answering with status
+func captureWebhook(t *testing.T, status int, bodies *[]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNotifiers(t *testing.T) {
+	tests := []struct {
+		notifierType string
+		status       int
+		expectError  bool
+		expected     []string
+	}{
+		{notifierType: "slack", status: http.StatusOK, expected: []string{`"type":"header"`, `"text":"*Read rate*\n46.6% (+1.2 pts)"`, `Stripe (30 unread)`}},
+		{notifierType: "discord", status: http.StatusNoContent, expected: []string{`"embeds"`, `"color":1483594`, `"value":"+3 (103 tracked)"`}},
+		{notifierType: "slack", status: http.StatusForbidden, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notifierType, func(t *testing.T) {
+			var bodies []string
+			server := captureWebhook(t, tt.status, &bodies)
+
+			notifier, err := New(config.NotifierConfig{Type: tt.notifierType, Options: map[string]string{"webhook_url": server.URL}})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			err = notifier.Notify(context.Background(), testSummary())
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Notify() error = %v, expectError %v", err, tt.expectError)
+			}
+			if len(bodies) != 1 || !json.Valid([]byte(bodies[0])) {
+				t.Fatalf("expected one JSON payload, got %v", bodies)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(bodies[0], want) {
+					t.Errorf("expected payload to contain %q, got %s", want, bodies[0])
+				}
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv("SLACK_WEBHOOK_URL", "")
+	if _, err := New(config.NotifierConfig{Type: "slack"}); err == nil {
+		t.Error("expected a missing webhook URL to fail")
+	}
+	if _, err := New(config.NotifierConfig{Type: "pager"}); err == nil {
+		t.Error("expected an unknown type to fail")
+	}
+
+	t.Setenv("DISCORD_WEBHOOK_URL", "https://discord.example/hook")
+	notifier, err := New(config.NotifierConfig{Type: "discord"})
+	if err != nil || notifier.(*DiscordNotifier).WebhookURL != "https://discord.example/hook" {
+		t.Errorf("expected the webhook URL from DISCORD_WEBHOOK_URL, got %v, %v", notifier, err)
+	}
+}
+
+func TestNotifyAll(t *testing.T) {
+	var bodies []string
+	server := captureWebhook(t, http.StatusOK, &bodies)
+
+	err := NotifyAll(context.Background(), []config.NotifierConfig{
+		{Type: "pager"},
+		{Type: "slack", Options: map[string]string{"webhook_url": server.URL}},
+		{Type: "discord", Options: map[string]string{"webhook_url": server.URL}},
+	}, testSummary())
+	if err == nil || !strings.Contains(err.Error(), "notifier 1 (pager)") {
+		t.Errorf("expected the unknown notifier to be reported, got %v", err)
+	}
+	if len(bodies) != 2 {
+		t.Errorf("expected the other channels to still be notified, got %d posts", len(bodies))
+	}
+}
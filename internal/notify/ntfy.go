@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func init() {
+	Register("ntfy", NewNtfyNotifier)
+}
+
+// NtfyBaseURL is the public ntfy server used when no server is configured
+const NtfyBaseURL = "https://ntfy.sh"
+
+// NtfyNotifier publishes a one-line update to an ntfy topic
+type NtfyNotifier struct {
+	Server string
+	Topic  string
+	Token  string // access token for protected topics
+	Client *http.Client
+}
+
+// NewNtfyNotifier builds an NtfyNotifier from the topic, server and token options, falling back to
+// NTFY_TOPIC, NTFY_SERVER and NTFY_TOKEN
+func NewNtfyNotifier(cfg config.NotifierConfig) (Notifier, error) {
+	notifier := &NtfyNotifier{
+		Server: cfg.Option("server", "NTFY_SERVER"),
+		Topic:  cfg.Option("topic", "NTFY_TOPIC"),
+		Token:  cfg.Option("token", "NTFY_TOKEN"),
+		Client: newHTTPClient(),
+	}
+	if notifier.Topic == "" {
+		return nil, fmt.Errorf("ntfy notifier needs a topic option or NTFY_TOPIC")
+	}
+	if notifier.Server == "" {
+		notifier.Server = NtfyBaseURL
+	}
+	return notifier, nil
+}
+
+// WithEnvNotifiers adds an ntfy notifier when NTFY_TOPIC is set and config lists none, so phone
+// pushes can be turned on from the environment alone
+func WithEnvNotifiers(cfgs []config.NotifierConfig) []config.NotifierConfig {
+	if os.Getenv("NTFY_TOPIC") == "" {
+		return cfgs
+	}
+	for _, cfg := range cfgs {
+		if cfg.Type == "ntfy" {
+			return cfgs
+		}
+	}
+	return append(cfgs, config.NotifierConfig{Type: "ntfy"})
+}
+
+// ntfyMessage is the short push body, such as "+12 articles, backlog 287 → 291"
+func ntfyMessage(s Summary) string {
+	if !s.HasPrevious {
+		return fmt.Sprintf("%d articles, backlog %d", s.TotalArticles, s.Backlog)
+	}
+	return fmt.Sprintf("%+d articles, backlog %d → %d", s.NewArticles, s.PreviousBacklog, s.Backlog)
+}
+
+// Notify publishes the update with the headline as its title
+func (n *NtfyNotifier) Notify(ctx context.Context, s Summary) error {
+	url := strings.TrimRight(n.Server, "/") + "/" + n.Topic
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(ntfyMessage(s)))
+	if err != nil {
+		return fmt.Errorf("failed to build ntfy request: %w", err)
+	}
+	req.Header.Set("Title", strings.TrimSpace(strings.TrimLeftFunc(headline(s), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })))
+	req.Header.Set("Tags", "books")
+	if n.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.Token)
+	}
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to notify ntfy: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to notify ntfy: server returned %s", resp.Status)
+	}
+	return nil
+}
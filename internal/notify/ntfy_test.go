@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func TestNtfyNotifier(t *testing.T) {
+	var path, body, title, tags, auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		path, body = r.URL.Path, string(content)
+		title, tags, auth = r.Header.Get("Title"), r.Header.Get("Tags"), r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	notifier, err := New(config.NotifierConfig{Type: "ntfy", Options: map[string]string{"server": server.URL + "/", "topic": "my-reading", "token": "tk_1"}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	prev := schema.Metrics{TotalArticles: 100, UnreadCount: 287}
+	latest := schema.Metrics{TotalArticles: 112, UnreadCount: 291}
+	if err := notifier.Notify(context.Background(), BuildSummary(&prev, latest)); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if path != "/my-reading" || body != "+12 articles, backlog 287 → 291" {
+		t.Errorf("unexpected push to %s: %q", path, body)
+	}
+	if title != "Reading metrics for Jan 1, 0001" || tags != "books" || auth != "Bearer tk_1" {
+		t.Errorf("unexpected headers: title %q, tags %q, auth %q", title, tags, auth)
+	}
+}
+
+func TestNtfyMessage(t *testing.T) {
+	first := BuildSummary(nil, schema.Metrics{TotalArticles: 5, UnreadCount: 3})
+	if got := ntfyMessage(first); got != "5 articles, backlog 3" {
+		t.Errorf("ntfyMessage() = %q", got)
+	}
+}
+
+func TestNewNtfyNotifierDefaults(t *testing.T) {
+	t.Setenv("NTFY_TOPIC", "")
+	if _, err := New(config.NotifierConfig{Type: "ntfy"}); err == nil {
+		t.Error("expected a missing topic to fail")
+	}
+
+	t.Setenv("NTFY_TOPIC", "env-topic")
+	t.Setenv("NTFY_SERVER", "")
+	notifier, err := New(config.NotifierConfig{Type: "ntfy"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if n := notifier.(*NtfyNotifier); n.Topic != "env-topic" || n.Server != NtfyBaseURL {
+		t.Errorf("unexpected notifier: %+v", n)
+	}
+}
+
+func TestWithEnvNotifiers(t *testing.T) {
+	slack := config.NotifierConfig{Type: "slack"}
+	tests := []struct {
+		name  string
+		topic string
+		cfgs  []config.NotifierConfig
+		want  int
+	}{
+		{name: "no topic", cfgs: []config.NotifierConfig{slack}, want: 1},
+		{name: "topic adds ntfy", topic: "t", cfgs: []config.NotifierConfig{slack}, want: 2},
+		{name: "configured ntfy is kept", topic: "t", cfgs: []config.NotifierConfig{{Type: "ntfy"}}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NTFY_TOPIC", tt.topic)
+			if got := WithEnvNotifiers(tt.cfgs); len(got) != tt.want {
+				t.Errorf("WithEnvNotifiers() = %v, want %d entries", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func init() {
+	Register("slack", NewSlackNotifier)
+}
+
+// SlackNotifier posts to a Slack incoming webhook
+type SlackNotifier struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+// NewSlackNotifier builds a SlackNotifier from the webhook_url option, falling back to SLACK_WEBHOOK_URL
+func NewSlackNotifier(cfg config.NotifierConfig) (Notifier, error) {
+	notifier := &SlackNotifier{WebhookURL: cfg.Option("webhook_url", "SLACK_WEBHOOK_URL"), Client: newHTTPClient()}
+	if notifier.WebhookURL == "" {
+		return nil, fmt.Errorf("slack notifier needs a webhook_url option or SLACK_WEBHOOK_URL")
+	}
+	return notifier, nil
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type   string      `json:"type"`
+	Text   *slackText  `json:"text,omitempty"`
+	Fields []slackText `json:"fields,omitempty"`
+}
+
+type slackMessage struct {
+	Text   string       `json:"text"` // notification fallback
+	Blocks []slackBlock `json:"blocks"`
+}
+
+// slackPayload formats the summary as a Block Kit message
+func slackPayload(s Summary) slackMessage {
+	field := func(label, value string) slackText {
+		return slackText{Type: "mrkdwn", Text: fmt.Sprintf("*%s*\n%s", label, value)}
+	}
+	return slackMessage{
+		Text: fmt.Sprintf("%s: read rate %s", headline(s), readRateChange(s)),
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: headline(s)}},
+			{Type: "section", Fields: []slackText{
+				field("New articles", newArticles(s)),
+				field("Read rate", readRateChange(s)),
+				field("Most unread source", mostUnread(s)),
+			}},
+		},
+	}
+}
+
+// Notify posts the summary to the webhook
+func (n *SlackNotifier) Notify(ctx context.Context, s Summary) error {
+	if err := postJSON(ctx, n.Client, n.WebhookURL, slackPayload(s)); err != nil {
+		return fmt.Errorf("failed to notify slack: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func init() {
+	Register("telegram", NewTelegramNotifier)
+}
+
+// TelegramBaseURL is the Telegram Bot API root
+const TelegramBaseURL = "https://api.telegram.org"
+
+// TelegramNotifier sends messages to one chat through a Telegram bot
+type TelegramNotifier struct {
+	Token   string
+	ChatID  string
+	SiteURL string // dashboard root; the message links to the snapshot's history page when set
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewTelegramNotifier builds a TelegramNotifier from the bot_token and chat_id options, falling back
+// to TELEGRAM_BOT_TOKEN and TELEGRAM_CHAT_ID
+func NewTelegramNotifier(cfg config.NotifierConfig) (Notifier, error) {
+	notifier := &TelegramNotifier{
+		Token:   cfg.Option("bot_token", "TELEGRAM_BOT_TOKEN"),
+		ChatID:  cfg.Option("chat_id", "TELEGRAM_CHAT_ID"),
+		SiteURL: cfg.Option("site_url", ""),
+		BaseURL: TelegramBaseURL,
+		Client:  newHTTPClient(),
+	}
+	if notifier.Token == "" || notifier.ChatID == "" {
+		return nil, fmt.Errorf("telegram notifier needs bot_token and chat_id options or TELEGRAM_BOT_TOKEN and TELEGRAM_CHAT_ID")
+	}
+	return notifier, nil
+}
+
+type telegramMessage struct {
+	ChatID                string `json:"chat_id"`
+	Text                  string `json:"text"`
+	ParseMode             string `json:"parse_mode"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview"`
+}
+
+// HistoryPageURL is the dashboard page archived for the snapshot taken on date (YYYY-MM-DD)
+func HistoryPageURL(siteURL, date string) string {
+	return strings.TrimRight(siteURL, "/") + "/history/" + date + "/analytics.html"
+}
+
+// telegramPayload formats the summary as an HTML message, linking to the snapshot's history page
+// when siteURL is set
+func telegramPayload(chatID, siteURL string, s Summary) telegramMessage {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<b>%s</b>\n\n", html.EscapeString(headline(s)))
+	fmt.Fprintf(&b, "🆕 New articles: %s\n", html.EscapeString(newArticles(s)))
+	fmt.Fprintf(&b, "📈 Read rate: %s\n", html.EscapeString(readRateChange(s)))
+	fmt.Fprintf(&b, "📥 Most unread: %s", html.EscapeString(mostUnread(s)))
+	if siteURL != "" && !s.Date.IsZero() {
+		fmt.Fprintf(&b, "\n\n<a href=\"%s\">Open this week's dashboard</a>", html.EscapeString(HistoryPageURL(siteURL, s.Date.Format("2006-01-02"))))
+	}
+	return telegramMessage{ChatID: chatID, Text: b.String(), ParseMode: "HTML", DisableWebPagePreview: true}
+}
+
+// Notify sends the summary with the bot's sendMessage method
+func (n *TelegramNotifier) Notify(ctx context.Context, s Summary) error {
+	url := fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimRight(n.BaseURL, "/"), n.Token)
+	if err := postJSON(ctx, n.Client, url, telegramPayload(n.ChatID, n.SiteURL, s)); err != nil {
+		// The request URL embeds the bot token, so keep it out of the logged error
+		return fmt.Errorf("failed to notify telegram: %s", strings.ReplaceAll(err.Error(), n.Token, "<token>"))
+	}
+	return nil
+}
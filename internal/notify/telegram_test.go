@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func TestTelegramNotifier(t *testing.T) {
+	var path string
+	var message telegramMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &message); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		if message.ChatID == "unknown" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		options     map[string]string
+		expectError bool
+		expected    []string
+		unexpected  []string
+	}{
+		{
+			name:     "with deep link",
+			options:  map[string]string{"bot_token": "123:abc", "chat_id": "42", "site_url": "https://example.com/reading/"},
+			expected: []string{"<b>📚 Reading metrics for Mar 13, 2026</b>", "+1.2 pts", `<a href="https://example.com/reading/history/2026-03-13/analytics.html">`},
+		},
+		{
+			name:       "without site url",
+			options:    map[string]string{"bot_token": "123:abc", "chat_id": "42"},
+			unexpected: []string{"<a href"},
+		},
+		{
+			name:        "rejected chat",
+			options:     map[string]string{"bot_token": "123:abc", "chat_id": "unknown"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier, err := New(config.NotifierConfig{Type: "telegram", Options: tt.options})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			notifier.(*TelegramNotifier).BaseURL = server.URL
+
+			err = notifier.Notify(context.Background(), testSummary())
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Notify() error = %v, expectError %v", err, tt.expectError)
+			}
+			if err != nil && strings.Contains(err.Error(), "123:abc") {
+				t.Errorf("expected the bot token to be redacted, got %v", err)
+			}
+			if path != "/bot123:abc/sendMessage" || message.ParseMode != "HTML" {
+				t.Errorf("unexpected request to %s: %+v", path, message)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(message.Text, want) {
+					t.Errorf("expected message to contain %q, got %s", want, message.Text)
+				}
+			}
+			for _, unwanted := range tt.unexpected {
+				if strings.Contains(message.Text, unwanted) {
+					t.Errorf("expected message not to contain %q, got %s", unwanted, message.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestNewTelegramNotifierRequiresChat(t *testing.T) {
+	t.Setenv("TELEGRAM_BOT_TOKEN", "123:abc")
+	t.Setenv("TELEGRAM_CHAT_ID", "")
+	if _, err := New(config.NotifierConfig{Type: "telegram"}); err == nil {
+		t.Error("expected a missing chat_id to fail")
+	}
+}
@@ -0,0 +1,102 @@
+package promexport
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	metrics "github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// ContentType is the Prometheus text exposition format served on /metrics
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Format renders the snapshot as Prometheus gauges
+func Format(m schema.Metrics) []byte {
+	var b strings.Builder
+	gauge := func(name, help string, value float64) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n%s %s\n", name, help, name, name, strconv.FormatFloat(value, 'f', -1, 64))
+	}
+	gauge("reading_total_articles", "Articles tracked in the latest snapshot.", float64(m.TotalArticles))
+	gauge("reading_read_count", "Articles marked read.", float64(m.ReadCount))
+	gauge("reading_unread_count", "Articles still unread.", float64(m.UnreadCount))
+	gauge("reading_read_rate", "Share of articles read, in percent.", m.ReadRate)
+	gauge("reading_snapshot_timestamp_seconds", "Unix time the latest snapshot was taken.", float64(m.LastUpdated.Unix()))
+
+	names := make([]string, 0, len(m.BySourceReadStatus))
+	for name := range m.BySourceReadStatus {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	b.WriteString("# HELP reading_source_articles Articles per source and read status.\n# TYPE reading_source_articles gauge\n")
+	for _, name := range names {
+		counts := m.BySourceReadStatus[name]
+		fmt.Fprintf(&b, "reading_source_articles{source=\"%s\",status=\"read\"} %d\n", labelValue(name), counts[0])
+		fmt.Fprintf(&b, "reading_source_articles{source=\"%s\",status=\"unread\"} %d\n", labelValue(name), counts[1])
+	}
+	return []byte(b.String())
+}
+
+// labelValue escapes backslashes, quotes and newlines in a label value
+func labelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// Exporter serves the latest snapshot in a metrics directory
+type Exporter struct {
+	Dir string
+
+	mu       sync.RWMutex
+	snapshot schema.Metrics
+	file     string
+}
+
+// NewExporter returns an Exporter loaded with the latest snapshot in dir
+func NewExporter(dir string) (*Exporter, error) {
+	e := &Exporter{Dir: dir}
+	if err := e.Reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Reload loads the latest snapshot in Dir, keeping the current one when loading fails
+func (e *Exporter) Reload() error {
+	files, err := metrics.ListSnapshotFiles(e.Dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no snapshots found in %s", e.Dir)
+	}
+	latest := files[len(files)-1]
+
+	// Always re-read, since a same-day rerun rewrites the latest file in place
+	snapshot, err := metrics.LoadSnapshot(e.Dir, latest)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	changed := latest != e.file
+	e.snapshot, e.file = *snapshot, latest
+	e.mu.Unlock()
+	if changed {
+		slog.Info("📈 Exporting", "snapshot", latest)
+	}
+	return nil
+}
+
+// ServeHTTP writes the current snapshot's gauges
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.RLock()
+	body := Format(e.snapshot)
+	e.mu.RUnlock()
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Write(body)
+}
@@ -0,0 +1,95 @@
+package promexport
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestFormat(t *testing.T) {
+	output := string(Format(schema.Metrics{
+		TotalArticles:      12,
+		ReadCount:          5,
+		UnreadCount:        7,
+		ReadRate:           41.67,
+		LastUpdated:        time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC),
+		BySourceReadStatus: map[string][2]int{"GitHub": {3, 4}, `Lenny's "Newsletter"`: {2, 3}},
+	}))
+
+	expected := []string{
+		"# TYPE reading_total_articles gauge\nreading_total_articles 12\n",
+		"reading_read_count 5\n",
+		"reading_unread_count 7\n",
+		"reading_read_rate 41.67\n",
+		"reading_snapshot_timestamp_seconds 1773360000\n",
+		`reading_source_articles{source="GitHub",status="read"} 3`,
+		`reading_source_articles{source="GitHub",status="unread"} 4`,
+		`reading_source_articles{source="Lenny's \"Newsletter\"",status="read"} 2`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Index(output, `source="GitHub"`) > strings.Index(output, `source="Lenny`) {
+		t.Errorf("expected sources in alphabetical order")
+	}
+}
+
+func TestExporter(t *testing.T) {
+	dir := t.TempDir()
+	write := func(date string, total int) {
+		content, _ := json.Marshal(schema.Metrics{TotalArticles: total, UnreadCount: total})
+		if err := os.WriteFile(filepath.Join(dir, date+".json"), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	scrape := func(e *Exporter) string {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		if got := rec.Header().Get("Content-Type"); got != ContentType {
+			t.Errorf("Content-Type = %q", got)
+		}
+		body, _ := io.ReadAll(rec.Body)
+		return string(body)
+	}
+
+	if _, err := NewExporter(dir); err == nil {
+		t.Fatal("expected an error without snapshots")
+	}
+
+	write("2026-03-06", 10)
+	write("2026-03-13", 12)
+	exporter, err := NewExporter(dir)
+	if err != nil {
+		t.Fatalf("NewExporter() error = %v", err)
+	}
+	if body := scrape(exporter); !strings.Contains(body, "reading_total_articles 12\n") {
+		t.Errorf("expected the latest snapshot, got:\n%s", body)
+	}
+
+	write("2026-03-20", 15)
+	if err := exporter.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if body := scrape(exporter); !strings.Contains(body, "reading_total_articles 15\n") {
+		t.Errorf("expected the reloaded snapshot, got:\n%s", body)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "2026-03-27.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.Reload(); err == nil {
+		t.Error("expected a broken snapshot to fail")
+	}
+	if body := scrape(exporter); !strings.Contains(body, "reading_total_articles 15\n") {
+		t.Errorf("expected the previous snapshot to be kept, got:\n%s", body)
+	}
+}
@@ -0,0 +1,226 @@
+package runmanifest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// Extension marks a metrics run manifest, written beside its YYYY-MM-DD.json snapshot
+const Extension = ".run.json"
+
+// SiteFile is the site build manifest, written to the root of dist
+const SiteFile = "build.json"
+
+// Outcomes of a run
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Counts keys shared by the metrics run and the site build
+const (
+	RowsProcessed  = "rows_processed"
+	RowsSkipped    = "rows_skipped"
+	Snapshots      = "snapshots"
+	PagesGenerated = "pages_generated"
+	FilesWritten   = "files_written"
+)
+
+// Stage is one timed step of a run
+type Stage struct {
+	Name       string `json:"name"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Failure is one item, such as a history page date, that a run could not produce
+type Failure struct {
+	Item  string `json:"item"`
+	Error string `json:"error"`
+}
+
+// Manifest records how long a run took, what it processed and how it ended
+type Manifest struct {
+	Command    string         `json:"command"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt time.Time      `json:"finished_at"`
+	DurationMS int64          `json:"duration_ms"`
+	Outcome    string         `json:"outcome"`
+	Error      string         `json:"error,omitempty"`
+	Stages     []Stage        `json:"stages"`
+	Counts     map[string]int `json:"counts,omitempty"`
+	Warnings   []string       `json:"warnings,omitempty"`
+	Failures   []Failure      `json:"failures,omitempty"`
+}
+
+// Filename returns the run manifest name for a snapshot date, such as 2025-01-05.run.json
+func Filename(date string) string {
+	return date + Extension
+}
+
+// Recorder collects a Manifest while a run progresses. A nil Recorder records nothing, so
+// callers and tests can pass nil.
+type Recorder struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	manifest Manifest
+}
+
+// New starts recording a run of command
+func New(command string) *Recorder {
+	return newRecorder(command, time.Now)
+}
+
+func newRecorder(command string, now func() time.Time) *Recorder {
+	return &Recorder{now: now, manifest: Manifest{Command: command, StartedAt: now(), Stages: []Stage{}}}
+}
+
+// Start begins timing a stage. Calling the returned function ends it, recording the error
+// passed to it, if any.
+func (r *Recorder) Start(name string) func(...error) {
+	if r == nil {
+		return func(...error) {}
+	}
+	started := r.now()
+	return func(errs ...error) {
+		stage := Stage{Name: name, DurationMS: r.now().Sub(started).Milliseconds()}
+		for _, err := range errs {
+			if err != nil {
+				stage.Error = err.Error()
+			}
+		}
+		r.mu.Lock()
+		r.manifest.Stages = append(r.manifest.Stages, stage)
+		r.mu.Unlock()
+	}
+}
+
+// Count sets a counter, such as RowsProcessed
+func (r *Recorder) Count(name string, n int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.manifest.Counts == nil {
+		r.manifest.Counts = map[string]int{}
+	}
+	r.manifest.Counts[name] = n
+}
+
+// Warn records a warning
+func (r *Recorder) Warn(message string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.manifest.Warnings = append(r.manifest.Warnings, message)
+	r.mu.Unlock()
+}
+
+// Fail records an item the run could not produce
+func (r *Recorder) Fail(item string, err error) {
+	if r == nil || err == nil {
+		return
+	}
+	r.mu.Lock()
+	r.manifest.Failures = append(r.manifest.Failures, Failure{Item: item, Error: err.Error()})
+	r.mu.Unlock()
+}
+
+// Finish stamps the end time and outcome and returns the manifest
+func (r *Recorder) Finish(err error) Manifest {
+	if r == nil {
+		return Manifest{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.manifest
+	m.FinishedAt = r.now()
+	m.DurationMS = m.FinishedAt.Sub(m.StartedAt).Milliseconds()
+	m.Outcome = OutcomeSuccess
+	if err != nil {
+		m.Outcome = OutcomeFailure
+		m.Error = err.Error()
+	}
+	m.Stages = append([]Stage{}, m.Stages...)
+	m.Warnings = append([]string(nil), m.Warnings...)
+	m.Failures = append([]Failure(nil), m.Failures...)
+	return m
+}
+
+// CaptureWarnings records every warning logged through the default slog logger until the
+// returned function restores the previous logger. Warnings are captured even when the log
+// level hides them.
+func (r *Recorder) CaptureWarnings() func() {
+	if r == nil {
+		return func() {}
+	}
+	previous := slog.Default()
+	slog.SetDefault(slog.New(captureHandler{Handler: previous.Handler(), r: r}))
+	return func() { slog.SetDefault(previous) }
+}
+
+// Write saves the manifest as indented JSON
+func Write(path string, m Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run manifest: %w", err)
+	}
+	if err := safefile.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write run manifest: %w", err)
+	}
+	return nil
+}
+
+// captureHandler copies warning records to a Recorder before passing them on
+type captureHandler struct {
+	slog.Handler
+	r     *Recorder
+	attrs []slog.Attr
+}
+
+func (h captureHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= slog.LevelWarn || h.Handler.Enabled(ctx, level)
+}
+
+func (h captureHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level >= slog.LevelWarn {
+		h.r.Warn(formatRecord(record, h.attrs))
+	}
+	if !h.Handler.Enabled(ctx, record.Level) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h captureHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return captureHandler{Handler: h.Handler.WithAttrs(attrs), r: h.r, attrs: append(append([]slog.Attr(nil), h.attrs...), attrs...)}
+}
+
+func (h captureHandler) WithGroup(name string) slog.Handler {
+	return captureHandler{Handler: h.Handler.WithGroup(name), r: h.r, attrs: h.attrs}
+}
+
+// formatRecord renders a record as "message (key=value, ...)"
+func formatRecord(record slog.Record, attrs []slog.Attr) string {
+	var parts []string
+	for _, attr := range attrs {
+		parts = append(parts, attr.String())
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		parts = append(parts, attr.String())
+		return true
+	})
+	if len(parts) == 0 {
+		return record.Message
+	}
+	return fmt.Sprintf("%s (%s)", record.Message, strings.Join(parts, ", "))
+}
@@ -0,0 +1,132 @@
+package runmanifest
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock returns the given times in order, repeating the last one
+func fakeClock(times ...time.Time) func() time.Time {
+	return func() time.Time {
+		now := times[0]
+		if len(times) > 1 {
+			times = times[1:]
+		}
+		return now
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	start := time.Date(2026, 3, 13, 7, 0, 0, 0, time.UTC)
+	r := newRecorder("metrics", fakeClock(
+		start,                            // run start
+		start,                            // fetch start
+		start.Add(1500*time.Millisecond), // fetch end
+		start.Add(1500*time.Millisecond), // save start
+		start.Add(1750*time.Millisecond), // save end
+		start.Add(2*time.Second),         // finish
+	))
+
+	endFetch := r.Start("fetch")
+	endFetch()
+	endSave := r.Start("save")
+	endSave(nil, errors.New("disk full"))
+	r.Count(RowsProcessed, 120)
+	r.Count(RowsSkipped, 2)
+	r.Warn("slow sheet")
+	r.Fail("2026-03-06", errors.New("template error"))
+	r.Fail("2026-02-27", nil)
+
+	m := r.Finish(errors.New("disk full"))
+	expected := Manifest{
+		Command:    "metrics",
+		StartedAt:  start,
+		FinishedAt: start.Add(2 * time.Second),
+		DurationMS: 2000,
+		Outcome:    OutcomeFailure,
+		Error:      "disk full",
+		Stages: []Stage{
+			{Name: "fetch", DurationMS: 1500},
+			{Name: "save", DurationMS: 250, Error: "disk full"},
+		},
+		Counts:   map[string]int{RowsProcessed: 120, RowsSkipped: 2},
+		Warnings: []string{"slow sheet"},
+		Failures: []Failure{{Item: "2026-03-06", Error: "template error"}},
+	}
+	if !reflect.DeepEqual(m, expected) {
+		t.Errorf("Finish() = %+v, expected %+v", m, expected)
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var r *Recorder
+	r.Start("fetch")()
+	r.Count(RowsProcessed, 1)
+	r.Warn("ignored")
+	r.Fail("2026-03-06", errors.New("ignored"))
+	r.CaptureWarnings()()
+	if m := r.Finish(nil); m.Command != "" || m.Outcome != "" {
+		t.Errorf("expected a nil recorder to record nothing, got %+v", m)
+	}
+}
+
+func TestCaptureWarnings(t *testing.T) {
+	previous := slog.Default()
+	defer slog.SetDefault(previous)
+	var logged strings.Builder
+	base := slog.New(slog.NewTextHandler(&logged, &slog.HandlerOptions{Level: slog.LevelError}))
+	slog.SetDefault(base)
+
+	r := New("web")
+	restore := r.CaptureWarnings()
+	slog.Info("not a warning")
+	slog.With("date", "2026-03-13").Warn("Skipping snapshot", "err", "bad json")
+	slog.Error("Failed", "err", "boom")
+	restore()
+	slog.Warn("after restore")
+
+	m := r.Finish(nil)
+	expected := []string{"Skipping snapshot (date=2026-03-13, err=bad json)", "Failed (err=boom)"}
+	if !reflect.DeepEqual(m.Warnings, expected) {
+		t.Errorf("expected warnings %q, got %q", expected, m.Warnings)
+	}
+	if strings.Contains(logged.String(), "Skipping snapshot") {
+		t.Errorf("expected the level filter to still hide warnings, got %q", logged.String())
+	}
+	if !strings.Contains(logged.String(), "Failed") {
+		t.Errorf("expected errors to still be logged, got %q", logged.String())
+	}
+	if slog.Default() != base {
+		t.Errorf("expected restore to reinstate the previous logger")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), Filename("2026-03-13"))
+	m := newRecorder("metrics", fakeClock(time.Date(2026, 3, 13, 7, 0, 0, 0, time.UTC))).Finish(nil)
+	if err := Write(path, m); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if filepath.Base(path) != "2026-03-13.run.json" {
+		t.Errorf("unexpected filename %s", filepath.Base(path))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+	var read Manifest
+	if err := json.Unmarshal(data, &read); err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+	if read.Outcome != OutcomeSuccess || read.Stages == nil {
+		t.Errorf("expected a successful manifest with an empty stage list, got %s", data)
+	}
+}
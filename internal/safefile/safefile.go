@@ -0,0 +1,133 @@
+package safefile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// File is a temporary file in the directory of its destination. Commit moves it into place in a
+// single rename, so readers and crashed runs never see a partial file.
+type File struct {
+	*os.File
+	path string
+	perm os.FileMode
+	done bool
+}
+
+// Create opens a temporary file that becomes path, with mode perm, on Commit. Close without
+// Commit discards it, so `defer f.Close()` cleans up after a failed write.
+func Create(path string, perm os.FileMode) (*File, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+	return &File{File: tmp, path: path, perm: perm}, nil
+}
+
+// Commit flushes the file to disk and renames it over the destination
+func (f *File) Commit() error {
+	if f.done {
+		return fmt.Errorf("%s is already closed", f.path)
+	}
+	f.done = true
+	tmp := f.Name()
+	err := f.Sync()
+	if closeErr := f.File.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp, f.perm)
+	}
+	if err == nil {
+		err = os.Rename(tmp, f.path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// Close discards the file unless it was committed
+func (f *File) Close() error {
+	if f.done {
+		return nil
+	}
+	f.done = true
+	f.File.Close()
+	return os.Remove(f.Name())
+}
+
+// WriteFile is os.WriteFile through a temporary file renamed into place
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	f, err := Create(path, perm)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		return err
+	}
+	return f.Commit()
+}
+
+// LockFile is the lock taken by runs that write metrics/ or dist/, relative to the repository root
+const LockFile = ".analytics.lock"
+
+// StaleLockAge is how old a lock may be before a run assumes its holder crashed and takes it over
+const StaleLockAge = 2 * time.Hour
+
+// Lock is a held lockfile
+type Lock struct {
+	path string
+}
+
+// Acquire creates the lockfile at path holding this process's ID. It fails while another run
+// holds a lock younger than staleAfter; an older lock is replaced.
+func Acquire(path string, staleAfter time.Duration) (*Lock, error) {
+	for attempt := 0; ; attempt++ {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, err = f.WriteString(strconv.Itoa(os.Getpid()) + "\n")
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock %s: %w", path, err)
+			}
+			return &Lock{path: path}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create lock %s: %w", path, err)
+		}
+
+		info, statErr := os.Stat(path)
+		if statErr != nil {
+			if errors.Is(statErr, os.ErrNotExist) && attempt == 0 {
+				continue // released between the create and the stat
+			}
+			return nil, fmt.Errorf("failed to inspect lock %s: %w", path, statErr)
+		}
+		holder, _ := os.ReadFile(path)
+		if attempt > 0 || time.Since(info.ModTime()) < staleAfter {
+			return nil, fmt.Errorf("another run holds %s (pid %s) since %s", path, strings.TrimSpace(string(holder)), info.ModTime().Format(time.RFC3339))
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove stale lock %s: %w", path, err)
+		}
+	}
+}
+
+// Release removes the lockfile
+func (l *Lock) Release() error {
+	if err := os.Remove(l.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to release lock %s: %w", l.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package safefile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "2025-01-05.json")
+	if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFile(path, []byte("new"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "new" {
+		t.Errorf("content = %q, expected %q", content, "new")
+	}
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0644 {
+		t.Errorf("mode = %v, expected 0644", info.Mode().Perm())
+	}
+	assertOnlyFiles(t, dir, "2025-01-05.json")
+}
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name     string
+		commit   bool
+		expected string
+	}{
+		{"commit replaces the file", true, "new"},
+		{"close without commit keeps the old file", false, "old"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "index.html")
+			if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := Create(path, 0644)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if _, err := f.WriteString("new"); err != nil {
+				t.Fatal(err)
+			}
+			if tt.commit {
+				if err := f.Commit(); err != nil {
+					t.Fatalf("Commit() error = %v", err)
+				}
+			}
+			if err := f.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			content, _ := os.ReadFile(path)
+			if string(content) != tt.expected {
+				t.Errorf("content = %q, expected %q", content, tt.expected)
+			}
+			assertOnlyFiles(t, dir, "index.html")
+		})
+	}
+}
+
+func TestAcquire(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LockFile)
+
+	lock, err := Acquire(path, time.Hour)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if _, err := Acquire(path, time.Hour); err == nil || !strings.Contains(err.Error(), "another run holds") {
+		t.Errorf("expected a held lock to refuse a second run, got %v", err)
+	}
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+
+	lock, err = Acquire(path, time.Hour)
+	if err != nil {
+		t.Fatalf("Acquire() after release error = %v", err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Acquire(path, time.Hour); err != nil {
+		t.Errorf("expected a stale lock to be taken over, got %v", err)
+	}
+	lock.Release()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the lock removed after release, got %v", err)
+	}
+}
+
+func assertOnlyFiles(t *testing.T, dir string, expected ...string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("files = %v, expected %v", names, expected)
+	}
+}
@@ -12,6 +12,15 @@ type Metrics struct {
 	ByMonthAndSource             map[string]map[string][2]int `json:"by_month_and_source_read_status"` // month -> source -> [read, unread]
 	ByCategory                   map[string][2]int            `json:"by_category"`                     // category -> [read, unread]
 	ByCategoryAndSource          map[string]map[string][2]int `json:"by_category_and_source"`          // category -> source -> [read, unread]
+	ByDomain                     map[string][2]int            `json:"by_domain,omitempty"`             // registrable link domain -> [read, unread]
+	ByQuarter                    map[string][2]int            `json:"by_quarter,omitempty"`            // quarter ("2025-Q1") -> [read, unread]
+	ByISOWeek                    map[string][2]int            `json:"by_iso_week,omitempty"`           // ISO week ("2025-W07") -> [read, unread]
+	Rolling                      []RollingWindow              `json:"rolling,omitempty"`               // last 30 and 90 days before LastUpdated
+	ByWeekday                    map[string][2]int            `json:"by_weekday,omitempty"`            // weekday saved ("Mon".."Sun") -> [read, unread]
+	ByFiscalYear                 map[string][2]int            `json:"by_fiscal_year,omitempty"`        // fiscal year ("2025-26") -> [read, unread], when years start after January
+	YearStartMonth               int                          `json:"year_start_month,omitempty"`      // first month of the fiscal years in ByFiscalYear
+	ByMediaType                  map[string][2]int            `json:"by_media_type,omitempty"`         // article/video/podcast -> [read, unread]
+	MediaTypes                   map[string]Metrics           `json:"media_types,omitempty"`           // media type -> snapshot of that type alone, when several are mixed
 	ReadUnreadTotals             [2]int                       `json:"read_unread_totals"`              // [read, unread]
 	UnreadByMonth                map[string]int               `json:"unread_by_month"`
 	UnreadByCategory             map[string]int               `json:"unread_by_category"`
@@ -27,21 +36,144 @@ type Metrics struct {
 	AvgArticlesPerMonth          float64                      `json:"avg_articles_per_month"`
 	LastUpdated                  time.Time                    `json:"last_updated"`
 	AIDeltaAnalysis              string                       `json:"ai_delta_analysis,omitempty"`
+	EnergyScore                  *EnergyScore                 `json:"energy_score,omitempty"`
+	ByTag                        map[string][2]int            `json:"by_tag,omitempty"`                // tag -> [read, unread]
+	CategoryRuleMatches          map[string]int               `json:"category_rule_matches,omitempty"` // rule name -> matched articles
+	Community                    *CommunityComparison         `json:"community,omitempty"`
+	Substack                     *SubstackStats               `json:"substack,omitempty"`
+	Consumption                  *ConsumptionStats            `json:"consumption,omitempty"`
+	Providers                    []ProviderEntry              `json:"providers,omitempty"`    // providers sheet rows when the snapshot was taken
+	SkippedRows                  int                          `json:"skipped_rows,omitempty"` // article rows left out for missing columns or an invalid date
+	QuickWins                    *QuickWins                   `json:"quick_wins,omitempty"`   // unread articles likely to be fast to clear
+	Alerts                       []Alert                      `json:"alerts,omitempty"`       // unusual changes and milestones detected for this snapshot
+}
+
+// Alert is an unusual change or a milestone detected when a snapshot was computed
+type Alert struct {
+	Kind    string `json:"kind"` // "anomaly" or "milestone"
+	Message string `json:"message"`
+}
+
+// QuickWins suggests unread articles likely to be fast to clear
+type QuickWins struct {
+	Shortest        []QuickWin      `json:"shortest,omitempty"`          // lowest estimated reading time first
+	OldestPerSource []QuickWin      `json:"oldest_per_source,omitempty"` // each source's oldest unread article, oldest first
+	DomainClusters  []DomainCluster `json:"domain_clusters,omitempty"`   // domains with several unread articles to read in one sitting
+}
+
+// QuickWin is a recommended unread article with its estimated reading time
+type QuickWin struct {
+	ArticleMeta
+	EstimatedMinutes int `json:"estimated_minutes"`
+}
+
+// DomainCluster groups unread articles from one registrable domain
+type DomainCluster struct {
+	Domain           string     `json:"domain"`
+	Unread           int        `json:"unread"`            // every unread article from the domain
+	EstimatedMinutes int        `json:"estimated_minutes"` // reading time of the listed articles
+	Articles         []QuickWin `json:"articles"`          // the oldest few, oldest first
+}
+
+// ProviderEntry is one row of the providers sheet
+type ProviderEntry struct {
+	Name string `json:"name"` // normalized source name
+	URL  string `json:"url,omitempty"`
+}
+
+// ProviderTimelinePoint holds the subscriptions added and removed across one month of snapshots
+type ProviderTimelinePoint struct {
+	Month        string   `json:"month"` // YYYY-MM
+	Added        int      `json:"added"`
+	Removed      int      `json:"removed"`
+	Total        int      `json:"total"` // subscriptions in the month's last snapshot
+	AddedNames   []string `json:"added_names,omitempty"`
+	RemovedNames []string `json:"removed_names,omitempty"`
+}
+
+// SourceReadRatePoint is every source's read rate in one snapshot
+type SourceReadRatePoint struct {
+	Date  string             `json:"date"`  // YYYY-MM-DD of the snapshot
+	Rates map[string]float64 `json:"rates"` // source -> percentage of its articles read
+}
+
+// BacklogFlowPoint holds the articles added and read across one month of snapshots
+type BacklogFlowPoint struct {
+	Month string `json:"month"` // YYYY-MM
+	Added int    `json:"added"`
+	Read  int    `json:"read"`
+	Net   int    `json:"net"` // change of the unread backlog, Added minus Read
+}
+
+// ConsumptionStats totals the watch/listen time of videos and podcasts with a duration
+type ConsumptionStats struct {
+	TotalMinutes    int            `json:"total_minutes"`     // finished items
+	BacklogMinutes  int            `json:"backlog_minutes"`   // unfinished items
+	MinutesByMonth  map[string]int `json:"minutes_by_month"`  // YYYY-MM saved -> minutes of finished items
+	MinutesBySource map[string]int `json:"minutes_by_source"` // source -> minutes of finished items
+}
+
+// SubstackStats breaks the Substack source down by author, one publication per feed
+type SubstackStats struct {
+	AuthorCount int               `json:"author_count"` // Substack feeds in the providers sheet
+	ByAuthor    map[string][2]int `json:"by_author"`    // publication domain -> [read, unread]
+}
+
+// CommunityComparison holds the opt-in community medians fetched alongside a snapshot
+type CommunityComparison struct {
+	Participants        int     `json:"participants"`
+	MedianReadRate      float64 `json:"median_read_rate"`
+	MedianTotalArticles float64 `json:"median_total_articles"`
+}
+
+// EnergyScore is a composite reading health score (0-100) and the signals behind it,
+// measured against the previous snapshot
+type EnergyScore struct {
+	Score        float64 `json:"score"`
+	Reads        int     `json:"reads"`         // articles read since the previous snapshot
+	Diversity    int     `json:"diversity"`     // distinct sources read since the previous snapshot
+	BacklogDelta int     `json:"backlog_delta"` // unread articles cleared (negative when the backlog grew)
+	Streak       int     `json:"streak"`        // consecutive snapshots with at least one read
+}
+
+// EnergyPoint is a dated energy score used for time-series charts
+type EnergyPoint struct {
+	Date  string  `json:"date"`
+	Score float64 `json:"score"`
 }
 
 // ArticleMeta holds minimal info for backlog/unread analysis
 type ArticleMeta struct {
-	Title    string `json:"title"`
-	Date     string `json:"date"`
-	Link     string `json:"link"`
-	Category string `json:"category"`
-	Read     bool   `json:"read"`
+	Title    string   `json:"title"`
+	Date     string   `json:"date"`
+	Link     string   `json:"link"`
+	Category string   `json:"category"`
+	Read     bool     `json:"read"`
+	Authors  []string `json:"authors,omitempty"`
+
+	// Notes and Highlights are the reader's own annotations, shown on the article's permalink page
+	Notes      string   `json:"notes,omitempty"`
+	Highlights []string `json:"highlights,omitempty"`
+
+	// ArchiveURL is the Wayback Machine capture of Link, guarding against link rot
+	ArchiveURL string `json:"archive_url,omitempty"`
+
+	// MediaType is article, video or podcast
+	MediaType string `json:"media_type,omitempty"`
+
+	// DurationMinutes is the watch/listen time of a video or podcast
+	DurationMinutes int `json:"duration_minutes,omitempty"`
+
+	// Topic is the category the article counts under instead of its source
+	Topic string `json:"topic,omitempty"`
 }
 
-// SourceMeta tracks when a source was added and its brand color
+// SourceMeta tracks when a source was added, its brand color and any labels it was renamed from
 type SourceMeta struct {
-	Added string `json:"added"`
-	Color string `json:"color"`
+	Added   string   `json:"added"`
+	Color   string   `json:"color"`
+	Aliases []string `json:"aliases,omitempty"`
+	Feeds   int      `json:"feeds,omitempty"` // provider rows (feeds, authors) counted under this source
 }
 
 type SourceInfo struct {
@@ -54,6 +186,87 @@ type SourceInfo struct {
 	Color       string
 }
 
+// DomainInfo is one link domain in the Top Domains table
+type DomainInfo struct {
+	Domain   string
+	Count    int
+	Read     int
+	Unread   int
+	SharePct float64 // share of all linked articles
+}
+
+// AuthorInfo is one Substack publication on the authors leaderboard
+type AuthorInfo struct {
+	Rank    int
+	Author  string
+	Count   int
+	Read    int
+	Unread  int
+	ReadPct float64
+}
+
+// CategoryInfo is one category on the topics page, with the sources feeding it, largest first
+type CategoryInfo struct {
+	Category string
+	Count    int
+	Read     int
+	Unread   int
+	ReadPct  float64
+	Sources  []CategorySource
+}
+
+// CategorySource is one source's articles within a category
+type CategorySource struct {
+	Source   string
+	Count    int
+	Read     int
+	Unread   int
+	SharePct float64 // share of the category's articles
+}
+
+// MediaTypeInfo is one media type (article, video or podcast) in the media type breakdown
+type MediaTypeInfo struct {
+	Type    string
+	Count   int
+	Read    int
+	Unread  int
+	ReadPct float64
+}
+
+// RollingWindow summarizes the Days days before a snapshot's LastUpdated
+type RollingWindow struct {
+	Days          int    `json:"days"`
+	Added         int    `json:"added"`              // articles saved within the window
+	Read          int    `json:"read"`               // growth of read_count since Baseline
+	BacklogChange int    `json:"backlog_change"`     // growth of unread_count since Baseline (negative when it shrank)
+	Baseline      string `json:"baseline,omitempty"` // date of the snapshot compared against; empty when none is old enough
+}
+
+// WeekdayPattern compares articles saved on weekends with those saved on weekdays
+type WeekdayPattern struct {
+	BusiestDay     string  // weekday with the most articles saved
+	WeekendPct     float64 // share of articles saved on Saturday or Sunday
+	WeekendReadPct float64 // read rate of articles saved on weekends
+	WeekdayReadPct float64 // read rate of articles saved Monday to Friday
+}
+
+// YearInReview is the year-in-review ("Wrapped") summary of one calendar year of snapshots
+type YearInReview struct {
+	Year               string
+	Snapshots          int          // snapshots taken during the year
+	Saved              int          // articles saved during the year
+	Read               int          // growth of read_count over the year
+	TopSources         []SourceInfo // most read sources of the year; Count and Read hold the year's growth
+	BusiestMonth       string       // month with the most articles saved, such as "March"
+	BusiestMonthCount  int
+	LongestStreak      int    // consecutive snapshots with at least one read
+	StreakStart        string // first snapshot of the longest streak
+	StreakEnd          string // last snapshot of the longest streak
+	BacklogReduction   int    // largest drop in unread_count between consecutive snapshots
+	BacklogReducedFrom string
+	BacklogReducedTo   string
+}
+
 type MonthInfo struct {
 	Name    string
 	Month   string
@@ -70,6 +283,8 @@ type YearInfo struct {
 type KeyMetric struct {
 	Title string
 	Value string
+	Delta string // change since the previous snapshot, such as "▲1.4"; empty without one
+	Trend string // "up", "down" or "flat", matching Delta
 }
 
 type HightlightMetric struct {
@@ -99,6 +314,7 @@ type Milestone struct {
 	Artifacts        []Artifact `yaml:"artifacts,omitempty"`
 	Description      string     `yaml:"description"`
 	DescriptionLines []string   `yaml:"-"`
+	Generated        bool       `yaml:"-"` // derived from the reading data rather than evolution.yml
 }
 
 type Author struct {
@@ -0,0 +1,79 @@
+package sources
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func init() {
+	Register("csv", NewCSVSource)
+}
+
+// CSVSource reads articles from a CSV file with date, title, link, source and read columns
+type CSVSource struct {
+	Path string
+}
+
+// NewCSVSource builds a CSVSource from the path option
+func NewCSVSource(cfg config.SourceConfig) (Source, error) {
+	path := cfg.Option("path", "")
+	if path == "" {
+		return nil, fmt.Errorf("path option is required")
+	}
+	return &CSVSource{Path: path}, nil
+}
+
+// Fetch parses the CSV file, locating columns by header name so their order does not matter
+func (s *CSVSource) Fetch(ctx context.Context) ([]schema.ArticleMeta, error) {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", s.Path, err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", s.Path, err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"date", "title"} {
+		if _, exists := columns[required]; !exists {
+			return nil, fmt.Errorf("%s is missing the %q column", s.Path, required)
+		}
+	}
+
+	value := func(record []string, column string) string {
+		if idx, exists := columns[column]; exists && idx < len(record) {
+			return strings.TrimSpace(record[idx])
+		}
+		return ""
+	}
+
+	var articles []schema.ArticleMeta
+	for _, record := range records[1:] {
+		articles = append(articles, schema.ArticleMeta{
+			Date:     value(record, "date"),
+			Title:    value(record, "title"),
+			Link:     value(record, "link"),
+			Category: value(record, "source"),
+			Read:     strings.EqualFold(value(record, "read"), "true"),
+		})
+	}
+
+	return articles, nil
+}
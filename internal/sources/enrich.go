@@ -0,0 +1,52 @@
+package sources
+
+import (
+	"context"
+	"log/slog"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+)
+
+// Default sources for identifier-based articles that arrive without one
+const (
+	PapersSource = "Papers"
+	BooksSource  = "Books"
+)
+
+// Enrich fills in title and authors for articles identified by DOI or ISBN, points their link at a
+// resolvable URL, and files them under Papers or Books when no source is set. Failed lookups are
+// logged and the article is kept as-is.
+func Enrich(ctx context.Context, articles []schema.ArticleMeta, resolver *identity.Resolver) []schema.ArticleMeta {
+	for i := range articles {
+		article := &articles[i]
+		id := identity.Parse(article.Link)
+		if id.Kind == identity.KindURL {
+			continue
+		}
+
+		article.Link = id.URL()
+		if article.Category == "" {
+			article.Category = PapersSource
+			if id.Kind == identity.KindISBN {
+				article.Category = BooksSource
+			}
+		}
+
+		if article.Title != "" && len(article.Authors) > 0 {
+			continue
+		}
+		meta, err := resolver.Lookup(ctx, id)
+		if err != nil {
+			slog.Warn("Unable to look up identifier", "id", id.String(), "err", err)
+			continue
+		}
+		if article.Title == "" {
+			article.Title = meta.Title
+		}
+		if len(article.Authors) == 0 {
+			article.Authors = meta.Authors
+		}
+	}
+	return articles
+}
@@ -0,0 +1,43 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+)
+
+func TestEnrich(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message": {"title": ["A Paper"], "author": [{"given": "Ada", "family": "Lovelace"}]}}`)
+	}))
+	defer server.Close()
+
+	resolver := identity.NewResolver()
+	resolver.CrossrefURL = server.URL
+
+	articles := []schema.ArticleMeta{
+		{Date: "2025-01-01", Link: "doi:10.1000/paper"},
+		{Date: "2025-01-02", Title: "My notes title", Link: "10.1000/paper", Category: "arXiv"},
+		{Date: "2025-01-03", Title: "Web post", Link: "https://example.com", Category: "Blog"},
+	}
+
+	enriched := Enrich(context.Background(), articles, resolver)
+
+	if enriched[0].Title != "A Paper" || enriched[0].Category != PapersSource || enriched[0].Link != "https://doi.org/10.1000/paper" {
+		t.Errorf("unexpected first article %+v", enriched[0])
+	}
+	if len(enriched[0].Authors) != 1 || enriched[0].Authors[0] != "Ada Lovelace" {
+		t.Errorf("expected looked-up authors, got %v", enriched[0].Authors)
+	}
+	if enriched[1].Title != "My notes title" || enriched[1].Category != "arXiv" {
+		t.Errorf("expected existing title and source kept, got %+v", enriched[1])
+	}
+	if enriched[2].Link != "https://example.com" || enriched[2].Authors != nil {
+		t.Errorf("expected web article untouched, got %+v", enriched[2])
+	}
+}
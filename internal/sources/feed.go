@@ -0,0 +1,283 @@
+package sources
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+func init() {
+	Register("feed", NewFeedSource)
+}
+
+// Feed is a single RSS or Atom feed to poll
+type Feed struct {
+	Name string // source label given to the feed's articles
+	URL  string
+}
+
+// FeedSource polls RSS and Atom feeds and returns their entries as unread articles
+type FeedSource struct {
+	Feeds  []Feed
+	Client *http.Client
+}
+
+// NewFeedSource builds a FeedSource from a single url (with optional name) or an opml file listing feeds
+func NewFeedSource(cfg config.SourceConfig) (Source, error) {
+	var feeds []Feed
+	if url := cfg.Option("url", ""); url != "" {
+		feeds = append(feeds, Feed{Name: cfg.Name, URL: url})
+	}
+	if path := cfg.Option("opml", ""); path != "" {
+		opmlFeeds, err := ReadOPML(path)
+		if err != nil {
+			return nil, err
+		}
+		feeds = append(feeds, opmlFeeds...)
+	}
+	if len(feeds) == 0 {
+		return nil, fmt.Errorf("url or opml option is required")
+	}
+	return NewFeedSourceFromFeeds(feeds), nil
+}
+
+// NewFeedSourceFromFeeds builds a FeedSource polling the given feeds
+func NewFeedSourceFromFeeds(feeds []Feed) *FeedSource {
+	return &FeedSource{Feeds: feeds, Client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// Fetch polls every feed in turn. A feed that fails is logged and skipped;
+// an error is only returned when no feed could be read.
+func (s *FeedSource) Fetch(ctx context.Context) ([]schema.ArticleMeta, error) {
+	var articles []schema.ArticleMeta
+	var lastErr error
+	failed := 0
+
+	for _, feed := range s.Feeds {
+		items, err := s.fetchFeed(ctx, feed)
+		if err != nil {
+			slog.Warn("Skipping feed", "url", feed.URL, "err", err)
+			lastErr = err
+			failed++
+			continue
+		}
+		articles = append(articles, items...)
+	}
+
+	if failed > 0 && failed == len(s.Feeds) {
+		return nil, fmt.Errorf("failed to read any of %d feeds: %w", failed, lastErr)
+	}
+	return articles, nil
+}
+
+func (s *FeedSource) fetchFeed(ctx context.Context, feed Feed) ([]schema.ArticleMeta, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feed.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feed returned status %d", resp.StatusCode)
+	}
+
+	return ParseFeed(resp.Body, feed.Name)
+}
+
+// feedDocument covers RSS 2.0 (channel>item), RSS 1.0 (top-level item) and Atom (entry)
+type feedDocument struct {
+	Title        string     `xml:"title"`
+	ChannelTitle string     `xml:"channel>title"`
+	ChannelItems []feedItem `xml:"channel>item"`
+	Items        []feedItem `xml:"item"`
+	Entries      []feedItem `xml:"entry"`
+}
+
+type feedItem struct {
+	Title     string     `xml:"title"`
+	Links     []feedLink `xml:"link"`
+	GUID      string     `xml:"guid"`
+	PubDate   string     `xml:"pubDate"`
+	Date      string     `xml:"date"` // Dublin Core dc:date
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+}
+
+// feedLink holds either an RSS link (text) or an Atom link (href attribute)
+type feedLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Text string `xml:",chardata"`
+}
+
+// ParseFeed parses an RSS or Atom document. Articles are unread and labelled with source,
+// falling back to the feed title and then the link domain.
+func ParseFeed(r io.Reader, source string) ([]schema.ArticleMeta, error) {
+	var doc feedDocument
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) { return input, nil }
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse feed: %w", err)
+	}
+
+	if source == "" {
+		source = strings.TrimSpace(doc.ChannelTitle)
+	}
+	if source == "" {
+		source = strings.TrimSpace(doc.Title)
+	}
+
+	items := append(append(doc.ChannelItems, doc.Items...), doc.Entries...)
+	var articles []schema.ArticleMeta
+	for _, item := range items {
+		link := item.link()
+		if link == "" {
+			continue
+		}
+		title := strings.TrimSpace(item.Title)
+		if title == "" {
+			title = link
+		}
+		category := source
+		if category == "" {
+			category = metrics.LinkDomain(link)
+		}
+		articles = append(articles, schema.ArticleMeta{
+			Date:     feedDate(item.PubDate, item.Published, item.Date, item.Updated),
+			Title:    title,
+			Link:     link,
+			Category: category,
+		})
+	}
+	return articles, nil
+}
+
+// link prefers an Atom alternate link, then an RSS link, then a permalink GUID
+func (item feedItem) link() string {
+	for _, l := range item.Links {
+		if l.Href != "" && (l.Rel == "" || l.Rel == "alternate") {
+			return strings.TrimSpace(l.Href)
+		}
+	}
+	for _, l := range item.Links {
+		if text := strings.TrimSpace(l.Text); text != "" {
+			return text
+		}
+	}
+	if guid := strings.TrimSpace(item.GUID); strings.HasPrefix(guid, "http") {
+		return guid
+	}
+	return ""
+}
+
+// feedDateLayouts covers RFC 822 variants seen in RSS and RFC 3339 used by Atom
+var feedDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	time.RFC822Z,
+	time.RFC822,
+	time.RFC3339,
+	"2006-01-02",
+}
+
+// feedDate formats the first parseable candidate as YYYY-MM-DD, falling back to today
+func feedDate(candidates ...string) string {
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "" {
+			continue
+		}
+		for _, layout := range feedDateLayouts {
+			if t, err := time.Parse(layout, candidate); err == nil {
+				return t.UTC().Format("2006-01-02")
+			}
+		}
+	}
+	return time.Now().Format("2006-01-02")
+}
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr"`
+	XMLURL   string        `xml:"xmlUrl,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+type opmlDocument struct {
+	Outlines []opmlOutline `xml:"body>outline"`
+}
+
+// ReadOPML reads the feeds listed in an OPML file
+func ReadOPML(path string) ([]Feed, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+	return ParseOPML(f)
+}
+
+// ParseOPML collects every outline with an xmlUrl, including those nested in folders
+func ParseOPML(r io.Reader) ([]Feed, error) {
+	var doc opmlDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OPML: %w", err)
+	}
+
+	var feeds []Feed
+	var walk func([]opmlOutline)
+	walk = func(outlines []opmlOutline) {
+		for _, outline := range outlines {
+			if outline.XMLURL != "" {
+				name := outline.Title
+				if name == "" {
+					name = outline.Text
+				}
+				feeds = append(feeds, Feed{Name: strings.TrimSpace(name), URL: strings.TrimSpace(outline.XMLURL)})
+			}
+			walk(outline.Outlines)
+		}
+	}
+	walk(doc.Outlines)
+	return feeds, nil
+}
+
+// FeedsFromProviders returns the providers sheet rows (header included) whose strategy is rss
+func FeedsFromProviders(rows [][]interface{}) []Feed {
+	var feeds []Feed
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		if len(row) <= metrics.ProvidersColStrategy {
+			continue
+		}
+		if !strings.EqualFold(strings.TrimSpace(fmt.Sprintf("%v", row[metrics.ProvidersColStrategy])), "rss") {
+			continue
+		}
+		url := strings.TrimSpace(fmt.Sprintf("%v", row[metrics.ProvidersColURL]))
+		if url == "" {
+			continue
+		}
+		feeds = append(feeds, Feed{Name: strings.TrimSpace(fmt.Sprintf("%v", row[metrics.ProvidersColName])), URL: url})
+	}
+	return feeds
+}
@@ -0,0 +1,175 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+const testRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel><title>Go Blog</title>
+<item><title>Range over func</title><link>https://go.dev/blog/range-functions</link><pubDate>Tue, 20 Aug 2024 10:00:00 +0000</pubDate></item>
+<item><title>GUID only</title><guid isPermaLink="true">https://go.dev/blog/guid</guid><pubDate>Wed, 4 Sep 2024 10:00:00 GMT</pubDate></item>
+<item><title>No link</title></item>
+</channel></rss>`
+
+const testAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><title>Stripe Engineering</title>
+<entry><title>Payments at scale</title><link rel="self" href="https://stripe.com/self"/><link rel="alternate" href="https://stripe.com/blog/scale"/><published>2025-01-02T08:00:00Z</published></entry>
+<entry><title></title><link href="https://stripe.com/blog/untitled"/><updated>2025-01-03T08:00:00+02:00</updated></entry>
+</feed>`
+
+func TestParseFeed(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		source   string
+		expected []struct{ date, title, link, category string }
+	}{
+		{
+			name: "rss uses the channel title as source",
+			body: testRSS,
+			expected: []struct{ date, title, link, category string }{
+				{"2024-08-20", "Range over func", "https://go.dev/blog/range-functions", "Go Blog"},
+				{"2024-09-04", "GUID only", "https://go.dev/blog/guid", "Go Blog"},
+			},
+		},
+		{
+			name:   "atom prefers alternate links and the given source",
+			body:   testAtom,
+			source: "Stripe",
+			expected: []struct{ date, title, link, category string }{
+				{"2025-01-02", "Payments at scale", "https://stripe.com/blog/scale", "Stripe"},
+				{"2025-01-03", "https://stripe.com/blog/untitled", "https://stripe.com/blog/untitled", "Stripe"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articles, err := ParseFeed(strings.NewReader(tt.body), tt.source)
+			if err != nil {
+				t.Fatalf("ParseFeed() error = %v", err)
+			}
+			if len(articles) != len(tt.expected) {
+				t.Fatalf("expected %d articles, got %+v", len(tt.expected), articles)
+			}
+			for i, want := range tt.expected {
+				got := articles[i]
+				if got.Date != want.date || got.Title != want.title || got.Link != want.link || got.Category != want.category || got.Read {
+					t.Errorf("article %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+
+	if _, err := ParseFeed(strings.NewReader("not xml"), ""); err == nil {
+		t.Error("expected error for invalid feed")
+	}
+}
+
+func TestParseOPML(t *testing.T) {
+	opml := `<?xml version="1.0"?>
+<opml version="2.0"><head><title>Subscriptions</title></head><body>
+<outline text="Go Blog" type="rss" xmlUrl="https://go.dev/blog/feed.atom"/>
+<outline text="Engineering">
+  <outline text="stripe" title="Stripe" type="rss" xmlUrl="https://stripe.com/blog/feed.rss"/>
+  <outline text="Not a feed" htmlUrl="https://example.com"/>
+</outline>
+</body></opml>`
+
+	feeds, err := ParseOPML(strings.NewReader(opml))
+	if err != nil {
+		t.Fatalf("ParseOPML() error = %v", err)
+	}
+	expected := []Feed{
+		{Name: "Go Blog", URL: "https://go.dev/blog/feed.atom"},
+		{Name: "Stripe", URL: "https://stripe.com/blog/feed.rss"},
+	}
+	if len(feeds) != len(expected) {
+		t.Fatalf("expected %d feeds, got %+v", len(expected), feeds)
+	}
+	for i := range expected {
+		if feeds[i] != expected[i] {
+			t.Errorf("feed %d = %+v, want %+v", i, feeds[i], expected[i])
+		}
+	}
+}
+
+func TestFeedsFromProviders(t *testing.T) {
+	rows := [][]interface{}{
+		{"name", "url", "element", "strategy"},
+		{"Go Blog", "https://go.dev/blog/feed.atom", "", "RSS"},
+		{"Stripe", "https://stripe.com/blog", "article", "html"},
+		{"Short row"},
+		{"Empty URL", "", "", "rss"},
+	}
+
+	feeds := FeedsFromProviders(rows)
+	if len(feeds) != 1 || feeds[0] != (Feed{Name: "Go Blog", URL: "https://go.dev/blog/feed.atom"}) {
+		t.Errorf("unexpected feeds %+v", feeds)
+	}
+}
+
+func TestFeedSourceFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rss":
+			fmt.Fprint(w, testRSS)
+		case "/atom":
+			fmt.Fprint(w, testAtom)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		feeds         []Feed
+		expectError   bool
+		expectedCount int
+	}{
+		{name: "skips failing feeds", feeds: []Feed{{Name: "Go", URL: server.URL + "/rss"}, {URL: server.URL + "/missing"}, {URL: server.URL + "/atom"}}, expectedCount: 4},
+		{name: "fails when every feed fails", feeds: []Feed{{URL: server.URL + "/missing"}}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articles, err := NewFeedSourceFromFeeds(tt.feeds).Fetch(context.Background())
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Fetch() error = %v, expectError %v", err, tt.expectError)
+			}
+			if len(articles) != tt.expectedCount {
+				t.Errorf("expected %d articles, got %d", tt.expectedCount, len(articles))
+			}
+		})
+	}
+}
+
+func TestNewFeedSource(t *testing.T) {
+	opmlPath := filepath.Join(t.TempDir(), "feeds.opml")
+	opml := `<opml version="2.0"><body><outline text="Go" xmlUrl="https://go.dev/blog/feed.atom"/></body></opml>`
+	if err := os.WriteFile(opmlPath, []byte(opml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := NewFeedSource(config.SourceConfig{Name: "Stripe", Options: map[string]string{"url": "https://stripe.com/feed", "opml": opmlPath}})
+	if err != nil {
+		t.Fatalf("NewFeedSource() error = %v", err)
+	}
+	if feeds := source.(*FeedSource).Feeds; len(feeds) != 2 || feeds[0].Name != "Stripe" || feeds[1].Name != "Go" {
+		t.Errorf("unexpected feeds %+v", feeds)
+	}
+
+	if _, err := NewFeedSource(config.SourceConfig{}); err == nil {
+		t.Error("expected error without url or opml")
+	}
+}
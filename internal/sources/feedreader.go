@@ -0,0 +1,414 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+func init() {
+	Register("miniflux", NewMinifluxSource)
+	Register("freshrss", NewFreshRSSSource)
+}
+
+const (
+	// minifluxPageSize is the number of entries requested per Miniflux page
+	minifluxPageSize = 100
+	// freshRSSPageSize is the number of items requested per Google Reader stream page
+	freshRSSPageSize = 1000
+	// freshRSSReadState is the category FreshRSS adds to items marked as read
+	freshRSSReadState = "user/-/state/com.google/read"
+)
+
+// feedMapping decides which source a feed reader entry is counted under
+type feedMapping struct {
+	sources         map[string]string // lowercase feed title -> source
+	groupByCategory bool
+}
+
+// newFeedMapping reads the feed_sources ("Feed Title=Source, ...") and group_by (feed|category) options
+func newFeedMapping(cfg config.SourceConfig) feedMapping {
+	mapping := feedMapping{
+		sources:         make(map[string]string),
+		groupByCategory: strings.EqualFold(cfg.Option("group_by", ""), "category"),
+	}
+	for _, pair := range strings.Split(cfg.Option("feed_sources", ""), ",") {
+		feed, source, found := strings.Cut(pair, "=")
+		if !found || strings.TrimSpace(feed) == "" || strings.TrimSpace(source) == "" {
+			continue
+		}
+		mapping.sources[strings.ToLower(strings.TrimSpace(feed))] = strings.TrimSpace(source)
+	}
+	return mapping
+}
+
+// source maps a feed to its source: an explicit feed_sources entry, then the category when
+// grouping by category, then the feed title, then the link domain
+func (m feedMapping) source(feedTitle, category, link string) string {
+	if source, exists := m.sources[strings.ToLower(strings.TrimSpace(feedTitle))]; exists {
+		return source
+	}
+	if m.groupByCategory && strings.TrimSpace(category) != "" {
+		return strings.TrimSpace(category)
+	}
+	if strings.TrimSpace(feedTitle) != "" {
+		return strings.TrimSpace(feedTitle)
+	}
+	return metrics.LinkDomain(link)
+}
+
+// feedProviders collects one providers-sheet-shaped row per subscribed feed, so several feeds
+// mapped to one source (e.g. Substack authors) show up as that source's feed count
+type feedProviders map[string][]interface{}
+
+func (p feedProviders) add(feedKey, source, siteURL string) {
+	if feedKey == "" {
+		return
+	}
+	if _, exists := p[feedKey]; !exists {
+		p[feedKey] = []interface{}{source, siteURL, "", "rss"}
+	}
+}
+
+func (p feedProviders) rows() [][]interface{} {
+	keys := make([]string, 0, len(p))
+	for key := range p {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rows := [][]interface{}{{"name", "url", "element", "strategy"}}
+	for _, key := range keys {
+		rows = append(rows, p[key])
+	}
+	return rows
+}
+
+// MinifluxSource lists entries from a Miniflux instance
+type MinifluxSource struct {
+	BaseURL string
+	Token   string
+	Client  *http.Client
+
+	mapping   feedMapping
+	fetched   bool
+	articles  []schema.ArticleMeta
+	providers feedProviders
+}
+
+type minifluxEntry struct {
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	PublishedAt string `json:"published_at"`
+	Status      string `json:"status"`
+	Feed        struct {
+		ID       int64  `json:"id"`
+		Title    string `json:"title"`
+		SiteURL  string `json:"site_url"`
+		Category struct {
+			Title string `json:"title"`
+		} `json:"category"`
+	} `json:"feed"`
+}
+
+type minifluxEntriesResponse struct {
+	Total   int             `json:"total"`
+	Entries []minifluxEntry `json:"entries"`
+}
+
+// NewMinifluxSource builds a MinifluxSource from the url and token options,
+// falling back to MINIFLUX_URL and MINIFLUX_TOKEN
+func NewMinifluxSource(cfg config.SourceConfig) (Source, error) {
+	source := &MinifluxSource{
+		BaseURL: strings.TrimRight(cfg.Option("url", "MINIFLUX_URL"), "/"),
+		Token:   cfg.Option("token", "MINIFLUX_TOKEN"),
+		Client:  &http.Client{Timeout: 30 * time.Second},
+		mapping: newFeedMapping(cfg),
+	}
+	if source.BaseURL == "" {
+		return nil, fmt.Errorf("url option or MINIFLUX_URL environment variable is required")
+	}
+	if source.Token == "" {
+		return nil, fmt.Errorf("token option or MINIFLUX_TOKEN environment variable is required")
+	}
+	return source, nil
+}
+
+// Fetch pages through every read and unread entry
+func (s *MinifluxSource) Fetch(ctx context.Context) ([]schema.ArticleMeta, error) {
+	if err := s.load(ctx); err != nil {
+		return nil, err
+	}
+	return s.articles, nil
+}
+
+// ProviderRows returns one row per Miniflux feed seen in the entries
+func (s *MinifluxSource) ProviderRows(ctx context.Context) ([][]interface{}, error) {
+	if err := s.load(ctx); err != nil {
+		return nil, err
+	}
+	return s.providers.rows(), nil
+}
+
+func (s *MinifluxSource) load(ctx context.Context) error {
+	if s.fetched {
+		return nil
+	}
+
+	var articles []schema.ArticleMeta
+	providers := feedProviders{}
+	for offset := 0; ; offset += minifluxPageSize {
+		query := url.Values{}
+		query.Add("status", "read")
+		query.Add("status", "unread")
+		query.Set("order", "published_at")
+		query.Set("direction", "asc")
+		query.Set("limit", strconv.Itoa(minifluxPageSize))
+		query.Set("offset", strconv.Itoa(offset))
+
+		var page minifluxEntriesResponse
+		if err := getFeedReaderJSON(ctx, s.Client, s.BaseURL+"/v1/entries?"+query.Encode(), "X-Auth-Token", s.Token, &page); err != nil {
+			return fmt.Errorf("failed to list Miniflux entries: %w", err)
+		}
+
+		for _, entry := range page.Entries {
+			if entry.URL == "" {
+				continue
+			}
+			source := s.mapping.source(entry.Feed.Title, entry.Feed.Category.Title, entry.URL)
+			providers.add(strconv.FormatInt(entry.Feed.ID, 10), source, entry.Feed.SiteURL)
+			articles = append(articles, feedReaderArticle(entry.URL, entry.Title, feedDate(entry.PublishedAt), source, entry.Status == "read"))
+		}
+
+		if len(page.Entries) < minifluxPageSize || offset+len(page.Entries) >= page.Total {
+			break
+		}
+	}
+
+	s.articles, s.providers, s.fetched = articles, providers, true
+	return nil
+}
+
+// FreshRSSSource lists items from a FreshRSS instance through its Google Reader compatible API
+type FreshRSSSource struct {
+	BaseURL  string // e.g. https://rss.example.com/api/greader.php
+	User     string
+	Password string // API password set in the FreshRSS profile
+	Client   *http.Client
+
+	mapping   feedMapping
+	fetched   bool
+	articles  []schema.ArticleMeta
+	providers feedProviders
+}
+
+type freshRSSLink struct {
+	Href string `json:"href"`
+}
+
+type freshRSSItem struct {
+	Title      string         `json:"title"`
+	Published  int64          `json:"published"`
+	Canonical  []freshRSSLink `json:"canonical"`
+	Alternate  []freshRSSLink `json:"alternate"`
+	Categories []string       `json:"categories"`
+	Origin     struct {
+		StreamID string `json:"streamId"`
+		Title    string `json:"title"`
+		HTMLURL  string `json:"htmlUrl"`
+	} `json:"origin"`
+}
+
+type freshRSSStreamResponse struct {
+	Items        []freshRSSItem `json:"items"`
+	Continuation string         `json:"continuation"`
+}
+
+// NewFreshRSSSource builds a FreshRSSSource from the url, user and password options,
+// falling back to FRESHRSS_URL, FRESHRSS_USER and FRESHRSS_API_PASSWORD
+func NewFreshRSSSource(cfg config.SourceConfig) (Source, error) {
+	source := &FreshRSSSource{
+		BaseURL:  strings.TrimRight(cfg.Option("url", "FRESHRSS_URL"), "/"),
+		User:     cfg.Option("user", "FRESHRSS_USER"),
+		Password: cfg.Option("password", "FRESHRSS_API_PASSWORD"),
+		Client:   &http.Client{Timeout: 30 * time.Second},
+		mapping:  newFeedMapping(cfg),
+	}
+	if source.BaseURL == "" {
+		return nil, fmt.Errorf("url option or FRESHRSS_URL environment variable is required")
+	}
+	if source.User == "" || source.Password == "" {
+		return nil, fmt.Errorf("user and password options (or FRESHRSS_USER and FRESHRSS_API_PASSWORD) are required")
+	}
+	return source, nil
+}
+
+// Fetch logs in and pages through the reading list, which holds every read and unread item
+func (s *FreshRSSSource) Fetch(ctx context.Context) ([]schema.ArticleMeta, error) {
+	if err := s.load(ctx); err != nil {
+		return nil, err
+	}
+	return s.articles, nil
+}
+
+// ProviderRows returns one row per FreshRSS feed seen in the reading list
+func (s *FreshRSSSource) ProviderRows(ctx context.Context) ([][]interface{}, error) {
+	if err := s.load(ctx); err != nil {
+		return nil, err
+	}
+	return s.providers.rows(), nil
+}
+
+func (s *FreshRSSSource) load(ctx context.Context) error {
+	if s.fetched {
+		return nil
+	}
+
+	token, err := s.login(ctx)
+	if err != nil {
+		return err
+	}
+
+	var articles []schema.ArticleMeta
+	providers := feedProviders{}
+	continuation := ""
+	for {
+		query := url.Values{}
+		query.Set("output", "json")
+		query.Set("n", strconv.Itoa(freshRSSPageSize))
+		if continuation != "" {
+			query.Set("c", continuation)
+		}
+
+		var page freshRSSStreamResponse
+		endpoint := s.BaseURL + "/reader/api/0/stream/contents/reading-list?" + query.Encode()
+		if err := getFeedReaderJSON(ctx, s.Client, endpoint, "Authorization", "GoogleLogin auth="+token, &page); err != nil {
+			return fmt.Errorf("failed to list FreshRSS items: %w", err)
+		}
+
+		for _, item := range page.Items {
+			link := item.link()
+			if link == "" {
+				continue
+			}
+			read, label := item.state()
+			source := s.mapping.source(item.Origin.Title, label, link)
+			providers.add(item.Origin.StreamID, source, item.Origin.HTMLURL)
+
+			date := time.Now().Format("2006-01-02")
+			if item.Published > 0 {
+				date = time.Unix(item.Published, 0).UTC().Format("2006-01-02")
+			}
+			articles = append(articles, feedReaderArticle(link, item.Title, date, source, read))
+		}
+
+		if page.Continuation == "" || len(page.Items) == 0 {
+			break
+		}
+		continuation = page.Continuation
+	}
+
+	s.articles, s.providers, s.fetched = articles, providers, true
+	return nil
+}
+
+// login exchanges the user's API password for a Google Reader auth token
+func (s *FreshRSSSource) login(ctx context.Context) (string, error) {
+	form := url.Values{"Email": {s.User}, "Passwd": {s.Password}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.BaseURL+"/accounts/ClientLogin", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to build FreshRSS login request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to log in to FreshRSS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read FreshRSS login response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("FreshRSS login returned status %d", resp.StatusCode)
+	}
+
+	for _, line := range strings.Split(string(body), "\n") {
+		if token, found := strings.CutPrefix(strings.TrimSpace(line), "Auth="); found && token != "" {
+			return token, nil
+		}
+	}
+	return "", fmt.Errorf("FreshRSS login response has no Auth token")
+}
+
+// link prefers the canonical URL over the alternate one
+func (item freshRSSItem) link() string {
+	for _, links := range [][]freshRSSLink{item.Canonical, item.Alternate} {
+		for _, l := range links {
+			if l.Href != "" {
+				return l.Href
+			}
+		}
+	}
+	return ""
+}
+
+// state reports whether the item is read and the first folder label it is filed under
+func (item freshRSSItem) state() (bool, string) {
+	read, label := false, ""
+	for _, category := range item.Categories {
+		if category == freshRSSReadState {
+			read = true
+		}
+		if name, found := strings.CutPrefix(category, "user/-/label/"); found && label == "" {
+			label = name
+		}
+	}
+	return read, label
+}
+
+// feedReaderArticle builds an article from a feed reader entry, falling back to the link as title
+func feedReaderArticle(link, title, date, source string, read bool) schema.ArticleMeta {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = link
+	}
+	return schema.ArticleMeta{Date: date, Title: title, Link: link, Category: source, Read: read}
+}
+
+// getFeedReaderJSON performs an authenticated GET and decodes the JSON response into out
+func getFeedReaderJSON(ctx context.Context, client *http.Client, endpoint, authHeader, authValue string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set(authHeader, authValue)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func TestFeedMappingSource(t *testing.T) {
+	mapping := newFeedMapping(config.SourceConfig{Options: map[string]string{
+		"feed_sources": "Lenny's Newsletter=Substack, The Pragmatic Engineer = Substack, broken",
+		"group_by":     "category",
+	}})
+
+	tests := []struct {
+		name      string
+		feedTitle string
+		category  string
+		link      string
+		expected  string
+	}{
+		{name: "explicit mapping wins", feedTitle: "lenny's newsletter", category: "Product", expected: "Substack"},
+		{name: "trimmed mapping", feedTitle: "The Pragmatic Engineer", expected: "Substack"},
+		{name: "category grouping", feedTitle: "Go Blog", category: "Languages", expected: "Languages"},
+		{name: "feed title without category", feedTitle: "Go Blog", expected: "Go Blog"},
+		{name: "link domain as last resort", link: "https://www.example.com/post", expected: "example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapping.source(tt.feedTitle, tt.category, tt.link); got != tt.expected {
+				t.Errorf("source() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMinifluxSourceFetch(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-Auth-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if statuses := r.URL.Query()["status"]; len(statuses) != 2 {
+			t.Errorf("expected read and unread statuses, got %v", statuses)
+		}
+		fmt.Fprint(w, `{"total": 3, "entries": [
+			{"title": "Growth loops", "url": "https://lenny.substack.com/p/1", "published_at": "2025-01-05T08:00:00Z", "status": "read", "feed": {"id": 1, "title": "Lenny's Newsletter", "site_url": "https://lenny.substack.com"}},
+			{"title": "Hiring", "url": "https://pragmatic.substack.com/p/2", "published_at": "2025-01-06T08:00:00-05:00", "status": "unread", "feed": {"id": 2, "title": "The Pragmatic Engineer", "site_url": "https://pragmatic.substack.com"}},
+			{"title": "Iterators", "url": "https://go.dev/blog/iter", "published_at": "2025-01-07T08:00:00Z", "status": "unread", "feed": {"id": 3, "title": "Go Blog", "category": {"title": "Languages"}}}
+		]}`)
+	}))
+	defer server.Close()
+
+	source, err := NewMinifluxSource(config.SourceConfig{Options: map[string]string{
+		"url":          server.URL + "/",
+		"token":        "secret",
+		"feed_sources": "Lenny's Newsletter=Substack, The Pragmatic Engineer=Substack",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	articles, err := source.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(articles) != 3 {
+		t.Fatalf("expected 3 articles, got %d", len(articles))
+	}
+	if articles[0].Category != "Substack" || !articles[0].Read || articles[0].Date != "2025-01-05" {
+		t.Errorf("unexpected first article %+v", articles[0])
+	}
+	if articles[2].Category != "Go Blog" || articles[2].Read {
+		t.Errorf("unexpected third article %+v", articles[2])
+	}
+
+	rows, err := source.(ProviderSource).ProviderRows(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 || rows[1][0] != "Substack" || rows[2][0] != "Substack" || rows[3][3] != "rss" {
+		t.Errorf("unexpected provider rows %v", rows)
+	}
+	if requests != 1 {
+		t.Errorf("expected Fetch and ProviderRows to share one request, got %d", requests)
+	}
+
+	if _, err := NewMinifluxSource(config.SourceConfig{Options: map[string]string{"url": server.URL}}); err == nil {
+		t.Error("expected error without a token")
+	}
+}
+
+func TestFreshRSSSourceFetch(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/greader.php/accounts/ClientLogin":
+			if r.FormValue("Email") != "alice" || r.FormValue("Passwd") != "api-pass" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, "SID=alice/x\nLSID=null\nAuth=alice/token\n")
+		case "/api/greader.php/reader/api/0/stream/contents/reading-list":
+			if r.Header.Get("Authorization") != "GoogleLogin auth=alice/token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			pages = append(pages, r.URL.Query().Get("c"))
+			if r.URL.Query().Get("c") == "" {
+				fmt.Fprint(w, `{"items": [
+					{"title": "Read one", "published": 1735689600, "canonical": [{"href": "https://a.substack.com/p/1"}], "categories": ["user/-/state/com.google/reading-list", "user/-/state/com.google/read", "user/-/label/Newsletters"], "origin": {"streamId": "feed/1", "title": "Author A"}},
+					{"title": "No link", "origin": {"streamId": "feed/1", "title": "Author A"}}
+				], "continuation": "page2"}`)
+				return
+			}
+			fmt.Fprint(w, `{"items": [
+				{"title": "", "published": 1735776000, "alternate": [{"href": "https://b.substack.com/p/2"}], "categories": ["user/-/label/Newsletters"], "origin": {"streamId": "feed/2", "title": "Author B"}}
+			]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	source, err := NewFreshRSSSource(config.SourceConfig{Options: map[string]string{
+		"url":      server.URL + "/api/greader.php",
+		"user":     "alice",
+		"password": "api-pass",
+		"group_by": "category",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	articles, err := source.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(articles) != 2 || len(pages) != 2 || pages[1] != "page2" {
+		t.Fatalf("expected 2 articles over 2 pages, got %d articles, pages %v", len(articles), pages)
+	}
+	if !articles[0].Read || articles[0].Category != "Newsletters" || articles[0].Date != "2025-01-01" {
+		t.Errorf("unexpected first article %+v", articles[0])
+	}
+	if articles[1].Read || articles[1].Title != "https://b.substack.com/p/2" {
+		t.Errorf("unexpected second article %+v", articles[1])
+	}
+
+	rows, err := source.(ProviderSource).ProviderRows(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[1][0] != "Newsletters" || rows[2][0] != "Newsletters" {
+		t.Errorf("unexpected provider rows %v", rows)
+	}
+
+	bad, _ := NewFreshRSSSource(config.SourceConfig{Options: map[string]string{"url": server.URL + "/api/greader.php", "user": "alice", "password": "wrong"}})
+	if _, err := bad.Fetch(context.Background()); err == nil {
+		t.Error("expected login error with a wrong password")
+	}
+}
@@ -0,0 +1,49 @@
+package sources
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Date-bearing inputs from feeds and notes; explore further with
+// go test ./internal/sources/ -run '^$' -fuzz FuzzParseFeed -fuzztime 30s
+
+func FuzzParseFeed(f *testing.F) {
+	f.Add(`<rss><channel><title>Blog</title><item><title>Post</title><link>https://a.com/1</link><pubDate>Mon, 02 Jan 2006 15:04:05 -0700</pubDate></item></channel></rss>`)
+	f.Add(`<feed><entry><link href="https://a.com/2"/><updated>2024-13-45T99:00:00Z</updated></entry></feed>`)
+	f.Add(`<rdf:RDF><item><link>https://a.com/3</link><dc:date>not a date</dc:date></item></rdf:RDF>`)
+	f.Add(`<rss><channel><item><guid>http://a.com/4</guid></item>`)
+
+	f.Fuzz(func(t *testing.T, content string) {
+		articles, err := ParseFeed(strings.NewReader(content), "")
+		if err != nil {
+			return
+		}
+		for _, article := range articles {
+			if _, err := time.Parse("2006-01-02", article.Date); err != nil {
+				t.Fatalf("feed entry has unparseable date %q", article.Date)
+			}
+		}
+	})
+}
+
+func FuzzParseMarkdownNote(f *testing.F) {
+	f.Add("---\ntitle: Post\nurl: https://a.com\ndate: 2024-01-02\nread: yes\nauthors: [[Jane]], John\n---\n# Heading\n")
+	f.Add("---\nurl: https://a.com\ndate: 2024-01-02T10:00:00Z\nread: true\nauthors:\n  - Jane\n  - 3\n---")
+	f.Add("---\nurl: https://a.com\ndate: [1, 2]\nread: {x: 1}\n---\n")
+	f.Add("\ufeff---\r\nlink: https://a.com\r\n---\r\n")
+
+	f.Fuzz(func(t *testing.T, content string) {
+		article, ok, err := ParseMarkdownNote([]byte(content), "fallback", time.Now())
+		if err != nil || !ok {
+			return
+		}
+		if _, err := time.Parse("2006-01-02", article.Date); err != nil {
+			t.Fatalf("note has unparseable date %q", article.Date)
+		}
+		if article.Title == "" || article.Link == "" {
+			t.Fatalf("note accepted without title or link: %+v", article)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func init() {
+	Register("instapaper", NewInstapaperSource)
+}
+
+// InstapaperSource reads Instapaper's CSV export (URL,Title,Selection,Folder,Timestamp)
+type InstapaperSource struct {
+	Path string
+}
+
+// NewInstapaperSource builds an InstapaperSource from the path option
+func NewInstapaperSource(cfg config.SourceConfig) (Source, error) {
+	path := cfg.Option("path", "")
+	if path == "" {
+		return nil, fmt.Errorf("path option is required")
+	}
+	return &InstapaperSource{Path: path}, nil
+}
+
+// Fetch parses the export file
+func (s *InstapaperSource) Fetch(ctx context.Context) ([]schema.ArticleMeta, error) {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", s.Path, err)
+	}
+	defer f.Close()
+
+	return ParseInstapaperCSV(f)
+}
+
+// ParseInstapaperCSV parses an Instapaper export; bookmarks in the Archive folder count as read
+func ParseInstapaperCSV(r io.Reader) ([]schema.ArticleMeta, error) {
+	records, columns, err := readCSVWithHeader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Instapaper CSV: %w", err)
+	}
+	if _, exists := columns["url"]; !exists {
+		return nil, fmt.Errorf("instapaper CSV is missing the URL column")
+	}
+
+	var articles []schema.ArticleMeta
+	for _, record := range records {
+		link := csvValue(record, columns, "url")
+		if link == "" {
+			continue
+		}
+		archived := strings.EqualFold(csvValue(record, columns, "folder"), "archive")
+		articles = append(articles, importedArticle(link, csvValue(record, columns, "title"), csvValue(record, columns, "timestamp"), archived))
+	}
+	return articles, nil
+}
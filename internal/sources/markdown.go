@@ -0,0 +1,228 @@
+package sources
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+func init() {
+	Register("markdown", NewMarkdownSource)
+}
+
+// MarkdownSource scans a notes vault (e.g. Obsidian) for Markdown files whose frontmatter describes an article
+type MarkdownSource struct {
+	Dir string
+}
+
+// markdownFrontmatter holds the article fields read from a note; unknown keys are ignored
+type markdownFrontmatter struct {
+	Title   string      `yaml:"title"`
+	Date    interface{} `yaml:"date"`
+	URL     string      `yaml:"url"`
+	Link    string      `yaml:"link"`
+	Source  string      `yaml:"source"`
+	Read    interface{} `yaml:"read"`
+	Authors interface{} `yaml:"authors"`
+	Author  string      `yaml:"author"`
+}
+
+// NewMarkdownSource builds a MarkdownSource from the path option
+func NewMarkdownSource(cfg config.SourceConfig) (Source, error) {
+	dir := cfg.Option("path", "")
+	if dir == "" {
+		return nil, fmt.Errorf("path option is required")
+	}
+	return &MarkdownSource{Dir: dir}, nil
+}
+
+// Fetch walks the vault, skipping hidden folders (.obsidian, .trash), notes without a url or link
+// and notes whose frontmatter is not valid YAML. Undated notes take the file's modification date.
+func (s *MarkdownSource) Fetch(ctx context.Context) ([]schema.ArticleMeta, error) {
+	var articles []schema.ArticleMeta
+	err := filepath.WalkDir(s.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != s.Dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		article, ok, err := ParseMarkdownNote(content, strings.TrimSuffix(d.Name(), filepath.Ext(d.Name())), info.ModTime())
+		if err != nil {
+			slog.Warn("Skipping note with invalid frontmatter", "file", path, "err", err)
+			return nil
+		}
+		if ok {
+			articles = append(articles, article)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", s.Dir, err)
+	}
+	return articles, nil
+}
+
+// ParseMarkdownNote reads the YAML frontmatter of a note. It reports false for notes without
+// frontmatter or without a url/link. The title falls back to the first heading, then to fallbackTitle,
+// and the date to fallbackDate.
+func ParseMarkdownNote(content []byte, fallbackTitle string, fallbackDate time.Time) (schema.ArticleMeta, bool, error) {
+	frontmatter, body, found := splitFrontmatter(content)
+	if !found {
+		return schema.ArticleMeta{}, false, nil
+	}
+
+	var fm markdownFrontmatter
+	if err := yaml.Unmarshal(frontmatter, &fm); err != nil {
+		return schema.ArticleMeta{}, false, fmt.Errorf("invalid frontmatter: %w", err)
+	}
+
+	link := strings.TrimSpace(fm.URL)
+	if link == "" {
+		link = strings.TrimSpace(fm.Link)
+	}
+	if link == "" {
+		return schema.ArticleMeta{}, false, nil
+	}
+
+	title := strings.TrimSpace(fm.Title)
+	if title == "" {
+		title = firstHeading(body)
+	}
+	if title == "" {
+		title = fallbackTitle
+	}
+
+	source := strings.TrimSpace(fm.Source)
+	if source == "" {
+		source = metrics.LinkDomain(link)
+	}
+
+	return schema.ArticleMeta{
+		Date:     frontmatterDate(fm.Date, fallbackDate),
+		Title:    title,
+		Link:     link,
+		Category: source,
+		Read:     frontmatterBool(fm.Read),
+		Authors:  frontmatterAuthors(fm.Authors, fm.Author),
+	}, true, nil
+}
+
+// splitFrontmatter separates a leading "---" delimited block from the rest of the note
+func splitFrontmatter(content []byte) ([]byte, []byte, bool) {
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(content, []byte("---\n")) {
+		return nil, content, false
+	}
+
+	rest := content[len("---\n"):]
+	if end := bytes.Index(rest, []byte("\n---")); end >= 0 {
+		body := rest[end+len("\n---"):]
+		if i := bytes.IndexByte(body, '\n'); i >= 0 {
+			body = body[i+1:]
+		} else {
+			body = nil
+		}
+		return rest[:end], body, true
+	}
+	return nil, content, false
+}
+
+// firstHeading returns the text of the first Markdown "# " heading
+func firstHeading(body []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		if heading, found := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "# "); found {
+			return strings.TrimSpace(heading)
+		}
+	}
+	return ""
+}
+
+// frontmatterDate accepts a YAML date or a date/datetime string, falling back to fallback
+func frontmatterDate(value interface{}, fallback time.Time) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format("2006-01-02")
+	case string:
+		v = strings.TrimSpace(v)
+		for _, layout := range []string{"2006-01-02", time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t.Format("2006-01-02")
+			}
+		}
+	}
+	return fallback.Format("2006-01-02")
+}
+
+// frontmatterBool treats true, yes, y, x and done as read
+func frontmatterBool(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "yes", "y", "x", "done":
+			return true
+		}
+	}
+	return false
+}
+
+// frontmatterAuthors accepts an authors list, an authors string separated by ";" (or "," when no ";"), or a single author
+func frontmatterAuthors(authors interface{}, author string) []string {
+	var names []string
+	switch v := authors.(type) {
+	case []interface{}:
+		for _, name := range v {
+			names = append(names, fmt.Sprintf("%v", name))
+		}
+	case string:
+		if !strings.Contains(v, ";") {
+			v = strings.ReplaceAll(v, ",", ";")
+		}
+		names = metrics.SplitAuthors(v)
+	}
+	if len(names) == 0 && strings.TrimSpace(author) != "" {
+		names = []string{author}
+	}
+
+	var cleaned []string
+	for _, name := range names {
+		// Obsidian wiki links such as [[Jane Doe]] become plain names
+		name = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(name), "[["), "]]"))
+		if name != "" {
+			cleaned = append(cleaned, name)
+		}
+	}
+	return cleaned
+}
@@ -0,0 +1,140 @@
+package sources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func TestParseMarkdownNote(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectOK      bool
+		expectError   bool
+		expectedTitle string
+		expectedDate  string
+		expectedSrc   string
+		expectedRead  bool
+		expectedAuth  []string
+	}{
+		{
+			name:          "full frontmatter",
+			content:       "---\ntitle: Range over func\ndate: 2024-08-20\nurl: https://go.dev/blog/range-functions\nsource: Go Blog\nread: true\nauthors:\n  - \"[[Ian Lance Taylor]]\"\n---\nNotes here\n",
+			expectOK:      true,
+			expectedTitle: "Range over func",
+			expectedDate:  "2024-08-20",
+			expectedSrc:   "Go Blog",
+			expectedRead:  true,
+			expectedAuth:  []string{"Ian Lance Taylor"},
+		},
+		{
+			name:          "heading title, link key, domain source and string flags",
+			content:       "\ufeff---\r\nlink: https://www.stripe.com/blog/scale\r\ndate: \"2025-01-02T08:00:00Z\"\r\nread: yes\r\nauthors: Jane Doe, John Roe\r\n---\r\n\r\n# Payments at scale\r\n",
+			expectOK:      true,
+			expectedTitle: "Payments at scale",
+			expectedDate:  "2025-01-02",
+			expectedSrc:   "stripe.com",
+			expectedRead:  true,
+			expectedAuth:  []string{"Jane Doe", "John Roe"},
+		},
+		{
+			name:          "file name title and single author",
+			content:       "---\nurl: https://example.com/a\ndate: 2024-01-01\nauthor: Ada\n---",
+			expectOK:      true,
+			expectedTitle: "fallback",
+			expectedDate:  "2024-01-01",
+			expectedSrc:   "example.com",
+			expectedAuth:  []string{"Ada"},
+		},
+		{
+			name:          "undated note takes the fallback date",
+			content:       "---\ntitle: Undated\nurl: https://example.com/b\ndate: someday\n---\n",
+			expectOK:      true,
+			expectedTitle: "Undated",
+			expectedDate:  "2023-05-06",
+			expectedSrc:   "example.com",
+		},
+		{name: "no frontmatter", content: "# Just a note\n"},
+		{name: "frontmatter without url", content: "---\ntitle: Daily note\n---\n"},
+		{name: "invalid frontmatter", content: "---\ntitle: [unclosed\n---\n", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article, ok, err := ParseMarkdownNote([]byte(tt.content), "fallback", time.Date(2023, 5, 6, 12, 0, 0, 0, time.UTC))
+			if (err != nil) != tt.expectError {
+				t.Fatalf("ParseMarkdownNote() error = %v, expectError %v", err, tt.expectError)
+			}
+			if ok != tt.expectOK {
+				t.Fatalf("ParseMarkdownNote() ok = %v, want %v", ok, tt.expectOK)
+			}
+			if !ok {
+				return
+			}
+			if article.Title != tt.expectedTitle || article.Date != tt.expectedDate || article.Category != tt.expectedSrc || article.Read != tt.expectedRead {
+				t.Errorf("unexpected article %+v", article)
+			}
+			if strings.Join(article.Authors, "|") != strings.Join(tt.expectedAuth, "|") {
+				t.Errorf("authors = %v, want %v", article.Authors, tt.expectedAuth)
+			}
+		})
+	}
+}
+
+func TestMarkdownSourceFetch(t *testing.T) {
+	vault := t.TempDir()
+	files := map[string]string{
+		"Reading/go.md":          "---\nurl: https://go.dev/blog/a\ndate: 2024-01-01\nread: true\n---\n",
+		"Reading/Deep/stripe.md": "---\nurl: https://stripe.com/b\ndate: 2024-02-01\n---\n",
+		"Daily/2024-01-01.md":    "---\ntags: [daily]\n---\n",
+		"Reading/image.png":      "not markdown",
+		".obsidian/templates.md": "---\nurl: https://hidden.example.com\n---\n",
+		"Reading/broken.md":      "---\ntitle: [unclosed\nurl: https://broken.example.com\n---\n",
+		"Reading/undated.md":     "---\nurl: https://example.com/c\n---\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(vault, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	modified := time.Date(2023, 5, 6, 12, 0, 0, 0, time.Local)
+	if err := os.Chtimes(filepath.Join(vault, "Reading/undated.md"), modified, modified); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := NewMarkdownSource(config.SourceConfig{Options: map[string]string{"path": vault}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	articles, err := source.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(articles) != 3 {
+		t.Fatalf("expected 3 articles, got %+v", articles)
+	}
+	for _, article := range articles {
+		if article.Link == "https://example.com/c" && article.Date != "2023-05-06" {
+			t.Errorf("undated note date = %q, want the modification date 2023-05-06", article.Date)
+		}
+	}
+
+	if _, err := NewMarkdownSource(config.SourceConfig{}); err == nil {
+		t.Error("expected error without path")
+	}
+	missing, _ := NewMarkdownSource(config.SourceConfig{Options: map[string]string{"path": filepath.Join(vault, "missing")}})
+	if _, err := missing.Fetch(context.Background()); err == nil {
+		t.Error("expected error for a missing vault")
+	}
+}
@@ -0,0 +1,184 @@
+package sources
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+func init() {
+	Register("pocket", NewPocketSource)
+}
+
+// PocketSource reads a Pocket export, either the legacy ril_export.html or the newer part_*.csv
+type PocketSource struct {
+	Path string
+}
+
+// NewPocketSource builds a PocketSource from the path option
+func NewPocketSource(cfg config.SourceConfig) (Source, error) {
+	path := cfg.Option("path", "")
+	if path == "" {
+		return nil, fmt.Errorf("path option is required")
+	}
+	return &PocketSource{Path: path}, nil
+}
+
+// Fetch parses the export, picking the HTML or CSV parser from the file extension
+func (s *PocketSource) Fetch(ctx context.Context) ([]schema.ArticleMeta, error) {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", s.Path, err)
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(s.Path), ".csv") {
+		return ParsePocketCSV(f)
+	}
+	return ParsePocketHTML(f)
+}
+
+// ParsePocketHTML parses Pocket's HTML export, where links under the "Read Archive" heading are read
+func ParsePocketHTML(r io.Reader) ([]schema.ArticleMeta, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Pocket HTML: %w", err)
+	}
+
+	var articles []schema.ArticleMeta
+	archived := false
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "h1":
+				archived = strings.Contains(strings.ToLower(textContent(n)), "archive")
+			case "a":
+				link := attr(n, "href")
+				if link != "" {
+					articles = append(articles, importedArticle(link, textContent(n), attr(n, "time_added"), archived))
+				}
+				return
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+
+	return articles, nil
+}
+
+// ParsePocketCSV parses Pocket's CSV export (title,url,time_added,tags,status)
+func ParsePocketCSV(r io.Reader) ([]schema.ArticleMeta, error) {
+	records, columns, err := readCSVWithHeader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Pocket CSV: %w", err)
+	}
+	if _, exists := columns["url"]; !exists {
+		return nil, fmt.Errorf("pocket CSV is missing the url column")
+	}
+
+	var articles []schema.ArticleMeta
+	for _, record := range records {
+		link := csvValue(record, columns, "url")
+		if link == "" {
+			continue
+		}
+		archived := strings.EqualFold(csvValue(record, columns, "status"), "archive")
+		articles = append(articles, importedArticle(link, csvValue(record, columns, "title"), csvValue(record, columns, "time_added"), archived))
+	}
+	return articles, nil
+}
+
+// importedArticle builds an article from a read-later export, using the link domain as the source
+func importedArticle(link, title, unixTime string, read bool) schema.ArticleMeta {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = link
+	}
+	return schema.ArticleMeta{
+		Date:     unixDate(unixTime),
+		Title:    title,
+		Link:     link,
+		Category: metrics.LinkDomain(link),
+		Read:     read,
+	}
+}
+
+// unixDate formats a Unix timestamp as YYYY-MM-DD, falling back to today when it is missing
+func unixDate(value string) string {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || seconds <= 0 {
+		return time.Now().Format("2006-01-02")
+	}
+	return time.Unix(seconds, 0).UTC().Format("2006-01-02")
+}
+
+// readCSVWithHeader reads all records and maps lowercase header names to column indexes
+func readCSVWithHeader(r io.Reader) ([][]string, map[string]int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, map[string]int{}, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	return records[1:], columns, nil
+}
+
+// csvValue returns the trimmed value of a named column, or "" when absent
+func csvValue(record []string, columns map[string]int, column string) string {
+	if idx, exists := columns[column]; exists && idx < len(record) {
+		return strings.TrimSpace(record[idx])
+	}
+	return ""
+}
+
+// attr returns an HTML attribute value
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// textContent concatenates the text below an HTML node
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,89 @@
+package sources
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePocketHTML(t *testing.T) {
+	export := `<!DOCTYPE html><html><body>
+<h1>Unread</h1>
+<ul>
+  <li><a href="https://github.blog/unread" time_added="1704067200" tags="">Unread   Post</a></li>
+</ul>
+<h1>Read Archive</h1>
+<ul>
+  <li><a href="https://www.stripe.com/blog/read" time_added="1706745600" tags="payments">Read Post</a></li>
+  <li><a href="https://example.com/untitled" time_added="1706745600"></a></li>
+</ul>
+</body></html>`
+
+	articles, err := ParsePocketHTML(strings.NewReader(export))
+	if err != nil {
+		t.Fatalf("ParsePocketHTML() error = %v", err)
+	}
+	if len(articles) != 3 {
+		t.Fatalf("expected 3 articles, got %d", len(articles))
+	}
+
+	tests := []struct {
+		title, date, source string
+		read                bool
+	}{
+		{"Unread Post", "2024-01-01", "github.blog", false},
+		{"Read Post", "2024-02-01", "stripe.com", true},
+		{"https://example.com/untitled", "2024-02-01", "example.com", true},
+	}
+	for i, want := range tests {
+		got := articles[i]
+		if got.Title != want.title || got.Date != want.date || got.Category != want.source || got.Read != want.read {
+			t.Errorf("article %d = %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+func TestParsePocketCSV(t *testing.T) {
+	export := "title,url,time_added,tags,status\n" +
+		"Archived,https://a.com/1,1704067200,,archive\n" +
+		"Queued,https://b.com/2,1704153600,go,unread\n" +
+		"No link,,1704153600,,unread\n"
+
+	articles, err := ParsePocketCSV(strings.NewReader(export))
+	if err != nil {
+		t.Fatalf("ParsePocketCSV() error = %v", err)
+	}
+	if len(articles) != 2 {
+		t.Fatalf("expected 2 articles, got %d", len(articles))
+	}
+	if !articles[0].Read || articles[1].Read {
+		t.Errorf("expected archive status to map to read, got %+v", articles)
+	}
+	if articles[1].Date != "2024-01-02" || articles[1].Category != "b.com" {
+		t.Errorf("unexpected second article %+v", articles[1])
+	}
+
+	if _, err := ParsePocketCSV(strings.NewReader("title,link\nA,https://a\n")); err == nil {
+		t.Error("expected error without url column")
+	}
+}
+
+func TestParseInstapaperCSV(t *testing.T) {
+	export := "URL,Title,Selection,Folder,Timestamp\n" +
+		"https://a.com/1,First,,Unread,1704067200\n" +
+		"https://a.com/2,Second,,Archive,1704153600\n" +
+		"https://a.com/3,Third,,Starred,1704240000\n"
+
+	articles, err := ParseInstapaperCSV(strings.NewReader(export))
+	if err != nil {
+		t.Fatalf("ParseInstapaperCSV() error = %v", err)
+	}
+	if len(articles) != 3 {
+		t.Fatalf("expected 3 articles, got %d", len(articles))
+	}
+	if articles[0].Read || !articles[1].Read || articles[2].Read {
+		t.Errorf("expected only the Archive folder to count as read, got %+v", articles)
+	}
+	if articles[2].Date != "2024-01-03" {
+		t.Errorf("expected 2024-01-03, got %s", articles[2].Date)
+	}
+}
@@ -0,0 +1,158 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+func init() {
+	Register("raindrop", NewRaindropSource)
+}
+
+const (
+	// RaindropBaseURL is the Raindrop.io REST API root
+	RaindropBaseURL = "https://api.raindrop.io/rest/v1"
+	// raindropPageSize is the largest page the raindrops endpoint allows
+	raindropPageSize = 50
+)
+
+// RaindropSource lists bookmarks from a Raindrop.io collection
+type RaindropSource struct {
+	Token      string
+	Collection string // collection ID; "0" lists every collection except Trash
+	ReadTag    string // bookmarks carrying this tag count as read
+	BaseURL    string
+	Client     *http.Client
+}
+
+type raindropItem struct {
+	Link      string   `json:"link"`
+	Title     string   `json:"title"`
+	Tags      []string `json:"tags"`
+	Created   string   `json:"created"`
+	Domain    string   `json:"domain"`
+	Important bool     `json:"important"`
+}
+
+type raindropListResponse struct {
+	Result       bool           `json:"result"`
+	Items        []raindropItem `json:"items"`
+	ErrorMessage string         `json:"errorMessage"`
+}
+
+// NewRaindropSource builds a RaindropSource from the token, collection and read_tag options,
+// falling back to RAINDROP_TOKEN and RAINDROP_COLLECTION
+func NewRaindropSource(cfg config.SourceConfig) (Source, error) {
+	source := &RaindropSource{
+		Token:      cfg.Option("token", "RAINDROP_TOKEN"),
+		Collection: cfg.Option("collection", "RAINDROP_COLLECTION"),
+		ReadTag:    cfg.Option("read_tag", ""),
+		BaseURL:    cfg.Option("base_url", ""),
+		Client:     &http.Client{Timeout: 30 * time.Second},
+	}
+	if source.Token == "" {
+		return nil, fmt.Errorf("token option or RAINDROP_TOKEN environment variable is required")
+	}
+	if source.Collection == "" {
+		source.Collection = "0"
+	}
+	if source.ReadTag == "" {
+		source.ReadTag = "archived"
+	}
+	if source.BaseURL == "" {
+		source.BaseURL = RaindropBaseURL
+	}
+	return source, nil
+}
+
+// Fetch pages through the collection. The first tag becomes the source (the domain when untagged);
+// favorites and bookmarks tagged with ReadTag count as read.
+func (s *RaindropSource) Fetch(ctx context.Context) ([]schema.ArticleMeta, error) {
+	var articles []schema.ArticleMeta
+
+	for page := 0; ; page++ {
+		items, err := s.fetchPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if article, ok := s.toArticle(item); ok {
+				articles = append(articles, article)
+			}
+		}
+		if len(items) < raindropPageSize {
+			return articles, nil
+		}
+	}
+}
+
+// fetchPage requests one page of raindrops
+func (s *RaindropSource) fetchPage(ctx context.Context, page int) ([]raindropItem, error) {
+	endpoint := fmt.Sprintf("%s/raindrops/%s?perpage=%d&page=%d", strings.TrimSuffix(s.BaseURL, "/"), s.Collection, raindropPageSize, page)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Raindrop request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.Token)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Raindrop: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("raindrop returned %s", resp.Status)
+	}
+
+	var body raindropListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode Raindrop response: %w", err)
+	}
+	if !body.Result {
+		return nil, fmt.Errorf("raindrop request failed: %s", body.ErrorMessage)
+	}
+	return body.Items, nil
+}
+
+// toArticle maps a raindrop onto ArticleMeta
+func (s *RaindropSource) toArticle(item raindropItem) (schema.ArticleMeta, bool) {
+	created, err := time.Parse(time.RFC3339, item.Created)
+	if err != nil || item.Link == "" {
+		return schema.ArticleMeta{}, false
+	}
+
+	read := item.Important
+	source := ""
+	for _, tag := range item.Tags {
+		if strings.EqualFold(tag, s.ReadTag) {
+			read = true
+			continue
+		}
+		if source == "" {
+			source = tag
+		}
+	}
+	if source == "" {
+		source = strings.TrimPrefix(item.Domain, "www.")
+	}
+	if source == "" {
+		source = metrics.LinkDomain(item.Link)
+	}
+
+	return schema.ArticleMeta{
+		Date:     created.Format("2006-01-02"),
+		Title:    item.Title,
+		Link:     item.Link,
+		Category: source,
+		Read:     read,
+	}, true
+}
@@ -0,0 +1,84 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func TestRaindropSourceFetch(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path+"?"+r.URL.RawQuery)
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("page") == "0" {
+			// A full page forces a request for the next one
+			items := make([]string, raindropPageSize)
+			items[0] = `{"link": "https://github.blog/a", "title": "Tagged", "tags": ["engineering"], "created": "2025-01-10T08:00:00.000Z", "domain": "github.blog"}`
+			items[1] = `{"link": "https://www.stripe.com/b", "title": "Favorite", "tags": [], "created": "2025-01-11T08:00:00Z", "domain": "stripe.com", "important": true}`
+			items[2] = `{"link": "https://example.com/c", "title": "Archived", "tags": ["Archived", "go"], "created": "2025-01-12T08:00:00Z"}`
+			for i := 3; i < raindropPageSize; i++ {
+				items[i] = `{"link": "", "created": "bad"}`
+			}
+			fmt.Fprintf(w, `{"result": true, "items": [%s]}`, strings.Join(items, ","))
+			return
+		}
+		fmt.Fprint(w, `{"result": true, "items": []}`)
+	}))
+	defer server.Close()
+
+	source, err := NewRaindropSource(config.SourceConfig{Options: map[string]string{"token": "secret", "collection": "123", "base_url": server.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	articles, err := source.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(articles) != 3 {
+		t.Fatalf("expected 3 articles, got %d", len(articles))
+	}
+	if len(paths) != 2 || !strings.HasPrefix(paths[0], "/raindrops/123?") {
+		t.Errorf("expected 2 page requests for collection 123, got %v", paths)
+	}
+
+	expected := []struct {
+		source string
+		read   bool
+	}{
+		{"engineering", false},
+		{"stripe.com", true},
+		{"go", true},
+	}
+	for i, want := range expected {
+		if articles[i].Category != want.source || articles[i].Read != want.read {
+			t.Errorf("article %d = %+v, want %+v", i, articles[i], want)
+		}
+	}
+}
+
+func TestRaindropSourceErrors(t *testing.T) {
+	t.Setenv("RAINDROP_TOKEN", "")
+	if _, err := NewRaindropSource(config.SourceConfig{}); err == nil {
+		t.Error("expected error without a token")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"result": false, "errorMessage": "collection not found"}`)
+	}))
+	defer server.Close()
+
+	source, _ := NewRaindropSource(config.SourceConfig{Options: map[string]string{"token": "t", "base_url": server.URL}})
+	if _, err := source.Fetch(context.Background()); err == nil || !strings.Contains(err.Error(), "collection not found") {
+		t.Errorf("expected API error to surface, got %v", err)
+	}
+}
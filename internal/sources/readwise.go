@@ -0,0 +1,193 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+func init() {
+	Register("readwise", NewReadwiseSource)
+}
+
+// ReadwiseBaseURL is the Readwise Reader API root
+const ReadwiseBaseURL = "https://readwise.io/api/v3"
+
+// readwiseMaxRetries caps how often a rate-limited page request is retried
+const readwiseMaxRetries = 3
+
+// ReadwiseSource lists saved documents from the Readwise Reader export API
+type ReadwiseSource struct {
+	Token   string
+	BaseURL string
+	Client  *http.Client
+}
+
+// readwiseDocument is the subset of a Reader document the metrics need
+type readwiseDocument struct {
+	ID        string  `json:"id"`
+	URL       string  `json:"url"`
+	SourceURL string  `json:"source_url"`
+	Title     string  `json:"title"`
+	Category  string  `json:"category"`
+	Location  string  `json:"location"`
+	SiteName  string  `json:"site_name"`
+	SavedAt   string  `json:"saved_at"`
+	CreatedAt string  `json:"created_at"`
+	ParentID  *string `json:"parent_id"`
+	Progress  float64 `json:"reading_progress"`
+	Notes     string  `json:"notes"`
+	Content   string  `json:"content"` // the highlighted text, on highlight documents
+}
+
+type readwiseListResponse struct {
+	Results        []readwiseDocument `json:"results"`
+	NextPageCursor *string            `json:"nextPageCursor"`
+}
+
+// NewReadwiseSource builds a ReadwiseSource from the token option, falling back to READWISE_TOKEN
+func NewReadwiseSource(cfg config.SourceConfig) (Source, error) {
+	source := &ReadwiseSource{
+		Token:   cfg.Option("token", "READWISE_TOKEN"),
+		BaseURL: cfg.Option("base_url", ""),
+		Client:  &http.Client{Timeout: 30 * time.Second},
+	}
+	if source.Token == "" {
+		return nil, fmt.Errorf("token option or READWISE_TOKEN environment variable is required")
+	}
+	if source.BaseURL == "" {
+		source.BaseURL = ReadwiseBaseURL
+	}
+	return source, nil
+}
+
+// Fetch pages through every saved document. Archived or fully read documents count as read;
+// highlights are attached to their parent document rather than listed as articles.
+func (s *ReadwiseSource) Fetch(ctx context.Context) ([]schema.ArticleMeta, error) {
+	var articles []schema.ArticleMeta
+	var ids []string
+	highlights := make(map[string][]string)
+	cursor := ""
+
+	for {
+		page, err := s.fetchPage(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range page.Results {
+			if doc.ParentID != nil || doc.Category == "highlight" || doc.Category == "note" {
+				if text := strings.TrimSpace(doc.Content); doc.ParentID != nil && doc.Category == "highlight" && text != "" {
+					highlights[*doc.ParentID] = append(highlights[*doc.ParentID], text)
+				}
+				continue
+			}
+			if article, ok := doc.toArticle(); ok {
+				articles = append(articles, article)
+				ids = append(ids, doc.ID)
+			}
+		}
+
+		if page.NextPageCursor == nil || *page.NextPageCursor == "" {
+			for i, id := range ids {
+				if id != "" {
+					articles[i].Highlights = highlights[id]
+				}
+			}
+			return articles, nil
+		}
+		cursor = *page.NextPageCursor
+	}
+}
+
+// fetchPage requests one page of the document list, waiting out rate limits
+func (s *ReadwiseSource) fetchPage(ctx context.Context, cursor string) (*readwiseListResponse, error) {
+	endpoint := strings.TrimSuffix(s.BaseURL, "/") + "/list/"
+	if cursor != "" {
+		endpoint += "?pageCursor=" + url.QueryEscape(cursor)
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build Readwise request: %w", err)
+		}
+		req.Header.Set("Authorization", "Token "+s.Token)
+
+		resp, err := s.Client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call Readwise: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < readwiseMaxRetries {
+			resp.Body.Close()
+			wait := retryAfter(resp.Header.Get("Retry-After"))
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("readwise returned %s", resp.Status)
+		}
+
+		var page readwiseListResponse
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode Readwise response: %w", err)
+		}
+		return &page, nil
+	}
+}
+
+// toArticle maps a Reader document onto ArticleMeta, using the site name (or domain) as the source
+func (d readwiseDocument) toArticle() (schema.ArticleMeta, bool) {
+	saved := d.SavedAt
+	if saved == "" {
+		saved = d.CreatedAt
+	}
+	date, err := time.Parse(time.RFC3339, saved)
+	if err != nil {
+		return schema.ArticleMeta{}, false
+	}
+
+	link := d.SourceURL
+	if link == "" {
+		link = d.URL
+	}
+	source := strings.TrimSpace(d.SiteName)
+	if source == "" {
+		source = metrics.LinkDomain(link)
+	}
+
+	return schema.ArticleMeta{
+		Date:     date.Format("2006-01-02"),
+		Title:    d.Title,
+		Link:     link,
+		Category: source,
+		Read:     d.Location == "archive" || d.Progress >= 1,
+		Notes:    strings.TrimSpace(d.Notes),
+	}, true
+}
+
+// retryAfter parses a Retry-After header in seconds, defaulting to a short pause
+func retryAfter(header string) time.Duration {
+	if seconds, err := strconv.Atoi(strings.TrimSpace(header)); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return 5 * time.Second
+}
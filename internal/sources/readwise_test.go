@@ -0,0 +1,112 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func TestReadwiseSourceFetch(t *testing.T) {
+	var authHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		if r.URL.Query().Get("pageCursor") == "" {
+			fmt.Fprint(w, `{"nextPageCursor": "page2", "results": [
+				{"id": "abc", "title": "Archived", "source_url": "https://github.blog/a", "site_name": "GitHub", "location": "archive", "saved_at": "2025-01-10T08:00:00Z", "notes": " Share with the team "},
+				{"title": "Highlight", "category": "highlight", "parent_id": "abc", "content": "A key sentence", "saved_at": "2025-01-10T08:00:00Z"}
+			]}`)
+			return
+		}
+		fmt.Fprint(w, `{"nextPageCursor": null, "results": [
+			{"title": "Later", "url": "https://read.readwise.io/x", "source_url": "https://www.stripe.com/blog/b", "location": "later", "reading_progress": 0.2, "saved_at": "2025-02-01T12:00:00+00:00"},
+			{"title": "Finished", "source_url": "https://example.com/c", "location": "new", "reading_progress": 1, "saved_at": "2025-02-02T12:00:00Z"},
+			{"title": "Bad date", "saved_at": "yesterday"},
+			{"title": "Late highlight", "category": "highlight", "parent_id": "abc", "content": "Another one", "saved_at": "2025-02-03T08:00:00Z"}
+		]}`)
+	}))
+	defer server.Close()
+
+	source, err := NewReadwiseSource(config.SourceConfig{Options: map[string]string{"token": "secret", "base_url": server.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	articles, err := source.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(articles) != 3 {
+		t.Fatalf("expected 3 articles, got %d: %+v", len(articles), articles)
+	}
+
+	expected := []struct {
+		date, source string
+		read         bool
+	}{
+		{"2025-01-10", "GitHub", true},
+		{"2025-02-01", "stripe.com", false},
+		{"2025-02-02", "example.com", true},
+	}
+	for i, want := range expected {
+		got := articles[i]
+		if got.Date != want.date || got.Category != want.source || got.Read != want.read {
+			t.Errorf("article %d = %+v, want %+v", i, got, want)
+		}
+	}
+
+	if articles[0].Notes != "Share with the team" || len(articles[0].Highlights) != 2 || articles[0].Highlights[1] != "Another one" {
+		t.Errorf("expected notes and highlights on the parent document, got %+v", articles[0])
+	}
+	if articles[1].Highlights != nil {
+		t.Errorf("expected no highlights on an unhighlighted document, got %v", articles[1].Highlights)
+	}
+
+	for _, header := range authHeaders {
+		if header != "Token secret" {
+			t.Errorf("unexpected Authorization header %q", header)
+		}
+	}
+}
+
+func TestReadwiseSourceErrors(t *testing.T) {
+	t.Setenv("READWISE_TOKEN", "")
+	if _, err := NewReadwiseSource(config.SourceConfig{}); err == nil {
+		t.Error("expected error without a token")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	source, _ := NewReadwiseSource(config.SourceConfig{Options: map[string]string{"token": "bad", "base_url": server.URL}})
+	if _, err := source.Fetch(context.Background()); err == nil {
+		t.Error("expected error for unauthorized response")
+	}
+}
+
+func TestReadwiseSourceRetriesRateLimit(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"results": []}`)
+	}))
+	defer server.Close()
+
+	source, _ := NewReadwiseSource(config.SourceConfig{Options: map[string]string{"token": "t", "base_url": server.URL}})
+	if _, err := source.Fetch(context.Background()); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected a retry after 429, got %d calls", calls)
+	}
+}
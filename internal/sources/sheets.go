@@ -0,0 +1,95 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/googleauth"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+func init() {
+	Register("sheets", NewSheetsSource)
+}
+
+// fetchSheetRows is a package-level variable that can be mocked in tests
+var fetchSheetRows = metrics.FetchSheetRows
+
+// SheetsSource reads articles and providers from a Google Sheet
+type SheetsSource struct {
+	SheetID         string
+	CredentialsPath string
+	SourcePrefix    string // prepended to every source name, telling apart sheets with the same sources
+
+	articleRows  [][]interface{}
+	providerRows [][]interface{}
+	fetched      bool
+}
+
+// NewSheetsSource builds a SheetsSource from the sheet_id, credentials_path and source_prefix
+// options, falling back to SHEET_ID and CREDENTIALS_PATH, then to Application Default Credentials
+func NewSheetsSource(cfg config.SourceConfig) (Source, error) {
+	source := &SheetsSource{
+		SheetID:         cfg.Option("sheet_id", "SHEET_ID"),
+		CredentialsPath: cfg.Option("credentials_path", "CREDENTIALS_PATH"),
+		SourcePrefix:    cfg.Option("source_prefix", ""),
+	}
+	if source.SheetID == "" {
+		return nil, fmt.Errorf("sheet_id option or SHEET_ID environment variable is required")
+	}
+	if source.CredentialsPath == "" {
+		source.CredentialsPath = googleauth.CredentialsPath()
+	}
+	return source, nil
+}
+
+// Fetch returns every valid article row in the sheet
+func (s *SheetsSource) Fetch(ctx context.Context) ([]schema.ArticleMeta, error) {
+	if err := s.load(ctx); err != nil {
+		return nil, err
+	}
+	articles := metrics.ParseArticles(s.articleRows, metrics.BuildSourceMap(s.providerRows))
+	if s.SourcePrefix != "" {
+		for i := range articles {
+			articles[i].Category = s.SourcePrefix + articles[i].Category
+		}
+	}
+	return articles, nil
+}
+
+// ProviderRows returns the providers sheet rows read alongside the articles, named with the source
+// prefix so brand colors and added dates still match the prefixed articles
+func (s *SheetsSource) ProviderRows(ctx context.Context) ([][]interface{}, error) {
+	if err := s.load(ctx); err != nil {
+		return nil, err
+	}
+	if s.SourcePrefix == "" {
+		return s.providerRows, nil
+	}
+	sourceMap := metrics.BuildSourceMap(s.providerRows)
+	rows := make([][]interface{}, len(s.providerRows))
+	for i, row := range s.providerRows {
+		rows[i] = row
+		if i == 0 || len(row) <= metrics.ProvidersColName {
+			continue
+		}
+		rows[i] = append([]interface{}{}, row...)
+		rows[i][metrics.ProvidersColName] = s.SourcePrefix + metrics.NormalizeSourceName(fmt.Sprintf("%v", row[metrics.ProvidersColName]), sourceMap)
+	}
+	return rows, nil
+}
+
+// load reads both sheets once so Fetch and ProviderRows share a single API round trip
+func (s *SheetsSource) load(ctx context.Context) error {
+	if s.fetched {
+		return nil
+	}
+	articleRows, providerRows, err := fetchSheetRows(ctx, s.SheetID, s.CredentialsPath)
+	if err != nil {
+		return err
+	}
+	s.articleRows, s.providerRows, s.fetched = articleRows, providerRows, true
+	return nil
+}
@@ -0,0 +1,147 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// Source is a backend that can list tracked articles
+type Source interface {
+	Fetch(ctx context.Context) ([]schema.ArticleMeta, error)
+}
+
+// ProviderSource is implemented by sources that also know provider metadata (brand colors, added dates)
+type ProviderSource interface {
+	ProviderRows(ctx context.Context) ([][]interface{}, error)
+}
+
+// Factory builds a Source from its config entry
+type Factory func(cfg config.SourceConfig) (Source, error)
+
+var registry = map[string]Factory{}
+
+// Register makes a source type available to config; it is called from each backend's init
+func Register(sourceType string, factory Factory) {
+	registry[sourceType] = factory
+}
+
+// Types lists the registered source types in alphabetical order
+func Types() []string {
+	var types []string
+	for sourceType := range registry {
+		types = append(types, sourceType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// New builds the source registered for cfg.Type
+func New(cfg config.SourceConfig) (Source, error) {
+	factory, exists := registry[cfg.Type]
+	if !exists {
+		return nil, fmt.Errorf("unknown source type %q (available: %v)", cfg.Type, Types())
+	}
+	return factory(cfg)
+}
+
+// NewAll builds every configured source, failing on the first invalid entry
+func NewAll(cfgs []config.SourceConfig) ([]Source, error) {
+	var built []Source
+	for i, cfg := range cfgs {
+		source, err := New(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("source %d (%s): %w", i+1, cfg.Type, err)
+		}
+		built = append(built, source)
+	}
+	return built, nil
+}
+
+// Options controls how fetched articles are enriched and aggregated
+type Options struct {
+	Compute  metrics.ComputeOptions
+	Resolver *identity.Resolver // looks up DOI/ISBN metadata when set
+}
+
+// FetchMetrics fetches articles from every source, merges them and aggregates one Metrics snapshot
+func FetchMetrics(ctx context.Context, all []Source, referenceDate time.Time, opts Options) (schema.Metrics, error) {
+	var articles []schema.ArticleMeta
+	var providerRows [][]interface{}
+
+	for _, source := range all {
+		fetched, err := source.Fetch(ctx)
+		if err != nil {
+			return schema.Metrics{}, fmt.Errorf("failed to fetch from %T: %w", source, err)
+		}
+		articles = append(articles, fetched...)
+
+		if ps, ok := source.(ProviderSource); ok {
+			rows, err := ps.ProviderRows(ctx)
+			if err != nil {
+				slog.Warn("Unable to read providers", "source", fmt.Sprintf("%T", source), "err", err)
+				continue
+			}
+			providerRows = mergeProviderRows(providerRows, rows)
+		}
+	}
+
+	if opts.Resolver != nil {
+		articles = Enrich(ctx, articles, opts.Resolver)
+	}
+
+	return metrics.ComputeMetricsWithOptions(ArticlesToRows(articles), providerRows, referenceDate, opts.Compute)
+}
+
+// FetchArticles fetches and concatenates the articles of every source
+func FetchArticles(ctx context.Context, all []Source) ([]schema.ArticleMeta, error) {
+	var articles []schema.ArticleMeta
+	for _, source := range all {
+		fetched, err := source.Fetch(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch from %T: %w", source, err)
+		}
+		articles = append(articles, fetched...)
+	}
+	return articles, nil
+}
+
+// ArticlesToRows converts articles into sheet-shaped rows (with a header) so they share the sheet aggregation path
+func ArticlesToRows(articles []schema.ArticleMeta) [][]interface{} {
+	rows := [][]interface{}{{"Date", "Title", "Link", "Category", "Read", "Authors", "Notes", "Highlights", "Archive", "Media Type", "Duration", "Topic"}}
+	for _, article := range articles {
+		read := "FALSE"
+		if article.Read {
+			read = "TRUE"
+		}
+		duration := ""
+		if article.DurationMinutes > 0 {
+			duration = strconv.Itoa(article.DurationMinutes)
+		}
+		rows = append(rows, []interface{}{
+			article.Date, article.Title, article.Link, article.Category, read, strings.Join(article.Authors, "; "),
+			article.Notes, strings.Join(article.Highlights, "\n"), article.ArchiveURL, article.MediaType, duration, article.Topic,
+		})
+	}
+	return rows
+}
+
+// mergeProviderRows appends provider rows from another source, keeping a single header row
+func mergeProviderRows(existing, rows [][]interface{}) [][]interface{} {
+	if len(rows) == 0 {
+		return existing
+	}
+	if len(existing) == 0 {
+		return rows
+	}
+	return append(existing, rows[1:]...)
+}
@@ -0,0 +1,187 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+type staticSource struct {
+	articles []schema.ArticleMeta
+	err      error
+}
+
+func (s staticSource) Fetch(ctx context.Context) ([]schema.ArticleMeta, error) {
+	return s.articles, s.err
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv("SHEET_ID", "")
+
+	tests := []struct {
+		name        string
+		cfg         config.SourceConfig
+		expectError bool
+	}{
+		{"csv with path", config.SourceConfig{Type: "csv", Options: map[string]string{"path": "a.csv"}}, false},
+		{"csv without path", config.SourceConfig{Type: "csv"}, true},
+		{"sheets with id", config.SourceConfig{Type: "sheets", Options: map[string]string{"sheet_id": "abc"}}, false},
+		{"sheets without id", config.SourceConfig{Type: "sheets"}, true},
+		{"unknown type", config.SourceConfig{Type: "delicious"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if (err != nil) != tt.expectError {
+				t.Errorf("New() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("static", func(cfg config.SourceConfig) (Source, error) {
+		return staticSource{}, nil
+	})
+	defer delete(registry, "static")
+
+	if _, err := New(config.SourceConfig{Type: "static"}); err != nil {
+		t.Fatalf("expected registered type to build, got %v", err)
+	}
+}
+
+func TestCSVSourceFetch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "papers.csv")
+	content := "Title,Date,Source,Link,Read\n" +
+		"Attention Is All You Need,2025-01-10,arXiv,https://arxiv.org/abs/1706.03762,true\n" +
+		"Dynamo,2025-02-01,arXiv,,false\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	articles, err := (&CSVSource{Path: path}).Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(articles) != 2 {
+		t.Fatalf("expected 2 articles, got %d", len(articles))
+	}
+	if articles[0].Date != "2025-01-10" || articles[0].Category != "arXiv" || !articles[0].Read {
+		t.Errorf("unexpected first article %+v", articles[0])
+	}
+	if articles[1].Read {
+		t.Errorf("expected second article unread")
+	}
+}
+
+func TestCSVSourceMissingColumn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.csv")
+	if err := os.WriteFile(path, []byte("title,link\nA,https://a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&CSVSource{Path: path}).Fetch(context.Background()); err == nil {
+		t.Error("expected error for missing date column")
+	}
+}
+
+func TestSheetsSourceFetch(t *testing.T) {
+	original := fetchSheetRows
+	defer func() { fetchSheetRows = original }()
+
+	calls := 0
+	fetchSheetRows = func(ctx context.Context, spreadsheetID, credentialsPath string) ([][]interface{}, [][]interface{}, error) {
+		calls++
+		articles := [][]interface{}{
+			{"Date", "Title", "Link", "Category", "Read"},
+			{"2025-01-01", "A", "https://a", "github", "TRUE"},
+		}
+		providers := [][]interface{}{{"Name", "Added"}, {"GitHub", "2024-01-01"}}
+		return articles, providers, nil
+	}
+
+	source := &SheetsSource{SheetID: "abc"}
+	articles, err := source.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(articles) != 1 || articles[0].Category != "GitHub" {
+		t.Errorf("unexpected articles %+v", articles)
+	}
+	if _, err := source.ProviderRows(context.Background()); err != nil {
+		t.Fatalf("ProviderRows() error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single sheet read, got %d", calls)
+	}
+}
+
+func TestSheetsSourcePrefix(t *testing.T) {
+	original := fetchSheetRows
+	defer func() { fetchSheetRows = original }()
+	fetchSheetRows = func(ctx context.Context, spreadsheetID, credentialsPath string) ([][]interface{}, [][]interface{}, error) {
+		articles := [][]interface{}{
+			{"Date", "Title", "Link", "Category", "Read"},
+			{"2019-01-01", "A", "https://a", "github", "TRUE"},
+		}
+		providers := [][]interface{}{{"Name", "URL"}, {"GitHub", "https://github.blog"}}
+		return articles, providers, nil
+	}
+
+	source, err := NewSheetsSource(config.SourceConfig{Type: "sheets", Options: map[string]string{"sheet_id": "old", "source_prefix": "Archive: "}})
+	if err != nil {
+		t.Fatalf("NewSheetsSource() error = %v", err)
+	}
+	articles, err := source.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(articles) != 1 || articles[0].Category != "Archive: GitHub" {
+		t.Errorf("expected a prefixed source, got %+v", articles)
+	}
+	rows, err := source.(ProviderSource).ProviderRows(context.Background())
+	if err != nil {
+		t.Fatalf("ProviderRows() error = %v", err)
+	}
+	if rows[0][0] != "Name" || rows[1][0] != "Archive: GitHub" {
+		t.Errorf("expected prefixed provider names under the header, got %v", rows)
+	}
+}
+
+func TestFetchMetrics(t *testing.T) {
+	ref := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("merges all sources", func(t *testing.T) {
+		all := []Source{
+			staticSource{articles: []schema.ArticleMeta{{Date: "2025-01-01", Title: "A", Category: "GitHub", Read: true}}},
+			staticSource{articles: []schema.ArticleMeta{
+				{Date: "2025-02-01", Title: "B", Category: "arXiv"},
+				{Date: "2025-02-02", Title: "C", Category: "arXiv", Read: true},
+			}},
+		}
+
+		m, err := FetchMetrics(context.Background(), all, ref, Options{})
+		if err != nil {
+			t.Fatalf("FetchMetrics() error = %v", err)
+		}
+		if m.TotalArticles != 3 || m.ReadCount != 2 {
+			t.Errorf("expected 3 articles with 2 read, got %d/%d", m.TotalArticles, m.ReadCount)
+		}
+		if m.BySource["arXiv"] != 2 {
+			t.Errorf("expected 2 arXiv articles, got %d", m.BySource["arXiv"])
+		}
+	})
+
+	t.Run("source error aborts", func(t *testing.T) {
+		all := []Source{staticSource{err: errors.New("boom")}}
+		if _, err := FetchMetrics(context.Background(), all, ref, Options{}); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package sources
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// defaultURLConcurrency is how many pages a URL list fetches at once
+const defaultURLConcurrency = 8
+
+func init() {
+	Register("urls", NewURLListSource)
+}
+
+// URLListSource reads a plain list of links (one per line, e.g. copied browser tabs)
+// and fetches each page's title and canonical URL
+type URLListSource struct {
+	Path        string
+	Concurrency int
+	Resolver    *identity.Resolver
+}
+
+// NewURLListSource builds a URLListSource from the path and optional concurrency options
+func NewURLListSource(cfg config.SourceConfig) (Source, error) {
+	path := cfg.Option("path", "")
+	if path == "" {
+		return nil, fmt.Errorf("path option is required")
+	}
+	concurrency, err := strconv.Atoi(cfg.Option("concurrency", strconv.Itoa(defaultURLConcurrency)))
+	if err != nil || concurrency < 1 {
+		return nil, fmt.Errorf("concurrency option must be a positive number")
+	}
+	return &URLListSource{Path: path, Concurrency: concurrency, Resolver: identity.NewResolver()}, nil
+}
+
+// Fetch reads the list and resolves every link, keeping the order of the file
+func (s *URLListSource) Fetch(ctx context.Context) ([]schema.ArticleMeta, error) {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", s.Path, err)
+	}
+	defer f.Close()
+
+	ids, err := ParseURLList(f)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("Fetching titles", "links", len(ids))
+
+	today := time.Now().Format("2006-01-02")
+	articles := make([]schema.ArticleMeta, len(ids))
+	slots := make(chan struct{}, max(s.Concurrency, 1))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			articles[i] = s.resolve(ctx, id, today)
+		}()
+	}
+	wg.Wait()
+
+	return articles, nil
+}
+
+// resolve builds the article for one link; a page that cannot be fetched keeps its link as the title
+func (s *URLListSource) resolve(ctx context.Context, id identity.Identifier, date string) schema.ArticleMeta {
+	article := schema.ArticleMeta{Date: date, Title: id.URL(), Link: id.URL()}
+
+	meta, err := s.Resolver.Lookup(ctx, id)
+	if err != nil {
+		slog.Warn("Unable to fetch title, using the link instead", "link", id.URL(), "err", err)
+	} else {
+		article.Title = meta.Title
+		article.Authors = meta.Authors
+		if id.Kind == identity.KindURL && meta.Canonical != "" {
+			article.Link = meta.Canonical
+		}
+	}
+
+	switch id.Kind {
+	case identity.KindDOI:
+		article.Category = PapersSource
+	case identity.KindISBN:
+		article.Category = BooksSource
+	default:
+		article.Category = metrics.LinkDomain(article.Link)
+	}
+	return article
+}
+
+// ParseURLList reads one link, DOI or ISBN per line, skipping blank lines, # comments and repeats
+func ParseURLList(r io.Reader) ([]identity.Identifier, error) {
+	var ids []identity.Identifier
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		id := identity.Parse(line)
+		if seen[id.URL()] {
+			continue
+		}
+		seen[id.URL()] = true
+		ids = append(ids, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read URL list: %w", err)
+	}
+	return ids, nil
+}
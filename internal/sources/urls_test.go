@@ -0,0 +1,37 @@
+package sources
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+)
+
+func TestParseURLList(t *testing.T) {
+	list := `# Tabs from Friday
+https://github.blog/post
+
+  https://stripe.com/blog/scale  
+doi:10.1000/XYZ
+https://doi.org/10.1000/xyz
+https://github.blog/post
+`
+	ids, err := ParseURLList(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("ParseURLList() error = %v", err)
+	}
+
+	expected := []identity.Identifier{
+		{Kind: identity.KindURL, Value: "https://github.blog/post"},
+		{Kind: identity.KindURL, Value: "https://stripe.com/blog/scale"},
+		{Kind: identity.KindDOI, Value: "10.1000/xyz"},
+	}
+	if len(ids) != len(expected) {
+		t.Fatalf("expected %d identifiers, got %v", len(expected), ids)
+	}
+	for i := range expected {
+		if ids[i] != expected[i] {
+			t.Errorf("identifier %d = %+v, want %+v", i, ids[i], expected[i])
+		}
+	}
+}
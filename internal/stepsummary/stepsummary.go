@@ -0,0 +1,145 @@
+package stepsummary
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// EnvVar names the file GitHub Actions renders as the step's Markdown summary
+const EnvVar = "GITHUB_STEP_SUMMARY"
+
+// TopSources caps the source table of the metrics summary
+const TopSources = 10
+
+// Append adds markdown to the step summary file. Outside GitHub Actions, where EnvVar is unset,
+// it does nothing.
+func Append(markdown string) error {
+	path := os.Getenv(EnvVar)
+	if path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open step summary: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(markdown + "\n"); err != nil {
+		return fmt.Errorf("failed to write step summary: %w", err)
+	}
+	return nil
+}
+
+// Metrics summarizes a new snapshot: totals with their change since prev (which may be nil), the
+// largest sources, and warnings about skipped rows and consistency issues
+func Metrics(prev *schema.Metrics, latest schema.Metrics, issues []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## 📚 Metrics snapshot %s\n\n", latest.LastUpdated.Format("2006-01-02"))
+
+	b.WriteString("| Metric | Value | Change |\n| :--- | ---: | ---: |\n")
+	row := func(label string, value, before int, hasBefore bool) {
+		change := "–"
+		if hasBefore {
+			change = fmt.Sprintf("%+d", value-before)
+		}
+		fmt.Fprintf(&b, "| %s | %d | %s |\n", label, value, change)
+	}
+	var before schema.Metrics
+	if prev != nil {
+		before = *prev
+	}
+	row("Total articles", latest.TotalArticles, before.TotalArticles, prev != nil)
+	row("Read", latest.ReadCount, before.ReadCount, prev != nil)
+	row("Unread", latest.UnreadCount, before.UnreadCount, prev != nil)
+	row("Sources", len(latest.BySource), len(before.BySource), prev != nil)
+	rateChange := "–"
+	if prev != nil {
+		rateChange = fmt.Sprintf("%+.1f pts", latest.ReadRate-before.ReadRate)
+	}
+	fmt.Fprintf(&b, "| Read rate | %.1f%% | %s |\n", latest.ReadRate, rateChange)
+
+	if len(latest.BySourceReadStatus) > 0 {
+		names := make([]string, 0, len(latest.BySourceReadStatus))
+		for name := range latest.BySourceReadStatus {
+			names = append(names, name)
+		}
+		total := func(name string) int {
+			counts := latest.BySourceReadStatus[name]
+			return counts[0] + counts[1]
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if total(names[i]) != total(names[j]) {
+				return total(names[i]) > total(names[j])
+			}
+			return names[i] < names[j]
+		})
+		if len(names) > TopSources {
+			names = names[:TopSources]
+		}
+
+		fmt.Fprintf(&b, "\n### Top %d sources\n\n| Source | Articles | Read | Unread |\n| :--- | ---: | ---: | ---: |\n", len(names))
+		for _, name := range names {
+			counts := latest.BySourceReadStatus[name]
+			fmt.Fprintf(&b, "| %s | %d | %d | %d |\n", Cell(name), total(name), counts[0], counts[1])
+		}
+	}
+
+	var warnings []string
+	if latest.SkippedRows > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d article rows were skipped for missing columns or an invalid date", latest.SkippedRows))
+	}
+	warnings = append(warnings, issues...)
+	b.WriteString(Warnings(warnings))
+	return b.String()
+}
+
+// Site summarizes a site build
+type Site struct {
+	ReportDate   string // latest snapshot date
+	Snapshots    int
+	HistoryPages int // history snapshots regenerated this run
+	Files        int // files written this run
+	SizeBytes    int64
+	Warnings     []string
+	Failures     []string // history pages that failed, as "date: error"
+}
+
+// SiteMarkdown renders a site build summary
+func SiteMarkdown(s Site) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## 🌐 Site build for %s\n\n", s.ReportDate)
+	b.WriteString("| Output | Value |\n| :--- | ---: |\n")
+	fmt.Fprintf(&b, "| Snapshots | %d |\n", s.Snapshots)
+	fmt.Fprintf(&b, "| History pages regenerated | %d |\n", s.HistoryPages)
+	fmt.Fprintf(&b, "| Files written | %d |\n", s.Files)
+	fmt.Fprintf(&b, "| Site size | %.1f MB |\n", float64(s.SizeBytes)/(1<<20))
+	if len(s.Failures) > 0 {
+		fmt.Fprintf(&b, "\n### ❌ Failed history pages (%d)\n\n", len(s.Failures))
+		for _, failure := range s.Failures {
+			fmt.Fprintf(&b, "- %s\n", strings.ReplaceAll(strings.TrimSpace(failure), "\n", " "))
+		}
+	}
+	b.WriteString(Warnings(s.Warnings))
+	return b.String()
+}
+
+// Warnings renders a warnings section, or nothing when there are none
+func Warnings(warnings []string) string {
+	if len(warnings) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n### ⚠️ Warnings (%d)\n\n", len(warnings))
+	for _, warning := range warnings {
+		fmt.Fprintf(&b, "- %s\n", strings.ReplaceAll(strings.TrimSpace(warning), "\n", " "))
+	}
+	return b.String()
+}
+
+// Cell escapes a value for a Markdown table cell
+func Cell(value string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(value)
+}
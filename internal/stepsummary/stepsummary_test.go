@@ -0,0 +1,115 @@
+package stepsummary
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestAppend(t *testing.T) {
+	t.Setenv(EnvVar, "")
+	if err := Append("ignored"); err != nil {
+		t.Fatalf("expected Append to do nothing outside Actions, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv(EnvVar, path)
+	for _, markdown := range []string{"## First", "## Second"} {
+		if err := Append(markdown); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	content, _ := os.ReadFile(path)
+	if string(content) != "## First\n## Second\n" {
+		t.Errorf("expected both sections appended, got %q", content)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	prev := schema.Metrics{TotalArticles: 100, ReadCount: 40, UnreadCount: 60, ReadRate: 40, BySource: map[string]int{"A": 1}}
+	latest := schema.Metrics{
+		TotalArticles:      103,
+		ReadCount:          45,
+		UnreadCount:        58,
+		ReadRate:           43.7,
+		LastUpdated:        time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC),
+		BySource:           map[string]int{"A": 1, "B|C": 2},
+		BySourceReadStatus: map[string][2]int{"A": {1, 0}, "B|C": {1, 1}},
+		SkippedRows:        2,
+	}
+
+	tests := []struct {
+		name       string
+		prev       *schema.Metrics
+		issues     []string
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name:   "with previous snapshot",
+			prev:   &prev,
+			issues: []string{"by_source sums to 3, expected 103"},
+			expected: []string{
+				"## 📚 Metrics snapshot 2026-03-13",
+				"| Total articles | 103 | +3 |",
+				"| Unread | 58 | -2 |",
+				"| Read rate | 43.7% | +3.7 pts |",
+				"### Top 2 sources",
+				`| B\|C | 2 | 1 | 1 |`,
+				"### ⚠️ Warnings (2)",
+				"- 2 article rows were skipped",
+				"- by_source sums to 3",
+			},
+		},
+		{
+			name:       "first snapshot",
+			expected:   []string{"| Total articles | 103 | – |", "| Read rate | 43.7% | – |"},
+			unexpected: []string{"pts"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := Metrics(tt.prev, latest, tt.issues)
+			for _, want := range tt.expected {
+				if !strings.Contains(output, want) {
+					t.Errorf("expected summary to contain %q, got:\n%s", want, output)
+				}
+			}
+			for _, unwanted := range tt.unexpected {
+				if strings.Contains(output, unwanted) {
+					t.Errorf("expected summary not to contain %q, got:\n%s", unwanted, output)
+				}
+			}
+		})
+	}
+}
+
+func TestSiteMarkdown(t *testing.T) {
+	output := SiteMarkdown(Site{ReportDate: "2026-03-13", Snapshots: 40, HistoryPages: 5, Files: 120, SizeBytes: 3 << 20})
+	for _, want := range []string{"## 🌐 Site build for 2026-03-13", "| History pages regenerated | 5 |", "| Site size | 3.0 MB |"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected summary to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Warnings") {
+		t.Errorf("expected no warnings section without warnings")
+	}
+
+	withWarnings := SiteMarkdown(Site{Warnings: []string{"Failed to publish badges:\nno space"}})
+	if !strings.Contains(withWarnings, "- Failed to publish badges: no space\n") {
+		t.Errorf("expected warnings on one line each, got:\n%s", withWarnings)
+	}
+	if strings.Contains(withWarnings, "Failed history pages") {
+		t.Errorf("expected no failures section without failures")
+	}
+
+	withFailures := SiteMarkdown(Site{Failures: []string{"2026-03-06: template error"}})
+	if !strings.Contains(withFailures, "### ❌ Failed history pages (1)\n\n- 2026-03-06: template error\n") {
+		t.Errorf("expected a failures section, got:\n%s", withFailures)
+	}
+}
@@ -0,0 +1,168 @@
+package triage
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/identity"
+)
+
+// Actions that can be assigned to a triaged article
+const (
+	ActionKeep    = "keep"
+	ActionRead    = "read"
+	ActionArchive = "archive"
+)
+
+// DefaultFile is where the triage file is written when no path is given
+const DefaultFile = "triage.yml"
+
+// File is the editable triage document written to and read back from YAML
+type File struct {
+	Generated string `yaml:"generated"`
+	Articles  []Item `yaml:"articles"`
+}
+
+// Item is a single unread article awaiting a triage decision
+type Item struct {
+	Date   string `yaml:"date"`
+	Title  string `yaml:"title"`
+	Link   string `yaml:"link"`
+	Source string `yaml:"source"`
+	Action string `yaml:"action"`
+}
+
+// Plan maps triage decisions onto zero-based article row indexes in the sheet
+type Plan struct {
+	ReadRows    []int
+	ArchiveRows []int
+	Kept        int
+	Unmatched   []Item
+}
+
+// Generate returns the oldest limit unread articles as triage items defaulting to keep
+func Generate(unread []schema.ArticleMeta, limit int, generated time.Time) File {
+	sorted := append([]schema.ArticleMeta(nil), unread...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date < sorted[j].Date
+	})
+
+	if limit > 0 && len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	file := File{Generated: generated.Format("2006-01-02")}
+	for _, article := range sorted {
+		file.Articles = append(file.Articles, Item{
+			Date:   article.Date,
+			Title:  article.Title,
+			Link:   article.Link,
+			Source: article.Category,
+			Action: ActionKeep,
+		})
+	}
+	return file
+}
+
+// Write saves the triage file as YAML with a usage header
+func Write(path string, file File) error {
+	content, err := yaml.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("failed to marshal triage file: %w", err)
+	}
+
+	header := "# Set each action to keep, read, or archive, then apply with:\n#   metricsjson triage apply " + path + "\n"
+	if err := os.WriteFile(path, append([]byte(header), content...), 0644); err != nil {
+		return fmt.Errorf("failed to write triage file: %w", err)
+	}
+	return nil
+}
+
+// Read loads a triage file and validates every action
+func Read(path string) (File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return File{}, fmt.Errorf("failed to read triage file: %w", err)
+	}
+
+	var file File
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return File{}, fmt.Errorf("failed to parse triage file: %w", err)
+	}
+
+	for i, item := range file.Articles {
+		action := strings.ToLower(strings.TrimSpace(item.Action))
+		switch action {
+		case ActionKeep, ActionRead, ActionArchive:
+			file.Articles[i].Action = action
+		default:
+			return File{}, fmt.Errorf("article %d (%q): unknown action %q", i+1, item.Title, item.Action)
+		}
+	}
+
+	return file, nil
+}
+
+// BuildPlan matches triage items to current article rows by link, falling back to date and title.
+// Rows are matched against the live sheet so edits made since generation do not shift targets.
+func BuildPlan(rows [][]interface{}, items []Item, linkCol, titleCol, dateCol int) Plan {
+	byLink := make(map[string]int)
+	byDateTitle := make(map[string]int)
+	for i := 1; i < len(rows); i++ {
+		if link := identity.Parse(cell(rows[i], linkCol)).URL(); link != "" {
+			if _, exists := byLink[link]; !exists {
+				byLink[link] = i
+			}
+		}
+		key := cell(rows[i], dateCol) + "|" + cell(rows[i], titleCol)
+		if _, exists := byDateTitle[key]; !exists {
+			byDateTitle[key] = i
+		}
+	}
+
+	// Two items can resolve to the same row, such as a link listed twice, so each row is planned once
+	var plan Plan
+	seenRead := make(map[int]bool)
+	seenArchive := make(map[int]bool)
+	for _, item := range items {
+		if item.Action == ActionKeep {
+			plan.Kept++
+			continue
+		}
+
+		idx, exists := byLink[identity.Parse(item.Link).URL()]
+		if !exists || item.Link == "" {
+			idx, exists = byDateTitle[item.Date+"|"+item.Title]
+		}
+		if !exists {
+			plan.Unmatched = append(plan.Unmatched, item)
+			continue
+		}
+
+		if item.Action == ActionRead {
+			if !seenRead[idx] {
+				seenRead[idx] = true
+				plan.ReadRows = append(plan.ReadRows, idx)
+			}
+		} else if !seenArchive[idx] {
+			seenArchive[idx] = true
+			plan.ArchiveRows = append(plan.ArchiveRows, idx)
+		}
+	}
+
+	return plan
+}
+
+// cell returns the trimmed string value of a row column, or empty when missing
+func cell(row []interface{}, col int) string {
+	if col >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprintf("%v", row[col]))
+}
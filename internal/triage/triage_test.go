@@ -0,0 +1,145 @@
+package triage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestGenerate(t *testing.T) {
+	unread := []schema.ArticleMeta{
+		{Date: "2025-03-01", Title: "Newest", Link: "https://example.com/3", Category: "GitHub"},
+		{Date: "2024-01-01", Title: "Oldest", Link: "https://example.com/1", Category: "Substack"},
+		{Date: "2024-06-01", Title: "Middle", Link: "https://example.com/2", Category: "Stripe"},
+	}
+
+	file := Generate(unread, 2, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	if file.Generated != "2026-01-01" {
+		t.Errorf("expected generated date 2026-01-01, got %s", file.Generated)
+	}
+	if len(file.Articles) != 2 {
+		t.Fatalf("expected 2 articles, got %d", len(file.Articles))
+	}
+	if file.Articles[0].Title != "Oldest" || file.Articles[1].Title != "Middle" {
+		t.Errorf("expected oldest first, got %+v", file.Articles)
+	}
+	for _, item := range file.Articles {
+		if item.Action != ActionKeep {
+			t.Errorf("expected default action keep, got %q", item.Action)
+		}
+	}
+
+	if all := Generate(unread, 0, time.Now()); len(all.Articles) != 3 {
+		t.Errorf("expected limit 0 to include all articles, got %d", len(all.Articles))
+	}
+}
+
+func TestWriteAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "triage.yml")
+	file := File{
+		Generated: "2026-01-01",
+		Articles: []Item{
+			{Date: "2024-01-01", Title: "A", Link: "https://example.com/a", Source: "GitHub", Action: ActionKeep},
+		},
+	}
+
+	if err := Write(path, file); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	got, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	if len(got.Articles) != 1 || got.Articles[0] != file.Articles[0] {
+		t.Errorf("round trip mismatch: got %+v", got)
+	}
+}
+
+func TestReadValidatesActions(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectError bool
+		expected    string
+	}{
+		{"normalizes case", "articles:\n  - title: A\n    action: \" READ \"\n", false, ActionRead},
+		{"rejects unknown action", "articles:\n  - title: A\n    action: delete\n", true, ""},
+		{"rejects invalid yaml", "articles: [", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "triage.yml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			file, err := Read(path)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Read() error = %v, expectError %v", err, tt.expectError)
+			}
+			if !tt.expectError && file.Articles[0].Action != tt.expected {
+				t.Errorf("expected action %q, got %q", tt.expected, file.Articles[0].Action)
+			}
+		})
+	}
+}
+
+func TestBuildPlan(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-01-01", "By Link", "https://example.com/a", "GitHub", "FALSE"},
+		{"2024-02-01", "By Title", "", "GitHub", "FALSE"},
+		{"2024-03-01", "Archive Me", "https://example.com/c", "Substack", "FALSE"},
+	}
+	items := []Item{
+		{Date: "2024-01-01", Title: "Renamed", Link: "https://example.com/a", Action: ActionRead},
+		{Date: "2024-02-01", Title: "By Title", Action: ActionRead},
+		{Date: "2024-03-01", Title: "Archive Me", Link: "https://example.com/c", Action: ActionArchive},
+		{Date: "2024-04-01", Title: "Gone", Link: "https://example.com/gone", Action: ActionArchive},
+		{Date: "2024-05-01", Title: "Keep", Link: "https://example.com/keep", Action: ActionKeep},
+	}
+
+	plan := BuildPlan(rows, items, 2, 1, 0)
+
+	if len(plan.ReadRows) != 2 || plan.ReadRows[0] != 1 || plan.ReadRows[1] != 2 {
+		t.Errorf("expected read rows [1 2], got %v", plan.ReadRows)
+	}
+	if len(plan.ArchiveRows) != 1 || plan.ArchiveRows[0] != 3 {
+		t.Errorf("expected archive rows [3], got %v", plan.ArchiveRows)
+	}
+	if plan.Kept != 1 {
+		t.Errorf("expected 1 kept, got %d", plan.Kept)
+	}
+	if len(plan.Unmatched) != 1 || plan.Unmatched[0].Title != "Gone" {
+		t.Errorf("expected Gone to be unmatched, got %+v", plan.Unmatched)
+	}
+}
+
+func TestBuildPlanSameRow(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Title", "Link", "Category", "Read"},
+		{"2024-01-01", "Twice", "https://example.com/a", "GitHub", "FALSE"},
+		{"2024-02-01", "Neighbour", "https://example.com/b", "GitHub", "FALSE"},
+	}
+	items := []Item{
+		{Date: "2024-01-01", Title: "Twice", Link: "https://example.com/a", Action: ActionArchive},
+		{Date: "2024-01-01", Title: "Twice", Action: ActionArchive},
+		{Link: "https://example.com/a", Action: ActionRead},
+		{Link: "https://example.com/a/", Action: ActionRead},
+	}
+
+	plan := BuildPlan(rows, items, 2, 1, 0)
+
+	if len(plan.ArchiveRows) != 1 || plan.ArchiveRows[0] != 1 {
+		t.Errorf("expected archive rows [1], got %v", plan.ArchiveRows)
+	}
+	if len(plan.ReadRows) != 1 || plan.ReadRows[0] != 1 {
+		t.Errorf("expected read rows [1], got %v", plan.ReadRows)
+	}
+}
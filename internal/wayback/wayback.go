@@ -0,0 +1,60 @@
+package wayback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SaveURL is the Internet Archive's Save Page Now endpoint; the page to archive is appended to it
+const SaveURL = "https://web.archive.org/save/"
+
+// ErrRateLimited is returned once the Internet Archive starts refusing captures
+var ErrRateLimited = errors.New("rate limited by the Internet Archive")
+
+// Client submits pages to Save Page Now
+type Client struct {
+	HTTP    *http.Client
+	SaveURL string
+}
+
+// NewClient returns a Client with a per-capture timeout; captures can take tens of seconds
+func NewClient(timeout time.Duration) *Client {
+	return &Client{HTTP: &http.Client{Timeout: timeout}, SaveURL: SaveURL}
+}
+
+// Save asks the Internet Archive to capture link and returns the URL of the capture. The capture is
+// read from the Content-Location header, falling back to the /web/ page the request redirected to.
+func (c *Client) Save(ctx context.Context, link string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.SaveURL+link, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build save request: %w", err)
+	}
+	req.Header.Set("User-Agent", "personal-reading-analytics (archive)")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to save %s: %w", link, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return "", ErrRateLimited
+	case resp.StatusCode >= 300:
+		return "", fmt.Errorf("failed to save %s: archive returned %s", link, resp.Status)
+	}
+
+	if location := resp.Header.Get("Content-Location"); location != "" {
+		if archived, err := resp.Request.URL.Parse(location); err == nil {
+			return archived.String(), nil
+		}
+	}
+	if strings.HasPrefix(resp.Request.URL.Path, "/web/") {
+		return resp.Request.URL.String(), nil
+	}
+	return "", fmt.Errorf("failed to save %s: no capture URL in the response", link)
+}
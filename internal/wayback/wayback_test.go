@@ -0,0 +1,60 @@
+package wayback
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSave(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/save/https://a.com/header":
+			w.Header().Set("Content-Location", "/web/20240101000000/https://a.com/header")
+		case "/save/https://a.com/redirect":
+			http.Redirect(w, r, "http://"+r.Host+"/web/20240102000000/https://a.com/redirect", http.StatusFound)
+		case "/web/20240102000000/https://a.com/redirect":
+		case "/save/https://a.com/busy":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/save/https://a.com/blocked":
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(0)
+	client.SaveURL = server.URL + "/save/"
+
+	tests := []struct {
+		name      string
+		link      string
+		expected  string
+		expectErr error
+	}{
+		{name: "content location", link: "https://a.com/header", expected: server.URL + "/web/20240101000000/https://a.com/header"},
+		{name: "redirect to capture", link: "https://a.com/redirect", expected: server.URL + "/web/20240102000000/https://a.com/redirect"},
+		{name: "rate limited", link: "https://a.com/busy", expectErr: ErrRateLimited},
+		{name: "refused", link: "https://a.com/blocked"},
+		{name: "no capture", link: "https://a.com/unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archived, err := client.Save(context.Background(), tt.link)
+			if tt.expected == "" {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", archived)
+				}
+				if tt.expectErr != nil && !errors.Is(err, tt.expectErr) {
+					t.Errorf("expected %v, got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil || archived != tt.expected {
+				t.Errorf("Save() = %q, %v, want %q", archived, err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package web
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ThemeCSSFile is the optional stylesheet a theme directory adds on top of the Tailwind build. It is
+// copied to dist/css/ and linked after styles.css on every page.
+const ThemeCSSFile = "css/theme.css"
+
+// embeddedAssets holds the page templates, static files, Tailwind input and content YAML, so a
+// copied binary renders the site from any working directory
+//
+//go:embed templates content
+var embeddedAssets embed.FS
+
+var (
+	// baseAssets is the embedded copy unless SetAssetsDir points elsewhere
+	baseAssets fs.FS = embeddedAssets
+	// themeDir overlays files under templates/, set by SetThemeDir
+	themeDir string
+	// assets is where templates and content are read from: baseAssets with the theme on top
+	assets fs.FS = embeddedAssets
+)
+
+// SetAssetsDir reads templates and content from dir, which must have the same templates/ and
+// content/ layout as internal/web, instead of the embedded copy. An empty dir restores the embedded
+// assets.
+func SetAssetsDir(dir string) error {
+	if dir == "" {
+		baseAssets = embeddedAssets
+		updateAssets()
+		return nil
+	}
+	if err := checkDir(dir); err != nil {
+		return fmt.Errorf("failed to open assets directory: %w", err)
+	}
+	baseAssets = os.DirFS(dir)
+	updateAssets()
+	return nil
+}
+
+// SetThemeDir overlays dir on the templates: a file in dir, such as base.html, static/robots.txt
+// or css/theme.css, replaces or adds to the template at the same path, and every other file keeps
+// its default. An empty dir removes the theme.
+func SetThemeDir(dir string) error {
+	if dir != "" {
+		if err := checkDir(dir); err != nil {
+			return fmt.Errorf("failed to open theme directory: %w", err)
+		}
+	}
+	themeDir = dir
+	updateAssets()
+	return nil
+}
+
+func checkDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
+
+func updateAssets() {
+	if themeDir == "" {
+		assets = baseAssets
+		return
+	}
+	assets = overlayFS{top: os.DirFS(themeDir), prefix: "templates", base: baseAssets}
+}
+
+// overlayFS serves the files of top in place of base's files under prefix, falling back to base
+// per file
+type overlayFS struct {
+	top    fs.FS
+	prefix string
+	base   fs.FS
+}
+
+// topName maps name to its path in top, reporting false outside prefix
+func (o overlayFS) topName(name string) (string, bool) {
+	if name == o.prefix {
+		return ".", true
+	}
+	if rest, ok := strings.CutPrefix(name, o.prefix+"/"); ok {
+		return rest, true
+	}
+	return "", false
+}
+
+// Open opens name from top when it exists there, else from base
+func (o overlayFS) Open(name string) (fs.File, error) {
+	if topName, ok := o.topName(name); ok {
+		if f, err := o.top.Open(topName); err == nil {
+			info, statErr := f.Stat()
+			if statErr == nil && !info.IsDir() {
+				return f, nil
+			}
+			f.Close()
+		}
+	}
+	return o.base.Open(name)
+}
+
+// ReadDir merges the entries of both layers, with top winning on name clashes
+func (o overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries := map[string]fs.DirEntry{}
+	baseEntries, baseErr := fs.ReadDir(o.base, name)
+	for _, entry := range baseEntries {
+		entries[entry.Name()] = entry
+	}
+	topErr := fs.ErrNotExist
+	if topName, ok := o.topName(name); ok {
+		var topEntries []fs.DirEntry
+		topEntries, topErr = fs.ReadDir(o.top, topName)
+		for _, entry := range topEntries {
+			entries[entry.Name()] = entry
+		}
+	}
+	if baseErr != nil && topErr != nil {
+		if errors.Is(topErr, fs.ErrNotExist) {
+			return nil, baseErr
+		}
+		return nil, topErr
+	}
+
+	merged := make([]fs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		merged = append(merged, entry)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Name() < merged[j].Name() })
+	return merged, nil
+}
+
+// readAsset reads a file from the active assets, such as "content/landing.yml"
+func readAsset(name string) ([]byte, error) {
+	return fs.ReadFile(assets, name)
+}
+
+// hasAsset reports whether the active assets contain name
+func hasAsset(name string) bool {
+	_, err := fs.Stat(assets, name)
+	return err == nil
+}
+
+// templatePath is the assets path of a file under templates/
+func templatePath(name string) string {
+	return "templates/" + name
+}
@@ -0,0 +1,133 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestEmbeddedAssets(t *testing.T) {
+	// The embedded copy is used whatever the working directory
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(originalWd)
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"templates/base.html", "templates/analytics.html", "templates/static/robots.txt", "templates/css/input.css", "content/landing.yml", "content/evolution.yml"} {
+		if _, err := readAsset(name); err != nil {
+			t.Errorf("expected %s to be embedded: %v", name, err)
+		}
+	}
+	if _, err := LoadLanding(); err != nil {
+		t.Errorf("LoadLanding() error = %v", err)
+	}
+}
+
+func TestSetAssetsDir(t *testing.T) {
+	t.Cleanup(func() { SetAssetsDir("") })
+
+	if err := SetAssetsDir(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetAssetsDir(file); err == nil {
+		t.Error("expected an error for a file")
+	}
+
+	dir := t.TempDir()
+	if err := SetAssetsDir(dir); err != nil {
+		t.Fatalf("SetAssetsDir() error = %v", err)
+	}
+	if _, err := readAsset("templates/base.html"); err == nil {
+		t.Error("expected the override directory to replace the embedded templates")
+	}
+	if err := SetAssetsDir(""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readAsset("templates/base.html"); err != nil {
+		t.Errorf("expected the embedded templates after reset: %v", err)
+	}
+}
+
+func TestSetThemeDir(t *testing.T) {
+	t.Cleanup(func() { SetThemeDir("") })
+
+	if err := SetThemeDir(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+
+	theme := t.TempDir()
+	files := map[string]string{
+		"static/robots.txt": "User-agent: *\nDisallow: /\n",
+		"static/humans.txt": "Themed by me",
+		"css/theme.css":     "body { background: black; }",
+	}
+	for name, content := range files {
+		path := filepath.Join(theme, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := SetThemeDir(theme); err != nil {
+		t.Fatalf("SetThemeDir() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"templates/static/robots.txt", "Disallow: /"},
+		{"templates/static/llms.txt", "{{"},
+		{"templates/css/theme.css", "background: black"},
+		{"content/landing.yml", "site_url"},
+	}
+	for _, tt := range tests {
+		content, err := readAsset(tt.name)
+		if err != nil {
+			t.Errorf("readAsset(%s) error = %v", tt.name, err)
+			continue
+		}
+		if !strings.Contains(string(content), tt.expected) {
+			t.Errorf("expected %s to contain %q, got %q", tt.name, tt.expected, content)
+		}
+	}
+
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	m := schema.Metrics{TotalArticles: 10, ReadCount: 4, UnreadCount: 6, ReadRate: 40}
+	if err := service.GenerateFullSite(t.Context(), m, GenConfig{OutputDir: dir, BaseURL: "./", PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateFullSite() error = %v", err)
+	}
+	for _, name := range []string{"robots.txt", "humans.txt", "llms.txt", "css/theme.css"} {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			t.Errorf("expected %s in the output: %v", name, err)
+		}
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "analytics.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(page), `href="./css/theme.css"`) {
+		t.Error("expected pages to link the theme stylesheet")
+	}
+
+	if err := SetThemeDir(""); err != nil {
+		t.Fatal(err)
+	}
+	if hasAsset(templatePath(ThemeCSSFile)) {
+		t.Error("expected no theme stylesheet without a theme")
+	}
+}
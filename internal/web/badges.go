@@ -0,0 +1,24 @@
+package web
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/badges"
+)
+
+// WriteBadges publishes the snapshot's shields.io endpoint badges as badges.Dir/NAME.json in outputDir
+func (s *AnalyticsService) WriteBadges(outputDir string, m schema.Metrics) error {
+	dir := filepath.Join(outputDir, badges.Dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create badges directory: %w", err)
+	}
+	for _, badge := range badges.FromMetrics(m) {
+		if err := s.writeJSON(filepath.Join(dir, badge.Name+".json"), badge.Badge); err != nil {
+			return err
+		}
+	}
+	return nil
+}
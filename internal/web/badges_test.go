@@ -0,0 +1,34 @@
+package web
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/badges"
+)
+
+func TestWriteBadges(t *testing.T) {
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+
+	if err := service.WriteBadges(dir, schema.Metrics{TotalArticles: 10, ReadRate: 40, UnreadCount: 6}); err != nil {
+		t.Fatalf("WriteBadges() error = %v", err)
+	}
+
+	var badge badges.Badge
+	data, err := os.ReadFile(filepath.Join(dir, badges.Dir, "read-rate.json"))
+	if err != nil || json.Unmarshal(data, &badge) != nil {
+		t.Fatalf("expected a valid read-rate badge, got %s (%v)", data, err)
+	}
+	if badge.SchemaVersion != 1 || badge.Message != "40.0%" {
+		t.Errorf("unexpected badge %+v", badge)
+	}
+
+	if written := strings.Join(service.WrittenFiles(), ","); written != "badges/backlog.json,badges/read-rate.json,badges/total-articles.json" {
+		t.Errorf("expected every badge in the site manifest, got %s", written)
+	}
+}
@@ -0,0 +1,84 @@
+package web
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/locale"
+)
+
+// NotFoundFile is the page hosts serve for paths that do not exist
+const NotFoundFile = "404.html"
+
+// DefaultFooter is the footer line shown when config.yml sets none
+const DefaultFooter = "📈 Data sourced from personal article collection • Weekly metrics via GitHub Actions"
+
+// defaultPageTitles are the page headings, keyed by page file, used when config.yml sets none
+var defaultPageTitles = map[string]string{
+	"index.html":     AnalyticsTitle,
+	"analytics.html": "📊 Analytics",
+	"authors.html":   "✍️ Authors",
+	"topics.html":    "🏷️ Topics",
+	"evolution.html": "⏳ Evolution",
+	"explorer.html":  "🔎 Snapshot Explorer",
+	NotFoundFile:     "🧭 Page Not Found",
+	HistoryIndexPage: "🗂️ Snapshot History",
+}
+
+// Branding names the dashboard and formats its numbers and dates
+type Branding struct {
+	Title      string
+	PageTitles map[string]string
+	Footer     string
+	Locale     locale.Locale
+}
+
+// DefaultBranding is the built-in title, page titles and footer in en-US
+func DefaultBranding() Branding {
+	return Branding{Title: AnalyticsTitle, PageTitles: defaultPageTitles, Footer: DefaultFooter, Locale: locale.MustParse(locale.Default)}
+}
+
+// BrandingFromConfig applies the branding section of config.yml over the defaults. An invalid
+// locale is reported with the rest of the branding applied and the default locale kept.
+func BrandingFromConfig(cfg config.BrandingConfig) (Branding, error) {
+	b := DefaultBranding()
+	if cfg.Title != "" {
+		b.Title = cfg.Title
+	}
+	if cfg.Footer != "" {
+		b.Footer = cfg.Footer
+	}
+	b.PageTitles = make(map[string]string, len(defaultPageTitles))
+	for page, title := range defaultPageTitles {
+		b.PageTitles[page] = title
+	}
+	if cfg.Title != "" {
+		b.PageTitles["index.html"] = cfg.Title
+	}
+	for page, title := range cfg.PageTitles {
+		b.PageTitles[page] = title
+	}
+
+	l, err := locale.Parse(cfg.Locale)
+	if err != nil {
+		return b, err
+	}
+	b.Locale = l
+	return b, nil
+}
+
+// PageTitle is the heading of a page file, falling back to the built-in one
+func (b Branding) PageTitle(page string) string {
+	if title, exists := b.PageTitles[page]; exists {
+		return title
+	}
+	return defaultPageTitles[page]
+}
+
+// plainTitle is the title without its leading emoji, for the social card's block font and feed titles
+func (b Branding) plainTitle() string {
+	return strings.TrimSpace(strings.TrimLeftFunc(b.Title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}))
+}
@@ -0,0 +1,90 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func TestBrandingFromConfig(t *testing.T) {
+	b, err := BrandingFromConfig(config.BrandingConfig{})
+	if err != nil {
+		t.Fatalf("BrandingFromConfig() error = %v", err)
+	}
+	if b.Title != AnalyticsTitle || b.Footer != DefaultFooter || b.Locale.Tag() != "en-US" || b.PageTitle("authors.html") != "✍️ Authors" {
+		t.Errorf("unexpected default branding: %+v", b)
+	}
+
+	b, err = BrandingFromConfig(config.BrandingConfig{
+		Title:      "📖 Lesejournal",
+		PageTitles: map[string]string{"analytics.html": "📊 Statistik"},
+		Footer:     "Jede Woche aktualisiert",
+		Locale:     "de-DE",
+	})
+	if err != nil {
+		t.Fatalf("BrandingFromConfig() error = %v", err)
+	}
+	tests := []struct {
+		got      string
+		expected string
+	}{
+		{b.PageTitle("index.html"), "📖 Lesejournal"},
+		{b.PageTitle("analytics.html"), "📊 Statistik"},
+		{b.PageTitle("evolution.html"), "⏳ Evolution"},
+		{b.plainTitle(), "Lesejournal"},
+		{b.Locale.Tag(), "de-DE"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("got %q, want %q", tt.got, tt.expected)
+		}
+	}
+	if DefaultBranding().PageTitle("index.html") != AnalyticsTitle {
+		t.Error("expected the custom title not to leak into the defaults")
+	}
+
+	b, err = BrandingFromConfig(config.BrandingConfig{Title: "Reading", Locale: "not a locale!"})
+	if err == nil {
+		t.Error("expected an error for an invalid locale")
+	}
+	if b.Title != "Reading" || b.Locale.Tag() != "en-US" {
+		t.Errorf("expected the title applied with the default locale, got %+v", b)
+	}
+}
+
+func TestBrandedSite(t *testing.T) {
+	b, err := BrandingFromConfig(config.BrandingConfig{Title: "📖 Lesejournal", Footer: "Jede Woche aktualisiert", Locale: "de-DE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	service.SetBranding(b)
+	m := schema.Metrics{TotalArticles: 1234, ReadCount: 600, UnreadCount: 634, ReadRate: 48.62}
+	if err := service.GenerateFullSite(t.Context(), m, GenConfig{OutputDir: dir, BaseURL: "./", PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateFullSite() error = %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	analytics, err := os.ReadFile(filepath.Join(dir, "analytics.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`<html lang="de-DE">`, "<title>📖 Lesejournal - 📖 Lesejournal</title>", "Jede Woche aktualisiert", `aria-current="page">Home`} {
+		if !strings.Contains(string(index), want) {
+			t.Errorf("expected index.html to contain %q", want)
+		}
+	}
+	for _, want := range []string{"1.234", "48,6%", `aria-current="page">Analytics`} {
+		if !strings.Contains(string(analytics), want) {
+			t.Errorf("expected analytics.html to contain %q", want)
+		}
+	}
+}
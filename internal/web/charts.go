@@ -76,14 +76,10 @@ func PrepareMonthChartData(months []schema.MonthInfo, sources []schema.SourceInf
 	var datasets []map[string]interface{}
 	for _, source := range sources {
 		if data, exists := datasetsMap[source.Name]; exists && len(data) > 0 {
-			color := source.Color
-			if color == "" {
-				color = "#" + colorHash(source.Name)
-			}
 			dataset := map[string]interface{}{
 				"label":           source.Name,
 				"data":            data,
-				"backgroundColor": color,
+				"backgroundColor": SourceColor(source.Name, source.Color),
 				"borderColor":     "#2d3748",
 				"borderWidth":     1,
 			}
@@ -106,23 +102,3 @@ func PrepareMonthChartData(months []schema.MonthInfo, sources []schema.SourceInf
 		TotalDataJSON: monthTotalDataJSON,
 	}
 }
-
-// colorHash generates a simple hash for generating colors
-func colorHash(s string) string {
-	h := uint32(5381)
-	for i := 0; i < len(s); i++ {
-		h = ((h << 5) + h) + uint32(s[i])
-	}
-	return formatHex(h % 16777215)
-}
-
-// formatHex formats a number as a 6-digit hex string
-func formatHex(n uint32) string {
-	const hex = "0123456789abcdef"
-	b := make([]byte, 6)
-	for i := 5; i >= 0; i-- {
-		b[i] = hex[n%16]
-		n /= 16
-	}
-	return string(b)
-}
@@ -0,0 +1,154 @@
+package web
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// Precompressed encodings selectable with --compress, named after their Content-Encoding
+const (
+	EncodingGzip   = "gzip"
+	EncodingBrotli = "br"
+)
+
+// encodingExts are the sibling extensions each encoding is written to
+var encodingExts = map[string]string{EncodingGzip: ".gz", EncodingBrotli: ".br"}
+
+// compressibleExts are the text assets worth serving precompressed
+var compressibleExts = map[string]bool{
+	".html": true, ".css": true, ".json": true, ".js": true, ".xml": true, ".svg": true, ".txt": true, ".md": true, ".ics": true,
+}
+
+// lookPath finds the brotli command; replaced in tests
+var lookPath = exec.LookPath
+
+// brotliFile writes src brotli-compressed to dst with the brotli command, as the standard library
+// has no brotli encoder; replaced in tests
+var brotliFile = func(src, dst string) error {
+	tmp := dst + ".tmp"
+	out, err := exec.Command("brotli", "--best", "--force", "--output="+tmp, src).CombinedOutput()
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return os.Rename(tmp, dst)
+}
+
+// ParseEncodings reads a comma-separated --compress list, such as "gzip,br". Brotli needs the
+// brotli command on PATH.
+func ParseEncodings(list string) ([]string, error) {
+	var encodings []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, known := encodingExts[name]; !known {
+			return nil, fmt.Errorf("unknown encoding %q: expected %s or %s", name, EncodingGzip, EncodingBrotli)
+		}
+		if name == EncodingBrotli {
+			if _, err := lookPath("brotli"); err != nil {
+				return nil, fmt.Errorf("brotli compression needs the brotli command: %w", err)
+			}
+		}
+		seen[name] = true
+		encodings = append(encodings, name)
+	}
+	return encodings, nil
+}
+
+// CompressDir writes a .gz or .br sibling per encoding next to every HTML, CSS, JSON and other text
+// file under dir. Siblings at least as new as their source are kept. Siblings whose source is gone,
+// or of an encoding no longer selected, are removed so they never serve stale content; no encodings
+// removes them all. It returns the number of siblings written.
+func CompressDir(dir string, encodings []string) (int, error) {
+	selected := make(map[string]bool, len(encodings))
+	for _, encoding := range encodings {
+		selected[encodingExts[encoding]] = true
+	}
+	written := 0
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		ext := filepath.Ext(path)
+		if ext == encodingExts[EncodingGzip] || ext == encodingExts[EncodingBrotli] {
+			return removeStaleSibling(path, selected[ext])
+		}
+		if !compressibleExts[strings.ToLower(ext)] {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		for _, encoding := range encodings {
+			dst := path + encodingExts[encoding]
+			if sibling, err := os.Stat(dst); err == nil && !sibling.ModTime().Before(info.ModTime()) {
+				continue
+			}
+			compress := gzipFile
+			if encoding == EncodingBrotli {
+				compress = brotliFile
+			}
+			if err := compress(path, dst); err != nil {
+				return fmt.Errorf("failed to compress %s: %w", path, err)
+			}
+			if err := os.Chtimes(dst, info.ModTime(), info.ModTime()); err != nil {
+				return err
+			}
+			written++
+		}
+		return nil
+	})
+	return written, err
+}
+
+// removeStaleSibling deletes a compressed sibling of a text file that no longer exists or is no
+// longer compressed with its encoding
+func removeStaleSibling(path string, selected bool) error {
+	source := strings.TrimSuffix(path, filepath.Ext(path))
+	if !compressibleExts[strings.ToLower(filepath.Ext(source))] {
+		return nil
+	}
+	if _, err := os.Stat(source); os.IsNotExist(err) || !selected {
+		return os.Remove(path)
+	}
+	return nil
+}
+
+// gzipFile writes src gzip-compressed at the best compression level to dst
+func gzipFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := safefile.Create(dst, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	zw, err := gzip.NewWriterLevel(out, gzip.BestCompression)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(zw, in); err != nil {
+		zw.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return out.Commit()
+}
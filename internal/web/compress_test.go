@@ -0,0 +1,120 @@
+package web
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseEncodings(t *testing.T) {
+	oldLookPath := lookPath
+	defer func() { lookPath = oldLookPath }()
+
+	tests := []struct {
+		name      string
+		list      string
+		hasBrotli bool
+		expected  string
+		expectErr bool
+	}{
+		{name: "off", list: "", expected: ""},
+		{name: "gzip", list: "gzip", expected: "gzip"},
+		{name: "both deduplicated", list: " GZIP, br,gzip", hasBrotli: true, expected: "gzip,br"},
+		{name: "brotli without the command", list: "br", expectErr: true},
+		{name: "unknown", list: "zstd", expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookPath = func(string) (string, error) {
+				if tt.hasBrotli {
+					return "/usr/bin/brotli", nil
+				}
+				return "", errors.New("not found")
+			}
+			encodings, err := ParseEncodings(tt.list)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseEncodings(%q) error = %v, expectErr %v", tt.list, err, tt.expectErr)
+			}
+			if got := strings.Join(encodings, ","); got != tt.expected {
+				t.Errorf("ParseEncodings(%q) = %q, want %q", tt.list, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompressDir(t *testing.T) {
+	oldBrotli := brotliFile
+	defer func() { brotliFile = oldBrotli }()
+	brotliFile = func(src, dst string) error {
+		return os.WriteFile(dst, []byte("br:"+filepath.Base(src)), 0644)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"index.html":                    "<html>home</html>",
+		"css/styles.css":                "body{}",
+		"history/2025-01-05/chart.json": `{"a":1}`,
+		"og-image.png":                  "png",
+		"gone.html.gz":                  "stale",
+		"archive.tar.gz":                "not a sibling",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	written, err := CompressDir(dir, []string{EncodingGzip, EncodingBrotli})
+	if err != nil {
+		t.Fatalf("CompressDir() error = %v", err)
+	}
+	if written != 6 {
+		t.Errorf("expected 6 siblings written, got %d", written)
+	}
+
+	f, err := os.Open(filepath.Join(dir, "index.html.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(zr)
+	f.Close()
+	if string(content) != files["index.html"] {
+		t.Errorf("unexpected gzip content %q", content)
+	}
+	if brotli, _ := os.ReadFile(filepath.Join(dir, "css", "styles.css.br")); string(brotli) != "br:styles.css" {
+		t.Errorf("unexpected brotli sibling %q", brotli)
+	}
+	for _, name := range []string{"og-image.png.gz", "gone.html.gz"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("expected no %s", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "archive.tar.gz")); err != nil {
+		t.Errorf("expected unrelated archives kept: %v", err)
+	}
+
+	// Unchanged files are skipped; a rewritten file is compressed again
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "index.html"), later, later); err != nil {
+		t.Fatal(err)
+	}
+	if written, err = CompressDir(dir, []string{EncodingGzip}); err != nil || written != 1 {
+		t.Errorf("expected only index.html recompressed, got %d (%v)", written, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "index.html.br")); !os.IsNotExist(err) {
+		t.Error("expected siblings of an encoding no longer selected removed")
+	}
+}
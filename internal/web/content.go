@@ -0,0 +1,140 @@
+package web
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"gopkg.in/yaml.v3"
+)
+
+// EvolutionFile is the content file holding the hand-written evolution timeline
+const EvolutionFile = "content/evolution.yml"
+
+// LandingFile is the content file holding the landing page copy
+const LandingFile = "content/landing.yml"
+
+// CheckContent strictly validates the active assets' content files, so a broken evolution.yml
+// fails the build instead of rendering an empty timeline
+func CheckContent() error {
+	var errs []error
+	if _, err := LoadEvolutionData(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := LoadLanding(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// parseEvolution decodes evolution.yml, rejecting unknown keys, chapters without a title, and
+// milestones without a title or a YYYY-MM-DD (or YYYY-MM) date. Every problem is reported as
+// evolution.yml:LINE: message.
+func parseEvolution(content []byte) (schema.EvolutionData, error) {
+	var data schema.EvolutionData
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	var problems []error
+	var typeErr *yaml.TypeError
+	switch err := decoder.Decode(&data); {
+	case errors.Is(err, io.EOF):
+		return data, fmt.Errorf("%s: empty file, expected chapters", EvolutionFile)
+	case errors.As(err, &typeErr):
+		for _, message := range typeErr.Errors {
+			// yaml reports "line 5: field x not found in type schema.Milestone"
+			problems = append(problems, fmt.Errorf("%s:%s", EvolutionFile, strings.TrimPrefix(message, "line ")))
+		}
+	case err != nil:
+		return data, fmt.Errorf("failed to parse %s: %w", EvolutionFile, err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return data, fmt.Errorf("failed to parse %s: %w", EvolutionFile, err)
+	}
+	problems = append(problems, validateEvolution(&root)...)
+	return data, errors.Join(problems...)
+}
+
+// validateEvolution walks the parsed document for the required keys and date formats
+func validateEvolution(root *yaml.Node) []error {
+	var problems []error
+	problem := func(node *yaml.Node, format string, args ...interface{}) {
+		problems = append(problems, fmt.Errorf("%s:%d: %s", EvolutionFile, node.Line, fmt.Sprintf(format, args...)))
+	}
+
+	if len(root.Content) == 0 {
+		return nil
+	}
+	document := root.Content[0]
+	chapters := mappingValue(document, "chapters")
+	if chapters == nil {
+		problem(document, "missing chapters")
+		return problems
+	}
+	if chapters.Kind != yaml.SequenceNode {
+		problem(chapters, "chapters must be a list")
+		return problems
+	}
+
+	for c, chapter := range chapters.Content {
+		if chapter.Kind != yaml.MappingNode {
+			problem(chapter, "chapter %d must be a mapping", c+1)
+			continue
+		}
+		if title := mappingValue(chapter, "title"); title == nil || title.Value == "" {
+			problem(chapter, "chapter %d is missing a title", c+1)
+		}
+		timeline := mappingValue(chapter, "timeline")
+		if timeline == nil {
+			continue
+		}
+		if timeline.Kind != yaml.SequenceNode {
+			problem(timeline, "timeline of chapter %d must be a list", c+1)
+			continue
+		}
+		for m, milestone := range timeline.Content {
+			if milestone.Kind != yaml.MappingNode {
+				problem(milestone, "milestone %d of chapter %d must be a mapping", m+1, c+1)
+				continue
+			}
+			if title := mappingValue(milestone, "title"); title == nil || title.Value == "" {
+				problem(milestone, "milestone %d of chapter %d is missing a title", m+1, c+1)
+			}
+			switch date := mappingValue(milestone, "date"); {
+			case date == nil || date.Value == "":
+				problem(milestone, "milestone %d of chapter %d is missing a date", m+1, c+1)
+			case !validMilestoneDate(date.Value):
+				problem(date, "invalid date %q: expected YYYY-MM-DD or YYYY-MM", date.Value)
+			}
+		}
+	}
+	return problems
+}
+
+// validMilestoneDate accepts a day (2024-02-04) or, for month-long milestones, a month (2024-02)
+func validMilestoneDate(value string) bool {
+	if _, err := time.Parse("2006-01-02", value); err == nil {
+		return true
+	}
+	_, err := time.Parse("2006-01", value)
+	return err == nil
+}
+
+// mappingValue returns the value node of key in a mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
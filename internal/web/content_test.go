@@ -0,0 +1,74 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseEvolution(t *testing.T) {
+	content := `chapters:
+  - title: "Foundation"
+    timeline:
+      - date: "2024-02-30"
+        title: "Bad date"
+        summary: "unknown key"
+      - title: "No date"
+  - intro: "No title"
+    timeline:
+      - date: 2024-03
+        title: "Month"
+`
+	_, err := parseEvolution([]byte(content))
+	if err == nil {
+		t.Fatal("expected errors for the broken timeline")
+	}
+	for _, want := range []string{
+		"evolution.yml:6: field summary not found",
+		`evolution.yml:4: invalid date "2024-02-30"`,
+		"evolution.yml:7: milestone 2 of chapter 1 is missing a date",
+		"evolution.yml:8: chapter 2 is missing a title",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "Month") || strings.Contains(err.Error(), `"2024-03"`) {
+		t.Errorf("expected month dates to be accepted, got:\n%v", err)
+	}
+
+	for name, content := range map[string]string{
+		"empty":       "",
+		"no chapters": "title: Evolution\n",
+		"syntax":      "chapters: [\n",
+	} {
+		if _, err := parseEvolution([]byte(content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCheckContent(t *testing.T) {
+	t.Cleanup(func() { SetAssetsDir("") })
+	if err := CheckContent(); err != nil {
+		t.Fatalf("expected the built-in content to be valid, got %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "content"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(EvolutionFile)), []byte("chapters:\n  - title: A\n    timeline:\n      - title: B\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(LandingFile)), []byte("header: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetAssetsDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckContent(); err == nil || !strings.Contains(err.Error(), "missing a date") {
+		t.Errorf("expected the missing date to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// SnapshotAPIDir is where snapshots are published as JSON for the explorer page
+const SnapshotAPIDir = "api/snapshots"
+
+// SnapshotIndexEntry lists a published snapshot with any consistency issues found in it
+type SnapshotIndexEntry struct {
+	Date   string   `json:"date"`
+	Issues []string `json:"issues,omitempty"`
+}
+
+// WriteSnapshotAPI publishes every snapshot as api/snapshots/YYYY-MM-DD.json, plus an index.json
+// listing them newest first. Public sites publish them redacted.
+func (s *AnalyticsService) WriteSnapshotAPI(outputDir string, snapshots map[string]schema.Metrics) error {
+	apiDir := filepath.Join(outputDir, SnapshotAPIDir)
+	if err := os.MkdirAll(apiDir, 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot api directory: %w", err)
+	}
+
+	dates := make([]string, 0, len(snapshots))
+	for date := range snapshots {
+		dates = append(dates, date)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(dates)))
+
+	index := make([]SnapshotIndexEntry, 0, len(dates))
+	for _, date := range dates {
+		m := snapshots[date]
+		if s.public {
+			metrics.Redact(&m)
+		}
+		if err := s.writeJSON(filepath.Join(apiDir, date+".json"), m); err != nil {
+			return err
+		}
+		index = append(index, SnapshotIndexEntry{Date: date, Issues: metrics.CheckConsistency(m)})
+	}
+
+	return s.writeJSON(filepath.Join(apiDir, "index.json"), index)
+}
+
+// writeJSON writes a value as compact JSON and records the file
+func (s *AnalyticsService) writeJSON(path string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(path), err)
+	}
+	if err := safefile.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	s.record(path)
+	return nil
+}
@@ -0,0 +1,74 @@
+package web
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestWriteSnapshotAPI(t *testing.T) {
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	snapshots := map[string]schema.Metrics{
+		"2024-01-01": {TotalArticles: 2, ReadCount: 1, UnreadCount: 1, BySource: map[string]int{"GitHub": 2}},
+		"2024-02-01": {TotalArticles: 3, ReadCount: 1, UnreadCount: 1},
+	}
+
+	if err := service.WriteSnapshotAPI(dir, snapshots); err != nil {
+		t.Fatalf("WriteSnapshotAPI() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, SnapshotAPIDir, "index.json"))
+	if err != nil {
+		t.Fatalf("index.json was not written: %v", err)
+	}
+	var index []SnapshotIndexEntry
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("index.json is not valid JSON: %v", err)
+	}
+	if len(index) != 2 || index[0].Date != "2024-02-01" || index[1].Date != "2024-01-01" {
+		t.Fatalf("expected snapshots newest first, got %+v", index)
+	}
+	if len(index[0].Issues) != 1 || !strings.Contains(index[0].Issues[0], "total_articles (3)") || len(index[1].Issues) != 0 {
+		t.Errorf("expected one consistency issue for 2024-02-01 only, got %+v", index)
+	}
+
+	var snapshot schema.Metrics
+	data, err = os.ReadFile(filepath.Join(dir, SnapshotAPIDir, "2024-01-01.json"))
+	if err != nil || json.Unmarshal(data, &snapshot) != nil || snapshot.BySource["GitHub"] != 2 {
+		t.Errorf("expected the 2024-01-01 snapshot to round-trip, got %+v (%v)", snapshot, err)
+	}
+
+	written := strings.Join(service.WrittenFiles(), ",")
+	for _, file := range []string{"api/snapshots/index.json", "api/snapshots/2024-01-01.json", "api/snapshots/2024-02-01.json"} {
+		if !strings.Contains(written, file) {
+			t.Errorf("expected %s in written files, got %s", file, written)
+		}
+	}
+}
+
+func TestWriteSnapshotAPIPublic(t *testing.T) {
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	service.SetPublic(true)
+	article := schema.ArticleMeta{Title: "Private", Date: "2020-01-01", Link: "https://example.com/private", Category: "GitHub"}
+	snapshots := map[string]schema.Metrics{"2024-01-01": {TotalArticles: 1, OldestUnreadArticle: &article}}
+
+	if err := service.WriteSnapshotAPI(dir, snapshots); err != nil {
+		t.Fatalf("WriteSnapshotAPI() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, SnapshotAPIDir, "2024-01-01.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "Private") || strings.Contains(string(data), "example.com") {
+		t.Errorf("expected the published snapshot to be redacted, got %s", data)
+	}
+	if article.Title != "Private" {
+		t.Error("expected the loaded snapshot to be left untouched")
+	}
+}
@@ -0,0 +1,50 @@
+package web
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/feed"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// FeedDescription describes the RSS feed to feed readers
+const FeedDescription = "Articles recently added to and read from the reading list"
+
+// WriteFeed publishes the newest reading events as feed.File (RSS) and feed.JSONFile (JSON Feed) in
+// outputDir. Feed links must be absolute, so site_url in landing.yml is required.
+func (s *AnalyticsService) WriteFeed(outputDir string, events []feed.Event) error {
+	landing, err := LoadLanding()
+	if err != nil {
+		return err
+	}
+	if landing.Header.SiteURL == "" {
+		return fmt.Errorf("site_url is not set in landing.yml")
+	}
+
+	title := landing.Header.ProjectName
+	if title == "" {
+		title = s.branding.plainTitle()
+	}
+	channel := feed.Channel{Title: title, Link: landing.Header.SiteURL, Description: FeedDescription}
+
+	formats := []struct {
+		file   string
+		render func([]feed.Event, feed.Channel) ([]byte, error)
+	}{
+		{feed.File, feed.RSS},
+		{feed.JSONFile, feed.JSONFeed},
+	}
+	for _, format := range formats {
+		content, err := format.render(events, channel)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(outputDir, format.file)
+		if err := safefile.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		s.record(path)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/feed"
+)
+
+func TestWriteFeed(t *testing.T) {
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	events := []feed.Event{{GUID: "read:https://a.com/1", Kind: feed.KindRead, Title: "Post", Link: "https://a.com/1", Date: time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC)}}
+	if err := service.WriteFeed(dir, events); err != nil {
+		t.Fatalf("WriteFeed() error = %v", err)
+	}
+
+	files := map[string][]string{
+		feed.File:     {"<title>Read: Post</title>", `<atom:link href="https://`},
+		feed.JSONFile: {`"title": "Read: Post"`, `"feed_url": "https://`},
+	}
+	for file, wants := range files {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected %s to contain %q, got:\n%s", file, want, content)
+			}
+		}
+	}
+	if got := len(service.WrittenFiles()); got != 2 {
+		t.Errorf("expected both feeds in the site manifest, got %d files", got)
+	}
+
+	if err := service.GenerateAnalyticsOnly(t.Context(), schema.Metrics{}, GenConfig{OutputDir: dir, BaseURL: "./", Feed: true, PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+	}
+	page, _ := os.ReadFile(filepath.Join(dir, "analytics.html"))
+	if !strings.Contains(string(page), `type="application/rss+xml"`) || !strings.Contains(string(page), `href="./feed.json"`) {
+		t.Errorf("expected the page head to advertise both feeds")
+	}
+}
@@ -0,0 +1,35 @@
+package web
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// FuzzPrepareViewModel feeds corrupted snapshot JSON through view model and chart data preparation;
+// explore further with go test ./internal/web/ -run '^$' -fuzz FuzzPrepareViewModel -fuzztime 30s
+func FuzzPrepareViewModel(f *testing.F) {
+	f.Add([]byte(`{"total_articles":2,"by_source":{"Substack":2},"by_source_read_status":{"Substack":[1,1],"substack_author_count":[3,0]}}`))
+	f.Add([]byte(`{}`))
+	f.Add([]byte(`{"by_month_and_source_read_status":{"01":null,"13":{"":[-1,-1]}},"by_month":{"00":1}}`))
+	f.Add([]byte(`{"by_year":{"":1,"abcd":-3},"unread_by_year":{"2024":1},"read_unread_totals":[0,0]}`))
+	f.Add([]byte(`{"unread_article_age_distribution":{"unknown":4},"top_oldest_unread_articles":[{"title":"<script>"}]}`))
+	f.Add([]byte(`{"source_metadata":{"GitHub":{"color":"#zzz","feeds":-2}},"by_source":{"GitHub":0}}`))
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		var m schema.Metrics
+		if err := json.Unmarshal(content, &m); err != nil {
+			return
+		}
+
+		service := NewAnalyticsService(t.TempDir())
+		vm, err := service.prepareViewModel(m, GenConfig{ReportDate: "2025-01-01", EnergyHistory: []schema.EnergyPoint{{Date: "bad", Score: -1}}})
+		if err != nil {
+			return
+		}
+		if _, err := ChartDataJSON(vm); err != nil {
+			t.Fatalf("ChartDataJSON failed: %v", err)
+		}
+	})
+}
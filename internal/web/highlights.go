@@ -0,0 +1,246 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/locale"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// Highlight is one badge of the analytics page's Highlights section: a metric of the snapshot,
+// reduced to one value by Aggregate when it has a value per source, year or month
+type Highlight struct {
+	Title     string
+	Metric    string
+	Aggregate string // max, min, sum, avg or count; empty for single-value metrics
+	Format    string // name (the source, year or month picked by max or min), int, decimal, percent, days or weeks
+}
+
+// DefaultHighlights are the badges shown without highlights in config.yml
+func DefaultHighlights() []Highlight {
+	return []Highlight{
+		{Title: "🎯 Top Read Rate Source", Metric: "source_read_rate", Aggregate: "max", Format: "name"},
+		{Title: "📚 Most Unread Source", Metric: "source_unread", Aggregate: "max", Format: "name"},
+		{Title: "✅ This Month's Articles", Metric: "this_month_read", Format: "int"},
+	}
+}
+
+// highlightInput is what highlight metrics read: the snapshot, its sources as listed on the page and
+// the month (MM) that counts as this month
+type highlightInput struct {
+	m            schema.Metrics
+	sources      []schema.SourceInfo
+	currentMonth string
+}
+
+// highlightMetric reads one metric, one value per key when keyed and a single value under "" otherwise
+type highlightMetric struct {
+	keyed  bool
+	format string // used when the highlight sets none
+	read   func(in highlightInput) map[string]float64
+}
+
+// highlightMetrics are the metrics highlights can be defined over, by the name config.yml uses
+var highlightMetrics = map[string]highlightMetric{
+	"source_read_rate": {true, "percent", func(in highlightInput) map[string]float64 {
+		return sourceValues(in, func(s schema.SourceInfo) float64 { return s.ReadPct })
+	}},
+	"source_read": {true, "int", func(in highlightInput) map[string]float64 {
+		return sourceValues(in, func(s schema.SourceInfo) float64 { return float64(s.Read) })
+	}},
+	"source_unread": {true, "int", func(in highlightInput) map[string]float64 {
+		return sourceValues(in, func(s schema.SourceInfo) float64 { return float64(s.Unread) })
+	}},
+	"source_total": {true, "int", func(in highlightInput) map[string]float64 {
+		return sourceValues(in, func(s schema.SourceInfo) float64 { return float64(s.Count) })
+	}},
+	"year_total": {true, "int", func(in highlightInput) map[string]float64 {
+		values := make(map[string]float64, len(in.m.ByYear))
+		for year, count := range in.m.ByYear {
+			values[year] = float64(count)
+		}
+		return values
+	}},
+	"month_total": {true, "int", func(in highlightInput) map[string]float64 {
+		values := make(map[string]float64, len(in.m.ByMonth))
+		for month, count := range in.m.ByMonth {
+			var index int
+			if _, err := fmt.Sscanf(month, "%d", &index); err == nil && index >= 1 && index <= 12 {
+				values[shortMonthNames[index-1]] = float64(count)
+			}
+		}
+		return values
+	}},
+	"this_month_read": {false, "int", func(in highlightInput) map[string]float64 {
+		return single(float64(metrics.CalculateThisMonthArticles(in.m, in.currentMonth)))
+	}},
+	"total_articles": {false, "int", func(in highlightInput) map[string]float64 { return single(float64(in.m.TotalArticles)) }},
+	"read_count":     {false, "int", func(in highlightInput) map[string]float64 { return single(float64(in.m.ReadCount)) }},
+	"unread_count":   {false, "int", func(in highlightInput) map[string]float64 { return single(float64(in.m.UnreadCount)) }},
+	"read_rate":      {false, "percent", func(in highlightInput) map[string]float64 { return single(in.m.ReadRate) }},
+	"avg_per_month":  {false, "decimal", func(in highlightInput) map[string]float64 { return single(in.m.AvgArticlesPerMonth) }},
+	"oldest_unread_days": {false, "days", func(in highlightInput) map[string]float64 {
+		if in.m.OldestUnreadArticle == nil {
+			return nil
+		}
+		date, err := time.Parse("2006-01-02", in.m.OldestUnreadArticle.Date)
+		if err != nil {
+			return nil
+		}
+		now := time.Now()
+		if !in.m.LastUpdated.IsZero() {
+			now = in.m.LastUpdated
+		}
+		return single(math.Floor(now.Sub(date).Hours() / 24))
+	}},
+	"streak": {false, "weeks", func(in highlightInput) map[string]float64 {
+		if in.m.EnergyScore == nil {
+			return nil
+		}
+		return single(float64(in.m.EnergyScore.Streak))
+	}},
+}
+
+var highlightAggregates = []string{"max", "min", "sum", "avg", "count"}
+
+var highlightFormats = []string{"name", "int", "decimal", "percent", "days", "weeks"}
+
+// HighlightsFromConfig builds the highlights of config.yml, falling back to DefaultHighlights when
+// none are set. Invalid highlights are left out and reported in the error.
+func HighlightsFromConfig(cfg []config.HighlightConfig) ([]Highlight, error) {
+	if len(cfg) == 0 {
+		return DefaultHighlights(), nil
+	}
+
+	var highlights []Highlight
+	var errs []error
+	for _, c := range cfg {
+		h, err := newHighlight(c)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		highlights = append(highlights, h)
+	}
+	return highlights, errors.Join(errs...)
+}
+
+// newHighlight validates a configured highlight and fills in its default format
+func newHighlight(c config.HighlightConfig) (Highlight, error) {
+	h := Highlight{Title: c.Title, Metric: c.Metric, Aggregate: c.Aggregate, Format: c.Format}
+	metric, exists := highlightMetrics[h.Metric]
+	switch {
+	case h.Title == "":
+		return h, fmt.Errorf("highlight for %q has no title", h.Metric)
+	case !exists:
+		return h, fmt.Errorf("highlight %q: unknown metric %q", h.Title, h.Metric)
+	case metric.keyed && !slices.Contains(highlightAggregates, h.Aggregate):
+		return h, fmt.Errorf("highlight %q: metric %s needs an aggregate, one of %v", h.Title, h.Metric, highlightAggregates)
+	case !metric.keyed && h.Aggregate != "":
+		return h, fmt.Errorf("highlight %q: metric %s has a single value and takes no aggregate", h.Title, h.Metric)
+	}
+
+	picksKey := h.Aggregate == "max" || h.Aggregate == "min"
+	if h.Format == "" {
+		h.Format = metric.format
+		if picksKey {
+			h.Format = "name"
+		}
+	}
+	switch {
+	case !slices.Contains(highlightFormats, h.Format):
+		return h, fmt.Errorf("highlight %q: unknown format %q, expected one of %v", h.Title, h.Format, highlightFormats)
+	case h.Format == "name" && !picksKey:
+		return h, fmt.Errorf("highlight %q: format name needs aggregate max or min", h.Title)
+	}
+	return h, nil
+}
+
+// value computes the highlight's text for a snapshot; empty when the metric has no data
+func (h Highlight) value(in highlightInput, loc locale.Locale) string {
+	metric, exists := highlightMetrics[h.Metric]
+	if !exists {
+		return ""
+	}
+	key, v, ok := aggregate(metric.read(in), h.Aggregate)
+	if !ok {
+		return ""
+	}
+
+	switch h.Format {
+	case "name":
+		return key
+	case "decimal":
+		return loc.Decimal(v, 1)
+	case "percent":
+		return loc.Decimal(v, 1) + "%"
+	case "days":
+		return loc.Int(int(math.Round(v))) + " days"
+	case "weeks":
+		return loc.Int(int(math.Round(v))) + " weeks"
+	default:
+		return loc.Int(int(math.Round(v)))
+	}
+}
+
+// aggregate reduces a metric's values to one, with the key max or min picked. Max skips values of
+// zero or less, so a snapshot where nothing qualifies shows no name. Ties go to the first key in
+// sorted order.
+func aggregate(values map[string]float64, how string) (string, float64, bool) {
+	if how == "" {
+		v, exists := values[""]
+		return "", v, exists
+	}
+	if how == "count" {
+		return "", float64(len(values)), true
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pick string
+	var result float64
+	found := false
+	for _, key := range keys {
+		v := values[key]
+		switch how {
+		case "max":
+			if v > 0 && (!found || v > result) {
+				pick, result, found = key, v, true
+			}
+		case "min":
+			if !found || v < result {
+				pick, result, found = key, v, true
+			}
+		case "sum", "avg":
+			result += v
+			found = true
+		}
+	}
+	if how == "avg" && found {
+		result /= float64(len(keys))
+	}
+	return pick, result, found
+}
+
+func sourceValues(in highlightInput, value func(schema.SourceInfo) float64) map[string]float64 {
+	values := make(map[string]float64, len(in.sources))
+	for _, source := range in.sources {
+		values[source.Name] = value(source)
+	}
+	return values
+}
+
+func single(v float64) map[string]float64 {
+	return map[string]float64{"": v}
+}
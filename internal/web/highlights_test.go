@@ -0,0 +1,103 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/locale"
+)
+
+func TestHighlightsFromConfig(t *testing.T) {
+	highlights, err := HighlightsFromConfig(nil)
+	if err != nil || len(highlights) != len(DefaultHighlights()) {
+		t.Fatalf("expected the default highlights without config, got %+v, %v", highlights, err)
+	}
+
+	highlights, err = HighlightsFromConfig([]config.HighlightConfig{
+		{Title: "Oldest Unread", Metric: "oldest_unread_days"},
+		{Title: "Busiest Year", Metric: "year_total", Aggregate: "max"},
+		{Title: "Sources", Metric: "source_total", Aggregate: "count"},
+		{Title: "Typo", Metric: "oldest_unred_days"},
+		{Title: "No Aggregate", Metric: "source_unread"},
+		{Title: "Aggregated Single", Metric: "read_rate", Aggregate: "max"},
+		{Title: "Bad Format", Metric: "read_rate", Format: "stars"},
+		{Title: "Name Without Pick", Metric: "source_unread", Aggregate: "sum", Format: "name"},
+		{Metric: "read_rate"},
+	})
+	if err == nil {
+		t.Fatal("expected errors for the invalid highlights")
+	}
+	for _, want := range []string{"oldest_unred_days", "No Aggregate", "Aggregated Single", "stars", "Name Without Pick", "no title"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected the error to mention %q, got %v", want, err)
+		}
+	}
+	if len(highlights) != 3 {
+		t.Fatalf("expected the 3 valid highlights to be kept, got %+v", highlights)
+	}
+	if highlights[0].Format != "days" || highlights[1].Format != "name" || highlights[2].Format != "int" {
+		t.Errorf("expected default formats days, name and int, got %+v", highlights)
+	}
+}
+
+func TestHighlightValue(t *testing.T) {
+	m := schema.Metrics{
+		ByYear:              map[string]int{"2024": 5, "2025": 9},
+		ReadRate:            62.14,
+		LastUpdated:         time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC),
+		OldestUnreadArticle: &schema.ArticleMeta{Date: "2026-01-01"},
+		ByMonthAndSource:    map[string]map[string][2]int{"03": {"GitHub": {4, 1}, "Stripe": {2, 0}}},
+	}
+	in := highlightInput{m: m, currentMonth: "03", sources: []schema.SourceInfo{
+		{Name: "GitHub", Count: 10, Read: 8, Unread: 2, ReadPct: 80},
+		{Name: "Stripe", Count: 4, Read: 1, Unread: 3, ReadPct: 25},
+		{Name: "Substack", Count: 4, Read: 0, Unread: 4, ReadPct: 0},
+	}}
+
+	tests := []struct {
+		highlight Highlight
+		want      string
+	}{
+		{DefaultHighlights()[0], "GitHub"},
+		{DefaultHighlights()[1], "Substack"},
+		{DefaultHighlights()[2], "6"},
+		{Highlight{Metric: "source_read_rate", Aggregate: "min", Format: "name"}, "Substack"},
+		{Highlight{Metric: "source_read_rate", Aggregate: "avg", Format: "percent"}, "35.0%"},
+		{Highlight{Metric: "source_unread", Aggregate: "sum", Format: "int"}, "9"},
+		{Highlight{Metric: "year_total", Aggregate: "max", Format: "name"}, "2025"},
+		{Highlight{Metric: "read_rate", Format: "percent"}, "62.1%"},
+		{Highlight{Metric: "oldest_unread_days", Format: "days"}, "69 days"},
+		{Highlight{Metric: "streak", Format: "weeks"}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.highlight.value(in, locale.Locale{}); got != tt.want {
+			t.Errorf("%s %s = %q, want %q", tt.highlight.Metric, tt.highlight.Aggregate, got, tt.want)
+		}
+	}
+}
+
+func TestConfiguredHighlightsSection(t *testing.T) {
+	m := schema.Metrics{TotalArticles: 3, ReadCount: 1, UnreadCount: 2, ReadRate: 33.3}
+
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	service.SetHighlights([]Highlight{{Title: "📖 Read So Far", Metric: "read_count", Format: "int"}})
+	if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: dir, BaseURL: "./", PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "analytics.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(page), "📖 Read So Far") {
+		t.Error("expected the configured highlight on analytics.html")
+	}
+	if strings.Contains(string(page), "Top Read Rate Source") {
+		t.Error("expected the configured highlights to replace the defaults")
+	}
+}
@@ -0,0 +1,128 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// HistoryIndexPage is the browsable list of snapshot pages, relative to the site root
+const HistoryIndexPage = "history/index.html"
+
+// HistoryYear groups the snapshot pages of one year, newest month first
+type HistoryYear struct {
+	Year   string
+	Months []HistoryMonth
+}
+
+// HistoryMonth groups the snapshot pages of one month, newest first
+type HistoryMonth struct {
+	Name    string
+	Entries []HistoryEntry
+}
+
+// HistoryEntry is one snapshot page with its key metrics; HasMetrics is false when the snapshot
+// could not be loaded
+type HistoryEntry struct {
+	Date        string
+	HasMetrics  bool
+	Total       int
+	ReadCount   int
+	UnreadCount int
+	ReadRate    float64
+}
+
+// PrepareHistoryIndex groups the dates that have a history page, newest first, by year and month
+// with each snapshot's totals
+func PrepareHistoryIndex(dates []string, snapshots map[string]schema.Metrics) []HistoryYear {
+	var years []HistoryYear
+	for _, date := range dates {
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			continue
+		}
+
+		year := day.Format("2006")
+		if len(years) == 0 || years[len(years)-1].Year != year {
+			years = append(years, HistoryYear{Year: year})
+		}
+		y := &years[len(years)-1]
+		month := day.Format("January")
+		if len(y.Months) == 0 || y.Months[len(y.Months)-1].Name != month {
+			y.Months = append(y.Months, HistoryMonth{Name: month})
+		}
+
+		entry := HistoryEntry{Date: date}
+		if m, exists := snapshots[date]; exists {
+			entry.HasMetrics = true
+			entry.Total = m.TotalArticles
+			entry.ReadCount = m.ReadCount
+			entry.UnreadCount = m.UnreadCount
+			entry.ReadRate = m.ReadRate
+		}
+		months := y.Months
+		months[len(months)-1].Entries = append(months[len(months)-1].Entries, entry)
+	}
+	return years
+}
+
+// adjacentReportDates finds the snapshot pages either side of date in dates, which run newest first
+func adjacentReportDates(dates []string, date string) (older, newer string) {
+	for i, d := range dates {
+		if d != date {
+			continue
+		}
+		if i+1 < len(dates) {
+			older = dates[i+1]
+		}
+		if i > 0 {
+			newer = dates[i-1]
+		}
+		break
+	}
+	return older, newer
+}
+
+// GenerateHistoryIndex writes index.html into config.OutputDir, normally dist/history, listing every
+// date in config.HistoryDates with its key metrics from snapshots. The page header comes from latest.
+func (s *AnalyticsService) GenerateHistoryIndex(ctx context.Context, latest schema.Metrics, snapshots map[string]schema.Metrics, config GenConfig) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	vm, err := s.prepareViewModel(latest, config)
+	if err != nil {
+		return fmt.Errorf("failed to prepare view model: %w", err)
+	}
+	vm.HistoryIndex = PrepareHistoryIndex(config.HistoryDates, snapshots)
+	vm.PageFile = HistoryIndexPage
+	vm.PageTitle = s.branding.PageTitle(HistoryIndexPage)
+
+	tmpl, err := template.ParseFS(assets, templatePath("base.html"), templatePath("history.html"))
+	if err != nil {
+		return fmt.Errorf("failed to parse history index templates: %w", err)
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	path := filepath.Join(config.OutputDir, "index.html")
+	f, err := safefile.Create(path, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+	if err := tmpl.ExecuteTemplate(f, "history.html", vm); err != nil {
+		return fmt.Errorf("failed to execute history index template: %w", err)
+	}
+	if err := f.Commit(); err != nil {
+		return err
+	}
+	s.record(path)
+	return nil
+}
@@ -0,0 +1,114 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestPrepareHistoryIndex(t *testing.T) {
+	snapshots := map[string]schema.Metrics{
+		"2025-02-03": {TotalArticles: 12, ReadCount: 6, UnreadCount: 6, ReadRate: 50},
+		"2025-01-27": {TotalArticles: 10, ReadCount: 4, UnreadCount: 6, ReadRate: 40},
+		"2024-12-30": {TotalArticles: 8, ReadCount: 2, UnreadCount: 6, ReadRate: 25},
+	}
+	dates := []string{"2025-02-03", "2025-01-27", "2025-01-20", "2024-12-30", "not-a-date"}
+
+	expected := []HistoryYear{
+		{Year: "2025", Months: []HistoryMonth{
+			{Name: "February", Entries: []HistoryEntry{
+				{Date: "2025-02-03", HasMetrics: true, Total: 12, ReadCount: 6, UnreadCount: 6, ReadRate: 50},
+			}},
+			{Name: "January", Entries: []HistoryEntry{
+				{Date: "2025-01-27", HasMetrics: true, Total: 10, ReadCount: 4, UnreadCount: 6, ReadRate: 40},
+				{Date: "2025-01-20"},
+			}},
+		}},
+		{Year: "2024", Months: []HistoryMonth{
+			{Name: "December", Entries: []HistoryEntry{
+				{Date: "2024-12-30", HasMetrics: true, Total: 8, ReadCount: 2, UnreadCount: 6, ReadRate: 25},
+			}},
+		}},
+	}
+	if got := PrepareHistoryIndex(dates, snapshots); !reflect.DeepEqual(got, expected) {
+		t.Errorf("PrepareHistoryIndex() = %+v, expected %+v", got, expected)
+	}
+	if got := PrepareHistoryIndex(nil, snapshots); got != nil {
+		t.Errorf("PrepareHistoryIndex(nil) = %+v, expected nil", got)
+	}
+}
+
+func TestAdjacentReportDates(t *testing.T) {
+	dates := []string{"2025-02-03", "2025-01-27", "2025-01-20"}
+	tests := []struct {
+		name          string
+		date          string
+		expectedOlder string
+		expectedNewer string
+	}{
+		{"newest", "2025-02-03", "2025-01-27", ""},
+		{"middle", "2025-01-27", "2025-01-20", "2025-02-03"},
+		{"oldest", "2025-01-20", "", "2025-01-27"},
+		{"unlisted", "2024-12-30", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			older, newer := adjacentReportDates(dates, tt.date)
+			if older != tt.expectedOlder || newer != tt.expectedNewer {
+				t.Errorf("adjacentReportDates(%s) = %q, %q, expected %q, %q", tt.date, older, newer, tt.expectedOlder, tt.expectedNewer)
+			}
+		})
+	}
+}
+
+func TestGenerateHistoryIndex(t *testing.T) {
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	dates := []string{"2025-02-03", "2025-01-27", "2025-01-20"}
+	snapshots := map[string]schema.Metrics{
+		"2025-02-03": {TotalArticles: 1234, ReadCount: 617, UnreadCount: 617, ReadRate: 50},
+		"2025-01-27": {TotalArticles: 10, ReadCount: 4, UnreadCount: 6, ReadRate: 40},
+	}
+
+	historyDir := filepath.Join(dir, "history")
+	if err := service.GenerateHistoryIndex(t.Context(), snapshots["2025-02-03"], snapshots, GenConfig{OutputDir: historyDir, BaseURL: "../", HistoryDates: dates, ReportDate: dates[0]}); err != nil {
+		t.Fatalf("GenerateHistoryIndex() error = %v", err)
+	}
+	middleDir := filepath.Join(historyDir, "2025-01-27")
+	if err := service.GenerateAnalyticsOnly(t.Context(), snapshots["2025-01-27"], GenConfig{OutputDir: middleDir, BaseURL: "../../", IsHistorical: true, HistoryDates: dates, ReportDate: "2025-01-27", PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+	}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"index.html", "🗂️ Snapshot History"},
+		{"index.html", `<h2 id="history-2025"`},
+		{"index.html", ">February</h3>"},
+		{"index.html", `href="../history/2025-01-27/analytics.html"`},
+		{"index.html", "1,234"},
+		{"index.html", "40.0%"},
+		{"index.html", "Metrics unavailable"},
+		{filepath.Join("2025-01-27", "analytics.html"), `href="../../history/2025-01-20/analytics.html" rel="prev"`},
+		{filepath.Join("2025-01-27", "analytics.html"), `href="../../history/2025-02-03/analytics.html" rel="next"`},
+		{filepath.Join("2025-01-27", "analytics.html"), `href="../../history/index.html"`},
+	}
+	for _, tt := range tests {
+		page, err := os.ReadFile(filepath.Join(historyDir, tt.path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(page), tt.expected) {
+			t.Errorf("%s: expected %q", tt.path, tt.expected)
+		}
+	}
+
+	if files := strings.Join(service.WrittenFiles(), ","); !strings.Contains(files, filepath.Join("history", "index.html")) {
+		t.Errorf("expected history/index.html in the written files, got %v", service.WrittenFiles())
+	}
+}
@@ -0,0 +1,99 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// JSONFile is the report JSONRenderer writes into the site root when no Path is set
+const JSONFile = "report.json"
+
+// JSONRenderer writes the latest snapshot's figures, highlights, alerts, sources and chart series
+// as one JSON document, for scripts that would otherwise scrape the pages. Like MarkdownRenderer
+// it renders the root pass only.
+type JSONRenderer struct {
+	Path string // output file; defaults to JSONFile in the root pass directory
+}
+
+// jsonReport is the document JSONRenderer writes. Numbers stay unformatted; highlights keep the
+// text shown on the page, since their format is configured.
+type jsonReport struct {
+	Title               string                     `json:"title"`
+	LastUpdated         time.Time                  `json:"last_updated"`
+	TotalArticles       int                        `json:"total_articles"`
+	ReadCount           int                        `json:"read_count"`
+	UnreadCount         int                        `json:"unread_count"`
+	ReadRate            float64                    `json:"read_rate"`
+	AvgArticlesPerMonth float64                    `json:"avg_articles_per_month"`
+	Highlights          []jsonHighlight            `json:"highlights"`
+	Alerts              []schema.Alert             `json:"alerts"`
+	Sources             []jsonSource               `json:"sources"`
+	Charts              map[string]json.RawMessage `json:"charts"` // the analytics page's chart series, null when empty
+}
+
+type jsonHighlight struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type jsonSource struct {
+	Name     string  `json:"name"`
+	Count    int     `json:"count"`
+	Read     int     `json:"read"`
+	Unread   int     `json:"unread"`
+	ReadRate float64 `json:"read_rate"`
+	Color    string  `json:"color"`
+}
+
+// Render marshals the view model's report and writes it
+func (r JSONRenderer) Render(ctx context.Context, vm ViewModel, target OutputTarget) error {
+	if !target.IsRoot {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	charts, err := chartSeries(vm)
+	if err != nil {
+		return err
+	}
+	report := jsonReport{
+		Title:               vm.AnalyticsTitle,
+		LastUpdated:         vm.LastUpdated,
+		TotalArticles:       vm.TotalArticles,
+		ReadCount:           vm.ReadCount,
+		UnreadCount:         vm.UnreadCount,
+		ReadRate:            vm.ReadRate,
+		AvgArticlesPerMonth: vm.AvgArticlesPerMonth,
+		Highlights:          make([]jsonHighlight, 0, len(vm.HighlightMetrics)),
+		Alerts:              vm.Alerts,
+		Sources:             make([]jsonSource, 0, len(vm.Sources)),
+		Charts:              charts,
+	}
+	if report.Alerts == nil {
+		report.Alerts = []schema.Alert{}
+	}
+	for _, h := range vm.HighlightMetrics {
+		report.Highlights = append(report.Highlights, jsonHighlight{Title: h.Title, Value: h.Value})
+	}
+	for _, s := range vm.Sources {
+		report.Sources = append(report.Sources, jsonSource{Name: s.Name, Count: s.Count, Read: s.Read, Unread: s.Unread, ReadRate: s.ReadPct, Color: s.Color})
+	}
+
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON report: %w", err)
+	}
+
+	path := r.Path
+	if path == "" {
+		path = filepath.Join(target.Dir, JSONFile)
+	}
+	return writeReport(path, append(content, '\n'), target)
+}
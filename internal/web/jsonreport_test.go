@@ -0,0 +1,58 @@
+package web
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestJSONRenderer(t *testing.T) {
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	service.SetRenderers(JSONRenderer{})
+	m := schema.Metrics{
+		TotalArticles:      10,
+		ReadCount:          4,
+		UnreadCount:        6,
+		ReadRate:           40,
+		BySource:           map[string]int{"GitHub": 10},
+		BySourceReadStatus: map[string][2]int{"GitHub": {4, 6}},
+		ByYear:             map[string]int{"2025": 10},
+	}
+
+	if err := service.GenerateFullSite(t.Context(), m, GenConfig{OutputDir: dir}); err != nil {
+		t.Fatalf("GenerateFullSite() error = %v", err)
+	}
+	history := filepath.Join(dir, "history", "2025-03-01")
+	if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: history, PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, JSONFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report jsonReport
+	if err := json.Unmarshal(content, &report); err != nil {
+		t.Fatalf("invalid JSON report: %v", err)
+	}
+	if report.TotalArticles != 10 || report.ReadRate != 40 || len(report.Highlights) != len(DefaultHighlights()) {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if len(report.Sources) != 1 || report.Sources[0].Name != "GitHub" || report.Sources[0].Unread != 6 || report.Sources[0].Color == "" {
+		t.Errorf("unexpected sources %+v", report.Sources)
+	}
+	var yearCounts []int
+	if err := json.Unmarshal(report.Charts["yearChartData"], &yearCounts); err != nil || len(yearCounts) != 1 || yearCounts[0] != 10 {
+		t.Errorf("unexpected year chart series %s", report.Charts["yearChartData"])
+	}
+	if string(report.Charts["backlogFlow"]) != "null" {
+		t.Errorf("expected missing chart series as null, got %s", report.Charts["backlogFlow"])
+	}
+	if _, err := os.Stat(filepath.Join(history, JSONFile)); !os.IsNotExist(err) {
+		t.Error("expected history passes to be skipped")
+	}
+}
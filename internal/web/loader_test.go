@@ -6,123 +6,9 @@ import (
 	"testing"
 )
 
-// TestGetTemplatesDir tests the GetTemplatesDir function
-func TestGetTemplatesDir(t *testing.T) {
-	// Save original working directory
-	originalWd, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("failed to get working directory: %v", err)
-	}
-	defer func() {
-		// Restore original working directory
-		if err := os.Chdir(originalWd); err != nil {
-			t.Fatalf("failed to restore working directory: %v", err)
-		}
-	}()
-
-	tests := []struct {
-		name        string
-		setup       func(t *testing.T) string // returns temp dir path
-		expectError bool
-		expectEmpty bool
-	}{
-		{
-			name: "finds templates directory from primary path",
-			setup: func(t *testing.T) string {
-				tmpDir := t.TempDir()
-				if err := os.Chdir(tmpDir); err != nil {
-					t.Fatalf("failed to change directory: %v", err)
-				}
-
-				// Create directory structure for primary path
-				templatesDir := filepath.Join("internal", "web", "templates")
-				if err := os.MkdirAll(templatesDir, 0755); err != nil {
-					t.Fatalf("failed to create directories: %v", err)
-				}
-
-				return tmpDir
-			},
-			expectError: false,
-			expectEmpty: false,
-		},
-		{
-			name: "finds templates directory from relative path",
-			setup: func(t *testing.T) string {
-				tmpDir := t.TempDir()
-				if err := os.Chdir(tmpDir); err != nil {
-					t.Fatalf("failed to change directory: %v", err)
-				}
-
-				// Create directory structure for relative path
-				templatesDir := filepath.Join(".", "internal", "web", "templates")
-				if err := os.MkdirAll(templatesDir, 0755); err != nil {
-					t.Fatalf("failed to create directories: %v", err)
-				}
-
-				return tmpDir
-			},
-			expectError: false,
-			expectEmpty: false,
-		},
-		{
-			name: "returns error when templates directory not found",
-			setup: func(t *testing.T) string {
-				tmpDir := t.TempDir()
-				if err := os.Chdir(tmpDir); err != nil {
-					t.Fatalf("failed to change directory: %v", err)
-				}
-				return tmpDir
-			},
-			expectError: true,
-			expectEmpty: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tmpDir := tt.setup(t)
-			defer os.RemoveAll(tmpDir)
-
-			dir, err := GetTemplatesDir()
-
-			if tt.expectError {
-				if err == nil {
-					t.Errorf("expected error, got nil")
-				}
-				if !tt.expectEmpty && dir != "" {
-					t.Errorf("expected empty path on error, got: %v", dir)
-				}
-				return
-			}
-
-			if err != nil {
-				t.Errorf("unexpected error: %v", err)
-			}
-
-			if tt.expectEmpty && dir == "" {
-				t.Errorf("expected non-empty path, got empty string")
-			}
-
-			if !tt.expectEmpty && dir == "" {
-				t.Errorf("expected non-empty path, got empty string")
-			}
-		})
-	}
-}
-
 // TestLoadEvolutionData tests the LoadEvolutionData function
 func TestLoadEvolutionData(t *testing.T) {
-	// Save original working directory
-	originalWd, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("failed to get working directory: %v", err)
-	}
-	defer func() {
-		// Restore original working directory
-		if err := os.Chdir(originalWd); err != nil {
-			t.Fatalf("failed to restore working directory: %v", err)
-		}
-	}()
+	t.Cleanup(func() { SetAssetsDir("") })
 
 	tests := []struct {
 		name        string
@@ -133,11 +19,7 @@ func TestLoadEvolutionData(t *testing.T) {
 			name: "loads evolution data successfully",
 			setup: func(t *testing.T) string {
 				tmpDir := t.TempDir()
-				if err := os.Chdir(tmpDir); err != nil {
-					t.Fatalf("failed to change directory: %v", err)
-				}
-
-				dir := filepath.Join("internal", "web", "content")
+				dir := filepath.Join(tmpDir, "content")
 				if err := os.MkdirAll(dir, 0755); err != nil {
 					t.Fatal(err)
 				}
@@ -161,11 +43,7 @@ chapters:
 		{
 			name: "returns error when file missing",
 			setup: func(t *testing.T) string {
-				tmpDir := t.TempDir()
-				if err := os.Chdir(tmpDir); err != nil {
-					t.Fatalf("failed to change directory: %v", err)
-				}
-				return tmpDir
+				return t.TempDir()
 			},
 			expectError: true,
 		},
@@ -173,8 +51,9 @@ chapters:
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tmpDir := tt.setup(t)
-			defer os.RemoveAll(tmpDir)
+			if err := SetAssetsDir(tt.setup(t)); err != nil {
+				t.Fatal(err)
+			}
 
 			data, err := LoadEvolutionData()
 
@@ -0,0 +1,79 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// ManifestFile lists every file the generator owns in the output directory
+const ManifestFile = "site-manifest.json"
+
+// SiteManifest records the generated files of a site so partial runs can merge with earlier builds
+type SiteManifest struct {
+	GeneratedAt string   `json:"generated_at"`
+	Files       []string `json:"files"` // slash-separated paths relative to the output directory
+}
+
+// ReadSiteManifest loads the manifest in dir; a missing manifest yields an empty one
+func ReadSiteManifest(dir string) (SiteManifest, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return SiteManifest{}, nil
+		}
+		return SiteManifest{}, fmt.Errorf("failed to read %s: %w", ManifestFile, err)
+	}
+
+	var manifest SiteManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return SiteManifest{}, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+	return manifest, nil
+}
+
+// WriteSiteManifest saves the manifest in dir
+func WriteSiteManifest(dir string, manifest SiteManifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", ManifestFile, err)
+	}
+	if err := safefile.WriteFile(filepath.Join(dir, ManifestFile), content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ManifestFile, err)
+	}
+	return nil
+}
+
+// MergeSiteManifest combines the files written this run with those owned by the previous manifest.
+// Previously owned files that are no longer on disk under dir are dropped and returned as missing.
+func MergeSiteManifest(dir string, previous SiteManifest, written []string, now time.Time) (SiteManifest, []string) {
+	owned := make(map[string]bool, len(previous.Files)+len(written))
+	for _, file := range written {
+		owned[file] = true
+	}
+
+	var missing []string
+	for _, file := range previous.Files {
+		if owned[file] {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(file))); err != nil {
+			missing = append(missing, file)
+			continue
+		}
+		owned[file] = true
+	}
+
+	merged := SiteManifest{GeneratedAt: now.UTC().Format(time.RFC3339)}
+	for file := range owned {
+		merged.Files = append(merged.Files, file)
+	}
+	sort.Strings(merged.Files)
+	sort.Strings(missing)
+	return merged, missing
+}
@@ -0,0 +1,70 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSiteManifestRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	manifest, err := ReadSiteManifest(dir)
+	if err != nil || len(manifest.Files) != 0 {
+		t.Fatalf("expected empty manifest for a fresh directory, got %+v, %v", manifest, err)
+	}
+
+	want := SiteManifest{GeneratedAt: "2025-01-01T00:00:00Z", Files: []string{"analytics.html", "history/2024-12-01/analytics.html"}}
+	if err := WriteSiteManifest(dir, want); err != nil {
+		t.Fatalf("WriteSiteManifest() error = %v", err)
+	}
+	got, err := ReadSiteManifest(dir)
+	if err != nil {
+		t.Fatalf("ReadSiteManifest() error = %v", err)
+	}
+	if got.GeneratedAt != want.GeneratedAt || strings.Join(got.Files, ",") != strings.Join(want.Files, ",") {
+		t.Errorf("ReadSiteManifest() = %+v, want %+v", got, want)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadSiteManifest(dir); err == nil {
+		t.Error("expected error for a corrupt manifest")
+	}
+}
+
+func TestMergeSiteManifest(t *testing.T) {
+	dir := t.TempDir()
+	// A history page kept from an earlier build
+	kept := filepath.Join(dir, "history", "2024-12-01", "analytics.html")
+	if err := os.MkdirAll(filepath.Dir(kept), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(kept, []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := SiteManifest{Files: []string{
+		"analytics.html",
+		"history/2024-11-01/analytics.html", // deleted since the last build
+		"history/2024-12-01/analytics.html",
+	}}
+	written := []string{"analytics.html", "history/2025-01-01/analytics.html"}
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	merged, missing := MergeSiteManifest(dir, previous, written, now)
+
+	expected := []string{"analytics.html", "history/2024-12-01/analytics.html", "history/2025-01-01/analytics.html"}
+	if strings.Join(merged.Files, ",") != strings.Join(expected, ",") {
+		t.Errorf("merged files = %v, want %v", merged.Files, expected)
+	}
+	if len(missing) != 1 || missing[0] != "history/2024-11-01/analytics.html" {
+		t.Errorf("missing = %v, want the deleted history page", missing)
+	}
+	if merged.GeneratedAt != "2025-01-02T03:04:05Z" {
+		t.Errorf("GeneratedAt = %s", merged.GeneratedAt)
+	}
+}
@@ -0,0 +1,52 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	texttmpl "text/template"
+)
+
+// MarkdownFile is the summary MarkdownRenderer writes into the site root when no Path is set
+const MarkdownFile = "report.md"
+
+// MarkdownRenderer summarizes the latest snapshot as Markdown tables, for pasting into a blog post
+// or committing as WEEKLY.md. It renders the root pass only; history passes are skipped.
+type MarkdownRenderer struct {
+	Path string // output file; defaults to MarkdownFile in the root pass directory
+}
+
+// Render executes the report.md template against the view model
+func (r MarkdownRenderer) Render(ctx context.Context, vm ViewModel, target OutputTarget) error {
+	if !target.IsRoot {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	tmpl, err := texttmpl.New("report.md").Funcs(texttmpl.FuncMap{"cell": markdownCell}).ParseFS(assets, templatePath("report.md"))
+	if err != nil {
+		return fmt.Errorf("failed to parse Markdown template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vm); err != nil {
+		return fmt.Errorf("failed to execute Markdown template: %w", err)
+	}
+	buf.WriteString("\n")
+
+	path := r.Path
+	if path == "" {
+		path = filepath.Join(target.Dir, MarkdownFile)
+	}
+	return writeReport(path, buf.Bytes(), target)
+}
+
+// markdownCell keeps a value on one line of a Markdown table row
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.Join(strings.Fields(value), " ")
+}
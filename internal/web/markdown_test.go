@@ -0,0 +1,83 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestMarkdownCell(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "GitHub", expected: "GitHub"},
+		{input: "a | b", expected: `a \| b`},
+		{input: "two\nlines  here", expected: "two lines here"},
+	}
+
+	for _, tt := range tests {
+		if got := markdownCell(tt.input); got != tt.expected {
+			t.Errorf("markdownCell(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestMarkdownRenderer(t *testing.T) {
+	dir := t.TempDir()
+	weekly := filepath.Join(dir, "WEEKLY.md")
+	service := NewAnalyticsService(dir)
+	service.SetRenderers(MarkdownRenderer{Path: weekly}, MarkdownRenderer{})
+	m := schema.Metrics{
+		TotalArticles:      10,
+		ReadCount:          4,
+		UnreadCount:        6,
+		ReadRate:           40,
+		BySource:           map[string]int{"GitHub": 7, "A | B": 3},
+		BySourceReadStatus: map[string][2]int{"GitHub": {3, 4}, "A | B": {1, 2}},
+		ByYear:             map[string]int{"2025": 10},
+		ByMonth:            map[string]int{"03": 10},
+		ByMonthAndSource:   map[string]map[string][2]int{"03": {"GitHub": {3, 4}, "A | B": {1, 2}}},
+		AIDeltaAnalysis:    "Reading picked up.",
+	}
+
+	if err := service.GenerateFullSite(t.Context(), m, GenConfig{OutputDir: dir}); err != nil {
+		t.Fatalf("GenerateFullSite() error = %v", err)
+	}
+	history := filepath.Join(dir, "history", "2025-03-01")
+	if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: history, PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+	}
+
+	report, err := os.ReadFile(weekly)
+	if err != nil {
+		t.Fatalf("expected the Markdown summary: %v", err)
+	}
+	for _, want := range []string{
+		"# 📚 Personal Reading Analytics",
+		"| Read Rate | 40.0% |",
+		"- **🎯 Top Read Rate Source:**",
+		"Reading picked up.",
+		"| GitHub | 7 | 3 | 4 | 42.9% |",
+		`| A \| B | 3 | 1 | 2 | 33.3% |`,
+		"| Mar | 10 |",
+		"| 2025 | 10 |",
+	} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("expected %q in the Markdown summary:\n%s", want, report)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, MarkdownFile)); err != nil {
+		t.Errorf("expected the default %s in the root pass directory: %v", MarkdownFile, err)
+	}
+	if _, err := os.Stat(filepath.Join(history, MarkdownFile)); err == nil {
+		t.Error("expected history passes to be skipped")
+	}
+	if written := strings.Join(service.WrittenFiles(), ","); !strings.Contains(written, "WEEKLY.md") || !strings.Contains(written, MarkdownFile) {
+		t.Errorf("expected both summaries in written files, got %s", written)
+	}
+}
@@ -0,0 +1,113 @@
+package web
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/locale"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// GeneratedChapterTitle names the chapter generated milestones go into when evolution.yml has none
+const GeneratedChapterTitle = "Reading Milestones"
+
+// GenerateMilestones derives timeline events from a snapshot: each source's first day of tracking
+// from its added date, the months the collection passed each of metrics.MilestoneCounts, and every month
+// that beat the previous record for articles saved. Month events are dated YYYY-MM.
+func GenerateMilestones(m schema.Metrics, loc locale.Locale) []schema.Milestone {
+	var milestones []schema.Milestone
+
+	sources := make([]string, 0, len(m.SourceMetadata))
+	for source := range m.SourceMetadata {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	for _, source := range sources {
+		added := m.SourceMetadata[source].Added
+		if _, err := time.Parse("2006-01-02", added); err != nil {
+			continue
+		}
+		milestones = append(milestones, generatedMilestone(added, fmt.Sprintf("%s Joins the Reading List", source),
+			fmt.Sprintf("%s was added to the providers sheet.", source)))
+	}
+
+	var months []string
+	counts := make(map[string]int)
+	for year, byMonth := range m.ByYearAndMonth {
+		for month, count := range byMonth {
+			months = append(months, year+"-"+month)
+			counts[year+"-"+month] = count
+		}
+	}
+	sort.Strings(months)
+
+	total, record, next := 0, 0, 0
+	for _, month := range months {
+		count := counts[month]
+		total += count
+		for next < len(metrics.MilestoneCounts) && total >= metrics.MilestoneCounts[next] {
+			milestones = append(milestones, generatedMilestone(month, fmt.Sprintf("%s Articles Saved", loc.Int(metrics.MilestoneCounts[next])),
+				fmt.Sprintf("The collection passed %s articles, %s by the end of the month.", loc.Int(metrics.MilestoneCounts[next]), loc.Int(total))))
+			next++
+		}
+		if count > record {
+			if record > 0 {
+				milestones = append(milestones, generatedMilestone(month, fmt.Sprintf("Record Month: %s Articles", loc.Int(count)),
+					fmt.Sprintf("The most articles saved in a month so far, beating the previous record of %s.", loc.Int(record))))
+			}
+			record = count
+		}
+	}
+
+	sort.SliceStable(milestones, func(i, j int) bool { return milestones[i].Date < milestones[j].Date })
+	return milestones
+}
+
+// MergeMilestones adds generated milestones to the chapter whose timeline they fall in: the last
+// chapter starting on or before their date, or the first chapter for earlier ones. Each chapter's
+// timeline is then ordered by date, hand-written milestones first on the same date. Without
+// chapters the milestones get one of their own.
+func MergeMilestones(data schema.EvolutionData, generated []schema.Milestone) schema.EvolutionData {
+	if len(generated) == 0 {
+		return data
+	}
+	if len(data.Chapters) == 0 {
+		data.Chapters = []schema.Chapter{{Title: GeneratedChapterTitle, Intro: "Milestones generated from the reading data."}}
+	}
+
+	chapters := make([]schema.Chapter, len(data.Chapters))
+	copy(chapters, data.Chapters)
+	for _, milestone := range generated {
+		target := 0
+		for c, chapter := range chapters {
+			if start := chapterStart(chapter); start != "" && start <= milestone.Date {
+				target = c
+			}
+		}
+		chapters[target].Timeline = append(append([]schema.Milestone(nil), chapters[target].Timeline...), milestone)
+	}
+	for c := range chapters {
+		timeline := chapters[c].Timeline
+		sort.SliceStable(timeline, func(i, j int) bool { return timeline[i].Date < timeline[j].Date })
+	}
+
+	data.Chapters = chapters
+	return data
+}
+
+// chapterStart returns the earliest hand-written milestone date of a chapter
+func chapterStart(chapter schema.Chapter) string {
+	start := ""
+	for _, milestone := range chapter.Timeline {
+		if !milestone.Generated && milestone.Date != "" && (start == "" || milestone.Date < start) {
+			start = milestone.Date
+		}
+	}
+	return start
+}
+
+func generatedMilestone(date, title, description string) schema.Milestone {
+	return schema.Milestone{Date: date, Title: title, Description: description, DescriptionLines: descriptionLines(description), Generated: true}
+}
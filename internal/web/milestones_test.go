@@ -0,0 +1,73 @@
+package web
+
+import (
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/locale"
+)
+
+func TestGenerateMilestones(t *testing.T) {
+	m := schema.Metrics{
+		SourceMetadata: map[string]schema.SourceMeta{
+			"GitHub": {Added: "2024-03-10"},
+			"Stripe": {Added: "initial"},
+		},
+		ByYearAndMonth: map[string]map[string]int{
+			"2024": {"02": 40, "03": 70, "04": 30},
+			"2025": {"01": 400, "02": 100},
+		},
+	}
+
+	milestones := GenerateMilestones(m, locale.MustParse(locale.Default))
+	expected := []struct{ date, title string }{
+		{"2024-03", "100 Articles Saved"},
+		{"2024-03", "Record Month: 70 Articles"},
+		{"2024-03-10", "GitHub Joins the Reading List"},
+		{"2025-01", "500 Articles Saved"},
+		{"2025-01", "Record Month: 400 Articles"},
+	}
+	if len(milestones) != len(expected) {
+		t.Fatalf("expected %d milestones, got %+v", len(expected), milestones)
+	}
+	for i, want := range expected {
+		if got := milestones[i]; got.Date != want.date || got.Title != want.title || !got.Generated || len(got.DescriptionLines) != 1 {
+			t.Errorf("milestone %d = %+v, expected %s %q", i, got, want.date, want.title)
+		}
+	}
+}
+
+func TestMergeMilestones(t *testing.T) {
+	data := schema.EvolutionData{Chapters: []schema.Chapter{
+		{Title: "Foundation", Timeline: []schema.Milestone{{Date: "2024-02-04", Title: "Begins"}, {Date: "2024-06-12", Title: "Docker"}}},
+		{Title: "Automation", Timeline: []schema.Milestone{{Date: "2025-01-01", Title: "Config"}}},
+	}}
+	generated := []schema.Milestone{
+		{Date: "2023-12", Title: "Early", Generated: true},
+		{Date: "2024-03", Title: "Hundred", Generated: true},
+		{Date: "2025-01-01", Title: "Same day", Generated: true},
+		{Date: "2025-07", Title: "Latest", Generated: true},
+	}
+
+	merged := MergeMilestones(data, generated)
+	titles := func(chapter schema.Chapter) []string {
+		var titles []string
+		for _, milestone := range chapter.Timeline {
+			titles = append(titles, milestone.Title)
+		}
+		return titles
+	}
+	if got := titles(merged.Chapters[0]); len(got) != 4 || got[0] != "Early" || got[1] != "Begins" || got[2] != "Hundred" || got[3] != "Docker" {
+		t.Errorf("expected the first chapter merged by date, got %v", got)
+	}
+	if got := titles(merged.Chapters[1]); len(got) != 3 || got[0] != "Config" || got[1] != "Same day" || got[2] != "Latest" {
+		t.Errorf("expected hand-written milestones first on the same date, got %v", got)
+	}
+	if len(data.Chapters[0].Timeline) != 2 {
+		t.Error("expected the loaded chapters to be left untouched")
+	}
+
+	if merged := MergeMilestones(schema.EvolutionData{}, generated); len(merged.Chapters) != 1 || merged.Chapters[0].Title != GeneratedChapterTitle {
+		t.Errorf("expected a chapter of its own without evolution.yml chapters, got %+v", merged.Chapters)
+	}
+}
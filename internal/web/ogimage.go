@@ -0,0 +1,157 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"path/filepath"
+	"strings"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// OGImageFile is the social preview card written to the site root
+const OGImageFile = "og-image.png"
+
+// Social card size recommended by Open Graph and Twitter large image cards
+const (
+	ogWidth  = 1200
+	ogHeight = 630
+)
+
+var (
+	ogSky      = color.RGBA{3, 105, 161, 255}   // sky-700
+	ogSkyLight = color.RGBA{56, 189, 248, 255}  // sky-400
+	ogPanel    = color.RGBA{248, 250, 252, 255} // slate-50
+	ogText     = color.RGBA{15, 23, 42, 255}    // slate-900
+	ogMuted    = color.RGBA{100, 116, 139, 255} // slate-500
+	ogTrack    = color.RGBA{226, 232, 240, 255} // slate-200
+	ogUnread   = color.RGBA{251, 146, 60, 255}  // orange-400, the charts' unread color
+)
+
+// WriteOGImage renders the snapshot's social preview card to OGImageFile in outputDir
+func (s *AnalyticsService) WriteOGImage(outputDir string, m schema.Metrics) error {
+	content, err := RenderOGImage(m, s.branding)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(outputDir, OGImageFile)
+	if err := safefile.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	s.record(path)
+	return nil
+}
+
+// RenderOGImage draws the read rate, totals and update date of a snapshot as a PNG social card.
+// Text uses a built-in block font, so no font files are needed at build time.
+func RenderOGImage(m schema.Metrics, b Branding) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, ogWidth, ogHeight))
+	fill(img, img.Bounds(), ogSkyLight)
+	fill(img, image.Rect(0, 0, ogWidth, 24), ogSky)
+	fill(img, image.Rect(60, 72, ogWidth-60, ogHeight-60), ogPanel)
+
+	drawText(img, 110, 122, 6, ogSky, strings.ToUpper(b.plainTitle()))
+	rate := fmt.Sprintf("%.1f%%", m.ReadRate)
+	drawText(img, 110, 200, 20, ogText, rate)
+	drawText(img, 110+textWidth(rate, 20)+40, 284, 8, ogMuted, "READ")
+
+	// Read rate bar: read in sky, the backlog in orange
+	bar := image.Rect(110, 380, ogWidth-110, 412)
+	fill(img, bar, ogTrack)
+	if m.TotalArticles > 0 {
+		readWidth := bar.Dx() * m.ReadCount / m.TotalArticles
+		fill(img, image.Rect(bar.Min.X, bar.Min.Y, bar.Min.X+readWidth, bar.Max.Y), ogSky)
+		fill(img, image.Rect(bar.Min.X+readWidth, bar.Min.Y, bar.Max.X, bar.Max.Y), ogUnread)
+	}
+
+	drawText(img, 110, 440, 4, ogText, fmt.Sprintf("%d ARTICLES - %d READ - %d UNREAD", m.TotalArticles, m.ReadCount, m.UnreadCount))
+	if !m.LastUpdated.IsZero() {
+		drawText(img, 110, 500, 4, ogMuted, "UPDATED "+strings.ToUpper(b.Locale.Date(m.LastUpdated.Local())))
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", OGImageFile, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func fill(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+// drawText draws s from its top-left corner with each font cell scale pixels wide; characters
+// outside ogGlyphs are left blank
+func drawText(img *image.RGBA, x, y, scale int, c color.Color, s string) {
+	for _, r := range s {
+		if glyph, ok := ogGlyphs[r]; ok {
+			for row, line := range glyph {
+				for col, cell := range line {
+					if cell == '#' {
+						fill(img, image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale), c)
+					}
+				}
+			}
+		}
+		x += ogGlyphAdvance * scale
+	}
+}
+
+func textWidth(s string, scale int) int {
+	return len([]rune(s))*ogGlyphAdvance*scale - scale
+}
+
+// ogGlyphAdvance is the width of a glyph plus the gap after it, in font cells
+const ogGlyphAdvance = 6
+
+// ogGlyphs is a 5x7 block font covering the characters the social card prints
+var ogGlyphs = map[rune][7]string{
+	' ': {"     ", "     ", "     ", "     ", "     ", "     ", "     "},
+	'0': {" ### ", "#   #", "#  ##", "# # #", "##  #", "#   #", " ### "},
+	'1': {"  #  ", " ##  ", "  #  ", "  #  ", "  #  ", "  #  ", " ### "},
+	'2': {" ### ", "#   #", "    #", "   # ", "  #  ", " #   ", "#####"},
+	'3': {"#####", "   # ", "  #  ", "   # ", "    #", "#   #", " ### "},
+	'4': {"   # ", "  ## ", " # # ", "#  # ", "#####", "   # ", "   # "},
+	'5': {"#####", "#    ", "#### ", "    #", "    #", "#   #", " ### "},
+	'6': {"  ## ", " #   ", "#    ", "#### ", "#   #", "#   #", " ### "},
+	'7': {"#####", "    #", "   # ", "  #  ", " #   ", " #   ", " #   "},
+	'8': {" ### ", "#   #", "#   #", " ### ", "#   #", "#   #", " ### "},
+	'9': {" ### ", "#   #", "#   #", " ####", "    #", "   # ", " ##  "},
+	'A': {" ### ", "#   #", "#   #", "#####", "#   #", "#   #", "#   #"},
+	'B': {"#### ", "#   #", "#   #", "#### ", "#   #", "#   #", "#### "},
+	'C': {" ### ", "#   #", "#    ", "#    ", "#    ", "#   #", " ### "},
+	'D': {"#### ", "#   #", "#   #", "#   #", "#   #", "#   #", "#### "},
+	'E': {"#####", "#    ", "#    ", "#### ", "#    ", "#    ", "#####"},
+	'F': {"#####", "#    ", "#    ", "#### ", "#    ", "#    ", "#    "},
+	'G': {" ### ", "#   #", "#    ", "# ###", "#   #", "#   #", " ####"},
+	'H': {"#   #", "#   #", "#   #", "#####", "#   #", "#   #", "#   #"},
+	'I': {" ### ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", " ### "},
+	'J': {"  ###", "   # ", "   # ", "   # ", "   # ", "#  # ", " ##  "},
+	'K': {"#   #", "#  # ", "# #  ", "##   ", "# #  ", "#  # ", "#   #"},
+	'L': {"#    ", "#    ", "#    ", "#    ", "#    ", "#    ", "#####"},
+	'M': {"#   #", "## ##", "# # #", "# # #", "#   #", "#   #", "#   #"},
+	'N': {"#   #", "#   #", "##  #", "# # #", "#  ##", "#   #", "#   #"},
+	'O': {" ### ", "#   #", "#   #", "#   #", "#   #", "#   #", " ### "},
+	'P': {"#### ", "#   #", "#   #", "#### ", "#    ", "#    ", "#    "},
+	'Q': {" ### ", "#   #", "#   #", "#   #", "# # #", "#  # ", " ## #"},
+	'R': {"#### ", "#   #", "#   #", "#### ", "# #  ", "#  # ", "#   #"},
+	'S': {" ####", "#    ", "#    ", " ### ", "    #", "    #", "#### "},
+	'T': {"#####", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  "},
+	'U': {"#   #", "#   #", "#   #", "#   #", "#   #", "#   #", " ### "},
+	'V': {"#   #", "#   #", "#   #", "#   #", "#   #", " # # ", "  #  "},
+	'W': {"#   #", "#   #", "#   #", "# # #", "# # #", "# # #", " # # "},
+	'X': {"#   #", "#   #", " # # ", "  #  ", " # # ", "#   #", "#   #"},
+	'Y': {"#   #", "#   #", " # # ", "  #  ", "  #  ", "  #  ", "  #  "},
+	'Z': {"#####", "    #", "   # ", "  #  ", " #   ", "#    ", "#####"},
+	'%': {"##   ", "##  #", "   # ", "  #  ", " #   ", "#  ##", "   ##"},
+	'.': {"     ", "     ", "     ", "     ", "     ", " ##  ", " ##  "},
+	',': {"     ", "     ", "     ", "     ", " ##  ", "  #  ", " #   "},
+	'-': {"     ", "     ", "     ", "#####", "     ", "     ", "     "},
+	':': {"     ", " ##  ", " ##  ", "     ", " ##  ", " ##  ", "     "},
+	'/': {"     ", "    #", "   # ", "  #  ", " #   ", "#    ", "     "},
+}
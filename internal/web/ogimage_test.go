@@ -0,0 +1,76 @@
+package web
+
+import (
+	"bytes"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestWriteOGImage(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics schema.Metrics
+	}{
+		{name: "empty snapshot", metrics: schema.Metrics{}},
+		{name: "full read rate", metrics: schema.Metrics{TotalArticles: 12, ReadCount: 12, ReadRate: 100, LastUpdated: time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			service := NewAnalyticsService(dir)
+			if err := service.WriteOGImage(dir, tt.metrics); err != nil {
+				t.Fatalf("WriteOGImage() error = %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(dir, OGImageFile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			img, err := png.Decode(bytes.NewReader(content))
+			if err != nil {
+				t.Fatalf("expected a PNG: %v", err)
+			}
+			if bounds := img.Bounds(); bounds.Dx() != 1200 || bounds.Dy() != 630 {
+				t.Errorf("expected a 1200x630 card, got %v", bounds)
+			}
+			if files := service.WrittenFiles(); len(files) != 1 || files[0] != OGImageFile {
+				t.Errorf("expected the image in the site manifest, got %v", files)
+			}
+		})
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	if got := textWidth("52.8%", 20); got != 5*6*20-20 {
+		t.Errorf("textWidth() = %d", got)
+	}
+	for _, r := range strings.ToUpper(DefaultBranding().plainTitle() + " 0123456789 UPDATED, ARTICLES - READ % .") {
+		if _, ok := ogGlyphs[r]; !ok {
+			t.Errorf("expected a glyph for %q", r)
+		}
+	}
+}
+
+func TestOGImageMetaTags(t *testing.T) {
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	if err := service.GenerateAnalyticsOnly(t.Context(), schema.Metrics{}, GenConfig{OutputDir: dir, PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "analytics.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`<meta property="og:image" content="https://`, `/` + OGImageFile + `">`, `<meta property="twitter:image"`} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("expected %q in the page head", want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package web
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// PermalinkDir holds the per-article permalink pages, relative to the site root
+const PermalinkDir = "read"
+
+// PermalinkEntry lists one permalink page in PermalinkDir/index.json
+type PermalinkEntry struct {
+	Path   string `json:"path"` // relative to PermalinkDir
+	Title  string `json:"title"`
+	Date   string `json:"date"`
+	Source string `json:"source"`
+	Link   string `json:"link"`
+}
+
+// PermalinkSlug names an article's permalink after its date and a hash of its link, so the URL
+// survives the article being retitled or moved to another source
+func PermalinkSlug(article schema.ArticleMeta) string {
+	sum := sha1.Sum([]byte(article.Link))
+	return fmt.Sprintf("%s-%x", article.Date, sum[:4])
+}
+
+// GeneratePermalinks writes a permalink.html page and a JSON stub per read article into
+// config.OutputDir, plus an index.json of every page, newest first. It returns the pages written, and
+// stops with ctx's error once ctx is done.
+func (s *AnalyticsService) GeneratePermalinks(ctx context.Context, m schema.Metrics, articles []schema.ArticleMeta, config GenConfig) (int, error) {
+	vm, err := s.prepareViewModel(m, config)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare view model: %w", err)
+	}
+
+	tmpl, err := template.ParseFS(assets, templatePath("base.html"), templatePath("permalink.html"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse permalink templates: %w", err)
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create permalink directory: %w", err)
+	}
+
+	var read []schema.ArticleMeta
+	for _, article := range articles {
+		if article.Read && article.Link != "" {
+			read = append(read, article)
+		}
+	}
+	sort.SliceStable(read, func(i, j int) bool { return read[i].Date > read[j].Date })
+
+	seen := make(map[string]bool)
+	index := []PermalinkEntry{}
+	for i := range read {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		article := read[i]
+		slug := PermalinkSlug(article)
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+
+		path := filepath.Join(config.OutputDir, slug+".html")
+		f, err := safefile.Create(path, 0644)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		vm.PageTitle = article.Title
+		vm.PageFile = slug + ".html"
+		vm.Article = &article
+		err = tmpl.ExecuteTemplate(f, "permalink.html", vm)
+		if err != nil {
+			f.Close()
+			return 0, fmt.Errorf("failed to execute permalink template for %s: %w", article.Link, err)
+		}
+		if err := f.Commit(); err != nil {
+			return 0, err
+		}
+		s.record(path)
+
+		if err := s.writeJSON(filepath.Join(config.OutputDir, slug+".json"), article); err != nil {
+			return 0, err
+		}
+		index = append(index, PermalinkEntry{Path: slug + ".html", Title: article.Title, Date: article.Date, Source: article.Category, Link: article.Link})
+	}
+
+	if err := s.writeJSON(filepath.Join(config.OutputDir, "index.json"), index); err != nil {
+		return 0, err
+	}
+	return len(index), nil
+}
@@ -0,0 +1,79 @@
+package web
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestPermalinkSlug(t *testing.T) {
+	article := schema.ArticleMeta{Date: "2024-03-01", Title: "Before", Link: "https://example.com/a"}
+	slug := PermalinkSlug(article)
+	if !strings.HasPrefix(slug, "2024-03-01-") || len(slug) != len("2024-03-01-")+8 {
+		t.Errorf("unexpected slug %q", slug)
+	}
+
+	article.Title, article.Category = "After", "Other"
+	if PermalinkSlug(article) != slug {
+		t.Error("expected the slug to survive a retitle")
+	}
+	article.Link = "https://example.com/b"
+	if PermalinkSlug(article) == slug {
+		t.Error("expected another link to get another slug")
+	}
+}
+
+func TestGeneratePermalinks(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, PermalinkDir)
+	service := NewAnalyticsService(root)
+	articles := []schema.ArticleMeta{
+		{Date: "2024-01-01", Title: "Older <read>", Link: "https://example.com/old", Category: "GitHub", Read: true,
+			Notes: "Worth a reread", Highlights: []string{"A key sentence"}, ArchiveURL: "https://web.archive.org/web/1/https://example.com/old"},
+		{Date: "2024-02-01", Title: "Newer", Link: "https://example.com/new", Category: "Stripe", Read: true},
+		{Date: "2024-02-01", Title: "Newer", Link: "https://example.com/new", Category: "Stripe", Read: true},
+		{Date: "2024-03-01", Title: "Unread", Link: "https://example.com/unread", Category: "GitHub"},
+	}
+
+	count, err := service.GeneratePermalinks(t.Context(), schema.Metrics{TotalArticles: 4}, articles, GenConfig{OutputDir: dir, BaseURL: "../"})
+	if err != nil {
+		t.Fatalf("GeneratePermalinks() error = %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 pages for the deduplicated read articles, got %d", count)
+	}
+
+	var index []PermalinkEntry
+	data, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	if err != nil || json.Unmarshal(data, &index) != nil {
+		t.Fatalf("expected a valid index.json, got %s (%v)", data, err)
+	}
+	if len(index) != 2 || index[0].Title != "Newer" || index[1].Path != PermalinkSlug(articles[0])+".html" {
+		t.Errorf("expected pages newest first, got %+v", index)
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, index[1].Path))
+	if err != nil {
+		t.Fatalf("expected permalink page: %v", err)
+	}
+	for _, want := range []string{"Older &lt;read&gt;", "Worth a reread", "A key sentence", `href="https://example.com/old"`, `href="../analytics.html"`, `href="https://web.archive.org/web/1/https://example.com/old"`} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("expected %q in the permalink page", want)
+		}
+	}
+
+	var stub schema.ArticleMeta
+	data, err = os.ReadFile(filepath.Join(dir, PermalinkSlug(articles[0])+".json"))
+	if err != nil || json.Unmarshal(data, &stub) != nil || stub.Notes != "Worth a reread" {
+		t.Errorf("expected the JSON stub to round-trip, got %+v (%v)", stub, err)
+	}
+
+	written := strings.Join(service.WrittenFiles(), ",")
+	if !strings.Contains(written, "read/index.json") || !strings.Contains(written, "read/"+index[0].Path) {
+		t.Errorf("expected permalink files in written files, got %s", written)
+	}
+}
@@ -0,0 +1,21 @@
+package web
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/forecast"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// WriteReadingPlan publishes the plan's daily reading blocks as forecast.CalendarFile in outputDir,
+// for calendar apps to import or subscribe to
+func (s *AnalyticsService) WriteReadingPlan(outputDir string, plan forecast.Plan, stamped time.Time) error {
+	path := filepath.Join(outputDir, forecast.CalendarFile)
+	if err := safefile.WriteFile(path, forecast.ICS(plan, stamped), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	s.record(path)
+	return nil
+}
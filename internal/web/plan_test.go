@@ -0,0 +1,33 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/forecast"
+)
+
+func TestWriteReadingPlan(t *testing.T) {
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	plan := forecast.NewPlan(schema.Metrics{UnreadCount: 6}, forecast.Options{}, time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC))
+
+	if err := service.WriteReadingPlan(dir, plan, time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("WriteReadingPlan() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, forecast.CalendarFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(content), "BEGIN:VEVENT") != 2 {
+		t.Errorf("expected 2 reading blocks, got:\n%s", content)
+	}
+	if files := service.WrittenFiles(); len(files) != 1 || files[0] != forecast.CalendarFile {
+		t.Errorf("expected the calendar in the site manifest, got %v", files)
+	}
+}
@@ -4,9 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"math"
 	"sort"
+	"strings"
+	"time"
 
 	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/locale"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
 )
 
 var shortMonthNames = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
@@ -51,6 +56,43 @@ func PrepareReadUnreadByYear(metrics schema.Metrics) template.JS {
 	return template.JS(jsonData)
 }
 
+// PrepareReadUnreadByFiscalYear creates JSON data for the read/unread chart's fiscal year view,
+// latest year first. Empty when the snapshot only has calendar years.
+func PrepareReadUnreadByFiscalYear(metrics schema.Metrics) template.JS {
+	if len(metrics.ByFiscalYear) == 0 {
+		return ""
+	}
+
+	years := make([]string, 0, len(metrics.ByFiscalYear))
+	for year := range metrics.ByFiscalYear {
+		years = append(years, year)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(years)))
+
+	readData := make([]int, len(years))
+	unreadData := make([]int, len(years))
+	for i, year := range years {
+		readData[i] = metrics.ByFiscalYear[year][0]
+		unreadData[i] = metrics.ByFiscalYear[year][1]
+	}
+
+	data := map[string]interface{}{
+		"labels":     years,
+		"readData":   readData,
+		"unreadData": unreadData,
+	}
+	jsonData, _ := json.Marshal(data)
+	return template.JS(jsonData)
+}
+
+// FiscalYearSpan names the months a fiscal year starting in startMonth covers, such as "Sep–Aug"
+func FiscalYearSpan(startMonth int) string {
+	if startMonth <= 1 || startMonth > 12 {
+		return ""
+	}
+	return shortMonthNames[startMonth-1] + "–" + shortMonthNames[startMonth-2]
+}
+
 // PrepareReadUnreadByMonth creates JSON data for read/unread monthly breakdown chart
 func PrepareReadUnreadByMonth(metrics schema.Metrics) template.JS {
 	readByMonthArray := make([]int, 12)
@@ -80,6 +122,64 @@ func PrepareReadUnreadByMonth(metrics schema.Metrics) template.JS {
 	return template.JS(jsonData)
 }
 
+// PrepareMonthTimeline creates JSON data for the monthly timeline: articles saved in every month from
+// the first to the last with articles, followed by one dataset per MovingAverageMonths window
+// trending through them. Empty for snapshots without articles.
+func PrepareMonthTimeline(m schema.Metrics) template.JS {
+	var first, last time.Time
+	for year, months := range m.ByYearAndMonth {
+		for month := range months {
+			date, err := time.Parse("2006-01", year+"-"+month)
+			if err != nil {
+				continue
+			}
+			if first.IsZero() || date.Before(first) {
+				first = date
+			}
+			if date.After(last) {
+				last = date
+			}
+		}
+	}
+	if first.IsZero() {
+		return ""
+	}
+
+	var labels []string
+	var counts []int
+	for date := first; !date.After(last); date = date.AddDate(0, 1, 0) {
+		labels = append(labels, date.Format("2006-01"))
+		counts = append(counts, m.ByYearAndMonth[date.Format("2006")][date.Format("01")])
+	}
+
+	datasets := []ChartDataset{{Label: "Articles", Data: counts}}
+	for _, window := range MovingAverageMonths {
+		datasets = append(datasets, ChartDataset{Label: fmt.Sprintf("%d-Month Average", window), Data: movingAverage(counts, window)})
+	}
+
+	data := map[string]interface{}{
+		"labels":   labels,
+		"datasets": datasets,
+	}
+	jsonData, _ := json.Marshal(data)
+	return template.JS(jsonData)
+}
+
+// movingAverage returns the trailing average of each value over window values, rounded to one
+// decimal place. The first values average the fewer values there are so far.
+func movingAverage(values []int, window int) []float64 {
+	averages := make([]float64, len(values))
+	sum := 0
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+		averages[i] = math.Round(float64(sum)/float64(min(i+1, window))*10) / 10
+	}
+	return averages
+}
+
 // PrepareReadUnreadBySource creates JSON data for read/unread by source chart
 func PrepareReadUnreadBySource(sources []schema.SourceInfo) template.JS {
 	readUnreadBySourceLabels := make([]string, 0)
@@ -100,6 +200,231 @@ func PrepareReadUnreadBySource(sources []schema.SourceInfo) template.JS {
 	return template.JS(jsonData)
 }
 
+// PrepareTopDomains lists the TopDomainsCount link domains with the most articles, with their
+// share of all articles that have a link
+func PrepareTopDomains(m schema.Metrics) []schema.DomainInfo {
+	linked := 0
+	for _, status := range m.ByDomain {
+		linked += status[0] + status[1]
+	}
+
+	var domains []schema.DomainInfo
+	for _, d := range metrics.TopDomains(m.ByDomain, TopDomainsCount) {
+		info := schema.DomainInfo{Domain: d.Domain, Count: d.Total(), Read: d.Read, Unread: d.Unread}
+		if linked > 0 {
+			info.SharePct = float64(d.Total()) / float64(linked) * 100
+		}
+		domains = append(domains, info)
+	}
+	return domains
+}
+
+// PrepareAuthors ranks the Substack publications for the authors leaderboard
+func PrepareAuthors(m schema.Metrics) []schema.AuthorInfo {
+	if m.Substack == nil {
+		return nil
+	}
+
+	var authors []schema.AuthorInfo
+	for i, a := range metrics.RankAuthors(m.Substack.ByAuthor) {
+		info := schema.AuthorInfo{Rank: i + 1, Author: a.Author, Count: a.Total(), Read: a.Read, Unread: a.Unread}
+		if info.Count > 0 {
+			info.ReadPct = float64(a.Read) / float64(info.Count) * 100
+		}
+		authors = append(authors, info)
+	}
+	return authors
+}
+
+// PrepareCategories lists the categories with the sources feeding each, both by article count
+// descending then name
+func PrepareCategories(m schema.Metrics) []schema.CategoryInfo {
+	var categories []schema.CategoryInfo
+	for category, bySource := range m.ByCategoryAndSource {
+		info := schema.CategoryInfo{Category: category}
+		for source, status := range bySource {
+			info.Sources = append(info.Sources, schema.CategorySource{Source: source, Count: status[0] + status[1], Read: status[0], Unread: status[1]})
+			info.Read += status[0]
+			info.Unread += status[1]
+		}
+		info.Count = info.Read + info.Unread
+		if info.Count == 0 {
+			continue
+		}
+		info.ReadPct = float64(info.Read) / float64(info.Count) * 100
+		for i := range info.Sources {
+			info.Sources[i].SharePct = float64(info.Sources[i].Count) / float64(info.Count) * 100
+		}
+		sort.Slice(info.Sources, func(i, j int) bool {
+			if info.Sources[i].Count != info.Sources[j].Count {
+				return info.Sources[i].Count > info.Sources[j].Count
+			}
+			return info.Sources[i].Source < info.Sources[j].Source
+		})
+		categories = append(categories, info)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Count != categories[j].Count {
+			return categories[i].Count > categories[j].Count
+		}
+		return categories[i].Category < categories[j].Category
+	})
+	return categories
+}
+
+// PrepareCategoryChart creates JSON data for the topics chart: one stacked dataset per source,
+// in its brand color, counting its articles in each category. Empty without categories.
+func PrepareCategoryChart(m schema.Metrics, categories []schema.CategoryInfo) template.JS {
+	if len(categories) == 0 {
+		return ""
+	}
+
+	labels := make([]string, len(categories))
+	totals := make(map[string]int)
+	for i, category := range categories {
+		labels[i] = category.Category
+		for _, source := range category.Sources {
+			totals[source.Source] += source.Count
+		}
+	}
+	sources := make([]string, 0, len(totals))
+	for source := range totals {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if totals[sources[i]] != totals[sources[j]] {
+			return totals[sources[i]] > totals[sources[j]]
+		}
+		return sources[i] < sources[j]
+	})
+
+	datasets := make([]ChartDataset, 0, len(sources))
+	for _, source := range sources {
+		counts := make([]int, len(categories))
+		for i, category := range categories {
+			status := m.ByCategoryAndSource[category.Category][source]
+			counts[i] = status[0] + status[1]
+		}
+		datasets = append(datasets, ChartDataset{Label: source, Data: counts, BackgroundColor: SourceColor(source, m.SourceMetadata[source].Color)})
+	}
+
+	data := map[string]interface{}{
+		"labels":   labels,
+		"datasets": datasets,
+	}
+	jsonData, _ := json.Marshal(data)
+	return template.JS(jsonData)
+}
+
+// PrepareMediaTypes lists the snapshot's media types with their read status, articles first;
+// nothing is listed when only one media type is present
+func PrepareMediaTypes(m schema.Metrics) []schema.MediaTypeInfo {
+	if len(m.ByMediaType) < 2 {
+		return nil
+	}
+
+	var mediaTypes []schema.MediaTypeInfo
+	for _, name := range metrics.MediaTypeNames(m.ByMediaType) {
+		status := m.ByMediaType[name]
+		info := schema.MediaTypeInfo{Type: name, Count: status[0] + status[1], Read: status[0], Unread: status[1]}
+		if info.Count > 0 {
+			info.ReadPct = float64(info.Read) / float64(info.Count) * 100
+		}
+		mediaTypes = append(mediaTypes, info)
+	}
+	return mediaTypes
+}
+
+// FormatRollingWindow summarizes a rolling window as saves, reads and backlog change, such as
+// "12 saved · 9 read · backlog +3". Reads and backlog change are left out without a baseline snapshot.
+func FormatRollingWindow(window schema.RollingWindow) string {
+	summary := fmt.Sprintf("%d saved", window.Added)
+	if window.Baseline == "" {
+		return summary
+	}
+	return fmt.Sprintf("%s · %d read · backlog %+d", summary, window.Read, window.BacklogChange)
+}
+
+// FormatDelta renders the change of a key metric as an arrow and its size, such as "▲1.4" or "▼3",
+// with the trend it shows. A change that rounds to zero is "±0" and flat.
+func FormatDelta(change float64, places int, loc locale.Locale) (delta, trend string) {
+	size := loc.Decimal(math.Abs(change), places)
+	if size == loc.Decimal(0, places) {
+		return "±0", "flat"
+	}
+	if change > 0 {
+		return "▲" + size, "up"
+	}
+	return "▼" + size, "down"
+}
+
+// AnnotateKeyMetrics adds the change since the previous snapshot to the headline key metrics, matched
+// by title; the rolling windows are left without one
+func AnnotateKeyMetrics(keyMetrics []schema.KeyMetric, change metrics.Comparison, loc locale.Locale) {
+	changes := map[string]struct {
+		value  float64
+		places int
+	}{
+		"Total Articles": {float64(change.TotalArticles), 0},
+		"Read Rate":      {change.ReadRate, 1},
+		"Read":           {float64(change.ReadCount), 0},
+		"Unread":         {float64(change.UnreadCount), 0},
+		"Avg/Month":      {change.AvgArticlesPerMonth, 0},
+	}
+	for i, metric := range keyMetrics {
+		if c, ok := changes[metric.Title]; ok {
+			keyMetrics[i].Delta, keyMetrics[i].Trend = FormatDelta(c.value, c.places, loc)
+		}
+	}
+}
+
+// PrepareWeekdayChart creates JSON data for the weekday chart, Monday to Sunday. Empty for snapshots
+// without weekday aggregates.
+func PrepareWeekdayChart(m schema.Metrics) template.JS {
+	if len(m.ByWeekday) == 0 {
+		return ""
+	}
+	return preparePeriodSeries(metrics.Weekdays, m.ByWeekday)
+}
+
+// PrepareWeekdayPattern summarizes when articles are saved and how many of them get read, weekends
+// against weekdays. Nil for snapshots without weekday aggregates.
+func PrepareWeekdayPattern(m schema.Metrics) *schema.WeekdayPattern {
+	if len(m.ByWeekday) == 0 {
+		return nil
+	}
+
+	pattern := &schema.WeekdayPattern{}
+	var weekend, weekday [2]int
+	busiest := -1
+	for _, day := range metrics.Weekdays {
+		status := m.ByWeekday[day]
+		if count := status[0] + status[1]; count > busiest {
+			busiest = count
+			pattern.BusiestDay = day
+		}
+		totals := &weekday
+		if day == "Sat" || day == "Sun" {
+			totals = &weekend
+		}
+		totals[0] += status[0]
+		totals[1] += status[1]
+	}
+
+	readPct := func(status [2]int) float64 {
+		if total := status[0] + status[1]; total > 0 {
+			return float64(status[0]) / float64(total) * 100
+		}
+		return 0
+	}
+	if total := weekend[0] + weekend[1] + weekday[0] + weekday[1]; total > 0 {
+		pattern.WeekendPct = float64(weekend[0]+weekend[1]) / float64(total) * 100
+	}
+	pattern.WeekendReadPct = readPct(weekend)
+	pattern.WeekdayReadPct = readPct(weekday)
+	return pattern
+}
+
 // PrepareUnreadArticleAgeDistribution creates JSON data for unread articles by age chart
 func PrepareUnreadArticleAgeDistribution(metrics schema.Metrics) template.JS {
 	// Define age bucket labels in display order
@@ -152,3 +477,245 @@ func PrepareUnreadByYear(metrics schema.Metrics) template.JS {
 	jsonData, _ := json.Marshal(data)
 	return template.JS(jsonData)
 }
+
+// PrepareConsumption creates JSON data for the consumption chart: items saved and hours watched or
+// listened per month over the last ConsumptionMonths months with either. Empty without durations.
+func PrepareConsumption(m schema.Metrics) template.JS {
+	if m.Consumption == nil {
+		return ""
+	}
+
+	monthSet := make(map[string]bool)
+	for month := range m.Consumption.MinutesByMonth {
+		monthSet[month] = true
+	}
+	for year, months := range m.ByYearAndMonth {
+		for month := range months {
+			monthSet[year+"-"+month] = true
+		}
+	}
+	months := make([]string, 0, len(monthSet))
+	for month := range monthSet {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+	if len(months) > ConsumptionMonths {
+		months = months[len(months)-ConsumptionMonths:]
+	}
+
+	items := make([]int, len(months))
+	hours := make([]float64, len(months))
+	for i, month := range months {
+		year, monthOfYear, _ := strings.Cut(month, "-")
+		items[i] = m.ByYearAndMonth[year][monthOfYear]
+		hours[i] = math.Round(float64(m.Consumption.MinutesByMonth[month])/60*10) / 10
+	}
+
+	data := map[string]interface{}{
+		"labels": months,
+		"items":  items,
+		"hours":  hours,
+	}
+	jsonData, _ := json.Marshal(data)
+	return template.JS(jsonData)
+}
+
+// PrepareQuarterTrend creates JSON data for the quarterly trend chart, every quarter from the
+// first to the last with articles. Empty for snapshots without quarterly aggregates.
+func PrepareQuarterTrend(m schema.Metrics) template.JS {
+	if len(m.ByQuarter) == 0 {
+		return ""
+	}
+
+	quarters := make([]string, 0, len(m.ByQuarter))
+	for quarter := range m.ByQuarter {
+		quarters = append(quarters, quarter)
+	}
+	sort.Strings(quarters)
+
+	var firstYear, firstQuarter, lastYear, lastQuarter int
+	if _, err := fmt.Sscanf(quarters[0], "%d-Q%d", &firstYear, &firstQuarter); err != nil {
+		return ""
+	}
+	if _, err := fmt.Sscanf(quarters[len(quarters)-1], "%d-Q%d", &lastYear, &lastQuarter); err != nil {
+		return ""
+	}
+
+	labels := make([]string, 0)
+	for year, quarter := firstYear, firstQuarter; year < lastYear || (year == lastYear && quarter <= lastQuarter); {
+		labels = append(labels, fmt.Sprintf("%d-Q%d", year, quarter))
+		if quarter++; quarter > 4 {
+			year, quarter = year+1, 1
+		}
+	}
+	return preparePeriodSeries(labels, m.ByQuarter)
+}
+
+// PrepareWeeklyTrend creates JSON data for the weekly cadence chart: the last WeeklyTrendWeeks ISO
+// weeks up to the latest one with articles, weeks without any included. Empty for snapshots without
+// ISO week aggregates.
+func PrepareWeeklyTrend(m schema.Metrics) template.JS {
+	var latest time.Time
+	for week := range m.ByISOWeek {
+		if start, err := metrics.ISOWeekStart(week); err == nil && start.After(latest) {
+			latest = start
+		}
+	}
+	if latest.IsZero() {
+		return ""
+	}
+
+	labels := make([]string, WeeklyTrendWeeks)
+	for i := range labels {
+		labels[i] = metrics.ISOWeekOf(latest.AddDate(0, 0, -7*(WeeklyTrendWeeks-1-i)))
+	}
+	return preparePeriodSeries(labels, m.ByISOWeek)
+}
+
+// preparePeriodSeries pairs period labels with their read and unread counts, zero for missing periods
+func preparePeriodSeries(labels []string, counts map[string][2]int) template.JS {
+	readData := make([]int, len(labels))
+	unreadData := make([]int, len(labels))
+	for i, label := range labels {
+		readData[i] = counts[label][0]
+		unreadData[i] = counts[label][1]
+	}
+
+	data := map[string]interface{}{
+		"labels":     labels,
+		"readData":   readData,
+		"unreadData": unreadData,
+	}
+	jsonData, _ := json.Marshal(data)
+	return template.JS(jsonData)
+}
+
+// PrepareProviderTimeline creates JSON data for the provider timeline chart, with removals negated
+// so they plot below the axis. Empty without provider history.
+func PrepareProviderTimeline(timeline []schema.ProviderTimelinePoint) template.JS {
+	if len(timeline) == 0 {
+		return ""
+	}
+
+	labels := make([]string, len(timeline))
+	added := make([]int, len(timeline))
+	removed := make([]int, len(timeline))
+	totals := make([]int, len(timeline))
+	for i, point := range timeline {
+		labels[i] = point.Month
+		added[i] = point.Added
+		removed[i] = -point.Removed
+		totals[i] = point.Total
+	}
+
+	data := map[string]interface{}{
+		"labels":  labels,
+		"added":   added,
+		"removed": removed,
+		"totals":  totals,
+	}
+	jsonData, _ := json.Marshal(data)
+	return template.JS(jsonData)
+}
+
+// PrepareBacklogFlow creates JSON data for the backlog flow chart: articles added and read per month,
+// with the net backlog change. Months after reportDate's are dropped so archived reports only show
+// their own past. Empty without at least one month of flow.
+func PrepareBacklogFlow(flow []schema.BacklogFlowPoint, reportDate string) template.JS {
+	var labels []string
+	var added, read, net []int
+	for _, point := range flow {
+		if reportDate != "" && point.Month > reportDate {
+			continue
+		}
+		labels = append(labels, point.Month)
+		added = append(added, point.Added)
+		read = append(read, point.Read)
+		net = append(net, point.Net)
+	}
+	if len(labels) == 0 {
+		return ""
+	}
+
+	data := map[string]interface{}{
+		"labels":    labels,
+		"addedData": added,
+		"readData":  read,
+		"netData":   net,
+	}
+	jsonData, _ := json.Marshal(data)
+	return template.JS(jsonData)
+}
+
+// PrepareSourceReadRates creates JSON data for the read rate by source over time chart: one line per
+// source for the SourceReadRateSources largest sources of the snapshot, with null where a snapshot
+// predates the source. Points after reportDate are dropped; empty with fewer than two snapshots.
+func PrepareSourceReadRates(points []schema.SourceReadRatePoint, m schema.Metrics, reportDate string) template.JS {
+	var shown []schema.SourceReadRatePoint
+	for _, point := range points {
+		if reportDate == "" || point.Date <= reportDate {
+			shown = append(shown, point)
+		}
+	}
+	if len(shown) < 2 || len(m.BySource) == 0 {
+		return ""
+	}
+
+	sources := make([]string, 0, len(m.BySource))
+	for source := range m.BySource {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if m.BySource[sources[i]] != m.BySource[sources[j]] {
+			return m.BySource[sources[i]] > m.BySource[sources[j]]
+		}
+		return sources[i] < sources[j]
+	})
+	if len(sources) > SourceReadRateSources {
+		sources = sources[:SourceReadRateSources]
+	}
+
+	labels := make([]string, len(shown))
+	for i, point := range shown {
+		labels[i] = point.Date
+	}
+	datasets := make([]ChartDataset, 0, len(sources))
+	for _, source := range sources {
+		rates := make([]*float64, len(shown))
+		for i, point := range shown {
+			if rate, exists := point.Rates[source]; exists {
+				rates[i] = &rate
+			}
+		}
+		datasets = append(datasets, ChartDataset{Label: source, Data: rates, BorderColor: SourceColor(source, m.SourceMetadata[source].Color)})
+	}
+
+	data := map[string]interface{}{
+		"labels":   labels,
+		"datasets": datasets,
+	}
+	jsonData, _ := json.Marshal(data)
+	return template.JS(jsonData)
+}
+
+// PrepareEnergyHistory creates JSON data for the energy score trend chart.
+// Points after reportDate are dropped so archived reports only show their own past.
+func PrepareEnergyHistory(points []schema.EnergyPoint, reportDate string) template.JS {
+	labels := make([]string, 0)
+	scores := make([]float64, 0)
+
+	for _, point := range points {
+		if reportDate != "" && point.Date > reportDate {
+			continue
+		}
+		labels = append(labels, point.Date)
+		scores = append(scores, point.Score)
+	}
+
+	data := map[string]interface{}{
+		"labels": labels,
+		"data":   scores,
+	}
+	jsonData, _ := json.Marshal(data)
+	return template.JS(jsonData)
+}
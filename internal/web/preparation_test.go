@@ -2,10 +2,12 @@ package web
 
 import (
 	"encoding/json"
+	"fmt"
 	"html/template"
 	"testing"
 
 	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/locale"
 )
 
 func TestPrepareReadUnreadByYear(t *testing.T) {
@@ -566,3 +568,408 @@ func TestPrepareUnreadByYearDataValidity(t *testing.T) {
 		})
 	}
 }
+
+func TestPrepareMonthTimeline(t *testing.T) {
+	m := schema.Metrics{ByYearAndMonth: map[string]map[string]int{
+		"2025": {"11": 6, "12": 3},
+		"2026": {"02": 9, "03": 2},
+	}}
+
+	var result struct {
+		Labels   []string `json:"labels"`
+		Datasets []struct {
+			Label string    `json:"label"`
+			Data  []float64 `json:"data"`
+		} `json:"datasets"`
+	}
+	if err := json.Unmarshal([]byte(PrepareMonthTimeline(m)), &result); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	expectedLabels := []string{"2025-11", "2025-12", "2026-01", "2026-02", "2026-03"}
+	if fmt.Sprint(result.Labels) != fmt.Sprint(expectedLabels) {
+		t.Errorf("labels = %v, want %v with the empty month filled in", result.Labels, expectedLabels)
+	}
+	expected := map[string][]float64{
+		"Articles":        {6, 3, 0, 9, 2},
+		"3-Month Average": {6, 4.5, 3, 4, 3.7},
+		"6-Month Average": {6, 4.5, 3, 4.5, 4},
+	}
+	if len(result.Datasets) != len(expected) {
+		t.Fatalf("expected %d datasets, got %d", len(expected), len(result.Datasets))
+	}
+	for _, dataset := range result.Datasets {
+		if fmt.Sprint(dataset.Data) != fmt.Sprint(expected[dataset.Label]) {
+			t.Errorf("%s = %v, want %v", dataset.Label, dataset.Data, expected[dataset.Label])
+		}
+	}
+
+	if got := PrepareMonthTimeline(schema.Metrics{}); got != "" {
+		t.Errorf("expected no timeline without articles, got %s", got)
+	}
+}
+
+func TestPrepareSourceReadRates(t *testing.T) {
+	points := []schema.SourceReadRatePoint{
+		{Date: "2026-01-25", Rates: map[string]float64{"GitHub": 50}},
+		{Date: "2026-02-01", Rates: map[string]float64{"GitHub": 66.7, "Substack": 75}},
+		{Date: "2026-02-08", Rates: map[string]float64{"GitHub": 70, "Substack": 60}},
+	}
+	m := schema.Metrics{
+		BySource:       map[string]int{"GitHub": 10, "Substack": 5},
+		SourceMetadata: map[string]schema.SourceMeta{"GitHub": {Color: "#111111"}},
+	}
+
+	var result struct {
+		Labels   []string `json:"labels"`
+		Datasets []struct {
+			Label       string     `json:"label"`
+			Data        []*float64 `json:"data"`
+			BorderColor string     `json:"borderColor"`
+		} `json:"datasets"`
+	}
+	if err := json.Unmarshal([]byte(PrepareSourceReadRates(points, m, "2026-02-01")), &result); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if len(result.Labels) != 2 || len(result.Datasets) != 2 {
+		t.Fatalf("expected 2 snapshots up to the report date and 2 sources, got %+v", result)
+	}
+	github, substack := result.Datasets[0], result.Datasets[1]
+	if github.Label != "GitHub" || github.BorderColor != "#111111" || *github.Data[1] != 66.7 {
+		t.Errorf("unexpected GitHub line %+v", github)
+	}
+	if substack.Label != "Substack" || substack.Data[0] != nil || *substack.Data[1] != 75 || substack.BorderColor == "" {
+		t.Errorf("expected Substack to start at its first snapshot, got %+v", substack)
+	}
+
+	if got := PrepareSourceReadRates(points[:1], m, ""); got != "" {
+		t.Errorf("expected no chart with a single snapshot, got %s", got)
+	}
+}
+
+func TestPrepareBacklogFlow(t *testing.T) {
+	flow := []schema.BacklogFlowPoint{
+		{Month: "2026-01", Added: 10, Read: 4, Net: 6},
+		{Month: "2026-02", Added: 2, Read: 9, Net: -7},
+	}
+
+	var result struct {
+		Labels    []string `json:"labels"`
+		AddedData []int    `json:"addedData"`
+		ReadData  []int    `json:"readData"`
+		NetData   []int    `json:"netData"`
+	}
+	if err := json.Unmarshal([]byte(PrepareBacklogFlow(flow, "")), &result); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if len(result.Labels) != 2 || result.AddedData[1] != 2 || result.ReadData[1] != 9 || result.NetData[1] != -7 {
+		t.Errorf("unexpected flow %+v", result)
+	}
+
+	if err := json.Unmarshal([]byte(PrepareBacklogFlow(flow, "2026-01-25")), &result); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if len(result.Labels) != 1 || result.Labels[0] != "2026-01" {
+		t.Errorf("expected an archived report to hide later months, got %v", result.Labels)
+	}
+
+	if got := PrepareBacklogFlow(nil, ""); got != "" {
+		t.Errorf("expected no chart without flow, got %s", got)
+	}
+}
+
+func TestPrepareEnergyHistory(t *testing.T) {
+	points := []schema.EnergyPoint{
+		{Date: "2026-01-01", Score: 40},
+		{Date: "2026-01-08", Score: 55.5},
+		{Date: "2026-01-15", Score: 60},
+	}
+
+	tests := []struct {
+		name           string
+		reportDate     string
+		expectedLabels []string
+	}{
+		{"latest report shows all points", "", []string{"2026-01-01", "2026-01-08", "2026-01-15"}},
+		{"archived report hides later points", "2026-01-08", []string{"2026-01-01", "2026-01-08"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result struct {
+				Labels []string  `json:"labels"`
+				Data   []float64 `json:"data"`
+			}
+			if err := json.Unmarshal([]byte(PrepareEnergyHistory(points, tt.reportDate)), &result); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			if len(result.Labels) != len(tt.expectedLabels) || len(result.Data) != len(tt.expectedLabels) {
+				t.Fatalf("expected %d points, got labels=%v data=%v", len(tt.expectedLabels), result.Labels, result.Data)
+			}
+			for i, label := range tt.expectedLabels {
+				if result.Labels[i] != label {
+					t.Errorf("label[%d] = %q, want %q", i, result.Labels[i], label)
+				}
+			}
+		})
+	}
+}
+
+func TestPrepareTopDomains(t *testing.T) {
+	byDomain := map[string][2]int{"shopify.com": {3, 1}}
+	for i := 0; i < TopDomainsCount+2; i++ {
+		byDomain[fmt.Sprintf("site%02d.com", i)] = [2]int{0, 1}
+	}
+
+	domains := PrepareTopDomains(schema.Metrics{ByDomain: byDomain})
+	if len(domains) != TopDomainsCount {
+		t.Fatalf("expected %d domains, got %d", TopDomainsCount, len(domains))
+	}
+	first := domains[0]
+	if first.Domain != "shopify.com" || first.Count != 4 || first.Read != 3 || first.Unread != 1 {
+		t.Errorf("unexpected top domain %+v", first)
+	}
+	if first.SharePct != 25 {
+		t.Errorf("expected a 25%% share of 16 linked articles, got %.1f", first.SharePct)
+	}
+	if domains := PrepareTopDomains(schema.Metrics{}); domains != nil {
+		t.Errorf("expected no domains for a snapshot without by_domain, got %v", domains)
+	}
+}
+
+func TestPrepareMediaTypes(t *testing.T) {
+	mediaTypes := PrepareMediaTypes(schema.Metrics{ByMediaType: map[string][2]int{"video": {1, 1}, "article": {3, 1}}})
+	if len(mediaTypes) != 2 {
+		t.Fatalf("expected 2 media types, got %d", len(mediaTypes))
+	}
+	if first := mediaTypes[0]; first.Type != "article" || first.Count != 4 || first.ReadPct != 75 {
+		t.Errorf("unexpected first media type %+v", first)
+	}
+	if mediaTypes := PrepareMediaTypes(schema.Metrics{ByMediaType: map[string][2]int{"article": {3, 1}}}); mediaTypes != nil {
+		t.Errorf("expected no breakdown for a single media type, got %v", mediaTypes)
+	}
+}
+
+func TestPrepareAuthors(t *testing.T) {
+	authors := PrepareAuthors(schema.Metrics{Substack: &schema.SubstackStats{
+		AuthorCount: 2,
+		ByAuthor:    map[string][2]int{"quiet.substack.com": {0, 0}, "one.substack.com": {3, 1}},
+	}})
+	if len(authors) != 2 {
+		t.Fatalf("expected 2 authors, got %d", len(authors))
+	}
+	if first := authors[0]; first.Rank != 1 || first.Author != "one.substack.com" || first.ReadPct != 75 {
+		t.Errorf("unexpected leader %+v", first)
+	}
+	if authors[1].ReadPct != 0 {
+		t.Errorf("expected a 0%% read rate without articles, got %.1f", authors[1].ReadPct)
+	}
+	if authors := PrepareAuthors(schema.Metrics{}); authors != nil {
+		t.Errorf("expected no authors without Substack stats, got %v", authors)
+	}
+}
+
+func TestPrepareCategories(t *testing.T) {
+	m := schema.Metrics{
+		ByCategoryAndSource: map[string]map[string][2]int{
+			"Databases": {"Stripe": {1, 1}, "GitHub": {3, 1}},
+			"AI":        {"GitHub": {1, 0}},
+			"Empty":     {},
+		},
+		SourceMetadata: map[string]schema.SourceMeta{"GitHub": {Color: "#24292e"}},
+	}
+
+	categories := PrepareCategories(m)
+	if len(categories) != 2 {
+		t.Fatalf("expected 2 categories with articles, got %+v", categories)
+	}
+	first := categories[0]
+	if first.Category != "Databases" || first.Count != 6 || first.Read != 4 || first.Unread != 2 {
+		t.Errorf("expected the largest category first, got %+v", first)
+	}
+	if first.Sources[0].Source != "GitHub" || int(first.Sources[0].SharePct) != 66 || first.Sources[1].Source != "Stripe" {
+		t.Errorf("expected sources by article count, got %+v", first.Sources)
+	}
+
+	var chart struct {
+		Labels   []string       `json:"labels"`
+		Datasets []ChartDataset `json:"datasets"`
+	}
+	if err := json.Unmarshal([]byte(PrepareCategoryChart(m, categories)), &chart); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(chart.Labels) != "[Databases AI]" || len(chart.Datasets) != 2 {
+		t.Fatalf("expected one bar per category and one dataset per source, got %+v", chart)
+	}
+	if github := chart.Datasets[0]; github.Label != "GitHub" || fmt.Sprint(github.Data) != "[4 1]" || github.BackgroundColor != "#24292e" {
+		t.Errorf("expected GitHub's articles per category in its brand color, got %+v", github)
+	}
+	if PrepareCategoryChart(schema.Metrics{}, nil) != "" {
+		t.Error("expected no chart without categories")
+	}
+}
+
+func TestPrepareConsumption(t *testing.T) {
+	if got := PrepareConsumption(schema.Metrics{}); got != "" {
+		t.Errorf("expected no consumption data without durations, got %s", got)
+	}
+
+	byYearAndMonth := map[string]map[string]int{"2024": {}, "2025": {"01": 4, "02": 2}}
+	for month := 1; month <= 12; month++ {
+		byYearAndMonth["2024"][fmt.Sprintf("%02d", month)] = 1
+	}
+	got := PrepareConsumption(schema.Metrics{
+		ByYearAndMonth: byYearAndMonth,
+		Consumption:    &schema.ConsumptionStats{MinutesByMonth: map[string]int{"2025-02": 95}},
+	})
+
+	var data struct {
+		Labels []string  `json:"labels"`
+		Items  []int     `json:"items"`
+		Hours  []float64 `json:"hours"`
+	}
+	if err := json.Unmarshal([]byte(got), &data); err != nil {
+		t.Fatalf("invalid consumption JSON %s: %v", got, err)
+	}
+	if len(data.Labels) != ConsumptionMonths || data.Labels[0] != "2024-03" || data.Labels[len(data.Labels)-1] != "2025-02" {
+		t.Fatalf("unexpected labels %v", data.Labels)
+	}
+	last := len(data.Labels) - 1
+	if data.Items[last] != 2 || data.Hours[last] != 1.6 || data.Hours[0] != 0 {
+		t.Errorf("unexpected series items %v hours %v", data.Items, data.Hours)
+	}
+}
+
+func TestPrepareProviderTimeline(t *testing.T) {
+	if got := PrepareProviderTimeline(nil); got != "" {
+		t.Errorf("expected no timeline data without provider history, got %s", got)
+	}
+
+	got := PrepareProviderTimeline([]schema.ProviderTimelinePoint{
+		{Month: "2025-11", Total: 5},
+		{Month: "2025-12", Added: 2, Removed: 1, Total: 6},
+	})
+	want := `{"added":[0,2],"labels":["2025-11","2025-12"],"removed":[0,-1],"totals":[5,6]}`
+	if string(got) != want {
+		t.Errorf("PrepareProviderTimeline() = %s, want %s", got, want)
+	}
+}
+
+func TestPrepareReadUnreadByFiscalYear(t *testing.T) {
+	if got := PrepareReadUnreadByFiscalYear(schema.Metrics{}); got != "" {
+		t.Errorf("expected no fiscal year data for calendar years, got %s", got)
+	}
+
+	got := PrepareReadUnreadByFiscalYear(schema.Metrics{
+		ByFiscalYear: map[string][2]int{"2024-25": {3, 1}, "2025-26": {2, 4}},
+	})
+	want := `{"labels":["2025-26","2024-25"],"readData":[2,3],"unreadData":[4,1]}`
+	if string(got) != want {
+		t.Errorf("PrepareReadUnreadByFiscalYear() = %s, want %s", got, want)
+	}
+
+	if span := FiscalYearSpan(9); span != "Sep–Aug" {
+		t.Errorf("FiscalYearSpan(9) = %q, want Sep–Aug", span)
+	}
+	if span := FiscalYearSpan(1); span != "" {
+		t.Errorf("FiscalYearSpan(1) = %q, want no span for calendar years", span)
+	}
+}
+
+func TestPrepareQuarterTrend(t *testing.T) {
+	if got := PrepareQuarterTrend(schema.Metrics{}); got != "" {
+		t.Errorf("expected no quarterly data without quarters, got %s", got)
+	}
+
+	got := PrepareQuarterTrend(schema.Metrics{
+		ByQuarter: map[string][2]int{"2024-Q3": {1, 0}, "2025-Q2": {2, 3}},
+	})
+	want := `{"labels":["2024-Q3","2024-Q4","2025-Q1","2025-Q2"],"readData":[1,0,0,2],"unreadData":[0,0,0,3]}`
+	if string(got) != want {
+		t.Errorf("PrepareQuarterTrend() = %s, want %s", got, want)
+	}
+}
+
+func TestPrepareWeeklyTrend(t *testing.T) {
+	if got := PrepareWeeklyTrend(schema.Metrics{}); got != "" {
+		t.Errorf("expected no weekly data without ISO weeks, got %s", got)
+	}
+
+	got := PrepareWeeklyTrend(schema.Metrics{
+		ByISOWeek: map[string][2]int{"2024-W52": {1, 0}, "2025-W02": {0, 2}, "2023-W01": {5, 5}},
+	})
+	var data struct {
+		Labels     []string `json:"labels"`
+		ReadData   []int    `json:"readData"`
+		UnreadData []int    `json:"unreadData"`
+	}
+	if err := json.Unmarshal([]byte(got), &data); err != nil {
+		t.Fatalf("invalid weekly JSON %s: %v", got, err)
+	}
+	last := len(data.Labels) - 1
+	if len(data.Labels) != WeeklyTrendWeeks || data.Labels[0] != "2024-W03" || data.Labels[last] != "2025-W02" || data.Labels[last-2] != "2024-W52" {
+		t.Fatalf("unexpected labels %v", data.Labels)
+	}
+	if data.UnreadData[last] != 2 || data.ReadData[last-2] != 1 || data.ReadData[last-1] != 0 {
+		t.Errorf("unexpected series read %v unread %v", data.ReadData, data.UnreadData)
+	}
+}
+
+func TestPrepareWeekdayPattern(t *testing.T) {
+	if pattern := PrepareWeekdayPattern(schema.Metrics{}); pattern != nil {
+		t.Errorf("expected no pattern without weekday aggregates, got %+v", pattern)
+	}
+	if got := PrepareWeekdayChart(schema.Metrics{}); got != "" {
+		t.Errorf("expected no weekday chart without weekday aggregates, got %s", got)
+	}
+
+	m := schema.Metrics{ByWeekday: map[string][2]int{"Mon": {2, 10}, "Wed": {3, 1}, "Sat": {3, 1}}}
+	pattern := PrepareWeekdayPattern(m)
+	want := schema.WeekdayPattern{BusiestDay: "Mon", WeekendPct: 20, WeekendReadPct: 75, WeekdayReadPct: 31.25}
+	if *pattern != want {
+		t.Errorf("PrepareWeekdayPattern() = %+v, want %+v", *pattern, want)
+	}
+
+	wantChart := `{"labels":["Mon","Tue","Wed","Thu","Fri","Sat","Sun"],"readData":[2,0,3,0,0,3,0],"unreadData":[10,0,1,0,0,1,0]}`
+	if got := PrepareWeekdayChart(m); string(got) != wantChart {
+		t.Errorf("PrepareWeekdayChart() = %s, want %s", got, wantChart)
+	}
+}
+
+func TestFormatRollingWindow(t *testing.T) {
+	tests := []struct {
+		window schema.RollingWindow
+		want   string
+	}{
+		{schema.RollingWindow{Days: 30, Added: 12}, "12 saved"},
+		{schema.RollingWindow{Days: 30, Added: 12, Read: 9, BacklogChange: 3, Baseline: "2026-02-20"}, "12 saved · 9 read · backlog +3"},
+		{schema.RollingWindow{Days: 90, Added: 4, Read: 20, BacklogChange: -16, Baseline: "2025-12-30"}, "4 saved · 20 read · backlog -16"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatRollingWindow(tt.window); got != tt.want {
+			t.Errorf("FormatRollingWindow(%+v) = %q, want %q", tt.window, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDelta(t *testing.T) {
+	tests := []struct {
+		change               float64
+		places               int
+		wantDelta, wantTrend string
+	}{
+		{1.4, 1, "▲1.4", "up"},
+		{-3, 0, "▼3", "down"},
+		{0.04, 1, "±0", "flat"},
+		{-0.2, 0, "±0", "flat"},
+		{1500, 0, "▲1,500", "up"},
+	}
+
+	for _, tt := range tests {
+		delta, trend := FormatDelta(tt.change, tt.places, locale.Locale{})
+		if delta != tt.wantDelta || trend != tt.wantTrend {
+			t.Errorf("FormatDelta(%v, %d) = %q, %q, want %q, %q", tt.change, tt.places, delta, trend, tt.wantDelta, tt.wantTrend)
+		}
+	}
+}
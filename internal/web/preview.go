@@ -0,0 +1,39 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Preview sorts the files of a dry-run build by how they would change the published site
+type Preview struct {
+	Added     []string `json:"added"`
+	Changed   []string `json:"changed"`
+	Unchanged []string `json:"unchanged"`
+}
+
+// ComparePreview compares the files a dry run wrote to previewDir, as slash-separated paths, with
+// their published copies in siteDir. A missing siteDir makes every file added.
+func ComparePreview(previewDir, siteDir string, files []string) (Preview, error) {
+	var preview Preview
+	for _, file := range files {
+		rendered, err := os.ReadFile(filepath.Join(previewDir, filepath.FromSlash(file)))
+		if err != nil {
+			return Preview{}, fmt.Errorf("failed to read preview of %s: %w", file, err)
+		}
+		published, err := os.ReadFile(filepath.Join(siteDir, filepath.FromSlash(file)))
+		switch {
+		case os.IsNotExist(err):
+			preview.Added = append(preview.Added, file)
+		case err != nil:
+			return Preview{}, fmt.Errorf("failed to read %s: %w", file, err)
+		case bytes.Equal(rendered, published):
+			preview.Unchanged = append(preview.Unchanged, file)
+		default:
+			preview.Changed = append(preview.Changed, file)
+		}
+	}
+	return preview, nil
+}
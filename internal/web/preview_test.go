@@ -0,0 +1,44 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestComparePreview(t *testing.T) {
+	previewDir, siteDir := t.TempDir(), t.TempDir()
+	write := func(dir, file, content string) {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(previewDir, "index.html", "<html>new</html>")
+	write(siteDir, "index.html", "<html>old</html>")
+	write(previewDir, "css/tokens.css", ":root{}")
+	write(siteDir, "css/tokens.css", ":root{}")
+	write(previewDir, "history/2025-01-05/analytics.html", "<html>")
+
+	files := []string{"css/tokens.css", "history/2025-01-05/analytics.html", "index.html"}
+	preview, err := ComparePreview(previewDir, siteDir, files)
+	if err != nil {
+		t.Fatalf("ComparePreview() error = %v", err)
+	}
+	expected := Preview{
+		Added:     []string{"history/2025-01-05/analytics.html"},
+		Changed:   []string{"index.html"},
+		Unchanged: []string{"css/tokens.css"},
+	}
+	if !reflect.DeepEqual(preview, expected) {
+		t.Errorf("ComparePreview() = %+v, expected %+v", preview, expected)
+	}
+
+	if _, err := ComparePreview(previewDir, siteDir, []string{"missing.html"}); err == nil {
+		t.Error("expected an error for a file missing from the preview")
+	}
+}
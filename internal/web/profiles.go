@@ -0,0 +1,52 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// ProfilesFile is the template of the page linking every profile's dashboard, written as the site
+// root's index.html
+const ProfilesFile = "profiles.html"
+
+// ProfileLink is one profile's entry on the profile index
+type ProfileLink struct {
+	Name    string // subdirectory the profile's site is built into
+	Title   string
+	Updated string // date of the profile's latest snapshot, empty when it has none
+}
+
+// WriteProfileIndex writes index.html to outputDir, linking the dashboard of every profile built
+// into a subdirectory of it
+func WriteProfileIndex(outputDir, title string, profiles []ProfileLink) error {
+	source, err := readAsset(templatePath(ProfilesFile))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", ProfilesFile, err)
+	}
+	tmpl, err := template.New(ProfilesFile).Parse(string(source))
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", ProfilesFile, err)
+	}
+	var buf bytes.Buffer
+	data := struct {
+		Title    string
+		Profiles []ProfileLink
+	}{title, profiles}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute %s: %w", ProfilesFile, err)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	path := filepath.Join(outputDir, "index.html")
+	if err := safefile.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteProfileIndex(t *testing.T) {
+	dir := t.TempDir()
+	profiles := []ProfileLink{
+		{Name: "victoria", Title: "Victoria's Reading", Updated: "2026-03-13"},
+		{Name: "partner", Title: "Partner's Reading"},
+	}
+	if err := WriteProfileIndex(dir, "Household Reading", profiles); err != nil {
+		t.Fatalf("WriteProfileIndex() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatalf("expected index.html: %v", err)
+	}
+	html := string(content)
+	for _, expected := range []string{
+		"<title>Household Reading</title>",
+		`href="victoria/index.html"`,
+		"Victoria&#39;s Reading",
+		"Updated 2026-03-13",
+		`href="partner/index.html"`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected %q in the profile index", expected)
+		}
+	}
+	if strings.Count(html, "Updated") != 1 {
+		t.Error("expected no update date for a profile without snapshots")
+	}
+}
@@ -0,0 +1,240 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	texttmpl "text/template"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
+)
+
+// Page is one page of a generation pass, named after its HTML template
+type Page struct {
+	Filename string
+	Title    string
+}
+
+// OutputTarget describes where a renderer writes one generation pass
+type OutputTarget struct {
+	Dir    string
+	Pages  []Page
+	IsRoot bool // the latest full-site pass, which also owns site-wide files
+
+	// Record, when set, is called with every file written so it lands in the site manifest
+	Record func(path string)
+}
+
+func (t OutputTarget) record(path string) {
+	if t.Record != nil {
+		t.Record(path)
+	}
+}
+
+// Renderer turns a prepared ViewModel into one output format. AnalyticsService prepares the
+// ViewModel once per pass and hands it to every configured renderer. Renderers writing several
+// files stop between them once ctx is done.
+type Renderer interface {
+	Render(ctx context.Context, vm ViewModel, target OutputTarget) error
+}
+
+// HTMLRenderer is the default renderer, producing the dashboard pages from the html/template files
+type HTMLRenderer struct{}
+
+// Render executes base.html with each page template, copying the static files on the root pass
+func (r HTMLRenderer) Render(ctx context.Context, vm ViewModel, target OutputTarget) error {
+	outputDir := target.Dir
+
+	// Common function map
+	funcMap := template.FuncMap{
+		"divideFloat": func(a, b int) float64 {
+			if b == 0 {
+				return 0
+			}
+			return float64(a) / float64(b)
+		},
+		"sub": func(a, b int) int {
+			return a - b
+		},
+		"add": func(a, b int) int {
+			return a + b
+		},
+	}
+
+	// Create output directory
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Copy static SEO/AI metadata files recursively
+	if target.IsRoot {
+		if err := copyStaticFiles(templatePath("static"), outputDir, vm, target); err != nil {
+			slog.Warn("Failed to process static directory", "err", err)
+		}
+		if err := writeThemeTokens(outputDir, vm.Theme, target); err != nil {
+			slog.Warn("Failed to write theme tokens", "err", err)
+		}
+		if vm.ThemeCSSURL != "" {
+			if err := copyThemeCSS(outputDir, target); err != nil {
+				slog.Warn("Failed to copy theme stylesheet", "err", err)
+			}
+		}
+	}
+
+	// Loop and generate each page
+	for _, page := range target.Pages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Create new template instance for this page
+		tmpl := template.New("").Funcs(funcMap)
+
+		// Parse shared templates and the specific page template
+		tmpl, err := tmpl.ParseFS(assets, templatePath("base.html"), templatePath(page.Filename))
+		if err != nil {
+			return fmt.Errorf("failed to parse templates for %s: %w", page.Filename, err)
+		}
+
+		// Create output file
+		outPath := filepath.Join(outputDir, page.Filename)
+		f, err := safefile.Create(outPath, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", outPath, err)
+		}
+		defer f.Close()
+
+		// Update PageTitle in ViewModel for this page
+		vm.PageTitle = page.Title
+		vm.PageFile = page.Filename
+
+		// Execute the template matching the filename
+		err = tmpl.ExecuteTemplate(f, page.Filename, vm)
+		if err != nil {
+			return fmt.Errorf("failed to execute template for %s: %w", page.Filename, err)
+		}
+		if err := f.Commit(); err != nil {
+			return err
+		}
+		target.record(outPath)
+	}
+
+	return nil
+}
+
+// writeReport writes a single-file report to path, which may sit outside the site, creating its
+// directory first
+func writeReport(path string, content []byte, target OutputTarget) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	if err := safefile.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	target.record(path)
+	return nil
+}
+
+// copyStaticFiles recursively processes the static assets directory, treating certain files as templates
+func copyStaticFiles(src, dst string, vm ViewModel, target OutputTarget) error {
+	entries, err := fs.ReadDir(assets, src)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		srcPath := path.Join(src, entry.Name())
+		dstPath := filepath.Join(dst, entry.Name())
+
+		if entry.IsDir() {
+			if err := copyStaticFiles(srcPath, dstPath, vm, target); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Treat text files as templates to inject config
+		if entry.Name() == "llms.txt" || entry.Name() == "robots.txt" {
+			t, err := texttmpl.ParseFS(assets, srcPath)
+			if err != nil {
+				slog.Warn("Failed to parse static file as template", "file", entry.Name(), "err", err)
+				continue
+			}
+
+			f, err := safefile.Create(dstPath, 0644)
+			if err != nil {
+				slog.Warn("Failed to create static file", "file", dstPath, "err", err)
+				continue
+			}
+
+			err = t.Execute(f, vm)
+			if err == nil {
+				err = f.Commit()
+			}
+			f.Close()
+			if err != nil {
+				slog.Warn("Failed to execute template", "file", entry.Name(), "err", err)
+				continue
+			}
+			target.record(dstPath)
+		} else {
+			if err := copyAsset(srcPath, dstPath); err != nil {
+				slog.Warn("Failed to copy static file", "file", entry.Name(), "err", err)
+				continue
+			}
+			target.record(dstPath)
+		}
+	}
+
+	return nil
+}
+
+// copyAsset writes the assets file name to dst
+func copyAsset(name, dst string) error {
+	content, err := readAsset(name)
+	if err != nil {
+		return err
+	}
+	return safefile.WriteFile(dst, content, 0644)
+}
+
+// writeThemeTokens writes the theme's color tokens as CSS custom properties
+func writeThemeTokens(outputDir string, theme Theme, target OutputTarget) error {
+	dst := filepath.Join(outputDir, filepath.FromSlash(ThemeTokensFile))
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := safefile.WriteFile(dst, []byte(theme.CSS()), 0644); err != nil {
+		return err
+	}
+	target.record(dst)
+	return nil
+}
+
+// copyThemeCSS writes the theme's stylesheet next to the Tailwind build
+func copyThemeCSS(outputDir string, target OutputTarget) error {
+	dst := filepath.Join(outputDir, filepath.FromSlash(ThemeCSSFile))
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := copyAsset(templatePath(ThemeCSSFile), dst); err != nil {
+		return err
+	}
+	target.record(dst)
+	return nil
+}
@@ -0,0 +1,129 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// recordingRenderer writes one text file per page and remembers each pass
+type recordingRenderer struct {
+	passes []OutputTarget
+	err    error
+}
+
+func (r *recordingRenderer) Render(ctx context.Context, vm ViewModel, target OutputTarget) error {
+	r.passes = append(r.passes, target)
+	if r.err != nil {
+		return r.err
+	}
+	for _, page := range target.Pages {
+		path := filepath.Join(target.Dir, strings.TrimSuffix(page.Filename, ".html")+".txt")
+		if err := os.MkdirAll(target.Dir, 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(fmt.Sprintf("%s: %d articles", page.Title, vm.TotalArticles)), 0644); err != nil {
+			return err
+		}
+		target.Record(path)
+	}
+	return nil
+}
+
+func TestSetRenderers(t *testing.T) {
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	if len(service.renderers) != 1 {
+		t.Fatalf("expected the HTML renderer by default, got %v", service.renderers)
+	}
+
+	text := &recordingRenderer{}
+	service.SetRenderers(text)
+	m := schema.Metrics{TotalArticles: 7}
+
+	if err := service.GenerateFullSite(t.Context(), m, GenConfig{OutputDir: dir}); err != nil {
+		t.Fatalf("GenerateFullSite() error = %v", err)
+	}
+	history := filepath.Join(dir, "history", "2024-01-01")
+	if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: history, PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+	}
+
+	if len(text.passes) != 2 || !text.passes[0].IsRoot || text.passes[1].IsRoot {
+		t.Fatalf("expected a root pass then a history pass, got %+v", text.passes)
+	}
+	if len(text.passes[0].Pages) != 6 || len(text.passes[1].Pages) != 1 || text.passes[1].Dir != history {
+		t.Errorf("unexpected pass targets %+v", text.passes)
+	}
+
+	content, err := os.ReadFile(filepath.Join(history, "analytics.txt"))
+	if err != nil || string(content) != "📊 Analytics (Archived): 7 articles" {
+		t.Errorf("unexpected rendered page %q, %v", content, err)
+	}
+	written := strings.Join(service.WrittenFiles(), ",")
+	if !strings.Contains(written, "index.txt") || !strings.Contains(written, "history/2024-01-01/analytics.txt") {
+		t.Errorf("expected renderer output in written files, got %s", written)
+	}
+
+	service.SetRenderers(text, &recordingRenderer{err: fmt.Errorf("boom")})
+	if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: history}); err == nil {
+		t.Error("expected a failing renderer to fail the pass")
+	}
+}
+
+func TestHTMLRendererOptionalSections(t *testing.T) {
+	tests := []struct {
+		name      string
+		community *schema.CommunityComparison
+		expected  bool
+	}{
+		{name: "without optional data", community: nil},
+		{name: "with community, domains, media types, consumption and periods", community: &schema.CommunityComparison{Participants: 12, MedianReadRate: 35.5, MedianTotalArticles: 120}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			service := NewAnalyticsService(dir)
+			m := schema.Metrics{TotalArticles: 10, ReadCount: 4, UnreadCount: 6, ReadRate: 40, Community: tt.community}
+			if tt.expected {
+				m.ByDomain = map[string][2]int{"one.substack.com": {3, 1}}
+				m.ByMediaType = map[string][2]int{"article": {3, 5}, "podcast": {1, 1}}
+				m.Consumption = &schema.ConsumptionStats{TotalMinutes: 90, MinutesByMonth: map[string]int{"2025-01": 90}, MinutesBySource: map[string]int{"GitHub": 90}}
+				m.ByQuarter = map[string][2]int{"2025-Q1": {4, 6}}
+				m.ByISOWeek = map[string][2]int{"2025-W02": {4, 6}}
+				m.ByWeekday = map[string][2]int{"Mon": {3, 5}, "Sat": {1, 1}}
+			}
+			if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: dir, PageBudgetBytes: -1}); err != nil {
+				t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+			}
+
+			page, err := os.ReadFile(filepath.Join(dir, "analytics.html"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, section := range []string{"You vs. Community", "Top Domains", "Media Types", "Consumption", "Quarterly &amp; Weekly Trends", "Weekday Pattern"} {
+				if strings.Contains(string(page), section) != tt.expected {
+					t.Fatalf("%s section present = %v, want %v", section, !tt.expected, tt.expected)
+				}
+			}
+			if tt.expected && (!strings.Contains(string(page), "35.5%") || !strings.Contains(string(page), "12 readers")) {
+				t.Error("expected the community median and participants in the section")
+			}
+			if tt.expected && !strings.Contains(string(page), `class="share-bar" min="0" max="100" value="100.0"`) {
+				t.Error("expected the domain share bar")
+			}
+			if tt.expected && !strings.Contains(string(page), "Weekends account for <span class=\"font-bold text-slate-900\">20.0%</span>") {
+				t.Error("expected the weekend share of saves")
+			}
+			if tt.expected && !strings.Contains(string(page), "1.5 hours") {
+				t.Error("expected the finished watch/listen hours")
+			}
+		})
+	}
+}
@@ -1,34 +1,121 @@
 package web
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"html/template"
 	"io"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
-	texttmpl "text/template"
 	"time"
 
 	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/feed"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/forecast"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/linkcheck"
 	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/safefile"
 )
 
 const (
 	AnalyticsTitle = "📚 Personal Reading Analytics"
+
+	// ChartDataDir holds a page's chart data files, next to the page, when its chart data is lazy-loaded
+	ChartDataDir = "data"
+
+	// DefaultPageBudgetBytes is the HTML page size above which a warning is logged
+	DefaultPageBudgetBytes = 200 * 1024
+
+	// TopDomainsCount is the number of link domains listed in the Top Domains table
+	TopDomainsCount = 10
+
+	// ConsumptionMonths is the number of months shown on the consumption chart
+	ConsumptionMonths = 12
+
+	// WeeklyTrendWeeks is the number of ISO weeks shown on the weekly cadence chart
+	WeeklyTrendWeeks = 52
+
+	// SourceReadRateSources is the number of largest sources drawn on the read rate over time chart
+	SourceReadRateSources = 6
 )
 
-// AnalyticsService handles the generation of the HTML analytics
+// MovingAverageMonths are the trailing windows averaged over the monthly timeline, shortest first
+var MovingAverageMonths = []int{3, 6}
+
+// AnalyticsService prepares the analytics ViewModel and hands it to its renderers
 type AnalyticsService struct {
-	outputDir string
+	outputDir  string
+	written    map[string]bool // files written by this service, for the site manifest
+	renderers  []Renderer
+	branding   Branding
+	theme      Theme
+	highlights []Highlight
+	public     bool // strip article titles and links, see SetPublic
 }
 
-// NewAnalyticsService creates a new AnalyticsService
+// NewAnalyticsService creates a new AnalyticsService rendering HTML
 func NewAnalyticsService(outputDir string) *AnalyticsService {
-	return &AnalyticsService{outputDir: outputDir}
+	return &AnalyticsService{outputDir: outputDir, written: make(map[string]bool), renderers: []Renderer{HTMLRenderer{}}, branding: DefaultBranding(), theme: DefaultTheme(), highlights: DefaultHighlights()}
+}
+
+// SetBranding replaces the dashboard title, page titles, footer and locale
+func (s *AnalyticsService) SetBranding(b Branding) {
+	s.branding = b
+}
+
+// SetTheme replaces the light and dark color tokens and the mode pages open in
+func (s *AnalyticsService) SetTheme(t Theme) {
+	s.theme = t
+}
+
+// SetHighlights replaces the badges of the Highlights section
+func (s *AnalyticsService) SetHighlights(highlights []Highlight) {
+	s.highlights = highlights
+}
+
+// SetPublic strips article titles, links and annotations from every page and published snapshot,
+// keeping only aggregates, so the dashboard can be public while the reading list stays private
+func (s *AnalyticsService) SetPublic(public bool) {
+	s.public = public
+}
+
+// SetRenderers replaces the renderers every generation pass is handed to
+func (s *AnalyticsService) SetRenderers(renderers ...Renderer) {
+	s.renderers = renderers
+}
+
+// renderAll runs every renderer over the view model for one pass
+func (s *AnalyticsService) renderAll(ctx context.Context, vm ViewModel, target OutputTarget) error {
+	target.Record = s.record
+	for _, renderer := range s.renderers {
+		if err := renderer.Render(ctx, vm, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// record remembers a generated file so it can be listed in the site manifest
+func (s *AnalyticsService) record(path string) {
+	s.written[path] = true
+}
+
+// WrittenFiles lists the files generated so far, relative to the service's output directory
+func (s *AnalyticsService) WrittenFiles() []string {
+	var files []string
+	for path := range s.written {
+		rel, err := filepath.Rel(s.outputDir, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		files = append(files, filepath.ToSlash(rel))
+	}
+	sort.Strings(files)
+	return files
 }
 
 // GenConfig holds configuration for a specific generation pass
@@ -38,50 +125,346 @@ type GenConfig struct {
 	IsHistorical bool
 	HistoryDates []string
 	ReportDate   string
+
+	// EnergyHistory holds the energy score of every snapshot, oldest first
+	EnergyHistory []schema.EnergyPoint
+
+	// ProviderTimeline holds the subscriptions added and removed per month, oldest first
+	ProviderTimeline []schema.ProviderTimelinePoint
+
+	// BacklogFlow holds the articles added and read per month across snapshots, oldest first
+	BacklogFlow []schema.BacklogFlowPoint
+
+	// SourceReadRates holds every source's read rate in each snapshot, oldest first
+	SourceReadRates []schema.SourceReadRatePoint
+
+	// LazyChartData writes chart data to ChartDataDir and fetches it at runtime instead of inlining it
+	LazyChartData bool
+
+	// PageBudgetBytes overrides DefaultPageBudgetBytes; a negative value disables the check
+	PageBudgetBytes int64
+
+	// LinkReport adds the backlog link health section when set
+	LinkReport *linkcheck.Report
+
+	// Previous is the snapshot before this one, annotating the key metrics with their change when set
+	Previous *schema.Metrics
+
+	// ReadingPlan adds the reading plan section, linking to its calendar, when set
+	ReadingPlan *forecast.Plan
+
+	// Charts selects the chart renderer: ChartsChartJS (or empty) or ChartsSVG
+	Charts string
+
+	// Feed links the RSS and JSON feeds from every page head
+	Feed bool
+
+	// CanonicalDir is the directory, relative to the site root, the pages' canonical URLs point
+	// into, such as history/2025-01-05; empty for the root. A page duplicating another directory's,
+	// like the latest snapshot's history page, points at that directory instead.
+	CanonicalDir string
 }
 
-// GenerateFullSite generates all pages (index, analytics, evolution)
-func (s *AnalyticsService) GenerateFullSite(m schema.Metrics, config GenConfig) error {
+// GenerateFullSite generates all pages (index, analytics, authors, topics, evolution, explorer).
+// It stops between pages once ctx is done, returning ctx's error.
+func (s *AnalyticsService) GenerateFullSite(ctx context.Context, m schema.Metrics, config GenConfig) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	vm, err := s.prepareViewModel(m, config)
 	if err != nil {
 		return fmt.Errorf("failed to prepare view model: %w", err)
 	}
 
-	pages := []struct {
-		Filename string
-		Title    string
-	}{
-		{"index.html", AnalyticsTitle},
-		{"analytics.html", "📊 Analytics"},
-		{"evolution.html", "⏳ Evolution"},
+	var pages []Page
+	for _, filename := range []string{"index.html", "analytics.html", "authors.html", "topics.html", "evolution.html", "explorer.html"} {
+		pages = append(pages, Page{filename, s.branding.PageTitle(filename)})
 	}
 
 	// Generate machine-readable registry
 	if err := s.generateRegistry(vm, config.OutputDir); err != nil {
-		log.Printf("⚠️ Warning: Failed to generate evolution registry: %v", err)
+		slog.Warn("Failed to generate evolution registry", "err", err)
 	}
 
-	return s.render(vm, config.OutputDir, pages, true)
+	if err := s.lazyLoadChartData(&vm, config); err != nil {
+		return err
+	}
+
+	if err := s.renderAll(ctx, vm, OutputTarget{Dir: config.OutputDir, Pages: pages, IsRoot: true}); err != nil {
+		return err
+	}
+	return s.generateNotFound(ctx, m, config)
 }
 
-// GenerateAnalyticsOnly generates only the analytics.html page
-func (s *AnalyticsService) GenerateAnalyticsOnly(m schema.Metrics, config GenConfig) error {
+// generateNotFound writes 404.html, which hosts such as GitHub Pages serve for a missing path at any
+// depth, so its links are absolute when site_url is set. Assets without the template skip it.
+func (s *AnalyticsService) generateNotFound(ctx context.Context, m schema.Metrics, config GenConfig) error {
+	if !hasAsset(templatePath(NotFoundFile)) {
+		return nil
+	}
+	landing, err := LoadLanding()
+	if err == nil && landing.Header.SiteURL != "" {
+		config.BaseURL = siteRoot(landing.Header.SiteURL)
+	}
 	vm, err := s.prepareViewModel(m, config)
 	if err != nil {
 		return fmt.Errorf("failed to prepare view model: %w", err)
 	}
+	return HTMLRenderer{}.Render(ctx, vm, OutputTarget{Dir: config.OutputDir, Pages: []Page{{NotFoundFile, s.branding.PageTitle(NotFoundFile)}}, Record: s.record})
+}
 
-	pages := []struct {
-		Filename string
-		Title    string
-	}{
-		{"analytics.html", "📊 Analytics (Archived)"},
+// siteRoot is site_url with exactly one trailing slash
+func siteRoot(siteURL string) string {
+	return strings.TrimRight(siteURL, "/") + "/"
+}
+
+// GenerateAnalyticsOnly generates only the analytics.html page, unless ctx is already done
+func (s *AnalyticsService) GenerateAnalyticsOnly(ctx context.Context, m schema.Metrics, config GenConfig) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	vm, err := s.prepareViewModel(m, config)
+	if err != nil {
+		return fmt.Errorf("failed to prepare view model: %w", err)
+	}
+
+	pages := []Page{
+		{"analytics.html", s.branding.PageTitle("analytics.html") + " (Archived)"},
+	}
+
+	if err := s.lazyLoadChartData(&vm, config); err != nil {
+		return err
+	}
+
+	if err := s.renderAll(ctx, vm, OutputTarget{Dir: config.OutputDir, Pages: pages}); err != nil {
+		return err
+	}
+
+	checkPageBudget(config.OutputDir, pages[0].Filename, config.PageBudgetBytes)
+	return nil
+}
+
+// lazyLoadChartData writes the chart data files for config.LazyChartData and points the page at
+// them; SVG charts need no data at runtime
+func (s *AnalyticsService) lazyLoadChartData(vm *ViewModel, config GenConfig) error {
+	if !config.LazyChartData || vm.SVGCharts != nil {
+		return nil
+	}
+	files, err := writeChartData(*vm, config.OutputDir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		s.record(filepath.Join(config.OutputDir, filepath.FromSlash(file)))
+	}
+	vm.ChartDataURLs = files
+	return nil
+}
+
+// chartDataFiles groups the chart series into one file per chart family, named without .json,
+// in the order the page lists them
+var chartDataFiles = []struct {
+	name string
+	keys []string
+}{
+	{"years", []string{"yearChartLabels", "yearChartData"}},
+	{"months", []string{"monthChartLabels", "monthChartDatasets", "monthTotalData", "monthTimeline"}},
+	{"read-unread", []string{"readUnreadByMonth", "readUnreadBySource", "readUnreadByYear", "readUnreadByFiscalYear"}},
+	{"unread", []string{"unreadArticleAgeDistribution", "unreadByYear"}},
+	{"periods", []string{"byQuarter", "byISOWeek", "byWeekday"}},
+	{"consumption", []string{"consumption"}},
+	{"energy", []string{"energyHistory"}},
+	{"backlog-flow", []string{"backlogFlow"}},
+	{"source-read-rates", []string{"sourceReadRates"}},
+	{"media-types", []string{"byMediaType"}},
+}
+
+// ChartDataJSON bundles every chart series of the view model into one JSON object,
+// keyed the same way the analytics page script reads it
+func ChartDataJSON(vm ViewModel) ([]byte, error) {
+	data, err := chartSeries(vm)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(data)
+}
+
+// ChartDataFiles splits the chart series into one JSON object per chart family, keyed by file name
+// such as "years.json". Families without any data are left out.
+func ChartDataFiles(vm ViewModel) (map[string][]byte, error) {
+	series, err := chartSeries(vm)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte, len(chartDataFiles))
+	for _, file := range chartDataFiles {
+		data := make(map[string]json.RawMessage, len(file.keys))
+		for _, key := range file.keys {
+			if string(series[key]) != "null" {
+				data[key] = series[key]
+			}
+		}
+		if len(data) == 0 {
+			continue
+		}
+		content, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		files[file.name+".json"] = content
 	}
+	return files, nil
+}
 
-	return s.render(vm, config.OutputDir, pages, false)
+// chartSeries validates every chart series of the view model, with missing ones as null
+func chartSeries(vm ViewModel) (map[string]json.RawMessage, error) {
+	series := map[string]template.JS{
+		"yearChartLabels":              vm.YearChartLabels,
+		"yearChartData":                vm.YearChartData,
+		"monthChartLabels":             vm.MonthChartLabels,
+		"monthChartDatasets":           vm.MonthChartDatasets,
+		"monthTotalData":               vm.MonthTotalData,
+		"monthTimeline":                vm.MonthTimelineJSON,
+		"readUnreadByMonth":            vm.ReadUnreadByMonthJSON,
+		"readUnreadBySource":           vm.ReadUnreadBySourceJSON,
+		"readUnreadByYear":             vm.ReadUnreadByYearJSON,
+		"readUnreadByFiscalYear":       vm.ReadUnreadByFiscalYearJSON,
+		"unreadArticleAgeDistribution": vm.UnreadArticleAgeDistributionJSON,
+		"unreadByYear":                 vm.UnreadByYearJSON,
+		"energyHistory":                vm.EnergyHistoryJSON,
+		"backlogFlow":                  vm.BacklogFlowJSON,
+		"sourceReadRates":              vm.SourceReadRateJSON,
+		"byMediaType":                  vm.MediaTypeChartDataJSON,
+		"consumption":                  vm.ConsumptionJSON,
+		"byQuarter":                    vm.QuarterTrendJSON,
+		"byISOWeek":                    vm.WeeklyTrendJSON,
+		"byWeekday":                    vm.WeekdayChartJSON,
+	}
+
+	data := make(map[string]json.RawMessage, len(series))
+	for key, value := range series {
+		if value == "" {
+			value = "null"
+		}
+		if !json.Valid([]byte(value)) {
+			return nil, fmt.Errorf("chart series %s is not valid JSON", key)
+		}
+		data[key] = json.RawMessage(value)
+	}
+	return data, nil
+}
+
+// prepareMediaTypeChartData builds the chart series of each media type's own snapshot, keyed by
+// media type, so the analytics page can filter every chart by type
+func (s *AnalyticsService) prepareMediaTypeChartData(m schema.Metrics) template.JS {
+	if len(m.MediaTypes) < 2 {
+		return ""
+	}
+
+	data := make(map[string]json.RawMessage, len(m.MediaTypes))
+	for mediaType, typeMetrics := range m.MediaTypes {
+		typeMetrics.SourceMetadata = m.SourceMetadata
+		vm, err := s.prepareViewModel(typeMetrics, GenConfig{})
+		if err != nil {
+			slog.Warn("Skipping media type charts", "media_type", mediaType, "err", err)
+			continue
+		}
+		chartData, err := ChartDataJSON(vm)
+		if err != nil {
+			slog.Warn("Skipping media type charts", "media_type", mediaType, "err", err)
+			continue
+		}
+		data[mediaType] = chartData
+	}
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return template.JS(jsonData)
+}
+
+// writeChartData writes the page's chart series to ChartDataDir in outputDir, returning the files'
+// paths relative to outputDir in the order the page fetches them
+func writeChartData(vm ViewModel, outputDir string) ([]string, error) {
+	files, err := ChartDataFiles(vm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build chart data: %w", err)
+	}
+
+	dir := filepath.Join(outputDir, ChartDataDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create chart data directory: %w", err)
+	}
+
+	var paths []string
+	for _, file := range chartDataFiles {
+		content, exists := files[file.name+".json"]
+		if !exists {
+			continue
+		}
+		if err := safefile.WriteFile(filepath.Join(dir, file.name+".json"), content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.name+".json", err)
+		}
+		paths = append(paths, ChartDataDir+"/"+file.name+".json")
+	}
+	return paths, nil
+}
+
+// checkPageBudget logs a warning when a generated page is larger than the budget
+func checkPageBudget(outputDir, filename string, budget int64) {
+	if budget == 0 {
+		budget = DefaultPageBudgetBytes
+	}
+	if budget < 0 {
+		return
+	}
+
+	info, err := os.Stat(filepath.Join(outputDir, filename))
+	if err != nil {
+		return
+	}
+	if info.Size() > budget {
+		slog.Warn("Page is over its size budget", "file", filepath.Join(outputDir, filename), "kb", info.Size()/1024, "budget_kb", budget/1024)
+	}
+}
+
+// DirSize returns the total size in bytes of all files under dir
+func DirSize(dir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to measure %s: %w", dir, err)
+	}
+	return total, nil
 }
 
 func (s *AnalyticsService) prepareViewModel(m schema.Metrics, config GenConfig) (ViewModel, error) {
+	// Public pages keep the link check's counts but not the articles it lists
+	if s.public {
+		metrics.Redact(&m)
+		if config.LinkReport != nil {
+			report := *config.LinkReport
+			report.Results = nil
+			config.LinkReport = &report
+		}
+	}
+
+	m.SourceMetadata = applySourceColors(m, s.theme.Sources)
+
 	// Sort sources by count
 	var sources []schema.SourceInfo
 	for name, count := range m.BySource {
@@ -95,12 +478,10 @@ func (s *AnalyticsService) prepareViewModel(m schema.Metrics, config GenConfig)
 
 		authorCount := 0
 		if name == "Substack" {
-			authorCount = m.BySourceReadStatus["substack_author_count"][0]
-		}
-
-		color := ""
-		if meta, exists := m.SourceMetadata[name]; exists {
-			color = meta.Color
+			authorCount = metrics.SubstackAuthorCount(m)
+		} else if meta := m.SourceMetadata[name]; meta.Feeds > 1 {
+			// Feed reader sources aggregating several feeds get the same per-author breakdown
+			authorCount = meta.Feeds
 		}
 
 		sources = append(sources, schema.SourceInfo{
@@ -110,7 +491,7 @@ func (s *AnalyticsService) prepareViewModel(m schema.Metrics, config GenConfig)
 			Unread:      unread,
 			ReadPct:     readPct,
 			AuthorCount: authorCount,
-			Color:       color,
+			Color:       SourceColor(name, m.SourceMetadata[name].Color),
 		})
 	}
 
@@ -171,11 +552,15 @@ func (s *AnalyticsService) prepareViewModel(m schema.Metrics, config GenConfig)
 		allSources = append(allSources, source.Name)
 	}
 
-	// Determine current month (MM format) for badge calculation
+	// Determine current month (MM format) for badge calculation, from the snapshot's own date in the
+	// configured timezone so archived pages and builds near a month boundary agree
 	now := time.Now()
+	if !m.LastUpdated.IsZero() {
+		now = m.LastUpdated.Local()
+	}
 	currentMonth := now.Format("01")
 
-	// If the current month (from system time) has no data,
+	// If the snapshot's month has no data,
 	// fall back to the latest month available in the metrics to provide
 	// a better "latest snapshot" view.
 	if _, exists := m.ByMonth[currentMonth]; !exists {
@@ -188,11 +573,6 @@ func (s *AnalyticsService) prepareViewModel(m schema.Metrics, config GenConfig)
 		}
 	}
 
-	// Calculate badges using metrics package helpers
-	topReadRateSource := metrics.CalculateTopReadRateSource(m)
-	mostUnreadSource := metrics.CalculateMostUnreadSource(m)
-	thisMonthArticles := metrics.CalculateThisMonthArticles(m, currentMonth)
-
 	// Prepare chart data using analytics helpers
 	yearChartData := PrepareYearChartData(years)
 	monthChartData := PrepareMonthChartData(monthlyAggregated, sources)
@@ -201,32 +581,45 @@ func (s *AnalyticsService) prepareViewModel(m schema.Metrics, config GenConfig)
 	readUnreadByMonthJSON := PrepareReadUnreadByMonth(m)
 	readUnreadBySourceJSON := PrepareReadUnreadBySource(sources)
 	readUnreadByYearJSON := PrepareReadUnreadByYear(m)
+	readUnreadByFiscalYearJSON := PrepareReadUnreadByFiscalYear(m)
 	unreadArticleAgeDistributionJSON := PrepareUnreadArticleAgeDistribution(m)
 	unreadByYearJSON := PrepareUnreadByYear(m)
+	energyHistoryJSON := PrepareEnergyHistory(config.EnergyHistory, config.ReportDate)
 
 	// Marshal AllYears and AllSources to JSON for JavaScript
 	allYearsJSON, _ := json.Marshal(allYears)
 	allSourcesJSON, _ := json.Marshal(allSources)
 
 	// Prepare key metrics
+	loc := s.branding.Locale
 	keyMetrics := []schema.KeyMetric{
-		{Title: "Total Articles", Value: fmt.Sprintf("%d", m.TotalArticles)},
-		{Title: "Read Rate", Value: fmt.Sprintf("%.1f%%", m.ReadRate)},
-		{Title: "Read", Value: fmt.Sprintf("%d", m.ReadCount)},
-		{Title: "Unread", Value: fmt.Sprintf("%d", m.UnreadCount)},
-		{Title: "Avg/Month", Value: fmt.Sprintf("%.0f", m.AvgArticlesPerMonth)},
+		{Title: "Total Articles", Value: loc.Int(m.TotalArticles)},
+		{Title: "Read Rate", Value: loc.Decimal(m.ReadRate, 1) + "%"},
+		{Title: "Read", Value: loc.Int(m.ReadCount)},
+		{Title: "Unread", Value: loc.Int(m.UnreadCount)},
+		{Title: "Avg/Month", Value: loc.Decimal(m.AvgArticlesPerMonth, 0)},
+	}
+	for _, window := range m.Rolling {
+		keyMetrics = append(keyMetrics, schema.KeyMetric{Title: fmt.Sprintf("Last %d Days", window.Days), Value: FormatRollingWindow(window)})
+	}
+	var deltaSince time.Time
+	if config.Previous != nil {
+		change := metrics.Compare(*config.Previous, m)
+		deltaSince = change.Since
+		AnnotateKeyMetrics(keyMetrics, change, loc)
 	}
 
-	highlightMetrics := []schema.HightlightMetric{
-		{Title: "🎯 Top Read Rate Source", Value: topReadRateSource},
-		{Title: "📚 Most Unread Source", Value: mostUnreadSource},
-		{Title: "✅ This Month's Articles", Value: fmt.Sprintf("%d", thisMonthArticles)},
+	// Compute the configured highlight badges
+	in := highlightInput{m: m, sources: sources, currentMonth: currentMonth}
+	highlights := make([]schema.HightlightMetric, 0, len(s.highlights))
+	for _, h := range s.highlights {
+		highlights = append(highlights, schema.HightlightMetric{Title: h.Title, Value: h.value(in, loc)})
 	}
 
-	// Load evolution data
-	evolutionData, err := LoadEvolutionData()
+	// Load evolution data, with milestones generated from the snapshot
+	evolutionData, err := LoadEvolutionTimeline(m, loc)
 	if err != nil {
-		log.Printf("⚠️ Warning: Failed to load evolution data: %v", err)
+		slog.Warn("Failed to load evolution data", "err", err)
 	} else {
 		// Sort chapters by period descending (assuming order in YAML is chronological, we reverse it)
 		// Or strictly, we just iterate backwards in the template.
@@ -247,21 +640,34 @@ func (s *AnalyticsService) prepareViewModel(m schema.Metrics, config GenConfig)
 	// Load landing content
 	landing, err := LoadLanding()
 	if err != nil {
-		log.Printf("⚠️ Warning: Failed to load landing content: %v", err)
+		slog.Warn("Failed to load landing content", "err", err)
 	}
 
-	return ViewModel{
-		AnalyticsTitle:                   AnalyticsTitle,
+	categories := PrepareCategories(m)
+
+	vm := ViewModel{
+		AnalyticsTitle:                   s.branding.Title,
+		Footer:                           s.branding.Footer,
+		Locale:                           loc,
+		Theme:                            s.theme,
+		ChartThemeJSON:                   s.theme.chartThemeJSON(),
 		KeyMetrics:                       keyMetrics,
-		HighlightMetrics:                 highlightMetrics,
+		DeltaSince:                       deltaSince,
+		Alerts:                           m.Alerts,
+		HighlightMetrics:                 highlights,
 		TotalArticles:                    m.TotalArticles,
 		ReadCount:                        m.ReadCount,
 		UnreadCount:                      m.UnreadCount,
 		ReadRate:                         m.ReadRate,
 		AvgArticlesPerMonth:              m.AvgArticlesPerMonth,
-		LastUpdated:                      m.LastUpdated,
+		LastUpdated:                      m.LastUpdated.Local(),
 		AIDeltaAnalysis:                  m.AIDeltaAnalysis,
 		Sources:                          sources,
+		TopDomains:                       PrepareTopDomains(m),
+		MediaTypes:                       PrepareMediaTypes(m),
+		Authors:                          PrepareAuthors(m),
+		Categories:                       categories,
+		CategoryChartJSON:                PrepareCategoryChart(m, categories),
 		Months:                           monthlyAggregated,
 		Years:                            years,
 		AllYears:                         allYears,
@@ -273,13 +679,32 @@ func (s *AnalyticsService) prepareViewModel(m schema.Metrics, config GenConfig)
 		MonthChartLabels:                 template.JS(monthChartData.LabelsJSON),
 		MonthChartDatasets:               template.JS(monthChartData.DatasetsJSON),
 		MonthTotalData:                   template.JS(monthChartData.TotalDataJSON),
+		MonthTimelineJSON:                PrepareMonthTimeline(m),
 		ReadUnreadByMonthJSON:            readUnreadByMonthJSON,
 		ReadUnreadBySourceJSON:           readUnreadBySourceJSON,
 		ReadUnreadByYearJSON:             readUnreadByYearJSON,
+		ReadUnreadByFiscalYearJSON:       readUnreadByFiscalYearJSON,
+		QuarterTrendJSON:                 PrepareQuarterTrend(m),
+		WeeklyTrendJSON:                  PrepareWeeklyTrend(m),
+		WeekdayPattern:                   PrepareWeekdayPattern(m),
+		WeekdayChartJSON:                 PrepareWeekdayChart(m),
+		FiscalYearSpan:                   FiscalYearSpan(m.YearStartMonth),
 		UnreadArticleAgeDistributionJSON: unreadArticleAgeDistributionJSON,
 		UnreadByYearJSON:                 unreadByYearJSON,
+		EnergyScore:                      m.EnergyScore,
+		EnergyHistoryJSON:                energyHistoryJSON,
+		BacklogFlowJSON:                  PrepareBacklogFlow(config.BacklogFlow, config.ReportDate),
+		SourceReadRateJSON:               PrepareSourceReadRates(config.SourceReadRates, m, config.ReportDate),
+		MediaTypeChartDataJSON:           s.prepareMediaTypeChartData(m),
+		Consumption:                      m.Consumption,
+		ConsumptionJSON:                  PrepareConsumption(m),
+		Community:                        m.Community,
 		TopOldestUnreadArticles:          m.TopOldestUnreadArticles,
+		QuickWins:                        m.QuickWins,
+		QuickWinMinutes:                  metrics.EstimatedArticleMinutes,
 		EvolutionData:                    evolutionData,
+		ProviderTimeline:                 config.ProviderTimeline,
+		ProviderTimelineJSON:             PrepareProviderTimeline(config.ProviderTimeline),
 		Landing:                          landing,
 
 		// New fields from config
@@ -287,81 +712,33 @@ func (s *AnalyticsService) prepareViewModel(m schema.Metrics, config GenConfig)
 		IsHistorical: config.IsHistorical,
 		HistoryDates: config.HistoryDates,
 		ReportDate:   config.ReportDate,
-	}, nil
-}
+		LinkReport:   config.LinkReport,
 
-func (s *AnalyticsService) render(vm ViewModel, outputDir string, pages []struct {
-	Filename string
-	Title    string
-}, isRoot bool) error {
-	// Get templates directory
-	tmplDir, err := GetTemplatesDir()
-	if err != nil {
-		return fmt.Errorf("failed to get templates directory: %w", err)
+		ReadingPlan:    config.ReadingPlan,
+		ReadingPlanURL: forecast.CalendarFile,
 	}
-
-	// Common function map
-	funcMap := template.FuncMap{
-		"divideFloat": func(a, b int) float64 {
-			if b == 0 {
-				return 0
-			}
-			return float64(a) / float64(b)
-		},
-		"sub": func(a, b int) int {
-			return a - b
-		},
+	if config.IsHistorical {
+		vm.OlderReportDate, vm.NewerReportDate = adjacentReportDates(config.HistoryDates, config.ReportDate)
 	}
-
-	// Create output directory
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+	if hasAsset(templatePath(ThemeCSSFile)) {
+		vm.ThemeCSSURL = config.BaseURL + ThemeCSSFile
 	}
-
-	// Copy static SEO/AI metadata files recursively
-	if isRoot {
-		staticSrc := filepath.Join(tmplDir, "static")
-		if err := s.copyStaticFiles(staticSrc, outputDir, vm); err != nil {
-			log.Printf("⚠️ Warning: Failed to process static directory: %v", err)
+	if landing.Header.SiteURL != "" {
+		vm.OGImageURL = siteRoot(landing.Header.SiteURL) + OGImageFile
+		vm.CanonicalBase = siteRoot(landing.Header.SiteURL)
+		if dir := strings.Trim(config.CanonicalDir, "/"); dir != "" {
+			vm.CanonicalBase += dir + "/"
 		}
 	}
-
-	// Loop and generate each page
-	for _, page := range pages {
-		// Create new template instance for this page
-		tmpl := template.New("").Funcs(funcMap)
-
-		// Parse shared templates and the specific page template
-		files := []string{
-			filepath.Join(tmplDir, "base.html"),
-			filepath.Join(tmplDir, page.Filename),
-		}
-
-		// Parse files
-		tmpl, err = tmpl.ParseFiles(files...)
-		if err != nil {
-			return fmt.Errorf("failed to parse templates for %s: %w", page.Filename, err)
-		}
-
-		// Create output file
-		outPath := filepath.Join(outputDir, page.Filename)
-		f, err := os.Create(outPath)
-		if err != nil {
-			return fmt.Errorf("failed to create %s: %w", outPath, err)
-		}
-		defer f.Close()
-
-		// Update PageTitle in ViewModel for this page
-		vm.PageTitle = page.Title
-
-		// Execute the template matching the filename
-		err = tmpl.ExecuteTemplate(f, page.Filename, vm)
-		if err != nil {
-			return fmt.Errorf("failed to execute template for %s: %w", page.Filename, err)
-		}
+	if config.Feed {
+		vm.FeedURL = config.BaseURL + feed.File
+		vm.JSONFeedURL = config.BaseURL + feed.JSONFile
 	}
-
-	return nil
+	vm.ChartTables = PrepareChartTables(vm)
+	if config.Charts == ChartsSVG {
+		vm.SVGCharts = PrepareSVGCharts(vm)
+	}
+	return vm, nil
 }
 
 // copyDir recursively copies a directory tree, attempting to preserve permissions.
@@ -415,7 +792,7 @@ func copyFile(src, dst string) error {
 	}
 	defer in.Close()
 
-	out, err := os.Create(dst)
+	out, err := safefile.Create(dst, 0644)
 	if err != nil {
 		return err
 	}
@@ -426,60 +803,7 @@ func copyFile(src, dst string) error {
 		return err
 	}
 
-	return out.Close()
-}
-
-// copyStaticFiles recursively processes the static directory, treating certain files as templates
-func (s *AnalyticsService) copyStaticFiles(src, dst string, vm ViewModel) error {
-	entries, err := os.ReadDir(src)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
-		}
-		return err
-	}
-
-	if err := os.MkdirAll(dst, 0755); err != nil {
-		return err
-	}
-
-	for _, entry := range entries {
-		srcPath := filepath.Join(src, entry.Name())
-		dstPath := filepath.Join(dst, entry.Name())
-
-		if entry.IsDir() {
-			if err := s.copyStaticFiles(srcPath, dstPath, vm); err != nil {
-				return err
-			}
-			continue
-		}
-
-		// Treat text files as templates to inject config
-		if entry.Name() == "llms.txt" || entry.Name() == "robots.txt" {
-			t, err := texttmpl.ParseFiles(srcPath)
-			if err != nil {
-				log.Printf("⚠️ Warning: Failed to parse %s as template: %v", entry.Name(), err)
-				continue
-			}
-
-			f, err := os.Create(dstPath)
-			if err != nil {
-				log.Printf("⚠️ Warning: Failed to create %s: %v", dstPath, err)
-				continue
-			}
-
-			if err := t.Execute(f, vm); err != nil {
-				log.Printf("⚠️ Warning: Failed to execute template %s: %v", entry.Name(), err)
-			}
-			f.Close()
-		} else {
-			if err := copyFile(srcPath, dstPath); err != nil {
-				log.Printf("⚠️ Warning: Failed to copy static file %s: %v", entry.Name(), err)
-			}
-		}
-	}
-
-	return nil
+	return out.Commit()
 }
 
 // generateRegistry creates the evolution-registry.json file from the evolution data
@@ -514,9 +838,10 @@ func (s *AnalyticsService) generateRegistry(vm ViewModel, outputDir string) erro
 	}
 
 	registryPath := filepath.Join(apiDir, "evolution-registry.json")
-	if err := os.WriteFile(registryPath, registryJSON, 0644); err != nil {
+	if err := safefile.WriteFile(registryPath, registryJSON, 0644); err != nil {
 		return fmt.Errorf("failed to write evolution-registry.json: %w", err)
 	}
+	s.record(registryPath)
 
 	return nil
 }
@@ -1,11 +1,17 @@
 package web
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/linkcheck"
 )
 
 func TestAnalyticsService_Generate(t *testing.T) {
@@ -50,8 +56,7 @@ func TestAnalyticsService_Generate(t *testing.T) {
 			}
 			defer os.RemoveAll(tmpDir)
 
-			// The service looks for templates relative to CWD or in specific paths.
-			// For testing, we'll create a mock structure.
+			// Mock templates and content, read through SetAssetsDir
 			templateDir := filepath.Join(tmpDir, "internal", "web", "templates")
 			if err := os.MkdirAll(templateDir, 0755); err != nil {
 				t.Fatal(err)
@@ -61,13 +66,19 @@ func TestAnalyticsService_Generate(t *testing.T) {
 			baseTmpl := `{{define "base"}}<html><head><title>{{.AnalyticsTitle}} - {{.PageTitle}}</title></head><body><div id="app"><header><h1>{{.PageTitle}}</h1><nav><ul><li><a href="{{.BaseURL}}index.html">Home</a></li></ul></nav></header>{{block "content" .}}{{end}}</div></body></html>{{end}}`
 			indexTmpl := `{{define "content"}}<h1>Home</h1>{{end}}{{template "base" .}}`
 			webTmpl := `{{define "content"}}<h1>Analytics</h1>{{end}}{{template "base" .}}`
+			authorsTmpl := `{{define "content"}}<h1>Authors</h1>{{end}}{{template "base" .}}`
+			topicsTmpl := `{{define "content"}}<h1>Topics</h1>{{end}}{{template "base" .}}`
 			evolutionTmpl := `{{define "content"}}<h1>Evolution</h1>{{end}}{{template "base" .}}`
+			explorerTmpl := `{{define "content"}}<h1>Explorer</h1>{{end}}{{template "base" .}}`
 
 			templates := map[string]string{
 				"base.html":      baseTmpl,
 				"index.html":     indexTmpl,
 				"analytics.html": webTmpl,
+				"authors.html":   authorsTmpl,
+				"topics.html":    topicsTmpl,
 				"evolution.html": evolutionTmpl,
+				"explorer.html":  explorerTmpl,
 			}
 
 			for name, content := range templates {
@@ -113,6 +124,10 @@ engineering_principles_section:
 			if err := os.Chdir(tmpDir); err != nil {
 				t.Fatal(err)
 			}
+			if err := SetAssetsDir(filepath.Join("internal", "web")); err != nil {
+				t.Fatal(err)
+			}
+			defer SetAssetsDir("")
 
 			service := NewAnalyticsService("dist")
 			config := GenConfig{
@@ -124,7 +139,7 @@ engineering_principles_section:
 			}
 
 			// Test Full Site Generation
-			err = service.GenerateFullSite(tt.metrics, config)
+			err = service.GenerateFullSite(t.Context(), tt.metrics, config)
 			if (err == nil) != tt.expectSuccess {
 				t.Errorf("GenerateFullSite() error = %v, expectSuccess %v", err, tt.expectSuccess)
 			}
@@ -137,7 +152,7 @@ engineering_principles_section:
 			config.IsHistorical = true
 			config.OutputDir = "dist/history/2024-01-01"
 			config.BaseURL = "../../"
-			err = service.GenerateAnalyticsOnly(tt.metrics, config)
+			err = service.GenerateAnalyticsOnly(t.Context(), tt.metrics, config)
 			if (err == nil) != tt.expectSuccess {
 				t.Errorf("GenerateAnalyticsOnly() error = %v, expectSuccess %v", err, tt.expectSuccess)
 			}
@@ -145,10 +160,60 @@ engineering_principles_section:
 			if _, err := os.Stat("dist/history/2024-01-01/analytics.html"); os.IsNotExist(err) {
 				t.Error("dist/history/2024-01-01/analytics.html was not created")
 			}
+
+			// Lazy chart data writes a JSON file per chart family next to the page, which fetches them
+			config.OutputDir = "dist/history/2024-01-02"
+			config.LazyChartData = true
+			if err := service.GenerateAnalyticsOnly(t.Context(), tt.metrics, config); err != nil {
+				t.Fatalf("GenerateAnalyticsOnly() with lazy chart data failed: %v", err)
+			}
+			chartData, err := os.ReadFile(filepath.Join(config.OutputDir, ChartDataDir, "years.json"))
+			if err != nil {
+				t.Fatalf("years.json was not created: %v", err)
+			}
+			var decoded map[string]json.RawMessage
+			if err := json.Unmarshal(chartData, &decoded); err != nil {
+				t.Fatalf("years.json is not valid JSON: %v", err)
+			}
+			if _, ok := decoded["yearChartLabels"]; !ok {
+				t.Errorf("expected yearChartLabels in years.json, got %s", chartData)
+			}
+
+			// Every generated file is tracked for the site manifest
+			written := strings.Join(service.WrittenFiles(), ",")
+			for _, file := range []string{"index.html", "explorer.html", "api/evolution-registry.json", "history/2024-01-01/analytics.html", "history/2024-01-02/data/months.json"} {
+				if !strings.Contains(written, file) {
+					t.Errorf("expected %s in written files, got %s", file, written)
+				}
+			}
 		})
 	}
 }
 
+func TestGenerateCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	m := schema.Metrics{TotalArticles: 1, UnreadCount: 1}
+	if err := service.GenerateFullSite(ctx, m, GenConfig{OutputDir: dir}); !errors.Is(err, context.Canceled) {
+		t.Errorf("GenerateFullSite() error = %v, want context.Canceled", err)
+	}
+	if err := service.GenerateAnalyticsOnly(ctx, m, GenConfig{OutputDir: dir}); !errors.Is(err, context.Canceled) {
+		t.Errorf("GenerateAnalyticsOnly() error = %v, want context.Canceled", err)
+	}
+	if err := (HTMLRenderer{}).Render(ctx, ViewModel{}, OutputTarget{Dir: dir, Pages: []Page{{"analytics.html", "Analytics"}}}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Render() error = %v, want context.Canceled", err)
+	}
+	if files := service.WrittenFiles(); len(files) != 0 {
+		t.Errorf("expected nothing written once cancelled, got %v", files)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "analytics.html")); !os.IsNotExist(err) {
+		t.Error("expected no page to be written once cancelled")
+	}
+}
+
 func TestCopyFile(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -281,3 +346,362 @@ func TestCopyDir(t *testing.T) {
 		})
 	}
 }
+
+func TestChartDataJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		vm      ViewModel
+		wantErr bool
+		want    map[string]string
+	}{
+		{
+			name: "bundles series and fills missing ones with null",
+			vm: ViewModel{
+				YearChartLabels: `["2024","2025"]`,
+				YearChartData:   `[3,4]`,
+			},
+			want: map[string]string{
+				"yearChartLabels":        `["2024","2025"]`,
+				"yearChartData":          `[3,4]`,
+				"energyHistory":          `null`,
+				"byMediaType":            `null`,
+				"consumption":            `null`,
+				"readUnreadByFiscalYear": `null`,
+				"byQuarter":              `null`,
+				"byISOWeek":              `null`,
+				"byWeekday":              `null`,
+				"backlogFlow":            `null`,
+				"monthTimeline":          `null`,
+				"sourceReadRates":        `null`,
+			},
+		},
+		{
+			name:    "rejects invalid JSON",
+			vm:      ViewModel{YearChartData: `[3,`},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := ChartDataJSON(tt.vm)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ChartDataJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var decoded map[string]json.RawMessage
+			if err := json.Unmarshal(content, &decoded); err != nil {
+				t.Fatalf("invalid JSON output: %v", err)
+			}
+			if len(decoded) != 20 {
+				t.Errorf("expected 20 chart series, got %d", len(decoded))
+			}
+			for key, want := range tt.want {
+				if got := string(decoded[key]); got != want {
+					t.Errorf("%s = %s, want %s", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestChartDataFiles(t *testing.T) {
+	files, err := ChartDataFiles(ViewModel{YearChartLabels: `["2025"]`, YearChartData: `[3]`, ConsumptionJSON: `{"labels":[]}`})
+	if err != nil {
+		t.Fatalf("ChartDataFiles() error = %v", err)
+	}
+	if len(files) != 2 || string(files["years.json"]) != `{"yearChartData":[3],"yearChartLabels":["2025"]}` || string(files["consumption.json"]) != `{"consumption":{"labels":[]}}` {
+		t.Errorf("expected only the families with data, got %q", files)
+	}
+
+	// Every series the page reads must land in some file
+	series, err := chartSeries(ViewModel{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	grouped := make(map[string]bool)
+	for _, file := range chartDataFiles {
+		for _, key := range file.keys {
+			grouped[key] = true
+		}
+	}
+	for key := range series {
+		if !grouped[key] {
+			t.Errorf("chart series %s is not written to any chart data file", key)
+		}
+	}
+
+	if _, err := ChartDataFiles(ViewModel{MonthTotalData: `[1,`}); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+
+	// Both the root and history pages fetch their files instead of inlining the series
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	m := schema.Metrics{TotalArticles: 1, ReadCount: 1, ByYear: map[string]int{"2025": 1}}
+	if err := service.GenerateFullSite(t.Context(), m, GenConfig{OutputDir: dir, BaseURL: "./", LazyChartData: true, PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateFullSite() error = %v", err)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "analytics.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(page), `"data/years.json"`) || strings.Contains(string(page), "initAnalyticsCharts({") {
+		t.Error("expected the page to fetch its chart data instead of inlining it")
+	}
+	if _, err := os.Stat(filepath.Join(dir, ChartDataDir, "read-unread.json")); err != nil {
+		t.Errorf("expected the root chart data files: %v", err)
+	}
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "history", "2024-01-01"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "history", "2024-01-01", "analytics.html"), make([]byte, 50), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	size, err := DirSize(dir)
+	if err != nil {
+		t.Fatalf("DirSize() error = %v", err)
+	}
+	if size != 150 {
+		t.Errorf("DirSize() = %d, want 150", size)
+	}
+
+	if _, err := DirSize(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
+
+func TestPrepareMediaTypeChartData(t *testing.T) {
+	service := NewAnalyticsService(t.TempDir())
+	if got := service.prepareMediaTypeChartData(schema.Metrics{}); got != "" {
+		t.Errorf("expected no media type chart data, got %s", got)
+	}
+
+	typeMetrics := schema.Metrics{ByYear: map[string]int{"2025": 2}, BySource: map[string]int{"GitHub": 2}}
+	got := service.prepareMediaTypeChartData(schema.Metrics{
+		MediaTypes: map[string]schema.Metrics{"article": typeMetrics, "video": typeMetrics},
+	})
+
+	var decoded map[string]map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+		t.Fatalf("invalid media type chart data %s: %v", got, err)
+	}
+	if len(decoded) != 2 || decoded["video"]["yearChartLabels"] == nil {
+		t.Errorf("expected chart series for both media types, got %s", got)
+	}
+}
+
+func TestPrepareViewModelMonthBoundary(t *testing.T) {
+	original := time.Local
+	t.Cleanup(func() { time.Local = original })
+	vancouver, err := time.LoadLocation("America/Vancouver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Local = vancouver
+
+	// Taken at 06:30 UTC on April 1, which is still March 31 in the configured zone
+	m := schema.Metrics{
+		TotalArticles:    5,
+		LastUpdated:      time.Date(2026, 4, 1, 6, 30, 0, 0, time.UTC),
+		ByMonth:          map[string]int{"03": 3, "04": 2},
+		ByMonthAndSource: map[string]map[string][2]int{"03": {"GitHub": {3, 0}}, "04": {"GitHub": {1, 1}}},
+	}
+	vm, err := NewAnalyticsService(t.TempDir()).prepareViewModel(m, GenConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := vm.HighlightMetrics[2].Value; got != "3" {
+		t.Errorf("This Month's Articles = %s, want March's 3", got)
+	}
+	if vm.LastUpdated.Location() != vancouver || vm.LastUpdated.Day() != 31 {
+		t.Errorf("expected LastUpdated in the configured zone, got %v", vm.LastUpdated)
+	}
+}
+
+func TestPublicViewModel(t *testing.T) {
+	article := schema.ArticleMeta{Title: "Private", Date: "2020-01-01", Link: "https://example.com/private", Category: "GitHub"}
+	m := schema.Metrics{TotalArticles: 1, UnreadCount: 1, TopOldestUnreadArticles: []schema.ArticleMeta{article}}
+	report := &linkcheck.Report{Checked: 1, Counts: map[string]int{"dead": 1}, Results: []linkcheck.Result{{Title: "Private", Link: article.Link}}}
+
+	service := NewAnalyticsService(t.TempDir())
+	service.SetPublic(true)
+	vm, err := service.prepareViewModel(m, GenConfig{LinkReport: report})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := vm.TopOldestUnreadArticles[0]; got.Title != "" || got.Link != "" || got.Date != "2020-01-01" || got.Category != "GitHub" {
+		t.Errorf("expected only the date and source of unread articles, got %+v", got)
+	}
+	if vm.LinkReport.Results != nil || vm.LinkReport.Counts["dead"] != 1 {
+		t.Errorf("expected the link check counts without its articles, got %+v", vm.LinkReport)
+	}
+	if m.TopOldestUnreadArticles[0].Title != "Private" || len(report.Results) != 1 {
+		t.Error("expected the caller's snapshot and report to be left untouched")
+	}
+}
+
+func TestQuickWinsSection(t *testing.T) {
+	quickWin := schema.QuickWin{ArticleMeta: schema.ArticleMeta{Title: "Short read", Date: "2025-01-01", Link: "https://example.com/a", Category: "GitHub"}, EstimatedMinutes: 3}
+	m := schema.Metrics{TotalArticles: 2, UnreadCount: 2, QuickWins: &schema.QuickWins{
+		Shortest:       []schema.QuickWin{quickWin},
+		DomainClusters: []schema.DomainCluster{{Domain: "example.com", Unread: 2, EstimatedMinutes: 13, Articles: []schema.QuickWin{quickWin}}},
+	}}
+
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: dir, BaseURL: "./", PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "analytics.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`aria-label="Quick Wins"`, "Shortest Reads", `href="https://example.com/a"`, "about 13 min for these"} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("expected analytics.html to contain %q", want)
+		}
+	}
+	if strings.Contains(string(page), "Oldest per Source") {
+		t.Error("expected empty quick wins lists to be left out")
+	}
+}
+
+func TestKeyMetricDeltas(t *testing.T) {
+	previous := schema.Metrics{TotalArticles: 100, ReadCount: 60, UnreadCount: 40, ReadRate: 60, LastUpdated: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	m := schema.Metrics{TotalArticles: 104, ReadCount: 64, UnreadCount: 40, ReadRate: 61.4}
+
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: dir, BaseURL: "./", PageBudgetBytes: -1, Previous: &previous}); err != nil {
+		t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "analytics.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`data-trend="up"`, "▲1.4", "▲4", `data-trend="flat"`, "±0"} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("expected analytics.html to contain %q", want)
+		}
+	}
+
+	if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: dir, BaseURL: "./", PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+	}
+	page, err = os.ReadFile(filepath.Join(dir, "analytics.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(page), "data-trend") {
+		t.Error("expected no deltas without a previous snapshot")
+	}
+}
+
+func TestAlertCallouts(t *testing.T) {
+	m := schema.Metrics{TotalArticles: 1000, Alerts: []schema.Alert{
+		{Kind: "milestone", Message: "Crossed 1000 articles saved"},
+		{Kind: "anomaly", Message: "Read rate dropped 11.5 points"},
+	}}
+
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: dir, BaseURL: "./", PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "analytics.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`aria-label="Alerts"`, "🎉</span> Crossed 1000 articles saved", "⚠️</span> Read rate dropped 11.5 points"} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("expected analytics.html to contain %q", want)
+		}
+	}
+}
+
+func TestTopicsPage(t *testing.T) {
+	m := schema.Metrics{TotalArticles: 3, ByCategoryAndSource: map[string]map[string][2]int{"Databases": {"GitHub": {2, 0}, "Stripe": {0, 1}}}}
+
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	if err := service.GenerateFullSite(t.Context(), m, GenConfig{OutputDir: dir, BaseURL: "./", PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateFullSite() error = %v", err)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "topics.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`id="categoryChart"`, "<summary", "Databases", "3 articles", "Stripe"} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("expected topics.html to contain %q", want)
+		}
+	}
+
+	if err := service.GenerateFullSite(t.Context(), m, GenConfig{OutputDir: dir, BaseURL: "./", PageBudgetBytes: -1, Charts: ChartsSVG}); err != nil {
+		t.Fatalf("GenerateFullSite() error = %v", err)
+	}
+	page, _ = os.ReadFile(filepath.Join(dir, "topics.html"))
+	if !strings.Contains(string(page), "<svg") || strings.Contains(string(page), "new Chart(") {
+		t.Error("expected the topics chart drawn as SVG without Chart.js")
+	}
+}
+
+func TestCanonicalURLs(t *testing.T) {
+	const site = "https://victoriacheng15.github.io/personal-reading-analytics/"
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	m := schema.Metrics{TotalArticles: 2, ReadCount: 1, UnreadCount: 1}
+	if err := service.GenerateFullSite(t.Context(), m, GenConfig{OutputDir: dir, BaseURL: "./", HistoryDates: []string{"2025-01-05"}, PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateFullSite() error = %v", err)
+	}
+	historyDir := filepath.Join(dir, "history", "2025-01-05")
+	if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: historyDir, BaseURL: "../../", IsHistorical: true, CanonicalDir: "history/2025-01-05", PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+	}
+	if err := service.GenerateHistoryIndex(t.Context(), m, nil, GenConfig{OutputDir: filepath.Join(dir, "history"), BaseURL: "../", HistoryDates: []string{"2025-01-05"}}); err != nil {
+		t.Fatalf("GenerateHistoryIndex() error = %v", err)
+	}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"index.html", `<link rel="canonical" href="` + site + `">`},
+		{filepath.Join("history", "index.html"), `<link rel="canonical" href="` + site + `history/">`},
+		{"analytics.html", `<link rel="canonical" href="` + site + `analytics.html">`},
+		{"analytics.html", `<meta property="og:url" content="` + site + `analytics.html">`},
+		{filepath.Join("history", "2025-01-05", "analytics.html"), `<link rel="canonical" href="` + site + `history/2025-01-05/analytics.html">`},
+		{"404.html", `<meta name="robots" content="noindex">`},
+		{"404.html", `href="` + site + `history/2025-01-05/analytics.html"`},
+		{"404.html", `href="` + site + `css/styles.css"`},
+	}
+	for _, tt := range tests {
+		page, err := os.ReadFile(filepath.Join(dir, tt.path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(page), tt.expected) {
+			t.Errorf("%s: expected %q", tt.path, tt.expected)
+		}
+	}
+
+	notFound, _ := os.ReadFile(filepath.Join(dir, "404.html"))
+	if strings.Contains(string(notFound), `rel="canonical"`) {
+		t.Error("expected no canonical link on 404.html")
+	}
+	if files := strings.Join(service.WrittenFiles(), ","); !strings.Contains(files, "404.html") {
+		t.Errorf("expected 404.html in the written files, got %v", service.WrittenFiles())
+	}
+}
@@ -0,0 +1,56 @@
+package web
+
+import (
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// SourceColor is the chart color of a source: the color it was given when set, otherwise one derived
+// from its name so the source keeps the same color on every chart and page
+func SourceColor(name, color string) string {
+	if color != "" {
+		return color
+	}
+	return "#" + colorHash(name)
+}
+
+// applySourceColors returns the snapshot's source metadata with the colors of config.yml's theme
+// sources over the brand colors of the providers sheet, for the sources the snapshot has. The
+// snapshot's own map is left untouched.
+func applySourceColors(m schema.Metrics, colors map[string]string) map[string]schema.SourceMeta {
+	if len(colors) == 0 {
+		return m.SourceMetadata
+	}
+	metadata := make(map[string]schema.SourceMeta, len(m.SourceMetadata)+len(colors))
+	for name, meta := range m.SourceMetadata {
+		metadata[name] = meta
+	}
+	for name, color := range colors {
+		meta, exists := metadata[name]
+		if _, counted := m.BySource[name]; !exists && !counted {
+			continue
+		}
+		meta.Color = color
+		metadata[name] = meta
+	}
+	return metadata
+}
+
+// colorHash generates a simple hash for generating colors
+func colorHash(s string) string {
+	h := uint32(5381)
+	for i := 0; i < len(s); i++ {
+		h = ((h << 5) + h) + uint32(s[i])
+	}
+	return formatHex(h % 16777215)
+}
+
+// formatHex formats a number as a 6-digit hex string
+func formatHex(n uint32) string {
+	const hex = "0123456789abcdef"
+	b := make([]byte, 6)
+	for i := 5; i >= 0; i-- {
+		b[i] = hex[n%16]
+		n /= 16
+	}
+	return string(b)
+}
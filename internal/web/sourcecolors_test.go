@@ -0,0 +1,177 @@
+package web
+
+import (
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// ============================================================================
+// formatHex: Formats a number as a 6-digit hex string
+// ============================================================================
+
+func TestFormatHex(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    uint32
+		expected string
+	}{
+		{
+			name:     "zero",
+			input:    0,
+			expected: "000000",
+		},
+		{
+			name:     "small number",
+			input:    255,
+			expected: "0000ff",
+		},
+		{
+			name:     "mid range",
+			input:    4095,
+			expected: "000fff",
+		},
+		{
+			name:     "large number",
+			input:    16777215,
+			expected: "ffffff",
+		},
+		{
+			name:     "arbitrary value",
+			input:    12345,
+			expected: "003039",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatHex(tt.input)
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// colorHash: Generates a simple hash for generating colors
+// ============================================================================
+
+func TestColorHash(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		expectedLength int
+		expectNonEmpty bool
+	}{
+		{
+			name:           "simple string",
+			input:          "test",
+			expectedLength: 6,
+			expectNonEmpty: true,
+		},
+		{
+			name:           "empty string",
+			input:          "",
+			expectedLength: 6,
+			expectNonEmpty: true,
+		},
+		{
+			name:           "long string",
+			input:          "this is a much longer test string",
+			expectedLength: 6,
+			expectNonEmpty: true,
+		},
+		{
+			name:           "special characters",
+			input:          "@#$%^&*()",
+			expectedLength: 6,
+			expectNonEmpty: true,
+		},
+		{
+			name:           "consistent hash",
+			input:          "Substack",
+			expectedLength: 6,
+			expectNonEmpty: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := colorHash(tt.input)
+			if len(result) != tt.expectedLength {
+				t.Errorf("expected length %d, got %d", tt.expectedLength, len(result))
+			}
+			if tt.expectNonEmpty && len(result) == 0 {
+				t.Error("expected non-empty result")
+			}
+
+			// Verify it's valid hex
+			for _, ch := range result {
+				if (ch < '0' || ch > '9') && (ch < 'a' || ch > 'f') {
+					t.Errorf("invalid hex character: %c", ch)
+				}
+			}
+		})
+	}
+}
+
+func TestColorHashConsistency(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "same input produces same hash",
+			input: "GitHub",
+		},
+		{
+			name:  "different inputs produce different hashes",
+			input: "Substack",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash1 := colorHash(tt.input)
+			hash2 := colorHash(tt.input)
+			if hash1 != hash2 {
+				t.Errorf("expected consistent hash, got %s and %s", hash1, hash2)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// SourceColor and applySourceColors: One color per source across charts
+// ============================================================================
+
+func TestSourceColor(t *testing.T) {
+	if got := SourceColor("GitHub", "#24292f"); got != "#24292f" {
+		t.Errorf("expected the given color, got %q", got)
+	}
+	if got := SourceColor("GitHub", ""); got != "#"+colorHash("GitHub") {
+		t.Errorf("expected the color derived from the name, got %q", got)
+	}
+}
+
+func TestApplySourceColors(t *testing.T) {
+	m := schema.Metrics{
+		BySource:       map[string]int{"GitHub": 3, "Stripe": 2},
+		SourceMetadata: map[string]schema.SourceMeta{"GitHub": {Added: "2025-01-01", Color: "#111111"}, "Shopify": {Color: "#222222"}},
+	}
+
+	metadata := applySourceColors(m, map[string]string{"GitHub": "#24292f", "Stripe": "#635bff", "Medium": "#000000"})
+	if metadata["GitHub"].Color != "#24292f" || metadata["GitHub"].Added != "2025-01-01" {
+		t.Errorf("expected the configured color over the brand color, got %+v", metadata["GitHub"])
+	}
+	if metadata["Stripe"].Color != "#635bff" || metadata["Shopify"].Color != "#222222" {
+		t.Errorf("unexpected colors %+v", metadata)
+	}
+	if _, exists := metadata["Medium"]; exists {
+		t.Error("expected sources outside the snapshot to be left out")
+	}
+	if m.SourceMetadata["GitHub"].Color != "#111111" {
+		t.Error("expected the snapshot's metadata to be left untouched")
+	}
+}
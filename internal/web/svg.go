@@ -0,0 +1,455 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Chart renderers selectable per build with GenConfig.Charts
+const (
+	ChartsChartJS = "chartjs" // interactive Chart.js canvases, the default
+	ChartsSVG     = "svg"     // static SVG drawn at build time, readable without JavaScript
+)
+
+// SVG chart geometry in viewBox units; charts scale to the width of their container
+const (
+	svgWidth       = 800
+	svgHeight      = 400
+	svgPadLeft     = 56
+	svgPadRight    = 16
+	svgPadTop      = 40
+	svgPadBottom   = 48
+	svgMaxXLabels  = 12
+	svgGridLines   = 4
+	svgDoughnutGap = 0.002
+)
+
+// svgPalette colors series and slices that bring no color of their own, when the theme has none
+var svgPalette = []string{"#0369a1", "#c2410c", "#059669", "#7c3aed", "#db2777", "#ca8a04", "#64748b"}
+
+// SVGSeries is one named series of an SVG chart, one value per label
+type SVGSeries struct {
+	Label  string
+	Values []float64
+	Color  string
+}
+
+// SVGBarChart draws each series as bars per label, stacked when stacked is set and side by side otherwise
+func SVGBarChart(title string, labels []string, series []SVGSeries, stacked bool) template.HTML {
+	series = colorSeries(series)
+	var b strings.Builder
+	openSVG(&b, title)
+
+	top := 0.0
+	for i := range labels {
+		total := 0.0
+		for _, s := range series {
+			v := valueAt(s.Values, i)
+			if stacked {
+				total += v
+			} else {
+				total = math.Max(total, v)
+			}
+		}
+		top = math.Max(top, total)
+	}
+	scale := drawAxes(&b, labels, top)
+
+	slot := plotWidth() / float64(max(len(labels), 1))
+	groupWidth := slot * 0.7
+	for i := range labels {
+		x := svgPadLeft + slot*float64(i) + (slot-groupWidth)/2
+		base := 0.0
+		for j, s := range series {
+			v := valueAt(s.Values, i)
+			if v <= 0 {
+				continue
+			}
+			barX, barWidth := x, groupWidth
+			if !stacked {
+				barWidth = groupWidth / float64(len(series))
+				barX = x + barWidth*float64(j)
+			}
+			y0, y1 := scale(base), scale(base+v)
+			fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s %s: %s</title></rect>`,
+				barX, y1, barWidth, y0-y1, attr(s.Color), html.EscapeString(labels[i]), html.EscapeString(s.Label), formatSVGNumber(v))
+			if stacked {
+				base += v
+			}
+		}
+	}
+
+	drawLegend(&b, series)
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// SVGLineChart draws each series as a line across the labels, with a dot per value
+func SVGLineChart(title string, labels []string, series []SVGSeries) template.HTML {
+	series = colorSeries(series)
+	var b strings.Builder
+	openSVG(&b, title)
+
+	top := 0.0
+	for _, s := range series {
+		for _, v := range s.Values {
+			if !math.IsNaN(v) {
+				top = math.Max(top, v)
+			}
+		}
+	}
+	scale := drawAxes(&b, labels, top)
+
+	slot := plotWidth() / float64(max(len(labels), 1))
+	for _, s := range series {
+		// NaN values are gaps, which split the line into segments
+		var segments [][]string
+		var points []string
+		var circles strings.Builder
+		for i := range labels {
+			v := valueAt(s.Values, i)
+			if math.IsNaN(v) {
+				if len(points) > 0 {
+					segments = append(segments, points)
+				}
+				points = nil
+				continue
+			}
+			x, y := svgPadLeft+slot*(float64(i)+0.5), scale(v)
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+			fmt.Fprintf(&circles, `<circle cx="%.1f" cy="%.1f" r="3.5" fill="%s"><title>%s %s: %s</title></circle>`,
+				x, y, attr(s.Color), html.EscapeString(labels[i]), html.EscapeString(s.Label), formatSVGNumber(v))
+		}
+		if len(points) > 0 {
+			segments = append(segments, points)
+		}
+		for _, segment := range segments {
+			fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="3" stroke-linejoin="round"/>`, strings.Join(segment, " "), attr(s.Color))
+		}
+		b.WriteString(circles.String())
+	}
+
+	drawLegend(&b, series)
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// SVGDoughnutChart draws values as the slices of a ring, with a legend of each label's value and share
+func SVGDoughnutChart(title string, labels []string, values []float64, colors []string) template.HTML {
+	var b strings.Builder
+	openSVG(&b, title)
+
+	total := 0.0
+	for _, v := range values {
+		total += math.Max(v, 0)
+	}
+
+	const cx, cy, radius, thickness = 200.0, 200.0, 120.0, 56.0
+	circumference := 2 * math.Pi * radius
+	if total == 0 {
+		fmt.Fprintf(&b, `<circle cx="%.0f" cy="%.0f" r="%.0f" fill="none" stroke="#e2e8f0" stroke-width="%.0f"/>`, cx, cy, radius, thickness)
+	}
+
+	offset := 0.0
+	for i, label := range labels {
+		v := math.Max(valueAt(values, i), 0)
+		color := paletteColor(colors, i)
+		share := 0.0
+		if total > 0 {
+			share = v / total
+		}
+		if share > 0 {
+			// Each slice is a dash of the ring's stroke, starting at twelve o'clock
+			length := math.Max(share-svgDoughnutGap, share/2) * circumference
+			fmt.Fprintf(&b, `<circle cx="%.0f" cy="%.0f" r="%.0f" fill="none" stroke="%s" stroke-width="%.0f" stroke-dasharray="%.2f %.2f" stroke-dashoffset="%.2f" transform="rotate(-90 %.0f %.0f)"><title>%s: %s</title></circle>`,
+				cx, cy, radius, attr(color), thickness, length, circumference-length, -offset*circumference, cx, cy, html.EscapeString(label), formatSVGNumber(v))
+			offset += share
+		}
+
+		y := 120 + 28*i
+		fmt.Fprintf(&b, `<rect x="400" y="%d" width="14" height="14" rx="3" fill="%s"/>`, y, attr(color))
+		fmt.Fprintf(&b, `<text x="422" y="%d" font-size="14" fill="currentColor">%s: %s (%.1f%%)</text>`, y+12, html.EscapeString(label), formatSVGNumber(v), share*100)
+	}
+
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// PrepareSVGCharts draws the analytics and topics page charts from the view model's chart series,
+// keyed by the id of the canvas each one replaces. Interactive views (ranges, filters, toggles) show
+// their default, in the colors of the theme pages open in.
+func PrepareSVGCharts(vm ViewModel) map[string]template.HTML {
+	charts := make(map[string]template.HTML)
+	colors := vm.Theme.staticColors()
+
+	var yearLabels []string
+	var yearCounts []float64
+	if decodeSeries(vm.YearChartLabels, &yearLabels) && decodeSeries(vm.YearChartData, &yearCounts) && len(yearLabels) > 0 {
+		// Year labels arrive newest first; the chart reads left to right
+		reverseStrings(yearLabels)
+		reverseFloats(yearCounts)
+		charts["yearChart"] = SVGBarChart("Articles by year", yearLabels, []SVGSeries{{Label: "Articles", Values: yearCounts, Color: colors.Read}}, false)
+	}
+
+	var monthLabels []string
+	var monthDatasets []struct {
+		Label           string    `json:"label"`
+		Data            []float64 `json:"data"`
+		BackgroundColor string    `json:"backgroundColor"`
+	}
+	if decodeSeries(vm.MonthChartLabels, &monthLabels) && decodeSeries(vm.MonthChartDatasets, &monthDatasets) && len(monthLabels) > 0 {
+		series := make([]SVGSeries, 0, len(monthDatasets))
+		for _, dataset := range monthDatasets {
+			series = append(series, SVGSeries{Label: dataset.Label, Values: dataset.Data, Color: dataset.BackgroundColor})
+		}
+		charts["monthChart"] = SVGBarChart("Articles by month and source, all years combined", monthLabels, series, true)
+	}
+
+	if timeline, ok := decodeDatasets(vm.MonthTimelineJSON); ok {
+		lineColors := []string{colors.Primary, colors.Secondary, colors.Accent}
+		for i := range timeline.Series {
+			timeline.Series[i].Color = lineColors[i%len(lineColors)]
+		}
+		charts["monthTimelineChart"] = SVGLineChart("Articles per month with moving averages", timeline.Labels, timeline.Series)
+	}
+
+	var categoryChart struct {
+		Labels   []string `json:"labels"`
+		Datasets []struct {
+			Label           string    `json:"label"`
+			Data            []float64 `json:"data"`
+			BackgroundColor string    `json:"backgroundColor"`
+		} `json:"datasets"`
+	}
+	if decodeSeries(vm.CategoryChartJSON, &categoryChart) && len(categoryChart.Labels) > 0 {
+		series := make([]SVGSeries, 0, len(categoryChart.Datasets))
+		for _, dataset := range categoryChart.Datasets {
+			series = append(series, SVGSeries{Label: dataset.Label, Values: dataset.Data, Color: dataset.BackgroundColor})
+		}
+		charts["categoryChart"] = SVGBarChart("Articles by category and source", categoryChart.Labels, series, true)
+	}
+
+	if data, ok := decodeChartSeries(vm.ReadUnreadByMonthJSON); ok {
+		charts["readUnreadChart"] = SVGBarChart("Read and unread articles by month", data.Labels, readUnreadSeries(data, colors), true)
+	}
+
+	if data, ok := decodeChartSeries(vm.QuarterTrendJSON); ok {
+		charts["periodTrendChart"] = SVGBarChart("Read and unread articles by quarter", data.Labels, readUnreadSeries(data, colors), true)
+	} else if data, ok := decodeChartSeries(vm.WeeklyTrendJSON); ok {
+		charts["periodTrendChart"] = SVGBarChart("Read and unread articles by ISO week", data.Labels, readUnreadSeries(data, colors), true)
+	}
+
+	if data, ok := decodeChartSeries(vm.WeekdayChartJSON); ok {
+		charts["weekdayChart"] = SVGBarChart("Read and unread articles by weekday saved", data.Labels, readUnreadSeries(data, colors), true)
+	}
+
+	if data, ok := decodeChartSeries(vm.UnreadByYearJSON); ok {
+		reverseStrings(data.Labels)
+		reverseFloats(data.Data)
+		charts["unreadByYearChart"] = SVGBarChart("Unread articles by year", data.Labels, []SVGSeries{{Label: "Unread", Values: data.Data, Color: colors.Unread}}, false)
+	}
+
+	if data, ok := decodeChartSeries(vm.UnreadArticleAgeDistributionJSON); ok {
+		charts["ageDistributionChart"] = SVGDoughnutChart("Unread articles by age", data.Labels, data.Data, colors.Palette)
+	}
+
+	if data, ok := decodeChartSeries(vm.ConsumptionJSON); ok {
+		charts["consumptionChart"] = SVGBarChart("Items saved and hours watched or listened per month", data.Labels, []SVGSeries{
+			{Label: "Items Saved", Values: data.Items, Color: colors.Primary},
+			{Label: "Hours Watched/Listened", Values: data.Hours, Color: colors.Secondary},
+		}, false)
+	}
+
+	if data, ok := decodeChartSeries(vm.EnergyHistoryJSON); ok {
+		charts["energyChart"] = SVGLineChart("Energy score per snapshot", data.Labels, []SVGSeries{{Label: "Energy Score", Values: data.Data, Color: colors.Accent}})
+	}
+
+	// The net change can be negative, which the bars cannot show; it is in the chart's table
+	if data, ok := decodeChartSeries(vm.BacklogFlowJSON); ok {
+		charts["backlogFlowChart"] = SVGBarChart("Articles added and read per month", data.Labels, []SVGSeries{
+			{Label: "Added", Values: data.AddedData, Color: colors.Unread},
+			{Label: "Read", Values: data.ReadData, Color: colors.Read},
+		}, false)
+	}
+
+	if rates, ok := decodeDatasets(vm.SourceReadRateJSON); ok {
+		charts["sourceReadRateChart"] = SVGLineChart("Read rate by source over time (%)", rates.Labels, rates.Series)
+	}
+
+	return charts
+}
+
+// svgChartSeries holds the shapes of the chart series JSON the SVG charts are drawn from
+type svgChartSeries struct {
+	Labels     []string  `json:"labels"`
+	Data       []float64 `json:"data"`
+	ReadData   []float64 `json:"readData"`
+	UnreadData []float64 `json:"unreadData"`
+	AddedData  []float64 `json:"addedData"`
+	NetData    []float64 `json:"netData"`
+	Items      []float64 `json:"items"`
+	Hours      []float64 `json:"hours"`
+}
+
+// datasetChart is a {labels, datasets} chart JSON with its datasets as series
+type datasetChart struct {
+	Labels []string
+	Series []SVGSeries
+}
+
+// decodeDatasets reads a {labels, datasets} chart JSON, with null values as NaN and each dataset's
+// border color as its series color
+func decodeDatasets(raw template.JS) (datasetChart, bool) {
+	var data struct {
+		Labels   []string `json:"labels"`
+		Datasets []struct {
+			Label       string     `json:"label"`
+			Data        []*float64 `json:"data"`
+			BorderColor string     `json:"borderColor"`
+		} `json:"datasets"`
+	}
+	if !decodeSeries(raw, &data) || len(data.Labels) == 0 {
+		return datasetChart{}, false
+	}
+	chart := datasetChart{Labels: data.Labels}
+	for _, dataset := range data.Datasets {
+		values := make([]float64, len(dataset.Data))
+		for i, v := range dataset.Data {
+			values[i] = math.NaN()
+			if v != nil {
+				values[i] = *v
+			}
+		}
+		chart.Series = append(chart.Series, SVGSeries{Label: dataset.Label, Values: values, Color: dataset.BorderColor})
+	}
+	return chart, true
+}
+
+func decodeChartSeries(raw template.JS) (svgChartSeries, bool) {
+	var data svgChartSeries
+	return data, decodeSeries(raw, &data) && len(data.Labels) > 0
+}
+
+func decodeSeries(raw template.JS, v interface{}) bool {
+	return raw != "" && json.Unmarshal([]byte(raw), v) == nil
+}
+
+func readUnreadSeries(data svgChartSeries, colors ThemeColors) []SVGSeries {
+	return []SVGSeries{
+		{Label: "Read", Values: data.ReadData, Color: colors.Read},
+		{Label: "Unread", Values: data.UnreadData, Color: colors.Unread},
+	}
+}
+
+// openSVG starts a responsive chart with an accessible title
+func openSVG(b *strings.Builder, title string) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" class="w-full h-full" role="img" aria-label="%s" font-family="system-ui, sans-serif"><title>%s</title>`,
+		svgWidth, svgHeight, attr(title), html.EscapeString(title))
+}
+
+// drawAxes draws the horizontal grid and both axes' labels, returning the value to y mapping
+func drawAxes(b *strings.Builder, labels []string, top float64) func(float64) float64 {
+	step := niceStep(top / svgGridLines)
+	axisMax := step * math.Max(math.Ceil(top/step), 1)
+	bottom := float64(svgHeight - svgPadBottom)
+	height := bottom - svgPadTop
+	scale := func(v float64) float64 { return bottom - v/axisMax*height }
+
+	for v := 0.0; v <= axisMax+step/2; v += step {
+		y := scale(v)
+		fmt.Fprintf(b, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#e2e8f0"/>`, svgPadLeft, y, svgWidth-svgPadRight, y)
+		fmt.Fprintf(b, `<text x="%d" y="%.1f" font-size="11" fill="#64748b" text-anchor="end">%s</text>`, svgPadLeft-8, y+4, formatSVGNumber(v))
+	}
+
+	slot := plotWidth() / float64(max(len(labels), 1))
+	every := int(math.Ceil(float64(len(labels)) / svgMaxXLabels))
+	for i, label := range labels {
+		if i%every != 0 {
+			continue
+		}
+		fmt.Fprintf(b, `<text x="%.1f" y="%.0f" font-size="11" fill="#64748b" text-anchor="middle">%s</text>`,
+			svgPadLeft+slot*(float64(i)+0.5), bottom+18, html.EscapeString(label))
+	}
+	return scale
+}
+
+// drawLegend lists the series across the top of a chart with more than one
+func drawLegend(b *strings.Builder, series []SVGSeries) {
+	if len(series) < 2 {
+		return
+	}
+	x := svgPadLeft
+	for _, s := range series {
+		fmt.Fprintf(b, `<rect x="%d" y="12" width="12" height="12" rx="2" fill="%s"/>`, x, attr(s.Color))
+		fmt.Fprintf(b, `<text x="%d" y="22" font-size="12" fill="currentColor">%s</text>`, x+16, html.EscapeString(s.Label))
+		x += 28 + 7*len([]rune(s.Label))
+	}
+}
+
+// niceStep rounds a raw grid step up to 1, 2 or 5 times a power of ten
+func niceStep(raw float64) float64 {
+	if raw <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, factor := range []float64{1, 2, 5, 10} {
+		if raw <= factor*magnitude {
+			return factor * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+func plotWidth() float64 {
+	return float64(svgWidth - svgPadLeft - svgPadRight)
+}
+
+func colorSeries(series []SVGSeries) []SVGSeries {
+	colored := make([]SVGSeries, len(series))
+	for i, s := range series {
+		if s.Color == "" {
+			s.Color = svgPalette[i%len(svgPalette)]
+		}
+		colored[i] = s
+	}
+	return colored
+}
+
+func paletteColor(colors []string, i int) string {
+	if i < len(colors) && colors[i] != "" {
+		return colors[i]
+	}
+	return svgPalette[i%len(svgPalette)]
+}
+
+func valueAt(values []float64, i int) float64 {
+	if i < len(values) {
+		return values[i]
+	}
+	return 0
+}
+
+func formatSVGNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
+}
+
+func attr(s string) string {
+	return html.EscapeString(s)
+}
+
+func reverseStrings(s []string) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func reverseFloats(s []float64) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
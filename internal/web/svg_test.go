@@ -0,0 +1,150 @@
+package web
+
+import (
+	"encoding/xml"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestNiceStep(t *testing.T) {
+	tests := []struct {
+		raw      float64
+		expected float64
+	}{
+		{raw: 0, expected: 1},
+		{raw: 0.3, expected: 0.5},
+		{raw: 1, expected: 1},
+		{raw: 3, expected: 5},
+		{raw: 12, expected: 20},
+		{raw: 250, expected: 500},
+		{raw: 600, expected: 1000},
+	}
+
+	for _, tt := range tests {
+		if got := niceStep(tt.raw); got != tt.expected {
+			t.Errorf("niceStep(%v) = %v, want %v", tt.raw, got, tt.expected)
+		}
+	}
+}
+
+func TestSVGCharts(t *testing.T) {
+	tests := []struct {
+		name     string
+		svg      string
+		expected []string
+	}{
+		{
+			name: "stacked bar",
+			svg:  string(SVGBarChart("Read & unread", []string{"Jan", "Feb"}, []SVGSeries{{Label: "Read", Values: []float64{2, 4}}, {Label: "Unread", Values: []float64{2}, Color: "#fb923c"}}, true)),
+			// 4 is the tallest stack, so both February's bar and January's stack reach the top gridline
+			expected: []string{`aria-label="Read &amp; unread"`, `y="40.0" width="`, `<title>Feb Read: 4</title>`, `fill="#fb923c"`, `>Unread</text>`},
+		},
+		{
+			name:     "grouped bar",
+			svg:      string(SVGBarChart("Years", []string{"2024"}, []SVGSeries{{Label: "A", Values: []float64{1}}, {Label: "B", Values: []float64{3}}}, false)),
+			expected: []string{`<title>2024 A: 1</title>`, `<title>2024 B: 3</title>`, `fill="#0369a1"`, `fill="#c2410c"`},
+		},
+		{
+			name:     "line",
+			svg:      string(SVGLineChart("Energy", []string{"2025-01-03", "2025-01-10"}, []SVGSeries{{Label: "Score", Values: []float64{42.5, 80}}})),
+			expected: []string{"<polyline", `<title>2025-01-10 Score: 80</title>`, ">2025-01-03</text>"},
+		},
+		{
+			name:     "line with a gap",
+			svg:      string(SVGLineChart("Rates", []string{"Jan", "Feb", "Mar"}, []SVGSeries{{Label: "GitHub", Values: []float64{math.NaN(), 50, 60}}})),
+			expected: []string{"<polyline", `<title>Feb GitHub: 50</title>`, `<title>Mar GitHub: 60</title>`},
+		},
+		{
+			name:     "doughnut",
+			svg:      string(SVGDoughnutChart("Age", []string{"New", "Old <1y>"}, []float64{1, 3}, []string{"#111111"})),
+			expected: []string{`stroke="#111111"`, "New: 1 (25.0%)", "Old &lt;1y&gt;: 3 (75.0%)"},
+		},
+		{
+			name:     "empty doughnut",
+			svg:      string(SVGDoughnutChart("Age", []string{"New"}, []float64{0}, nil)),
+			expected: []string{`stroke="#e2e8f0"`, "New: 0 (0.0%)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := xml.Unmarshal([]byte(tt.svg), new(struct{})); err != nil {
+				t.Fatalf("expected well-formed SVG: %v\n%s", err, tt.svg)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(tt.svg, want) {
+					t.Errorf("expected %q in\n%s", want, tt.svg)
+				}
+			}
+		})
+	}
+}
+
+func TestPrepareSVGCharts(t *testing.T) {
+	vm := ViewModel{
+		YearChartLabels:                  `["2025","2024"]`,
+		YearChartData:                    `[5,2]`,
+		MonthChartLabels:                 `["Jan"]`,
+		MonthChartDatasets:               `[{"label":"GitHub","data":[3],"backgroundColor":"#24292e"}]`,
+		ReadUnreadByMonthJSON:            `{"labels":["Jan"],"readData":[1],"unreadData":[2]}`,
+		WeeklyTrendJSON:                  `{"labels":["2025-W01"],"readData":[1],"unreadData":[0]}`,
+		UnreadArticleAgeDistributionJSON: `{"labels":["Less than 1 month"],"data":[2]}`,
+		EnergyHistoryJSON:                `{"labels":[],"data":[]}`,
+	}
+
+	charts := PrepareSVGCharts(vm)
+	for _, id := range []string{"yearChart", "monthChart", "readUnreadChart", "periodTrendChart", "ageDistributionChart"} {
+		if charts[id] == "" {
+			t.Errorf("expected an SVG chart for %s", id)
+		}
+	}
+	for _, id := range []string{"energyChart", "weekdayChart", "consumptionChart", "unreadByYearChart"} {
+		if _, exists := charts[id]; exists {
+			t.Errorf("expected no %s without data", id)
+		}
+	}
+	if year := string(charts["yearChart"]); strings.Index(year, ">2024</text>") > strings.Index(year, ">2025</text>") {
+		t.Error("expected years oldest first")
+	}
+	if !strings.Contains(string(charts["monthChart"]), `fill="#24292e"`) || !strings.Contains(string(charts["periodTrendChart"]), "ISO week") {
+		t.Error("expected source colors and the weekly trend fallback")
+	}
+}
+
+func TestGenerateAnalyticsOnlySVGCharts(t *testing.T) {
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	m := schema.Metrics{
+		TotalArticles:      3,
+		BySource:           map[string]int{"GitHub": 3},
+		BySourceReadStatus: map[string][2]int{"GitHub": {1, 2}},
+		ByYear:             map[string]int{"2025": 3},
+		ByMonth:            map[string]int{"01": 3},
+		ByYearAndMonth:     map[string]map[string]int{"2025": {"01": 3}},
+		ByMonthAndSource:   map[string]map[string][2]int{"01": {"GitHub": {1, 2}}},
+	}
+	if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: dir, LazyChartData: true, Charts: ChartsSVG, PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "analytics.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, unwanted := range []string{`<canvas id="yearChart">`, "initAnalyticsCharts", `id="yearViewToggle"`} {
+		if strings.Contains(string(page), unwanted) {
+			t.Errorf("expected no %q with SVG charts", unwanted)
+		}
+	}
+	if !strings.Contains(string(page), `aria-label="Articles by year"`) {
+		t.Error("expected the SVG year chart")
+	}
+	if _, err := os.Stat(filepath.Join(dir, ChartDataDir)); err == nil {
+		t.Error("expected no chart data files with SVG charts")
+	}
+}
@@ -0,0 +1,140 @@
+package web
+
+import (
+	"html/template"
+	"math"
+)
+
+// ChartTable is the data behind one chart view as a table, for screen readers and pages read
+// without JavaScript. Rows follow the chart's order.
+type ChartTable struct {
+	Caption string
+	Headers []string // the label column, then one column per series
+	Rows    []ChartTableRow
+}
+
+// ChartTableRow is one label of a chart with its formatted value per series
+type ChartTableRow struct {
+	Label  string
+	Values []string
+}
+
+// PrepareChartTables builds a table for each view of the analytics charts from the same chart
+// series JSON they are drawn from, keyed by the chart's canvas id
+func PrepareChartTables(vm ViewModel) map[string][]ChartTable {
+	tables := make(map[string][]ChartTable)
+	add := func(chartID, caption, labelHeader string, labels []string, series []SVGSeries) {
+		if len(labels) == 0 {
+			return
+		}
+		table := ChartTable{Caption: caption, Headers: []string{labelHeader}}
+		for _, s := range series {
+			table.Headers = append(table.Headers, s.Label)
+		}
+		for i, label := range labels {
+			row := ChartTableRow{Label: label}
+			for _, s := range series {
+				row.Values = append(row.Values, formatTableValue(vm, valueAt(s.Values, i)))
+			}
+			table.Rows = append(table.Rows, row)
+		}
+		tables[chartID] = append(tables[chartID], table)
+	}
+
+	var yearLabels []string
+	var yearCounts []float64
+	if decodeSeries(vm.YearChartLabels, &yearLabels) && decodeSeries(vm.YearChartData, &yearCounts) {
+		add("yearChart", "Articles by year", "Year", yearLabels, []SVGSeries{{Label: "Articles", Values: yearCounts}})
+	}
+
+	var monthLabels []string
+	var monthTotals []float64
+	var monthDatasets []struct {
+		Label string    `json:"label"`
+		Data  []float64 `json:"data"`
+	}
+	if decodeSeries(vm.MonthChartLabels, &monthLabels) && decodeSeries(vm.MonthChartDatasets, &monthDatasets) {
+		var series []SVGSeries
+		if decodeSeries(vm.MonthTotalData, &monthTotals) {
+			series = append(series, SVGSeries{Label: "Total", Values: monthTotals})
+		}
+		for _, dataset := range monthDatasets {
+			series = append(series, SVGSeries{Label: dataset.Label, Values: dataset.Data})
+		}
+		add("monthChart", "Articles by month and source, all years combined", "Month", monthLabels, series)
+	}
+	if timeline, ok := decodeDatasets(vm.MonthTimelineJSON); ok {
+		add("monthChart", "Articles per month with moving averages", "Month", timeline.Labels, timeline.Series)
+	}
+
+	readUnreadViews := []struct {
+		raw         template.JS
+		caption     string
+		labelHeader string
+	}{
+		{vm.ReadUnreadByYearJSON, "Read and unread articles by year", "Year"},
+		{vm.ReadUnreadByFiscalYearJSON, "Read and unread articles by fiscal year", "Fiscal year"},
+		{vm.ReadUnreadByMonthJSON, "Read and unread articles by month", "Month"},
+		{vm.ReadUnreadBySourceJSON, "Read and unread articles by source", "Source"},
+	}
+	for _, view := range readUnreadViews {
+		if data, ok := decodeChartSeries(view.raw); ok {
+			add("readUnreadChart", view.caption, view.labelHeader, data.Labels, readUnreadSeries(data, ThemeColors{}))
+		}
+	}
+
+	if data, ok := decodeChartSeries(vm.QuarterTrendJSON); ok {
+		add("periodTrendChart", "Read and unread articles by quarter", "Quarter", data.Labels, readUnreadSeries(data, ThemeColors{}))
+	}
+	if data, ok := decodeChartSeries(vm.WeeklyTrendJSON); ok {
+		add("periodTrendChart", "Read and unread articles by ISO week", "Week", data.Labels, readUnreadSeries(data, ThemeColors{}))
+	}
+
+	if data, ok := decodeChartSeries(vm.WeekdayChartJSON); ok {
+		add("weekdayChart", "Read and unread articles by weekday saved", "Weekday", data.Labels, readUnreadSeries(data, ThemeColors{}))
+	}
+
+	if data, ok := decodeChartSeries(vm.UnreadByYearJSON); ok {
+		add("unreadByYearChart", "Unread articles by year", "Year", data.Labels, []SVGSeries{{Label: "Unread", Values: data.Data}})
+	}
+
+	if data, ok := decodeChartSeries(vm.UnreadArticleAgeDistributionJSON); ok {
+		add("ageDistributionChart", "Unread articles by age", "Age", data.Labels, []SVGSeries{{Label: "Unread", Values: data.Data}})
+	}
+
+	if data, ok := decodeChartSeries(vm.ConsumptionJSON); ok {
+		add("consumptionChart", "Items saved and hours watched or listened per month", "Month", data.Labels, []SVGSeries{
+			{Label: "Items saved", Values: data.Items},
+			{Label: "Hours watched/listened", Values: data.Hours},
+		})
+	}
+
+	if data, ok := decodeChartSeries(vm.EnergyHistoryJSON); ok {
+		add("energyChart", "Energy score per snapshot", "Snapshot", data.Labels, []SVGSeries{{Label: "Energy score", Values: data.Data}})
+	}
+
+	if data, ok := decodeChartSeries(vm.BacklogFlowJSON); ok {
+		add("backlogFlowChart", "Articles added and read per month", "Month", data.Labels, []SVGSeries{
+			{Label: "Added", Values: data.AddedData},
+			{Label: "Read", Values: data.ReadData},
+			{Label: "Net change", Values: data.NetData},
+		})
+	}
+	if rates, ok := decodeDatasets(vm.SourceReadRateJSON); ok {
+		add("sourceReadRateChart", "Read rate by source over time (%)", "Snapshot", rates.Labels, rates.Series)
+	}
+
+	return tables
+}
+
+// formatTableValue formats counts as whole numbers and anything else, such as hours or scores,
+// with one decimal place
+func formatTableValue(vm ViewModel, v float64) string {
+	if math.IsNaN(v) {
+		return "–"
+	}
+	if v == math.Trunc(v) {
+		return vm.Locale.Int(int(v))
+	}
+	return vm.Locale.Decimal(v, 1)
+}
@@ -0,0 +1,78 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/locale"
+)
+
+func TestPrepareChartTables(t *testing.T) {
+	vm := ViewModel{
+		Locale:                           locale.MustParse("en-US"),
+		YearChartLabels:                  `["2025","2024"]`,
+		YearChartData:                    `[1200,30]`,
+		ReadUnreadByYearJSON:             `{"labels":["2025","2024"],"readData":[5,6],"unreadData":[7,8]}`,
+		ReadUnreadBySourceJSON:           `{"labels":["GitHub"],"readData":[3],"unreadData":[4]}`,
+		UnreadArticleAgeDistributionJSON: `{"labels":["Less than 1 month","1-3 months"],"data":[2,0]}`,
+		ConsumptionJSON:                  `{"labels":["2025-01"],"items":[4],"hours":[1.25]}`,
+		WeeklyTrendJSON:                  `not json`,
+	}
+	tables := PrepareChartTables(vm)
+
+	year := tables["yearChart"]
+	if len(year) != 1 || strings.Join(year[0].Headers, ",") != "Year,Articles" || year[0].Rows[0].Label != "2025" || year[0].Rows[0].Values[0] != "1,200" {
+		t.Errorf("unexpected year table: %+v", year)
+	}
+
+	readUnread := tables["readUnreadChart"]
+	if len(readUnread) != 2 || readUnread[1].Caption != "Read and unread articles by source" {
+		t.Fatalf("expected a table per read/unread view, got %+v", readUnread)
+	}
+	if got := strings.Join(readUnread[1].Rows[0].Values, ","); got != "3,4" {
+		t.Errorf("unexpected source row %q", got)
+	}
+
+	if got := tables["consumptionChart"][0].Rows[0].Values; got[0] != "4" || got[1] != "1.2" {
+		t.Errorf("unexpected consumption row %v", got)
+	}
+	if _, exists := tables["periodTrendChart"]; exists {
+		t.Error("expected invalid series to be skipped")
+	}
+	if rows := tables["ageDistributionChart"][0].Rows; len(rows) != 2 || rows[1].Values[0] != "0" {
+		t.Errorf("expected every age bucket, got %+v", rows)
+	}
+}
+
+func TestChartTablesRendered(t *testing.T) {
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	m := schema.Metrics{
+		TotalArticles:                12,
+		ReadCount:                    5,
+		UnreadCount:                  7,
+		BySource:                     map[string]int{"GitHub": 12},
+		BySourceReadStatus:           map[string][2]int{"GitHub": {5, 7}},
+		ByYear:                       map[string]int{"2025": 12},
+		ByMonth:                      map[string]int{"01": 12},
+		UnreadByYear:                 map[string]int{"2025": 7},
+		UnreadArticleAgeDistribution: map[string]int{"less_than_1_month": 7},
+	}
+	for _, charts := range []string{ChartsChartJS, ChartsSVG} {
+		if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: dir, Charts: charts, PageBudgetBytes: -1}); err != nil {
+			t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+		}
+		page, err := os.ReadFile(filepath.Join(dir, "analytics.html"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{"Show data table: Articles by year", `<caption class="sr-only">Unread articles by age</caption>`, `<th scope="row" class="px-3 py-1.5 font-semibold whitespace-nowrap">GitHub</th>`} {
+			if !strings.Contains(string(page), want) {
+				t.Errorf("%s page: expected %q", charts, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+// ThemeTokensFile is the stylesheet of theme color tokens generated into dist/css/ and linked on
+// every page
+const ThemeTokensFile = "css/tokens.css"
+
+// Theme modes a page opens in before the visitor toggles one
+const (
+	ThemeSystem = "system" // follows the visitor's prefers-color-scheme
+	ThemeLight  = "light"
+	ThemeDark   = "dark"
+)
+
+var (
+	// themeColorPattern admits hex, named and functional colors such as rgb() or oklch(), and
+	// nothing that could close the declaration
+	themeColorPattern = regexp.MustCompile(`^[#A-Za-z0-9(),.%/ -]+$`)
+	// themePropertyPattern admits custom property names such as color-slate-50
+	themePropertyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// ThemeColors are the color tokens of one theme: named chart colors, a palette for series without
+// their own color, and extra CSS custom properties
+type ThemeColors struct {
+	Read      string            `json:"read"`
+	Unread    string            `json:"unread"`
+	Primary   string            `json:"primary"`
+	Secondary string            `json:"secondary"`
+	Accent    string            `json:"accent"`
+	Text      string            `json:"text"`
+	Muted     string            `json:"muted"`
+	Grid      string            `json:"grid"`
+	Palette   []string          `json:"palette"`
+	CSS       map[string]string `json:"-"`
+}
+
+// Theme holds the light and dark color tokens, the mode pages open in and the configured source colors
+type Theme struct {
+	Default string
+	Light   ThemeColors
+	Dark    ThemeColors
+	Sources map[string]string // source name -> chart color, in both modes
+}
+
+// DefaultTheme is the built-in sky and orange palette, following the visitor's OS setting. Its dark
+// theme flips Tailwind's slate scale so the page classes need no dark variants.
+func DefaultTheme() Theme {
+	return Theme{
+		Default: ThemeSystem,
+		Light: ThemeColors{
+			Read:      "#2b6cb0",
+			Unread:    "#fb923c",
+			Primary:   "#0369a1",
+			Secondary: "#c2410c",
+			Accent:    "#059669",
+			Text:      "#0f172a",
+			Muted:     "#64748b",
+			Grid:      "#e2e8f0",
+			Palette:   []string{"#0369a1", "#c2410c", "#059669", "#7c3aed", "#db2777", "#ca8a04", "#64748b"},
+		},
+		Dark: ThemeColors{
+			Read:      "#60a5fa",
+			Unread:    "#fdba74",
+			Primary:   "#38bdf8",
+			Secondary: "#fb923c",
+			Accent:    "#34d399",
+			Text:      "#e2e8f0",
+			Muted:     "#94a3b8",
+			Grid:      "#334155",
+			Palette:   []string{"#38bdf8", "#fb923c", "#34d399", "#a78bfa", "#f472b6", "#facc15", "#94a3b8"},
+			CSS: map[string]string{
+				"color-slate-50":  "#0f172a",
+				"color-slate-100": "#1e293b",
+				"color-slate-200": "#334155",
+				"color-slate-300": "#475569",
+				"color-slate-400": "#64748b",
+				"color-slate-500": "#94a3b8",
+				"color-slate-600": "#cbd5e1",
+				"color-slate-700": "#e2e8f0",
+				"color-slate-800": "#f1f5f9",
+				"color-slate-900": "#f8fafc",
+				"color-white":     "#1e293b",
+				"color-sky-400":   "#0c4a6e",
+				"color-cyan-300":  "#164e63",
+				"color-sky-600":   "#38bdf8",
+				"color-sky-700":   "#7dd3fc",
+			},
+		},
+	}
+}
+
+// ThemeFromConfig applies the theme section of config.yml over the defaults. Invalid values are
+// reported together, with the defaults kept in their place and every valid value applied.
+func ThemeFromConfig(cfg config.ThemeConfig) (Theme, error) {
+	t := DefaultTheme()
+	var errs []error
+	switch cfg.Default {
+	case "":
+	case ThemeSystem, ThemeLight, ThemeDark:
+		t.Default = cfg.Default
+	default:
+		errs = append(errs, fmt.Errorf("invalid theme default %q: want %s, %s or %s", cfg.Default, ThemeSystem, ThemeLight, ThemeDark))
+	}
+	errs = append(errs, applyThemeColors(&t.Light, cfg.Light, ThemeLight)...)
+	errs = append(errs, applyThemeColors(&t.Dark, cfg.Dark, ThemeDark)...)
+	for name, color := range cfg.Sources {
+		if !themeColorPattern.MatchString(color) {
+			errs = append(errs, fmt.Errorf("invalid theme color for source %s: %q", name, color))
+			continue
+		}
+		if t.Sources == nil {
+			t.Sources = make(map[string]string, len(cfg.Sources))
+		}
+		t.Sources[name] = color
+	}
+	return t, errors.Join(errs...)
+}
+
+func applyThemeColors(c *ThemeColors, cfg config.ThemeColors, mode string) []error {
+	var errs []error
+	set := func(dst *string, name, value string) {
+		if value == "" {
+			return
+		}
+		if !themeColorPattern.MatchString(value) {
+			errs = append(errs, fmt.Errorf("invalid %s theme color %s: %q", mode, name, value))
+			return
+		}
+		*dst = value
+	}
+	set(&c.Read, "read", cfg.Read)
+	set(&c.Unread, "unread", cfg.Unread)
+	set(&c.Primary, "primary", cfg.Primary)
+	set(&c.Secondary, "secondary", cfg.Secondary)
+	set(&c.Accent, "accent", cfg.Accent)
+	set(&c.Text, "text", cfg.Text)
+	set(&c.Muted, "muted", cfg.Muted)
+	set(&c.Grid, "grid", cfg.Grid)
+
+	if len(cfg.Palette) > 0 {
+		palette := make([]string, 0, len(cfg.Palette))
+		for _, color := range cfg.Palette {
+			if themeColorPattern.MatchString(color) {
+				palette = append(palette, color)
+			} else {
+				errs = append(errs, fmt.Errorf("invalid %s theme palette color %q", mode, color))
+			}
+		}
+		if len(palette) > 0 {
+			c.Palette = palette
+		}
+	}
+
+	if len(cfg.CSS) > 0 {
+		css := make(map[string]string, len(c.CSS)+len(cfg.CSS))
+		for name, value := range c.CSS {
+			css[name] = value
+		}
+		for name, value := range cfg.CSS {
+			name = strings.TrimPrefix(name, "--")
+			if !themePropertyPattern.MatchString(name) || !themeColorPattern.MatchString(value) {
+				errs = append(errs, fmt.Errorf("invalid %s theme CSS property %q: %q", mode, name, value))
+				continue
+			}
+			css[name] = value
+		}
+		c.CSS = css
+	}
+	return errs
+}
+
+// CSS renders the tokens as custom properties: the light theme on :root, the dark theme when
+// data-theme="dark" is set or, without a choice, when the OS prefers dark
+func (t Theme) CSS() string {
+	var b strings.Builder
+	b.WriteString("/* Generated from the theme section of config.yml */\n")
+	writeThemeBlock(&b, ":root", t.Light, "")
+	writeThemeBlock(&b, `:root[data-theme="dark"]`, t.Dark, "")
+	if t.Default == ThemeDark {
+		writeThemeBlock(&b, `:root:not([data-theme="light"])`, t.Dark, "")
+	} else if t.Default == ThemeSystem {
+		b.WriteString("@media (prefers-color-scheme: dark) {\n")
+		writeThemeBlock(&b, `:root:not([data-theme="light"])`, t.Dark, "  ")
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+func writeThemeBlock(b *strings.Builder, selector string, c ThemeColors, indent string) {
+	fmt.Fprintf(b, "%s%s {\n", indent, selector)
+	property := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(b, "%s  --%s: %s;\n", indent, name, value)
+		}
+	}
+	property("theme-read", c.Read)
+	property("theme-unread", c.Unread)
+	property("theme-primary", c.Primary)
+	property("theme-secondary", c.Secondary)
+	property("theme-accent", c.Accent)
+	property("theme-text", c.Text)
+	property("theme-muted", c.Muted)
+	property("theme-grid", c.Grid)
+	for i, color := range c.Palette {
+		property(fmt.Sprintf("theme-palette-%d", i+1), color)
+	}
+	names := make([]string, 0, len(c.CSS))
+	for name := range c.CSS {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property(name, c.CSS[name])
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+// chartThemeJSON is both themes' chart colors for the Chart.js charts, keyed by mode
+func (t Theme) chartThemeJSON() template.JS {
+	data, _ := json.Marshal(map[string]ThemeColors{ThemeLight: t.Light, ThemeDark: t.Dark})
+	return template.JS(data)
+}
+
+// staticColors are the colors of charts drawn at build time, which cannot follow the toggle
+func (t Theme) staticColors() ThemeColors {
+	if t.Default == ThemeDark {
+		return t.Dark
+	}
+	return t.Light
+}
@@ -0,0 +1,124 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/config"
+)
+
+func TestThemeFromConfig(t *testing.T) {
+	theme, err := ThemeFromConfig(config.ThemeConfig{
+		Default: "dark",
+		Light:   config.ThemeColors{Read: "#112233", Palette: []string{"red", "oklch(70% 0.1 200)"}},
+		Dark:    config.ThemeColors{Unread: "rgb(1, 2, 3)", CSS: map[string]string{"--color-slate-50": "#000"}},
+		Sources: map[string]string{"GitHub": "#24292f"},
+	})
+	if err != nil {
+		t.Fatalf("ThemeFromConfig() error = %v", err)
+	}
+	tests := []struct {
+		got      string
+		expected string
+	}{
+		{theme.Default, ThemeDark},
+		{theme.Light.Read, "#112233"},
+		{theme.Light.Unread, DefaultTheme().Light.Unread},
+		{strings.Join(theme.Light.Palette, ","), "red,oklch(70% 0.1 200)"},
+		{theme.Dark.Unread, "rgb(1, 2, 3)"},
+		{theme.Dark.CSS["color-slate-50"], "#000"},
+		{theme.Dark.CSS["color-slate-900"], DefaultTheme().Dark.CSS["color-slate-900"]},
+		{theme.Sources["GitHub"], "#24292f"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("got %q, want %q", tt.got, tt.expected)
+		}
+	}
+
+	theme, err = ThemeFromConfig(config.ThemeConfig{
+		Default: "sepia",
+		Light:   config.ThemeColors{Read: "red; } body { display: none", Text: "#333"},
+		Dark:    config.ThemeColors{CSS: map[string]string{"bad name": "#000"}},
+		Sources: map[string]string{"Substack": "red; }"},
+	})
+	if err == nil {
+		t.Fatal("expected errors for the invalid default, color, property and source color")
+	}
+	for _, want := range []string{"sepia", "read", "bad name", "Substack"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error %q", want, err)
+		}
+	}
+	if theme.Default != ThemeSystem || theme.Light.Read != DefaultTheme().Light.Read || theme.Light.Text != "#333" {
+		t.Errorf("expected invalid values to keep the defaults and valid ones applied, got %+v", theme)
+	}
+}
+
+func TestThemeCSS(t *testing.T) {
+	tests := []struct {
+		mode     string
+		expected []string
+		absent   []string
+	}{
+		{ThemeSystem, []string{":root {\n  --theme-read: #2b6cb0;", `:root[data-theme="dark"] {`, "@media (prefers-color-scheme: dark)", "--theme-palette-7: #64748b;", "--color-slate-50: #0f172a;"}, nil},
+		{ThemeLight, []string{`:root[data-theme="dark"] {`}, []string{"prefers-color-scheme", `:not([data-theme="light"])`}},
+		{ThemeDark, []string{`:root:not([data-theme="light"]) {`}, []string{"prefers-color-scheme"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			theme := DefaultTheme()
+			theme.Default = tt.mode
+			css := theme.CSS()
+			for _, want := range tt.expected {
+				if !strings.Contains(css, want) {
+					t.Errorf("expected %q in:\n%s", want, css)
+				}
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(css, unwanted) {
+					t.Errorf("unexpected %q in:\n%s", unwanted, css)
+				}
+			}
+		})
+	}
+}
+
+func TestThemedSite(t *testing.T) {
+	theme, err := ThemeFromConfig(config.ThemeConfig{Default: "dark", Dark: config.ThemeColors{Read: "#abcdef"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	service := NewAnalyticsService(dir)
+	service.SetTheme(theme)
+	m := schema.Metrics{TotalArticles: 3, ReadCount: 1, UnreadCount: 2, ByQuarter: map[string][2]int{"2025-Q1": {1, 2}}}
+	for _, charts := range []string{ChartsChartJS, ChartsSVG} {
+		if err := service.GenerateAnalyticsOnly(t.Context(), m, GenConfig{OutputDir: dir, Charts: charts, PageBudgetBytes: -1}); err != nil {
+			t.Fatalf("GenerateAnalyticsOnly() error = %v", err)
+		}
+		page, err := os.ReadFile(filepath.Join(dir, "analytics.html"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{`data-theme="dark"`, `css/tokens.css`, `id="theme-toggle"`, "#abcdef"} {
+			if !strings.Contains(string(page), want) {
+				t.Errorf("%s page: expected %q", charts, want)
+			}
+		}
+	}
+
+	if err := service.GenerateFullSite(t.Context(), m, GenConfig{OutputDir: dir, BaseURL: "./", PageBudgetBytes: -1}); err != nil {
+		t.Fatalf("GenerateFullSite() error = %v", err)
+	}
+	tokens, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(ThemeTokensFile)))
+	if err != nil {
+		t.Fatalf("expected the tokens stylesheet: %v", err)
+	}
+	if !strings.Contains(string(tokens), "--theme-read: #abcdef;") {
+		t.Errorf("expected the configured dark read color in:\n%s", tokens)
+	}
+}
@@ -2,17 +2,28 @@ package web
 
 import (
 	"html/template"
+	"strings"
 	"time"
 
 	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/forecast"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/linkcheck"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/locale"
 )
 
 // ViewModel represents the data structure passed to HTML templates
 type ViewModel struct {
 	AnalyticsTitle                   string
 	PageTitle                        string
+	PageFile                         string // template file of the page being rendered, for navigation state
+	Footer                           string
+	Locale                           locale.Locale
+	Theme                            Theme
+	ChartThemeJSON                   template.JS // light and dark chart colors, for the Chart.js charts
 	KeyMetrics                       []schema.KeyMetric
+	DeltaSince                       time.Time // previous snapshot the key metric deltas compare against; zero without one
 	HighlightMetrics                 []schema.HightlightMetric
+	Alerts                           []schema.Alert // unusual changes and milestones detected for the snapshot
 	TotalArticles                    int
 	ReadCount                        int
 	UnreadCount                      int
@@ -21,6 +32,11 @@ type ViewModel struct {
 	LastUpdated                      time.Time
 	AIDeltaAnalysis                  string
 	Sources                          []schema.SourceInfo
+	TopDomains                       []schema.DomainInfo
+	MediaTypes                       []schema.MediaTypeInfo
+	Authors                          []schema.AuthorInfo
+	Categories                       []schema.CategoryInfo
+	CategoryChartJSON                template.JS // category -> source stacked chart on the topics page
 	Months                           []schema.MonthInfo
 	Years                            []schema.YearInfo
 	AllYears                         []string
@@ -32,13 +48,32 @@ type ViewModel struct {
 	MonthChartLabels                 template.JS
 	MonthChartDatasets               template.JS
 	MonthTotalData                   template.JS
+	MonthTimelineJSON                template.JS // articles per month over time, with moving averages
 	ReadUnreadByMonthJSON            template.JS
 	ReadUnreadBySourceJSON           template.JS
 	ReadUnreadByYearJSON             template.JS
+	ReadUnreadByFiscalYearJSON       template.JS
+	FiscalYearSpan                   string // months the fiscal years cover, such as "Sep–Aug"
+	QuarterTrendJSON                 template.JS
+	WeeklyTrendJSON                  template.JS
+	WeekdayPattern                   *schema.WeekdayPattern
+	WeekdayChartJSON                 template.JS
 	UnreadArticleAgeDistributionJSON template.JS
 	UnreadByYearJSON                 template.JS
+	EnergyScore                      *schema.EnergyScore
+	EnergyHistoryJSON                template.JS
+	BacklogFlowJSON                  template.JS // articles added and read per month across snapshots
+	SourceReadRateJSON               template.JS // the largest sources' read rates across snapshots
+	MediaTypeChartDataJSON           template.JS // media type -> that type's chart series, for the media type filter
+	Consumption                      *schema.ConsumptionStats
+	ConsumptionJSON                  template.JS
+	Community                        *schema.CommunityComparison
 	TopOldestUnreadArticles          []schema.ArticleMeta
+	QuickWins                        *schema.QuickWins
+	QuickWinMinutes                  int // reading time assumed for articles without a duration
 	EvolutionData                    schema.EvolutionData
+	ProviderTimeline                 []schema.ProviderTimelinePoint
+	ProviderTimelineJSON             template.JS
 	Landing                          schema.Landing
 
 	// Historical Metrics context
@@ -46,4 +81,59 @@ type ViewModel struct {
 	IsHistorical bool
 	HistoryDates []string
 	ReportDate   string
+
+	// OlderReportDate and NewerReportDate are the snapshot pages either side of a historical page;
+	// empty at either end of the history
+	OlderReportDate string
+	NewerReportDate string
+
+	// HistoryIndex lists every snapshot page by year and month on the history index
+	HistoryIndex []HistoryYear
+
+	// ThemeCSSURL links the theme directory's css/theme.css; empty without one
+	ThemeCSSURL string
+
+	// ChartTables holds the data of each chart as expandable tables, keyed by canvas id
+	ChartTables map[string][]ChartTable
+
+	// OGImageURL is the absolute URL of the social preview card; empty without a site_url
+	OGImageURL string
+
+	// CanonicalBase is the absolute URL of the directory the page's canonical URL is in, ending
+	// in a slash; empty without a site_url
+	CanonicalBase string
+
+	// FeedURL and JSONFeedURL are the feeds advertised to feed readers; empty when no feed is published
+	FeedURL     string
+	JSONFeedURL string
+
+	// ChartDataURLs are the chart data files the page fetches and merges; when empty the chart data
+	// is inlined
+	ChartDataURLs []string
+
+	// SVGCharts holds charts drawn at build time, keyed by the canvas id they replace; when set the
+	// analytics page needs no JavaScript and its interactive chart controls are hidden
+	SVGCharts map[string]template.HTML
+
+	// ReadingPlan is the latest backlog forecast, shown with a link to its calendar at ReadingPlanURL
+	ReadingPlan    *forecast.Plan
+	ReadingPlanURL string
+
+	// LinkReport is the latest checklinks result, shown on the latest analytics page only
+	LinkReport *linkcheck.Report
+
+	// YearInReview is the year a wrapped page summarizes
+	YearInReview *schema.YearInReview
+
+	// Article is the read article a permalink page describes
+	Article *schema.ArticleMeta
+}
+
+// CanonicalURL is the absolute URL search engines should index the page under, with index.html
+// left to the directory; empty without a site_url
+func (vm ViewModel) CanonicalURL() string {
+	if vm.CanonicalBase == "" {
+		return ""
+	}
+	return vm.CanonicalBase + strings.TrimSuffix(vm.PageFile, "index.html")
 }
@@ -0,0 +1,37 @@
+package web
+
+import (
+	"context"
+	"fmt"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+// WrappedDir holds one year-in-review directory per year, relative to the site root
+const WrappedDir = "wrapped"
+
+// GenerateWrapped renders the standalone year-in-review page for review as wrapped.html in
+// config.OutputDir, with m supplying the page header and footer
+func (s *AnalyticsService) GenerateWrapped(ctx context.Context, m schema.Metrics, review schema.YearInReview, config GenConfig) error {
+	vm, err := s.prepareViewModel(m, config)
+	if err != nil {
+		return fmt.Errorf("failed to prepare view model: %w", err)
+	}
+
+	// Sources keep the colors they have on the analytics charts
+	sources := make([]schema.SourceInfo, len(review.TopSources))
+	for i, source := range review.TopSources {
+		if color, exists := s.theme.Sources[source.Name]; exists {
+			source.Color = color
+		}
+		source.Color = SourceColor(source.Name, source.Color)
+		sources[i] = source
+	}
+	review.TopSources = sources
+	vm.YearInReview = &review
+
+	pages := []Page{
+		{"wrapped.html", "🎁 " + review.Year + " Wrapped"},
+	}
+	return HTMLRenderer{}.Render(ctx, vm, OutputTarget{Dir: config.OutputDir, Pages: pages, Record: s.record})
+}
@@ -0,0 +1,46 @@
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+)
+
+func TestGenerateWrapped(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, WrappedDir, "2025")
+	service := NewAnalyticsService(root)
+	review := schema.YearInReview{
+		Year:              "2025",
+		Snapshots:         52,
+		Saved:             240,
+		Read:              180,
+		TopSources:        []schema.SourceInfo{{Name: "Stripe", Read: 40, Count: 45, Color: "#635bff"}, {Name: "GitHub", Read: 30, Count: 50}},
+		BusiestMonth:      "March",
+		BusiestMonthCount: 31,
+		LongestStreak:     9,
+		StreakStart:       "2025-04-04",
+		StreakEnd:         "2025-05-30",
+	}
+
+	if err := service.GenerateWrapped(t.Context(), schema.Metrics{TotalArticles: 300}, review, GenConfig{OutputDir: dir, BaseURL: "../../"}); err != nil {
+		t.Fatalf("GenerateWrapped() error = %v", err)
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "wrapped.html"))
+	if err != nil {
+		t.Fatalf("expected wrapped page: %v", err)
+	}
+	for _, want := range []string{"2025 Wrapped", "Stripe", "40 read · 45 saved", "#635bff", "March", "9 snapshots", "2025-05-30", "The backlog never shrank", `href="../../analytics.html"`} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("expected %q in the wrapped page", want)
+		}
+	}
+
+	if written := service.WrittenFiles(); len(written) != 1 || written[0] != "wrapped/2025/wrapped.html" {
+		t.Errorf("expected the wrapped page in written files, got %v", written)
+	}
+}
@@ -0,0 +1,94 @@
+// Package metrics is the importable API of the metrics engine: it computes reading metrics
+// snapshots from the articles and providers sheets, loads saved snapshots and builds the series
+// that span them. Its signatures are kept stable across releases; the types are those cmd/metrics
+// writes to metrics/YYYY-MM-DD.json.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	schema "github.com/victoriacheng15/personal-reading-analytics/internal"
+	"github.com/victoriacheng15/personal-reading-analytics/internal/metrics"
+)
+
+// Metrics is one snapshot of the reading list's aggregates
+type Metrics = schema.Metrics
+
+// ArticleMeta is one article of a snapshot, as listed in its oldest unread and quick win sections
+type ArticleMeta = schema.ArticleMeta
+
+// Comparison holds how the headline figures changed between two snapshots
+type Comparison = metrics.Comparison
+
+// Series spanning snapshots, oldest first
+type (
+	EnergyPoint           = schema.EnergyPoint
+	BacklogFlowPoint      = schema.BacklogFlowPoint
+	SourceReadRatePoint   = schema.SourceReadRatePoint
+	ProviderTimelinePoint = schema.ProviderTimelinePoint
+)
+
+// FetchMetricsFromSheets reads the articles and providers sheets of spreadsheetID and computes a
+// snapshot as of now. credentialsPath is a service account key or OAuth client file.
+func FetchMetricsFromSheets(ctx context.Context, spreadsheetID, credentialsPath string) (Metrics, error) {
+	return metrics.FetchMetricsFromSheets(ctx, spreadsheetID, credentialsPath)
+}
+
+// ComputeMetrics aggregates article and provider rows, header rows included, into a snapshot.
+// Unread ages, the partial-month average and LastUpdated are measured against referenceDate.
+func ComputeMetrics(articleRows, providerRows [][]interface{}, referenceDate time.Time) (Metrics, error) {
+	return metrics.ComputeMetrics(articleRows, providerRows, referenceDate)
+}
+
+// LoadSnapshots reads every YYYY-MM-DD.json snapshot in dir, keyed by date. Each source's added date
+// is taken from the first snapshot listing it, as the site generator does.
+func LoadSnapshots(dir string) (map[string]Metrics, error) {
+	files, err := metrics.ListSnapshotFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no snapshots found in %s", dir)
+	}
+
+	snapshots := make(map[string]Metrics, len(files))
+	for _, file := range files {
+		m, err := metrics.LoadSnapshot(dir, file)
+		if err != nil {
+			return nil, err
+		}
+		snapshots[strings.TrimSuffix(file, ".json")] = *m
+	}
+	metrics.ApplyProviderAddedDates(snapshots)
+	return snapshots, nil
+}
+
+// Compare returns the change in the headline figures from prev to latest
+func Compare(prev, latest Metrics) Comparison {
+	return metrics.Compare(prev, latest)
+}
+
+// BuildEnergyHistory returns the energy score of every snapshot that has one
+func BuildEnergyHistory(snapshots map[string]Metrics) []EnergyPoint {
+	return metrics.BuildEnergyHistory(snapshots)
+}
+
+// BuildBacklogFlow returns the articles added and read per month, from the change between
+// consecutive snapshots
+func BuildBacklogFlow(snapshots map[string]Metrics) []BacklogFlowPoint {
+	return metrics.BuildBacklogFlow(snapshots)
+}
+
+// BuildSourceReadRates returns every source's read rate in each snapshot
+func BuildSourceReadRates(snapshots map[string]Metrics) []SourceReadRatePoint {
+	return metrics.BuildSourceReadRates(snapshots)
+}
+
+// BuildProviderTimeline returns the subscriptions added and removed per month, counting from the
+// earliest snapshot with provider data
+func BuildProviderTimeline(snapshots map[string]Metrics) []ProviderTimelinePoint {
+	return metrics.BuildProviderTimeline(snapshots)
+}
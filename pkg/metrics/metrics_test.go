@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"2026-01-04.json": `{"total_articles": 10, "unread_count": 10, "source_metadata": {"GitHub": {"added": "2025-06-01"}}}`,
+		"2026-01-11.json": `{"total_articles": 12, "unread_count": 12, "source_metadata": {"GitHub": {"added": "2025-06-01"}, "Stripe": {"added": "2020-01-01"}}}`,
+		"notes.json":      `not a snapshot`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshots, err := LoadSnapshots(dir)
+	if err != nil {
+		t.Fatalf("LoadSnapshots() error = %v", err)
+	}
+	if len(snapshots) != 2 || snapshots["2026-01-11"].TotalArticles != 12 {
+		t.Fatalf("expected the 2 dated snapshots, got %+v", snapshots)
+	}
+	if added := snapshots["2026-01-11"].SourceMetadata["Stripe"].Added; added != "2026-01-11" {
+		t.Errorf("expected the date of the first snapshot listing the source, got %q", added)
+	}
+
+	if _, err := LoadSnapshots(t.TempDir()); err == nil {
+		t.Error("expected an error for a directory without snapshots")
+	}
+}
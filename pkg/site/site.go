@@ -0,0 +1,72 @@
+// Package site is the importable API of the site generator: it renders the dashboard pages of the
+// latest snapshot, with the charts that span every snapshot, into a directory. Its signatures are
+// kept stable across releases.
+package site
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/victoriacheng15/personal-reading-analytics/internal/web"
+	"github.com/victoriacheng15/personal-reading-analytics/pkg/metrics"
+)
+
+// Chart renderers
+const (
+	ChartsChartJS = web.ChartsChartJS // interactive Chart.js canvases, the default
+	ChartsSVG     = web.ChartsSVG     // static SVG drawn at build time, for pages without JavaScript
+)
+
+// Options configures a site build
+type Options struct {
+	OutputDir string // required
+	BaseURL   string // prefix of the pages' links to each other; defaults to "./"
+	Charts    string // ChartsChartJS (or empty) or ChartsSVG
+	Public    bool   // keep only aggregates, leaving out article titles and links
+}
+
+// Generate writes every page of the latest of snapshots, keyed by YYYY-MM-DD, into opts.OutputDir.
+// The energy, backlog flow, subscription and source read rate charts are built across all of
+// snapshots, and the key metrics show their change since the snapshot before the latest. It stops
+// between pages once ctx is done, returning ctx's error.
+func Generate(ctx context.Context, snapshots map[string]metrics.Metrics, opts Options) error {
+	if opts.OutputDir == "" {
+		return errors.New("site: no output directory")
+	}
+	if len(snapshots) == 0 {
+		return errors.New("site: no snapshots")
+	}
+	if opts.Charts != "" && opts.Charts != ChartsChartJS && opts.Charts != ChartsSVG {
+		return fmt.Errorf("site: unknown charts %q, expected %s or %s", opts.Charts, ChartsChartJS, ChartsSVG)
+	}
+	if opts.BaseURL == "" {
+		opts.BaseURL = "./"
+	}
+
+	dates := make([]string, 0, len(snapshots))
+	for date := range snapshots {
+		dates = append(dates, date)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(dates)))
+	var previous *metrics.Metrics
+	if len(dates) > 1 {
+		prev := snapshots[dates[1]]
+		previous = &prev
+	}
+
+	service := web.NewAnalyticsService(opts.OutputDir)
+	service.SetPublic(opts.Public)
+	return service.GenerateFullSite(ctx, snapshots[dates[0]], web.GenConfig{
+		OutputDir:        opts.OutputDir,
+		BaseURL:          opts.BaseURL,
+		ReportDate:       dates[0],
+		EnergyHistory:    metrics.BuildEnergyHistory(snapshots),
+		ProviderTimeline: metrics.BuildProviderTimeline(snapshots),
+		BacklogFlow:      metrics.BuildBacklogFlow(snapshots),
+		SourceReadRates:  metrics.BuildSourceReadRates(snapshots),
+		Previous:         previous,
+		Charts:           opts.Charts,
+	})
+}
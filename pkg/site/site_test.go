@@ -0,0 +1,54 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/victoriacheng15/personal-reading-analytics/pkg/metrics"
+)
+
+func TestGenerate(t *testing.T) {
+	snapshots := map[string]metrics.Metrics{
+		"2026-01-04": {TotalArticles: 10, ReadCount: 4, UnreadCount: 6, ReadRate: 40},
+		"2026-01-11": {TotalArticles: 12, ReadCount: 6, UnreadCount: 6, ReadRate: 50},
+	}
+
+	dir := t.TempDir()
+	if err := Generate(t.Context(), snapshots, Options{OutputDir: dir, Charts: ChartsSVG}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, page := range []string{"index.html", "analytics.html", "evolution.html"} {
+		if _, err := os.Stat(filepath.Join(dir, page)); err != nil {
+			t.Errorf("expected %s to be written: %v", page, err)
+		}
+	}
+	analytics, err := os.ReadFile(filepath.Join(dir, "analytics.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(analytics), "▲") {
+		t.Error("expected the key metrics to show their change since the previous snapshot")
+	}
+}
+
+func TestGenerateRejectsInvalidOptions(t *testing.T) {
+	snapshots := map[string]metrics.Metrics{"2026-01-04": {TotalArticles: 1}}
+	tests := []struct {
+		name      string
+		snapshots map[string]metrics.Metrics
+		opts      Options
+	}{
+		{"no output directory", snapshots, Options{}},
+		{"no snapshots", nil, Options{OutputDir: t.TempDir()}},
+		{"unknown charts", snapshots, Options{OutputDir: t.TempDir(), Charts: "png"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Generate(t.Context(), tt.snapshots, tt.opts); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}